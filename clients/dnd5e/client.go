@@ -1,6 +1,8 @@
 package dnd5e
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
 	"net/http"
 	"strconv"
@@ -13,9 +15,12 @@ import (
 	"github.com/fadedpez/dnd5e-api/clients/dnd5e"
 )
 
+const baseURL = "https://www.dnd5eapi.co/api/"
+
 // TODO: add context to functions
 type client struct {
-	client dnd5e.Interface
+	client     dnd5e.Interface
+	httpClient *http.Client
 }
 
 type Config struct {
@@ -34,8 +39,14 @@ func New(cfg *Config) (Client, error) {
 		return nil, err
 	}
 
+	httpClient := cfg.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
 	return &client{
-		client: dndClient,
+		client:     dndClient,
+		httpClient: httpClient,
 	}, nil
 }
 
@@ -121,6 +132,99 @@ func (c *client) GetMonster(key string) (*entities.MonsterTemplate, error) {
 	return apiToMonsterTemplate(monsterTemplate), nil
 }
 
+type monsterListResponse struct {
+	Results []*struct {
+		Index string `json:"index"`
+		Name  string `json:"name"`
+	} `json:"results"`
+}
+
+// ListMonstersByChallengeRating calls the api directly, the api client does not support the
+// challenge_rating filter
+func (c *client) ListMonstersByChallengeRating(ratings ...float32) ([]*entities.ReferenceItem, error) {
+	if len(ratings) == 0 {
+		return nil, dnderr.NewMissingParameterError("ratings")
+	}
+
+	values := make([]string, len(ratings))
+	for idx, rating := range ratings {
+		values[idx] = strconv.FormatFloat(float64(rating), 'f', -1, 32)
+	}
+
+	resp, err := c.httpClient.Get(baseURL + "monsters?challenge_rating=" + strings.Join(values, ","))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	response := &monsterListResponse{}
+	err = json.NewDecoder(resp.Body).Decode(response)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*entities.ReferenceItem, len(response.Results))
+	for idx, result := range response.Results {
+		out[idx] = &entities.ReferenceItem{
+			Key:  result.Index,
+			Name: result.Name,
+			Type: entities.ReferenceTypeMonster,
+		}
+	}
+
+	return out, nil
+}
+
+type equipmentCategoryResponse struct {
+	Equipment []*struct {
+		Index string `json:"index"`
+		Name  string `json:"name"`
+	} `json:"equipment"`
+}
+
+// ListEquipmentByCategory calls the api directly, the api client does not expose the
+// equipment categories
+func (c *client) ListEquipmentByCategory(category string) ([]*entities.ReferenceItem, error) {
+	if category == "" {
+		return nil, dnderr.NewMissingParameterError("category")
+	}
+
+	resp, err := c.httpClient.Get(baseURL + "equipment-categories/" + category)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, dnderr.NewNotFoundError(fmt.Sprintf("equipment category %s not found", category))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	response := &equipmentCategoryResponse{}
+	err = json.NewDecoder(resp.Body).Decode(response)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*entities.ReferenceItem, len(response.Equipment))
+	for idx, result := range response.Equipment {
+		out[idx] = &entities.ReferenceItem{
+			Key:  result.Index,
+			Name: result.Name,
+			Type: entities.ReferenceTypeEquipment,
+		}
+	}
+
+	return out, nil
+}
+
 func apiToMonsterTemplate(input *apiEntities.Monster) *entities.MonsterTemplate {
 	if input == nil {
 		return nil
@@ -133,11 +237,46 @@ func apiToMonsterTemplate(input *apiEntities.Monster) *entities.MonsterTemplate
 		ArmorClass:      input.ArmorClass,
 		HitPoints:       input.HitPoints,
 		HitDice:         input.HitDice,
+		XP:              input.XP,
+		Dexterity:       input.Dexterity,
+		Wisdom:          input.Wisdom,
+		Speed:           apiSpeedToFeet(input.Speed),
 		ChallengeRating: input.ChallengeRating,
 		Actions:         apisToMonsterActions(input.MonsterActions),
+		Senses:          apiToMonsterSenses(input.MonsterSenses),
+		Defenses: &damage.Defenses{
+			Resistances:     damage.ParseTypes(input.DamageResistances),
+			Vulnerabilities: damage.ParseTypes(input.DamageVulnerabilities),
+			Immunities:      damage.ParseTypes(input.DamageImmunities),
+		},
 	}
 }
 
+// apiToMonsterSenses keeps the passive perception, the rest of the senses are not used yet
+func apiToMonsterSenses(input *apiEntities.MonsterSenses) *entities.MonsterSenses {
+	if input == nil {
+		return nil
+	}
+
+	return &entities.MonsterSenses{
+		PassivePerception: input.PassivePerception,
+	}
+}
+
+// apiSpeedToFeet reads the walking speed, the api lists it as "30 ft."
+func apiSpeedToFeet(input *apiEntities.Speed) int {
+	if input == nil {
+		return 0
+	}
+
+	feet, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(input.Walk, "ft.")))
+	if err != nil {
+		return 0
+	}
+
+	return feet
+}
+
 func apiToDamage(input *apiEntities.Damage) *damage.Damage {
 	a := strings.Split(input.DamageDice, "+")
 	var dice string = input.DamageDice
@@ -153,11 +292,11 @@ func apiToDamage(input *apiEntities.Damage) *damage.Damage {
 		diceValue, _ = strconv.Atoi(b[1])
 	}
 
-	// TODO: add damage type
 	return &damage.Damage{
-		DiceCount: diceCount,
-		DiceSize:  diceValue,
-		Bonus:     bonus,
+		DiceCount:  diceCount,
+		DiceSize:   diceValue,
+		Bonus:      bonus,
+		DamageType: apiDamageTypeToDamageType(input.DamageType),
 	}
 }
 
@@ -11,5 +11,8 @@ type Client interface {
 	GetClass(key string) (*entities.Class, error)
 	GetProficiency(key string) (*entities.Proficiency, error)
 	GetMonster(key string) (*entities.MonsterTemplate, error)
+	ListMonstersByChallengeRating(ratings ...float32) ([]*entities.ReferenceItem, error)
 	GetEquipment(key string) (entities.Equipment, error)
+	// ListEquipmentByCategory lists the equipment in an SRD equipment category such as martial-weapons
+	ListEquipmentByCategory(category string) ([]*entities.ReferenceItem, error)
 }
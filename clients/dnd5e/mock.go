@@ -78,3 +78,23 @@ func (m *Mock) GetMonster(key string) (*entities.MonsterTemplate, error) {
 
 	return args.Get(0).(*entities.MonsterTemplate), nil
 }
+
+func (m *Mock) ListMonstersByChallengeRating(ratings ...float32) ([]*entities.ReferenceItem, error) {
+	args := m.Called(ratings)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entities.ReferenceItem), nil
+}
+
+func (m *Mock) ListEquipmentByCategory(category string) ([]*entities.ReferenceItem, error) {
+	args := m.Called(category)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entities.ReferenceItem), nil
+}
@@ -52,6 +52,7 @@ func apiRaceToRace(input *apiEntities.Race) *entities.Race {
 		StartingProficiencyOptions: apiChoiceOptionToChoice(input.StartingProficiencyOptions),
 		StartingProficiencies:      apiReferenceItemsToReferenceItems(input.StartingProficiencies),
 		AbilityBonuses:             apiAbilityBonusesToAbilityBonuses(input.AbilityBonuses),
+		Traits:                     apiReferenceItemsToReferenceItems(input.Traits),
 	}
 }
 
@@ -173,6 +174,11 @@ func apiEquipmentInterfaceToEquipment(input dnd5e.EquipmentInterface) entities.E
 }
 
 func apiWeaponToWeapon(input *apiEntities.Weapon) *entities.Weapon {
+	weaponRange := 0
+	if input.Range != nil {
+		weaponRange = input.Range.Normal
+	}
+
 	return &entities.Weapon{
 		Base: entities.BasicEquipment{
 			Key:    input.Key,
@@ -180,6 +186,7 @@ func apiWeaponToWeapon(input *apiEntities.Weapon) *entities.Weapon {
 			Weight: input.Weight,
 			Cost:   apiCostToCost(input.Cost),
 		},
+		Range:          weaponRange,
 		WeaponCategory: input.WeaponCategory,
 		WeaponRange:    input.WeaponRange,
 		CategoryRange:  input.CategoryRange,
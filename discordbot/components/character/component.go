@@ -20,6 +20,7 @@ const (
 	equipInventoryAction    = "equip-inventory"
 	selectProficiencyAction = "select-proficiency"
 	selectEquipmentAction   = "select-equipment"
+	selectImprovementAction = "select-improvement"
 	rollCharacterAction     = "roll-character"
 	selectAttributeKey      = "select-attribute"
 	buttonAttributeKey      = "button-attribute"
@@ -85,9 +86,26 @@ func (c *Character) GetApplicationCommand() *discordgo.ApplicationCommand {
 				Description: "Attack a target using your equipped weapon",
 				Type:        discordgo.ApplicationCommandOptionSubCommand,
 			}, {
-				Name:        "encounter",
-				Description: "Start an encounter to join with other players",
+				Name:        "validate",
+				Description: "Check your character against the rules",
 				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			}, {
+				Name:        "improve",
+				Description: "Use an ability score improvement or take a feat",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			}, {
+				Name:        "levelup",
+				Description: "Take a level you have earned in a class",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "class",
+						Description: "The class to take the level in, a new class multiclasses",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						Choices:     levelUpClasses,
+					},
+				},
 			},
 		},
 	}
@@ -109,8 +127,12 @@ func (c *Character) HandleInteractionCreate(s *discordgo.Session, i *discordgo.I
 				c.handleEquipInventory(s, i)
 			case "attack":
 				c.handleAttack(s, i)
-			case "encounter":
-				c.handleEncounterCreate(s, i)
+			case "validate":
+				c.handleValidateCharacter(s, i)
+			case "improve":
+				c.handleImproveStep(s, i)
+			case "levelup":
+				c.handleLevelUp(s, i)
 			}
 		}
 	case discordgo.InteractionMessageComponent:
@@ -150,12 +172,10 @@ func (c *Character) HandleInteractionCreate(s *discordgo.Session, i *discordgo.I
 			c.handleEquipmentSelect(s, i)
 		case equipInventoryAction:
 			c.handleEquipInventorySelect(s, i)
+		case selectImprovementAction:
+			c.handleImprovementSelect(s, i)
 		default:
 			data := i.MessageComponentData()
-			if strings.HasPrefix(data.CustomID, "encounter:join:") {
-				c.handleEncounterJoin(s, i)
-			}
-
 			if strings.HasPrefix(data.CustomID, "char:") {
 				if strings.HasSuffix(data.CustomID, ":stats") {
 					c.handleShowStats(s, i)
@@ -258,7 +278,7 @@ func (c *Character) handleLoadCharacter(s *discordgo.Session, i *discordgo.Inter
 	response := &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("Loaded character %s the %s %s", char.Name, char.Race.Name, char.Class.Name),
+			Content: fmt.Sprintf("Loaded character %s", char.NameString()),
 			Flags:   discordgo.MessageFlagsEphemeral,
 		},
 	}
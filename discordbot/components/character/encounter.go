@@ -6,6 +6,7 @@ import (
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
 	"github.com/bwmarrin/discordgo"
 	"log"
+	"strings"
 )
 
 type rederPlayerCardInput struct {
@@ -50,9 +51,44 @@ func (c *Character) renderPlayerCard(s *discordgo.Session, i *discordgo.Interact
 			Inline: true,
 		})
 
+		if char.TempHitPoints > 0 {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   "Temporary Hit Points",
+				Value:  fmt.Sprintf("%d", char.TempHitPoints),
+				Inline: true,
+			})
+		}
+
+		if resistances := char.DamageDefenses().Resistances; len(resistances) > 0 {
+			names := make([]string, len(resistances))
+			for idx, resistance := range resistances {
+				names[idx] = string(resistance)
+			}
+
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   "Resistances",
+				Value:  strings.Join(names, ", "),
+				Inline: true,
+			})
+		}
+
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
 			Name:   "Level",
-			Value:  fmt.Sprintf("%d", char.Level),
+			Value:  fmt.Sprintf("%d", char.TotalLevel()),
+			Inline: true,
+		})
+
+		if len(char.Classes) > 1 {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   "Classes",
+				Value:  char.ClassString(),
+				Inline: true,
+			})
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "Proficiency Bonus",
+			Value:  fmt.Sprintf("%+d", char.ProficiencyBonus()),
 			Inline: true,
 		})
 
@@ -63,11 +99,37 @@ func (c *Character) renderPlayerCard(s *discordgo.Session, i *discordgo.Interact
 		})
 
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   "Hit Die",
-			Value:  fmt.Sprintf("%d", char.HitDie),
+			Name:   "Hit Dice",
+			Value:  char.HitDiceString(),
 			Inline: true,
 		})
 
+		if len(char.Feats) > 0 {
+			featNames := make([]string, len(char.Feats))
+			for idx, feat := range char.Feats {
+				featNames[idx] = feat.Name
+			}
+
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  "Feats",
+				Value: strings.Join(featNames, ", "),
+			})
+		}
+
+		if char.PendingLevels > 0 {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  "Levels",
+				Value: fmt.Sprintf("%d to take with `/character levelup`", char.PendingLevels),
+			})
+		}
+
+		if char.PendingImprovements > 0 {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  "Ability Score Improvements",
+				Value: fmt.Sprintf("%d to use with `/character improve`", char.PendingImprovements),
+			})
+		}
+
 		embeds = append(embeds, embed)
 	case "attributes":
 		embed.Title = "Attributes"
@@ -181,37 +243,6 @@ func (c *Character) renderPlayerCard(s *discordgo.Session, i *discordgo.Interact
 	}
 }
 
-func (c *Character) handleEncounterJoin(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// First make sure the user has a character created
-	// if not, send them to the character creation flow
-	// if they do, add them to the encounter
-	char, err := c.charManager.Get(context.Background(), i.Member.User.ID)
-	if err != nil {
-		log.Println(err)
-		return // TODO handle error
-	}
-
-	data := i.MessageComponentData()
-	encounterID := data.CustomID[len("encounter:join:"):]
-	encounter, err := c.charManager.GetEncounter(context.Background(), encounterID)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-
-	encounter.Players = append(encounter.Players, i.Member.User.ID)
-	_, err = c.charManager.UpdateEncounter(context.Background(), encounter)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-
-	c.renderPlayerCard(s, i, &rederPlayerCardInput{
-		section: "stats",
-		char:    char,
-	})
-}
-
 func (c *Character) handleShowStats(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	char, err := c.charManager.Get(context.Background(), i.Member.User.ID)
 	if err != nil {
@@ -263,54 +294,3 @@ func (c *Character) handleShowAttributes(s *discordgo.Session, i *discordgo.Inte
 		char:    char,
 	})
 }
-
-func (c *Character) handleEncounterCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	encounter, err := c.charManager.CreateEncounter(context.Background(), &entities.Encounter{
-		Players: []string{},
-	})
-	if err != nil {
-		log.Println(err)
-		return // TODO handle error
-	}
-
-	embed := &discordgo.MessageEmbed{
-		Title:       "Encounter",
-		Description: "This is the encounter",
-	}
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{embed},
-			Components: []discordgo.MessageComponent{
-				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.Button{
-							Label:    "Join Encounter",
-							Style:    discordgo.SuccessButton,
-							CustomID: fmt.Sprintf("encounter:join:%s", encounter.ID),
-						},
-					},
-				},
-			},
-		},
-	})
-	if err != nil {
-		log.Println(err)
-		return
-	}
-
-	// grabe the response and set the message id
-	msg, err := s.InteractionResponse(i.Interaction)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-
-	// save the message id to the encounter
-	encounter.MessageID = msg.ID
-	_, err = c.charManager.UpdateEncounter(context.Background(), encounter)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-}
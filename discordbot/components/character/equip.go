@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -50,19 +52,14 @@ func (c *Character) handleEquipInventory(s *discordgo.Session, i *discordgo.Inte
 }
 
 func (c *Character) handleEquipInventorySelect(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	char, err := c.charManager.Get(context.Background(), i.Member.User.ID)
-	if err != nil {
-		log.Println(err)
-		return // TODO handle error
-	}
-
-	added := char.Equip(i.MessageComponentData().Values[0])
-	if added == false {
-		log.Println("Item not found")
-		return // TODO handle error
-	}
+	_, err := c.charManager.Update(context.Background(), i.Member.User.ID, func(char *entities.Character) error {
+		added := char.Equip(i.MessageComponentData().Values[0])
+		if added == false {
+			return dnderr.NewNotFoundError("item not found in inventory")
+		}
 
-	_, err = c.charManager.Put(context.Background(), char)
+		return nil
+	})
 	if err != nil {
 		log.Println(err)
 		return // TODO handle error
@@ -0,0 +1,211 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/bwmarrin/discordgo"
+)
+
+// improvementComponents renders the options of a choice as a select menu, nested choices keep
+// their index so the selected one can be made active
+func improvementComponents(choice *entities.Choice) []discordgo.MessageComponent {
+	options := make([]discordgo.SelectMenuOption, len(choice.Options))
+	for idx, option := range choice.Options {
+		if option.GetOptionType() == entities.OptionTypeChoice {
+			options[idx] = discordgo.SelectMenuOption{
+				Label: option.GetName(),
+				Value: fmt.Sprintf("%s::%s::%d", option.GetOptionType(), option.GetKey(), idx),
+			}
+			continue
+		}
+
+		label := option.GetName()
+		if feat := entities.GetFeat(option.GetKey()); feat != nil {
+			label = feat.String()
+		}
+
+		options[idx] = discordgo.SelectMenuOption{
+			Label: label,
+			Value: fmt.Sprintf("%s::%s::%s", option.GetOptionType(), option.GetKey(), option.GetName()),
+		}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					MinValues: &choice.Count,
+					MaxValues: choice.Count,
+					CustomID:  selectImprovementAction,
+					Options:   options,
+				},
+			},
+		},
+	}
+}
+
+func (c *Character) handleImproveStep(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	char, err := c.charManager.Get(context.Background(), i.Member.User.ID)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	response := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}
+
+	choice, err := c.charManager.StartImprovement(context.Background(), char)
+	if err != nil {
+		var exhaustedErr *dnderr.ResourceExhaustedError
+		if !errors.As(err, &exhaustedErr) {
+			log.Println(err)
+			return // TODO handle error
+		}
+
+		response.Data.Content = fmt.Sprintf("%s has no ability score improvements to use", char.Name)
+	} else {
+		response.Data.Content = fmt.Sprintf("%s has an ability score improvement, choose how to use it:", char.Name)
+		response.Data.Components = improvementComponents(choice)
+	}
+
+	err = s.InteractionRespond(i.Interaction, response)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (c *Character) handleImprovementSelect(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	choices, err := c.charManager.GetChoices(context.Background(), i.Member.User.ID, entities.ChoiceTypeAbilityScoreImprovement)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	if len(choices) == 0 {
+		log.Println("no ability score improvement choice found")
+		return // TODO handle error
+	}
+
+	choice := choices[0]
+	values := i.MessageComponentData().Values
+	parts := strings.Split(values[0], "::")
+
+	if parts[0] == string(entities.OptionTypeChoice) {
+		index, convErr := strconv.Atoi(parts[2])
+		if convErr != nil || index < 0 || index >= len(choice.Options) {
+			log.Println("invalid improvement option", values[0])
+			return // TODO handle error
+		}
+
+		choice.Status = entities.ChoiceStatusActive
+		for idx, option := range choice.Options {
+			if idx == index {
+				option.SetStatus(entities.ChoiceStatusActive)
+			} else {
+				option.SetStatus(entities.ChoiceStatusInactive)
+			}
+		}
+
+		err = c.charManager.SaveChoices(context.Background(), i.Member.User.ID, entities.ChoiceTypeAbilityScoreImprovement, choices)
+		if err != nil {
+			log.Println(err)
+			return // TODO handle error
+		}
+
+		active := choice.Options[index].(*entities.Choice)
+		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    fmt.Sprintf("%s:", active.Name),
+				Components: improvementComponents(active),
+			},
+		})
+		if err != nil {
+			log.Println(err)
+		}
+
+		return
+	}
+
+	var active *entities.Choice
+	for _, option := range choice.Options {
+		if option.GetStatus() == entities.ChoiceStatusActive {
+			active = option.(*entities.Choice)
+		}
+	}
+
+	if active == nil {
+		log.Println("no active ability score improvement option")
+		return // TODO handle error
+	}
+
+	for _, value := range values {
+		key := strings.Split(value, "::")[1]
+		for _, option := range active.Options {
+			if option.GetKey() == key {
+				option.SetStatus(entities.ChoiceStatusSelected)
+			}
+		}
+	}
+
+	active.Status = entities.ChoiceStatusSelected
+	choice.Status = entities.ChoiceStatusSelected
+
+	err = c.charManager.SaveChoices(context.Background(), i.Member.User.ID, entities.ChoiceTypeAbilityScoreImprovement, choices)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	improvement, err := entities.ImprovementFromChoice(choice)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	char, err := c.charManager.ApplyImprovement(context.Background(), i.Member.User.ID, improvement)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	msg := strings.Builder{}
+	if improvement.FeatKey != "" {
+		msg.WriteString(fmt.Sprintf("%s took the %s feat\n", char.Name, entities.GetFeat(improvement.FeatKey).Name))
+	} else {
+		msg.WriteString(fmt.Sprintf("%s improved their ability scores\n", char.Name))
+		for _, attr := range entities.Attributes {
+			if char.Attribues[attr] == nil {
+				continue
+			}
+
+			msg.WriteString(fmt.Sprintf("  -  %s: %s\n", attr, char.Attribues[attr]))
+		}
+	}
+
+	if char.PendingImprovements > 0 {
+		msg.WriteString(fmt.Sprintf("\n%d more to use, run `/character improve` again", char.PendingImprovements))
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    msg.String(),
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
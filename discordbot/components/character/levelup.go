@@ -0,0 +1,68 @@
+package character
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/bwmarrin/discordgo"
+)
+
+// levelUpClasses are the SRD classes a pending level can be taken in
+var levelUpClasses = []*discordgo.ApplicationCommandOptionChoice{
+	{Name: "Barbarian", Value: "barbarian"},
+	{Name: "Bard", Value: "bard"},
+	{Name: "Cleric", Value: "cleric"},
+	{Name: "Druid", Value: "druid"},
+	{Name: "Fighter", Value: "fighter"},
+	{Name: "Monk", Value: "monk"},
+	{Name: "Paladin", Value: "paladin"},
+	{Name: "Ranger", Value: "ranger"},
+	{Name: "Rogue", Value: "rogue"},
+	{Name: "Sorcerer", Value: "sorcerer"},
+	{Name: "Warlock", Value: "warlock"},
+	{Name: "Wizard", Value: "wizard"},
+}
+
+// handleLevelUp takes one of the character's pending levels in the chosen class, a class the
+// character does not have yet multiclasses into it
+func (c *Character) handleLevelUp(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var classKey string
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		if option.Name == "class" {
+			classKey = option.StringValue()
+		}
+	}
+
+	response := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}
+
+	char, err := c.charManager.AddClassLevel(context.Background(), i.Member.User.ID, classKey)
+	if err != nil {
+		var exhaustedErr *dnderr.ResourceExhaustedError
+		var invalidErr *dnderr.InvalidEntityError
+		switch {
+		case errors.As(err, &exhaustedErr), errors.As(err, &invalidErr):
+			response.Data.Content = err.Error()
+		default:
+			log.Println(err)
+			response.Data.Content = "Could not level up your character, try again"
+		}
+	} else {
+		response.Data.Content = fmt.Sprintf("%s is now level %d (%s)", char.Name, char.TotalLevel(), char.ClassString())
+		if char.PendingLevels > 0 {
+			response.Data.Content += fmt.Sprintf("\n%d more level(s) to take with `/character levelup`", char.PendingLevels)
+		}
+	}
+
+	err = s.InteractionRespond(i.Interaction, response)
+	if err != nil {
+		log.Println(err)
+	}
+}
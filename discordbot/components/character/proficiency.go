@@ -7,6 +7,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/KirkDiggler/dnd-bot-go/dnderr"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
@@ -128,6 +129,7 @@ func (c *Character) handleProficiencySelect(s *discordgo.Session, i *discordgo.I
 
 		if choice.Status == entities.ChoiceStatusActive {
 			selectedChoiceIndex := -1
+			indexMu := sync.Mutex{}
 
 			// here is where I would reload the options for a given choice option
 			for _, value := range i.MessageComponentData().Values {
@@ -139,19 +141,24 @@ func (c *Character) handleProficiencySelect(s *discordgo.Session, i *discordgo.I
 					if parts[0] == string(entities.OptionTypeChoice) {
 						// if we have a choice, we will check which was choses, set that to active and pass that choice back in
 						// get index and iteract through options, setting other indexes to inactive and this to active, feed back into choice
-						selectedChoiceIndex, err = strconv.Atoi(parts[2])
-						if err != nil {
-							log.Println(err)
-							return err
+						index, convErr := strconv.Atoi(parts[2])
+						if convErr != nil {
+							log.Println(convErr)
+							return convErr
 						}
+
+						indexMu.Lock()
+						selectedChoiceIndex = index
+						indexMu.Unlock()
 					} else {
-						char, err = c.charManager.AddProficiency(runCtx, char, &entities.ReferenceItem{
+						// AddProficiency mutates char under its own lock, so only the error is kept here
+						_, addErr := c.charManager.AddProficiency(runCtx, char, &entities.ReferenceItem{
 							Key:  parts[1],
 							Type: entities.ReferenceTypeProficiency,
 						})
-						if err != nil {
-							log.Println(err)
-							return err
+						if addErr != nil {
+							log.Println(addErr)
+							return addErr
 						}
 					}
 
@@ -122,6 +122,10 @@ func (c *Character) initializeCharacter(charID string) error {
 	char.HitDie = char.Class.HitDie
 	char.AC = 10
 	char.Level = 1
+	char.Classes = []*entities.ClassLevel{{
+		Class: char.Class,
+		Level: 1,
+	}}
 
 	char.Speed = char.Race.Speed
 
@@ -129,12 +133,9 @@ func (c *Character) initializeCharacter(charID string) error {
 	for _, prof := range char.Race.StartingProficiencies {
 		prof := prof
 		g.Go(func() error {
-			char, err = c.charManager.AddProficiency(runCtx, char, prof)
-			if err != nil {
-				return err
-			}
+			_, addErr := c.charManager.AddProficiency(runCtx, char, prof)
 
-			return nil
+			return addErr
 		})
 	}
 
@@ -145,24 +146,18 @@ func (c *Character) initializeCharacter(charID string) error {
 	for _, prof := range char.Class.Proficiencies {
 		prof := prof
 		g.Go(func() error {
-			char, err = c.charManager.AddProficiency(runCtx, char, prof)
-			if err != nil {
-				return err
-			}
+			_, addErr := c.charManager.AddProficiency(runCtx, char, prof)
 
-			return nil
+			return addErr
 		})
 	}
 
 	for _, equip := range char.Class.StartingEquipment {
 		equip := equip
 		g.Go(func() error {
-			char, err = c.charManager.AddInventory(runCtx, char, equip.Equipment.Key)
-			if err != nil {
-				return err
-			}
+			_, addErr := c.charManager.AddInventory(runCtx, char, equip.Equipment.Key)
 
-			return nil
+			return addErr
 		})
 	}
 	err = g.Wait()
@@ -0,0 +1,56 @@
+package character
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/validation"
+	"github.com/bwmarrin/discordgo"
+)
+
+func (c *Character) handleValidateCharacter(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	char, err := c.charManager.Get(context.Background(), i.Member.User.ID)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	result, err := c.charManager.Validate(context.Background(), char)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Rules check (%s)", result.RuleSet),
+		Description: char.NameString(),
+	}
+
+	if len(result.Violations) == 0 {
+		embed.Description = fmt.Sprintf("%s follows the rules", char.NameString())
+	}
+
+	for _, violation := range result.Violations {
+		icon := "⚠️"
+		if violation.Severity == validation.SeverityError {
+			icon = "⛔"
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s %s", icon, violation.Field),
+			Value: violation.Message,
+		})
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{embed},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
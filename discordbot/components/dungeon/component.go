@@ -0,0 +1,787 @@
+package dungeon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/discordbot/components/loot"
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// button custom ids are dungeon:<action>:<player id> so only the player in the room can use them,
+	// attack buttons add the monster id as dungeon:attack:<player id>:<monster id>
+	dungeonAttackAction    = "attack"
+	dungeonFleeAction      = "flee"
+	dungeonDisengageAction = "disengage"
+	dungeonHelpAction      = "help"
+	dungeonEndTurnAction   = "endturn"
+	dungeonSurrenderAction = "surrender"
+	// move buttons add the direction as dungeon:move:<player id>:<direction>
+	dungeonMoveAction = "move"
+	// step buttons move the character on the battle map as dungeon:step:<player id>:<direction>
+	dungeonStepAction = "step"
+	// history buttons page through past rooms as dungeon:history:<player id>:<page>
+	dungeonHistoryAction = "history"
+	// disarm has the player try the trap or challenge in the party's room
+	dungeonDisarmAction = "disarm"
+)
+
+// stepLabels are the arrows on the step buttons
+var stepLabels = map[dungeon.Direction]string{
+	dungeon.DirectionNorth: "⬆️",
+	dungeon.DirectionWest:  "⬅️",
+	dungeon.DirectionSouth: "⬇️",
+	dungeon.DirectionEast:  "➡️",
+}
+
+type Dungeon struct {
+	roomManager rooms.Manager
+}
+
+type DungeonConfig struct {
+	RoomManager rooms.Manager
+}
+
+func NewDungeon(cfg *DungeonConfig) (*Dungeon, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.RoomManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.RoomManager")
+	}
+
+	return &Dungeon{
+		roomManager: cfg.RoomManager,
+	}, nil
+}
+
+func (d *Dungeon) GetApplicationCommand() *discordgo.ApplicationCommand {
+	minPage := float64(1)
+
+	return &discordgo.ApplicationCommand{
+		Name:        "dungeon",
+		Description: "Explore a dungeon of connected rooms",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "enter",
+				Description: "Enter the dungeon, or return to the room you are in",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "seed",
+						Description: "Build the same dungeon as someone else",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+					},
+				},
+			}, {
+				Name:        "move",
+				Description: "Go through a door to the next room",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "direction",
+						Description: "The door to go through",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						Choices:     directionChoices(),
+					},
+				},
+			}, {
+				Name:        "history",
+				Description: "Page through the rooms you have fought in",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "page",
+						Description: "Page of your history to show, defaults to the first",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minPage,
+					},
+				},
+			}, {
+				Name:        "abandon",
+				Description: "Walk away from the fight you are in without finishing it",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	}
+}
+
+func directionChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(dungeon.Directions))
+	for idx, direction := range dungeon.Directions {
+		choices[idx] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(direction),
+			Value: string(direction),
+		}
+	}
+
+	return choices
+}
+
+func (d *Dungeon) HandleInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		if i.ApplicationCommandData().Name != "dungeon" {
+			return
+		}
+
+		switch i.ApplicationCommandData().Options[0].Name {
+		case "enter":
+			d.handleEnter(s, i)
+		case "move":
+			direction := i.ApplicationCommandData().Options[0].Options[0].StringValue()
+			d.handleMove(s, i, dungeon.Direction(direction), discordgo.InteractionResponseChannelMessageWithSource)
+		case "history":
+			page := 1
+			for _, option := range i.ApplicationCommandData().Options[0].Options {
+				if option.Name == "page" {
+					page = int(option.IntValue())
+				}
+			}
+
+			d.handleHistory(s, i, page, discordgo.InteractionResponseChannelMessageWithSource)
+		case "abandon":
+			d.handleAbandon(s, i)
+		}
+	case discordgo.InteractionMessageComponent:
+		parts := strings.Split(i.MessageComponentData().CustomID, ":")
+		if len(parts) < 3 || len(parts) > 4 || parts[0] != "dungeon" {
+			return
+		}
+
+		if parts[2] != i.Member.User.ID {
+			respondError(s, i, "This is not your dungeon, use `/dungeon enter` to start your own")
+			return
+		}
+
+		switch parts[1] {
+		case dungeonAttackAction:
+			var targetID string
+			if len(parts) == 4 {
+				targetID = parts[3]
+			}
+
+			d.handleAttack(s, i, targetID)
+		case dungeonFleeAction:
+			d.handleFlee(s, i)
+		case dungeonDisengageAction:
+			d.handleDisengage(s, i)
+		case dungeonHelpAction:
+			d.handleHelp(s, i)
+		case dungeonEndTurnAction:
+			d.handleEndTurn(s, i)
+		case dungeonSurrenderAction:
+			d.handleSurrender(s, i)
+		case dungeonMoveAction:
+			if len(parts) == 4 {
+				d.handleMove(s, i, dungeon.Direction(parts[3]), discordgo.InteractionResponseUpdateMessage)
+			}
+		case dungeonStepAction:
+			if len(parts) == 4 {
+				d.handleStep(s, i, dungeon.Direction(parts[3]))
+			}
+		case dungeonDisarmAction:
+			d.handleDisarm(s, i)
+		case dungeonHistoryAction:
+			if len(parts) == 4 {
+				page, err := strconv.Atoi(parts[3])
+				if err != nil {
+					return
+				}
+
+				d.handleHistory(s, i, page, discordgo.InteractionResponseUpdateMessage)
+			}
+		}
+	}
+}
+
+func (d *Dungeon) handleEnter(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	input := &rooms.EnterDungeonInput{
+		PlayerID: i.Member.User.ID,
+	}
+
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		if option.Name == "seed" {
+			seed := option.IntValue()
+			input.Seed = &seed
+		}
+	}
+
+	result, err := d.roomManager.EnterDungeon(context.Background(), input)
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			respondError(s, i, "You need a character to enter the dungeon, try `/character random`")
+			return
+		}
+
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	d.respondDungeon(s, i, discordgo.InteractionResponseChannelMessageWithSource, result.Dungeon, result.Room, result.Trap, result.Log, result.Outcome)
+}
+
+func (d *Dungeon) handleMove(s *discordgo.Session, i *discordgo.InteractionCreate, direction dungeon.Direction, responseType discordgo.InteractionResponseType) {
+	result, err := d.roomManager.Move(context.Background(), &rooms.MoveInput{
+		PlayerID:  i.Member.User.ID,
+		Direction: direction,
+	})
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			respondError(s, i, "You are not in a dungeon, use `/dungeon enter`")
+			return
+		}
+
+		var conflictErr *dnderr.ConflictError
+		if errors.As(err, &conflictErr) {
+			respondError(s, i, "Finish the fight or flee before moving on")
+			return
+		}
+
+		var invalidErr *dnderr.InvalidParameterError
+		if errors.As(err, &invalidErr) {
+			respondError(s, i, fmt.Sprintf("There is no door to the %s", direction))
+			return
+		}
+
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	d.respondDungeon(s, i, responseType, result.Dungeon, result.Room, result.Trap, result.Log, result.Outcome)
+	loot.Send(s, i, result.Loot)
+}
+
+// respondDungeon shows the map and the fight or trap in the party's room when there is one, extra
+// embeds such as the experience summary follow them
+func (d *Dungeon) respondDungeon(s *discordgo.Session, i *discordgo.InteractionCreate, responseType discordgo.InteractionResponseType,
+	current *dungeon.Dungeon, fight *entities.Room, found *entities.Trap, lines []string, outcome rooms.Outcome, extra ...*discordgo.MessageEmbed) {
+	msg := strings.Builder{}
+	msg.WriteString(strings.Join(lines, "\n"))
+
+	embeds := []*discordgo.MessageEmbed{mapEmbed(current)}
+	components := moveComponents(i.Member.User.ID, current)
+	if found != nil && found.Detected {
+		embeds = append(embeds, trapEmbed(found))
+		if found.IsArmed() && current.Status == dungeon.StatusActive {
+			components = append(trapComponents(i.Member.User.ID, found), components...)
+		}
+	}
+
+	if fight != nil {
+		embeds = append(embeds, roomEmbed(fight))
+		components = roomComponents(i.Member.User.ID, fight)
+		if message := outcomeMessage(fight, outcome); message != "" {
+			msg.WriteString(message)
+			components = moveComponents(i.Member.User.ID, current)
+		}
+	}
+
+	embeds = append(embeds, extra...)
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: responseType,
+		Data: &discordgo.InteractionResponseData{
+			Content:    msg.String(),
+			Embeds:     embeds,
+			Components: components,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (d *Dungeon) handleAttack(s *discordgo.Session, i *discordgo.InteractionCreate, targetID string) {
+	result, err := d.roomManager.Attack(context.Background(), &rooms.AttackInput{
+		PlayerID: i.Member.User.ID,
+		TargetID: targetID,
+	})
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			respondError(s, i, "This fight is over, use `/dungeon enter` to find another")
+			return
+		}
+
+		var conflictErr *dnderr.ConflictError
+		if errors.As(err, &conflictErr) {
+			respondError(s, i, fmt.Sprintf("Not yet, %s", conflictErr.Error()))
+			return
+		}
+
+		var invalidErr *dnderr.InvalidParameterError
+		if errors.As(err, &invalidErr) {
+			respondError(s, i, fmt.Sprintf("You cannot attack that, %s", invalidErr.Msg))
+			return
+		}
+
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	d.respondFight(s, i, result.Dungeon, result.Room, result.Log, result.Outcome, result.Experience)
+	loot.Send(s, i, result.Loot)
+}
+
+// respondFight redraws the fight in place after the player's turn, in a dungeon the map comes with
+// it. Once the fight is over the experience summary follows.
+func (d *Dungeon) respondFight(s *discordgo.Session, i *discordgo.InteractionCreate, current *dungeon.Dungeon, fight *entities.Room,
+	lines []string, outcome rooms.Outcome, awards []*rooms.ExperienceAward) {
+	extra := []*discordgo.MessageEmbed{}
+	if len(awards) > 0 {
+		extra = append(extra, experienceEmbed(awards))
+	}
+
+	if current != nil {
+		d.respondDungeon(s, i, discordgo.InteractionResponseUpdateMessage, current, fight, nil, lines, outcome, extra...)
+		return
+	}
+
+	msg := strings.Builder{}
+	msg.WriteString(strings.Join(lines, "\n"))
+
+	components := roomComponents(i.Member.User.ID, fight)
+	if message := outcomeMessage(fight, outcome); message != "" {
+		msg.WriteString(message)
+		components = []discordgo.MessageComponent{}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    msg.String(),
+			Embeds:     append([]*discordgo.MessageEmbed{roomEmbed(fight)}, extra...),
+			Components: components,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// respondTurnError tells the player why they could not play their turn
+func respondTurnError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	var notFoundErr *dnderr.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		respondError(s, i, "This fight is over, use `/dungeon enter` to find another")
+		return
+	}
+
+	var conflictErr *dnderr.ConflictError
+	if errors.As(err, &conflictErr) {
+		respondError(s, i, fmt.Sprintf("Not yet, %s", conflictErr.Error()))
+		return
+	}
+
+	var exhaustedErr *dnderr.ResourceExhaustedError
+	if errors.As(err, &exhaustedErr) {
+		respondError(s, i, "You have already used your action this turn")
+		return
+	}
+
+	log.Println(err)
+}
+
+// handleStep moves the character a square on the battle map and redraws it in place
+func (d *Dungeon) handleStep(s *discordgo.Session, i *discordgo.InteractionCreate, direction dungeon.Direction) {
+	result, err := d.roomManager.Step(context.Background(), &rooms.StepInput{
+		PlayerID:  i.Member.User.ID,
+		Direction: direction,
+	})
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			respondError(s, i, "This fight is over, use `/dungeon enter` to find another")
+			return
+		}
+
+		var conflictErr *dnderr.ConflictError
+		if errors.As(err, &conflictErr) {
+			respondError(s, i, fmt.Sprintf("Not yet, %s", conflictErr.Error()))
+			return
+		}
+
+		var invalidErr *dnderr.InvalidParameterError
+		if errors.As(err, &invalidErr) {
+			respondError(s, i, fmt.Sprintf("You cannot move %s, the way is blocked", direction))
+			return
+		}
+
+		var exhaustedErr *dnderr.ResourceExhaustedError
+		if errors.As(err, &exhaustedErr) {
+			respondError(s, i, "You do not have enough movement left this turn")
+			return
+		}
+
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	d.respondFight(s, i, result.Dungeon, result.Room, result.Log, result.Outcome, nil)
+}
+
+// handleDisengage spends the character's action so they can step away from the monsters
+func (d *Dungeon) handleDisengage(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := d.roomManager.Disengage(context.Background(), &rooms.DisengageInput{
+		PlayerID: i.Member.User.ID,
+	})
+	if err != nil {
+		respondTurnError(s, i, err)
+		return
+	}
+
+	d.respondFight(s, i, result.Dungeon, result.Room, result.Log, result.Outcome, nil)
+}
+
+// handleHelp spends the character's action distracting the nearest monster for the party
+func (d *Dungeon) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := d.roomManager.Help(context.Background(), &rooms.HelpInput{
+		PlayerID: i.Member.User.ID,
+	})
+	if err != nil {
+		var invalidErr *dnderr.InvalidParameterError
+		if errors.As(err, &invalidErr) {
+			respondError(s, i, "Get next to a monster to help against it")
+			return
+		}
+
+		respondTurnError(s, i, err)
+		return
+	}
+
+	d.respondFight(s, i, result.Dungeon, result.Room, result.Log, result.Outcome, nil)
+}
+
+// handleEndTurn hands the fight over to whoever is next
+func (d *Dungeon) handleEndTurn(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := d.roomManager.EndTurn(context.Background(), &rooms.EndTurnInput{
+		PlayerID: i.Member.User.ID,
+	})
+	if err != nil {
+		respondTurnError(s, i, err)
+		return
+	}
+
+	d.respondFight(s, i, result.Dungeon, result.Room, result.Log, result.Outcome, result.Experience)
+	loot.Send(s, i, result.Loot)
+}
+
+// handleSurrender asks the monsters to let the party go, the ones that refuse fight on
+func (d *Dungeon) handleSurrender(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := d.roomManager.Surrender(context.Background(), &rooms.SurrenderInput{
+		PlayerID: i.Member.User.ID,
+	})
+	if err != nil {
+		respondTurnError(s, i, err)
+		return
+	}
+
+	d.respondFight(s, i, result.Dungeon, result.Room, result.Log, result.Outcome, result.Experience)
+}
+
+// handleFlee runs the party out of the fight, the monsters in reach get a parting attack
+func (d *Dungeon) handleFlee(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := d.roomManager.Flee(context.Background(), &rooms.FleeInput{
+		PlayerID: i.Member.User.ID,
+	})
+	if err != nil {
+		respondTurnError(s, i, err)
+		return
+	}
+
+	d.respondFight(s, i, result.Dungeon, result.Room, result.Log, result.Outcome, result.Experience)
+}
+
+// outcomeMessage is empty while the fight goes on
+func outcomeMessage(room *entities.Room, outcome rooms.Outcome) string {
+	switch outcome {
+	case rooms.OutcomeVictory:
+		return fmt.Sprintf("\n\n**Victory!** %s defeated %s", partyNames(room), monsterNames(room, "the"))
+	case rooms.OutcomeDefeat:
+		return fmt.Sprintf("\n\n**Defeat!** %s fell to %s", partyNames(room), monsterNames(room, "the"))
+	case rooms.OutcomeFled:
+		return fmt.Sprintf("\n\n**Escaped!** %s flees from %s", partyNames(room), monsterNames(room, "the"))
+	case rooms.OutcomeSurrendered:
+		return fmt.Sprintf("\n\n**Surrendered!** %s gives up to %s", partyNames(room), monsterNames(room, "the"))
+	default:
+		return ""
+	}
+}
+
+// partyNames lists the characters in the room
+func partyNames(room *entities.Room) string {
+	names := make([]string, len(room.Characters))
+	for idx, char := range room.Characters {
+		names[idx] = char.Name
+	}
+
+	return joinNames(names)
+}
+
+// monsterNames lists the monsters in the room, a lone monster gets the article
+func monsterNames(room *entities.Room, article string) string {
+	if len(room.Monsters) == 1 {
+		return fmt.Sprintf("%s %s", article, room.Monsters[0].GetName())
+	}
+
+	names := make([]string, len(room.Monsters))
+	for idx, mon := range room.Monsters {
+		names[idx] = mon.GetName()
+	}
+
+	return joinNames(names)
+}
+
+func joinNames(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+
+	return fmt.Sprintf("%s and %s", strings.Join(names[:len(names)-1], ", "), names[len(names)-1])
+}
+
+// RoomResponse shows the fight to the player with the buttons to play their turn, it lets other
+// components hand a player off to a fight
+func RoomResponse(playerID string, room *entities.Room, lines []string) *discordgo.InteractionResponseData {
+	return &discordgo.InteractionResponseData{
+		Content:    strings.Join(lines, "\n"),
+		Embeds:     []*discordgo.MessageEmbed{roomEmbed(room)},
+		Components: roomComponents(playerID, room),
+	}
+}
+
+func roomEmbed(room *entities.Room) *discordgo.MessageEmbed {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(room.Characters)+len(room.Monsters))
+	for _, char := range room.Characters {
+		characterHP := fmt.Sprintf("%d/%d", char.CurrentHitPoints, char.MaxHitPoints)
+		if char.TempHitPoints > 0 {
+			characterHP = fmt.Sprintf("%s (+%d temp)", characterHP, char.TempHitPoints)
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   char.Name,
+			Value:  fmt.Sprintf("%s\nHP: %s\nAC: %d", char.ClassString(), characterHP, char.AC),
+			Inline: true,
+		})
+	}
+
+	for _, mon := range room.Monsters {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   mon.GetName(),
+			Value:  fmt.Sprintf("CR %v\nHP: %d/%d\nAC: %d", mon.Template.ChallengeRating, mon.CurrentHP, mon.Template.HitPoints, mon.Template.ArmorClass),
+			Inline: true,
+		})
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:  "Dungeon",
+		Fields: fields,
+	}
+
+	if room.Grid != nil {
+		labels, legend := gridLabels(room)
+		embed.Description = fmt.Sprintf("```\n%s\n```", room.Grid.Render(labels))
+		embed.Footer = &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%s  ~ difficult  # wall", strings.Join(legend, "  ")),
+		}
+	}
+
+	return embed
+}
+
+// gridLabels marks the characters on the battle map with numbers and the monsters with letters,
+// the legend names each mark
+func gridLabels(room *entities.Room) (map[string]string, []string) {
+	labels := make(map[string]string)
+	legend := make([]string, 0, len(room.Characters)+len(room.Monsters))
+	for idx, char := range room.Characters {
+		label := fmt.Sprintf("%d", (idx+1)%10)
+		labels[char.ID] = label
+		legend = append(legend, fmt.Sprintf("%s %s", label, char.Name))
+	}
+
+	for idx, mon := range room.Monsters {
+		label := string(rune('A' + idx%26))
+		labels[mon.ID] = label
+		legend = append(legend, fmt.Sprintf("%s %s", label, mon.GetName()))
+	}
+
+	return labels, legend
+}
+
+// experienceEmbed is the post fight summary of the experience each character earned
+func experienceEmbed(awards []*rooms.ExperienceAward) *discordgo.MessageEmbed {
+	fields := make([]*discordgo.MessageEmbedField, len(awards))
+	for idx, award := range awards {
+		progress := fmt.Sprintf("%d XP, max level", award.Experience)
+		if award.NextLevel > 0 {
+			progress = fmt.Sprintf("%d/%d XP to level %d", award.Experience, award.NextLevel, award.Level+award.PendingLevels+1)
+		}
+
+		value := fmt.Sprintf("+%d XP\n%s", award.XP, progress)
+		if award.PendingLevels > 0 {
+			value = fmt.Sprintf("%s\n**Level up!** Take %d level(s) with `/character levelup`", value, award.PendingLevels)
+		}
+
+		fields[idx] = &discordgo.MessageEmbedField{
+			Name:   award.Name,
+			Value:  value,
+			Inline: true,
+		}
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:  "Experience",
+		Color:  0x2ecc71,
+		Fields: fields,
+	}
+}
+
+// roomComponents has an attack button for each monster still standing and a row of the other
+// things to do on a turn, with a battle map a row of buttons steps the character around it
+func roomComponents(playerID string, room *entities.Room) []discordgo.MessageComponent {
+	buttons := make([]discordgo.MessageComponent, 0, len(room.Monsters))
+	for _, mon := range room.Monsters {
+		if mon.IsDown() {
+			continue
+		}
+
+		label := "Attack"
+		if len(room.Monsters) > 1 {
+			label = fmt.Sprintf("Attack %s", mon.GetName())
+		}
+
+		buttons = append(buttons, discordgo.Button{
+			Label:    label,
+			Style:    discordgo.DangerButton,
+			CustomID: fmt.Sprintf("dungeon:%s:%s:%s", dungeonAttackAction, playerID, mon.ID),
+		})
+	}
+
+	actions := make([]discordgo.MessageComponent, 0, 5)
+	for _, action := range []struct {
+		label string
+		name  string
+	}{
+		{"Help", dungeonHelpAction},
+		{"Disengage", dungeonDisengageAction},
+		{"End Turn", dungeonEndTurnAction},
+		{"Flee", dungeonFleeAction},
+		{"Surrender", dungeonSurrenderAction},
+	} {
+		actions = append(actions, discordgo.Button{
+			Label:    action.label,
+			Style:    discordgo.SecondaryButton,
+			CustomID: fmt.Sprintf("dungeon:%s:%s", action.name, playerID),
+		})
+	}
+
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: buttons,
+		},
+		discordgo.ActionsRow{
+			Components: actions,
+		},
+	}
+
+	if room.Grid == nil {
+		return components
+	}
+
+	steps := make([]discordgo.MessageComponent, 0, len(dungeon.Directions))
+	for _, direction := range []dungeon.Direction{dungeon.DirectionWest, dungeon.DirectionNorth, dungeon.DirectionSouth, dungeon.DirectionEast} {
+		steps = append(steps, discordgo.Button{
+			Label:    stepLabels[direction],
+			Style:    discordgo.SecondaryButton,
+			CustomID: fmt.Sprintf("dungeon:%s:%s:%s", dungeonStepAction, playerID, direction),
+		})
+	}
+
+	return append(components, discordgo.ActionsRow{
+		Components: steps,
+	})
+}
+
+func mapEmbed(current *dungeon.Dungeon) *discordgo.MessageEmbed {
+	status := "Exploring"
+	switch current.Status {
+	case dungeon.StatusComplete:
+		status = "Cleared"
+	case dungeon.StatusFailed:
+		status = "Lost"
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Dungeon %d", current.Seed),
+		Description: fmt.Sprintf("```\n%s\n```", dungeon.Render(current)),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Status",
+				Value:  status,
+				Inline: true,
+			}, {
+				Name:   "Rooms",
+				Value:  fmt.Sprintf("%d", len(current.Rooms)),
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: "@ you  ? unexplored  M monsters  T trap  $ treasure  E entrance",
+		},
+	}
+}
+
+// moveComponents has a button for each door out of the party's room while the dungeon is explored
+func moveComponents(playerID string, current *dungeon.Dungeon) []discordgo.MessageComponent {
+	room := current.Current()
+	if current.Status != dungeon.StatusActive || room == nil {
+		return []discordgo.MessageComponent{}
+	}
+
+	buttons := make([]discordgo.MessageComponent, 0, len(room.Exits))
+	for _, direction := range dungeon.Directions {
+		if !room.HasExit(direction) {
+			continue
+		}
+
+		buttons = append(buttons, discordgo.Button{
+			Label:    fmt.Sprintf("Go %s", direction),
+			Style:    discordgo.PrimaryButton,
+			CustomID: fmt.Sprintf("dungeon:%s:%s:%s", dungeonMoveAction, playerID, direction),
+		})
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: buttons,
+		},
+	}
+}
+
+func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, msg string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: msg,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
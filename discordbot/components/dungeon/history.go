@@ -0,0 +1,190 @@
+package dungeon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/bwmarrin/discordgo"
+)
+
+// historyPageSize is how many rooms a page of history shows
+const historyPageSize = 5
+
+// handleHistory shows a page of the rooms the player fought in, latest first
+func (d *Dungeon) handleHistory(s *discordgo.Session, i *discordgo.InteractionCreate, page int, responseType discordgo.InteractionResponseType) {
+	page = max(page, 1)
+	result, err := d.roomManager.History(context.Background(), &rooms.HistoryInput{
+		PlayerID: i.Member.User.ID,
+		Offset:   (page - 1) * historyPageSize,
+		Limit:    historyPageSize,
+	})
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	if len(result.Rooms) == 0 && page == 1 {
+		respondError(s, i, "You have not fought in any rooms yet, use `/dungeon enter`")
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: responseType,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{historyEmbed(result.Rooms, page)},
+			Components: historyComponents(i.Member.User.ID, page, result.HasMore),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func historyEmbed(summaries []*rooms.RoomSummary, page int) *discordgo.MessageEmbed {
+	fields := make([]*discordgo.MessageEmbedField, len(summaries))
+	for idx, summary := range summaries {
+		where := "Room"
+		if summary.DungeonID != "" {
+			where = "Dungeon room"
+		}
+
+		when := "some time ago"
+		if !summary.StartedAt.IsZero() {
+			when = fmt.Sprintf("<t:%d:f>", summary.StartedAt.Unix())
+		}
+
+		fields[idx] = &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s, %s", where, outcomeName(summary)),
+			Value: fmt.Sprintf("%s\n%s", countMonsters(summary.Monsters), when),
+		}
+	}
+
+	if len(fields) == 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "Nothing here",
+			Value: "There are no rooms on this page",
+		})
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:  "History",
+		Fields: fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Page %d", page),
+		},
+	}
+}
+
+// outcomeName is how the room ended in the words of the history
+func outcomeName(summary *rooms.RoomSummary) string {
+	switch summary.Outcome {
+	case rooms.OutcomeVictory:
+		return "won"
+	case rooms.OutcomeDefeat:
+		return "lost"
+	case rooms.OutcomeFled:
+		return "fled"
+	case rooms.OutcomeAbandoned:
+		return "abandoned"
+	case rooms.OutcomeSurrendered:
+		return "surrendered"
+	}
+
+	if summary.Status == entities.RoomStatusActive {
+		return "in progress"
+	}
+
+	return "over"
+}
+
+// countMonsters groups the monsters of a kind, 2 x Goblin
+func countMonsters(names []string) string {
+	if len(names) == 0 {
+		return "Unknown monsters"
+	}
+
+	counts := make(map[string]int)
+	kinds := make([]string, 0)
+	for _, name := range names {
+		if counts[name] == 0 {
+			kinds = append(kinds, name)
+		}
+
+		counts[name]++
+	}
+
+	parts := make([]string, len(kinds))
+	for idx, kind := range kinds {
+		parts[idx] = kind
+		if counts[kind] > 1 {
+			parts[idx] = fmt.Sprintf("%d x %s", counts[kind], kind)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func historyComponents(playerID string, page int, hasMore bool) []discordgo.MessageComponent {
+	if page <= 1 && !hasMore {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Newer",
+					Style:    discordgo.SecondaryButton,
+					Disabled: page <= 1,
+					CustomID: fmt.Sprintf("dungeon:%s:%s:%d", dungeonHistoryAction, playerID, page-1),
+				},
+				discordgo.Button{
+					Label:    "Older",
+					Style:    discordgo.SecondaryButton,
+					Disabled: !hasMore,
+					CustomID: fmt.Sprintf("dungeon:%s:%s:%d", dungeonHistoryAction, playerID, page+1),
+				},
+			},
+		},
+	}
+}
+
+// handleAbandon closes the player's fight, in a dungeon they are shown the map again
+func (d *Dungeon) handleAbandon(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := d.roomManager.Abandon(context.Background(), &rooms.AbandonInput{
+		PlayerID: i.Member.User.ID,
+	})
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			respondError(s, i, "You are not in a fight")
+			return
+		}
+
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	data := &discordgo.InteractionResponseData{
+		Content: fmt.Sprintf("%s abandons the fight with %s", partyNames(result.Room), monsterNames(result.Room, "the")),
+	}
+
+	if result.Dungeon != nil {
+		data.Embeds = []*discordgo.MessageEmbed{mapEmbed(result.Dungeon)}
+		data.Components = moveComponents(i.Member.User.ID, result.Dungeon)
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,104 @@
+package dungeon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/bwmarrin/discordgo"
+)
+
+// trapStatusNames are how the trap embed shows where the trap is at
+var trapStatusNames = map[entities.TrapStatus]string{
+	entities.TrapStatusArmed:    "Armed",
+	entities.TrapStatusDisarmed: "Disarmed",
+	entities.TrapStatusSprung:   "Sprung",
+}
+
+// handleDisarm has the player try to disarm the trap or get past the challenge in the party's room
+func (d *Dungeon) handleDisarm(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := d.roomManager.Disarm(context.Background(), &rooms.DisarmInput{
+		PlayerID: i.Member.User.ID,
+	})
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			respondError(s, i, "There is nothing here to disarm")
+			return
+		}
+
+		var invalidErr *dnderr.InvalidEntityError
+		if errors.As(err, &invalidErr) {
+			respondError(s, i, "Your character is down, rest before trying again")
+			return
+		}
+
+		var conflictErr *dnderr.ConflictError
+		if errors.As(err, &conflictErr) {
+			respondError(s, i, "Someone else is already at the trap, see how it went before trying again")
+			return
+		}
+
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	d.respondDungeon(s, i, discordgo.InteractionResponseUpdateMessage, result.Dungeon, nil, result.Trap, result.Log, rooms.OutcomeUnset)
+}
+
+func trapEmbed(found *entities.Trap) *discordgo.MessageEmbed {
+	check := fmt.Sprintf("DC %d %s", found.DisarmDC, found.DisarmAttribute)
+	if len(found.DisarmWith) > 0 {
+		check = fmt.Sprintf("%s, proficiency with %s helps", check, strings.Join(found.DisarmWith, " or "))
+	}
+
+	status := trapStatusNames[found.Status]
+	if status == "" {
+		status = trapStatusNames[entities.TrapStatusArmed]
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       found.Name,
+		Description: found.Description,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Status",
+				Value:  status,
+				Inline: true,
+			}, {
+				Name:   "Check",
+				Value:  check,
+				Inline: true,
+			}, {
+				Name:   "Saving Throw",
+				Value:  fmt.Sprintf("DC %d %s, %dd%d %s damage, half on a save", found.SaveDC, found.SaveAttribute, found.Damage.DiceCount, found.Damage.DiceSize, found.Damage.DamageType),
+				Inline: true,
+			},
+		},
+	}
+}
+
+// trapComponents is the button to try the trap, the door buttons follow it
+func trapComponents(playerID string, found *entities.Trap) []discordgo.MessageComponent {
+	label := fmt.Sprintf("Disarm the %s", found.Name)
+	if found.Kind == entities.TrapKindChallenge {
+		label = fmt.Sprintf("Cross the %s", found.Name)
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    label,
+					Style:    discordgo.DangerButton,
+					CustomID: fmt.Sprintf("dungeon:%s:%s", dungeonDisarmAction, playerID),
+				},
+			},
+		},
+	}
+}
@@ -1,4 +1,362 @@
 package encounter
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/characters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/turntimers"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	defaultPartySize = 1
+	maxPartySize     = 8
+	maxTurnTimeout   = 3600
+	// customIDPrefix namespaces the component's buttons, a custom ID is encounter:<action>:<args>
+	customIDPrefix = "encounter"
+)
+
+// commandHandler plays a /encounter subcommand
+type commandHandler func(s *discordgo.Session, i *discordgo.InteractionCreate)
+
+// buttonHandler plays a button or select, args are the parts of the custom ID after the action
+type buttonHandler func(s *discordgo.Session, i *discordgo.InteractionCreate, args []string)
+
 type Encounter struct {
+	encounterManager encounters.Manager
+	characterManager characters.Manager
+	roomManager      rooms.Manager
+	turnTimerManager turntimers.Manager
+
+	commands map[string]commandHandler
+	buttons  map[string]buttonHandler
+}
+
+type EncounterConfig struct {
+	EncounterManager encounters.Manager
+	CharacterManager characters.Manager
+	// RoomManager has the logs of the fights played in rooms and dungeons
+	RoomManager rooms.Manager
+	// TurnTimerManager reminds the player whose turn it is and skips their turn when time runs out
+	TurnTimerManager turntimers.Manager
+}
+
+func NewEncounter(cfg *EncounterConfig) (*Encounter, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.EncounterManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.EncounterManager")
+	}
+
+	if cfg.CharacterManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.CharacterManager")
+	}
+
+	if cfg.RoomManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.RoomManager")
+	}
+
+	if cfg.TurnTimerManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.TurnTimerManager")
+	}
+
+	e := &Encounter{
+		encounterManager: cfg.EncounterManager,
+		characterManager: cfg.CharacterManager,
+		roomManager:      cfg.RoomManager,
+		turnTimerManager: cfg.TurnTimerManager,
+	}
+
+	e.commands = map[string]commandHandler{
+		"build":  e.handleBuild,
+		"create": e.handleCreate,
+		"join":   e.lobbyCommand(joinAction, "You joined the encounter"),
+		"status": e.handleStatus,
+		"end":    e.lobbyCommand(endAction, "The encounter is over"),
+		"log":    e.handleLogCommand,
+		"replay": e.handleReplay,
+		"export": e.handleExport,
+	}
+
+	e.buttons = map[string]buttonHandler{
+		logAction:        e.handleLogPage,
+		joinAction:       e.lobbyButton(joinAction),
+		leaveAction:      e.lobbyButton(leaveAction),
+		kickAction:       e.lobbyButton(kickAction),
+		readyCheckAction: e.lobbyButton(readyCheckAction),
+		readyAction:      e.lobbyButton(readyAction),
+		startAction:      e.handleStartButton,
+		turnAction:       e.handleTurnButton,
+		endAction:        e.lobbyButton(endAction),
+		closeAction:      e.lobbyButton(closeAction),
+	}
+
+	return e, nil
+}
+
+// customID builds a custom ID in the component's namespace
+func customID(action string, args ...string) string {
+	return strings.Join(append([]string{customIDPrefix, action}, args...), ":")
+}
+
+func (e *Encounter) GetApplicationCommand() *discordgo.ApplicationCommand {
+	difficulties := make([]*discordgo.ApplicationCommandOptionChoice, len(encounters.Difficulties))
+	for idx, difficulty := range encounters.Difficulties {
+		difficulties[idx] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  string(difficulty),
+			Value: string(difficulty),
+		}
+	}
+
+	minValue := float64(1)
+	noTimer := float64(0)
+
+	return &discordgo.ApplicationCommand{
+		Name:        "encounter",
+		Description: "Plan encounters for your party",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "build",
+				Description: "Build a balanced group of monsters",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "difficulty",
+						Description: "How hard the encounter should be",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						Choices:     difficulties,
+					}, {
+						Name:        "party_size",
+						Description: "Number of characters in the party",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minValue,
+						MaxValue:    maxPartySize,
+					}, {
+						Name:        "level",
+						Description: "Level of the characters, defaults to the level of your character",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minValue,
+						MaxValue:    20,
+					},
+				},
+			}, {
+				Name:        "create",
+				Description: "Open an encounter in this channel for players to join",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "max_players",
+						Description: fmt.Sprintf("Most players that can join, defaults to %d", entities.DefaultMaxPlayers),
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minValue,
+						MaxValue:    maxPartySize,
+					}, {
+						Name:        "turn_timeout",
+						Description: fmt.Sprintf("Seconds each player has for their turn, defaults to %d and 0 turns it off", entities.DefaultTurnTimeout),
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &noTimer,
+						MaxValue:    maxTurnTimeout,
+					}, {
+						Name:        "ping_after",
+						Description: fmt.Sprintf("Seconds before the player is reminded it is their turn, defaults to %d", entities.DefaultPingAfter),
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minValue,
+						MaxValue:    maxTurnTimeout,
+					}, {
+						Name:        "stealth",
+						Description: "Have the party sneak up on the monsters to try to surprise them",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+					},
+				},
+			}, {
+				Name:        "join",
+				Description: "Join the encounter in this channel",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			}, {
+				Name:        "status",
+				Description: "Show the encounter in this channel",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			}, {
+				Name:        "end",
+				Description: "End the encounter in this channel, only the GM can",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			}, {
+				Name:        "log",
+				Description: "Page through the log of your last fight",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "page",
+						Description: "Page of the log to show, defaults to the first",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minValue,
+					},
+				},
+			}, {
+				Name:        "replay",
+				Description: "Replay your last fight turn by turn",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			}, {
+				Name:        "export",
+				Description: "Download the log of your last fight as JSON",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	}
+}
+
+func (e *Encounter) HandleInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		if i.ApplicationCommandData().Name != "encounter" {
+			return
+		}
+
+		handler, ok := e.commands[i.ApplicationCommandData().Options[0].Name]
+		if !ok {
+			return
+		}
+
+		handler(s, i)
+	case discordgo.InteractionMessageComponent:
+		parts := strings.Split(i.MessageComponentData().CustomID, ":")
+		if len(parts) < 2 || parts[0] != customIDPrefix {
+			return
+		}
+
+		handler, ok := e.buttons[parts[1]]
+		if !ok {
+			return
+		}
+
+		handler(s, i, parts[2:])
+	}
+}
+
+func (e *Encounter) handleBuild(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var difficulty encounters.Difficulty
+	partySize := defaultPartySize
+	level := 0
+
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		switch option.Name {
+		case "difficulty":
+			difficulty = encounters.Difficulty(option.StringValue())
+		case "party_size":
+			partySize = int(option.IntValue())
+		case "level":
+			level = int(option.IntValue())
+		}
+	}
+
+	if level == 0 {
+		char, err := e.characterManager.Get(context.Background(), i.Member.User.ID)
+		if err != nil {
+			var notFoundErr *dnderr.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				respondError(s, i, "You need a character or a `level` to build an encounter")
+				return
+			}
+
+			log.Println(err)
+			return // TODO handle error
+		}
+
+		level = max(char.TotalLevel(), 1)
+	}
+
+	levels := make([]int, partySize)
+	for idx := range levels {
+		levels[idx] = level
+	}
+
+	result, err := e.encounterManager.Build(context.Background(), &encounters.BuildInput{
+		Difficulty:  difficulty,
+		PartyLevels: levels,
+	})
+	if err != nil {
+		log.Println(err)
+		respondError(s, i, "Could not build an encounter, try again")
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{buildEmbed(result, partySize, level)},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func buildEmbed(result *encounters.BuildOutput, partySize, level int) *discordgo.MessageEmbed {
+	counts := make(map[string]int)
+	unique := make([]*entities.MonsterTemplate, 0)
+	for _, monster := range result.Monsters {
+		if counts[monster.Key] == 0 {
+			unique = append(unique, monster)
+		}
+
+		counts[monster.Key]++
+	}
+
+	monsters := strings.Builder{}
+	for _, monster := range unique {
+		monsters.WriteString(fmt.Sprintf("%d x %s (CR %s, %d XP)\n", counts[monster.Key], monster.Name,
+			encounters.FormatChallengeRating(monster.ChallengeRating), monster.XP))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("A %s encounter", result.Difficulty),
+		Description: fmt.Sprintf("For %d level %d character(s)", partySize, level),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Monsters",
+				Value: monsters.String(),
+			}, {
+				Name:   "XP Budget",
+				Value:  fmt.Sprintf("%d", result.Budget),
+				Inline: true,
+			}, {
+				Name:   "Adjusted XP",
+				Value:  fmt.Sprintf("%d", result.AdjustedXP),
+				Inline: true,
+			}, {
+				Name:   "XP Reward",
+				Value:  fmt.Sprintf("%d", result.XP),
+				Inline: true,
+			},
+		},
+	}
+}
+
+func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, msg string) {
+	respondEphemeral(s, i, msg)
+}
+
+// respondEphemeral answers only the player who used the command
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, msg string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: msg,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
 }
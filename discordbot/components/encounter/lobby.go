@@ -0,0 +1,554 @@
+package encounter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	dungeonComponent "github.com/KirkDiggler/dnd-bot-go/discordbot/components/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	logAction        = "log"
+	joinAction       = "join"
+	leaveAction      = "leave"
+	kickAction       = "kick"
+	readyCheckAction = "readycheck"
+	readyAction      = "ready"
+	startAction      = "start"
+	turnAction       = "turn"
+	endAction        = "end"
+	closeAction      = "close"
+)
+
+var statusNames = map[entities.EncounterStatus]string{
+	entities.EncounterStatusOpen:       "Waiting for players",
+	entities.EncounterStatusReadyCheck: "Ready check",
+	entities.EncounterStatusInProgress: "Fighting",
+	entities.EncounterStatusResolved:   "Over",
+	entities.EncounterStatusArchived:   "Closed",
+}
+
+// outcomeNames are how a resolved encounter's fight ended
+var outcomeNames = map[rooms.Outcome]string{
+	rooms.OutcomeVictory:     "the party won",
+	rooms.OutcomeDefeat:      "the party fell",
+	rooms.OutcomeFled:        "the party fled",
+	rooms.OutcomeSurrendered: "the party surrendered",
+	rooms.OutcomeAbandoned:   "the party walked away",
+}
+
+// handleCreate opens an encounter in the channel with the player as its GM
+func (e *Encounter) handleCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	input := &encounters.CreateInput{
+		GMID:      i.Member.User.ID,
+		ChannelID: i.ChannelID,
+	}
+
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		switch option.Name {
+		case "max_players":
+			input.MaxPlayers = int(option.IntValue())
+		case "turn_timeout":
+			timeout := int(option.IntValue())
+			input.TurnTimeout = &timeout
+		case "ping_after":
+			input.PingAfter = int(option.IntValue())
+		case "stealth":
+			input.Stealth = option.BoolValue()
+		}
+	}
+
+	encounter, err := e.encounterManager.Create(context.Background(), input)
+	if err != nil {
+		var invalidErr *dnderr.InvalidParameterError
+		if errors.As(err, &invalidErr) {
+			respondError(s, i, "A turn timeout is 0 or at least 15 seconds, and the reminder has to come before it")
+			return
+		}
+
+		log.Println(err)
+		respondError(s, i, "Could not create the encounter, try again")
+		return
+	}
+
+	e.postEncounter(s, i, encounter)
+}
+
+// handleStatus posts the channel's encounter again, the new message is the one kept up to date
+func (e *Encounter) handleStatus(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	encounter, err := e.encounterManager.GetByChannel(context.Background(), i.ChannelID)
+	if err != nil {
+		respondEncounterError(s, i, err)
+		return
+	}
+
+	e.postEncounter(s, i, encounter)
+}
+
+// postEncounter shows the encounter and saves the message so it can be kept up to date
+func (e *Encounter) postEncounter(s *discordgo.Session, i *discordgo.InteractionCreate, encounter *entities.Encounter) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{encounterEmbed(encounter)},
+			Components: e.encounterComponents(encounter),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	msg, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	_, err = e.encounterManager.SetMessage(context.Background(), &encounters.SetMessageInput{
+		EncounterID: encounter.ID,
+		ChannelID:   msg.ChannelID,
+		MessageID:   msg.ID,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// lobbyCommand plays the action on the channel's encounter and refreshes its message, the player
+// is told done
+func (e *Encounter) lobbyCommand(action, done string) commandHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		ctx := context.Background()
+		encounter, err := e.encounterManager.GetByChannel(ctx, i.ChannelID)
+		if err != nil {
+			respondEncounterError(s, i, err)
+			return
+		}
+
+		encounter, err = e.playAction(ctx, action, encounter.ID, i.Member.User.ID, nil)
+		if err != nil {
+			respondEncounterError(s, i, err)
+			return
+		}
+
+		e.refreshEncounterMessage(s, encounter)
+		respondEphemeral(s, i, done)
+	}
+}
+
+// lobbyButton plays the action on the encounter the button is for and redraws its message, the
+// buttons are <action>:<encounter id>
+func (e *Encounter) lobbyButton(action string) buttonHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate, args []string) {
+		if len(args) != 1 {
+			return
+		}
+
+		encounter, err := e.playAction(context.Background(), action, args[0], i.Member.User.ID, i.MessageComponentData().Values)
+		if err != nil {
+			respondEncounterError(s, i, err)
+			return
+		}
+
+		e.updateEncounterMessage(s, i, encounter, "")
+	}
+}
+
+// playAction runs the player's action on the encounter, values are what they picked in a select
+func (e *Encounter) playAction(ctx context.Context, action, encounterID, playerID string, values []string) (*entities.Encounter, error) {
+	player := &encounters.PlayerInput{
+		EncounterID: encounterID,
+		PlayerID:    playerID,
+	}
+
+	switch action {
+	case joinAction:
+		_, err := e.characterManager.Get(ctx, playerID)
+		if err != nil {
+			var notFoundErr *dnderr.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				return nil, dnderr.NewNotFoundError("you need a character to join, try `/character random`")
+			}
+
+			return nil, err
+		}
+
+		return e.encounterManager.Join(ctx, player)
+	case leaveAction:
+		return e.encounterManager.Leave(ctx, player)
+	case kickAction:
+		if len(values) == 0 {
+			return nil, dnderr.NewMissingParameterError("values")
+		}
+
+		return e.encounterManager.Kick(ctx, &encounters.KickInput{
+			EncounterID: encounterID,
+			GMID:        playerID,
+			PlayerID:    values[0],
+		})
+	case readyCheckAction:
+		return e.encounterManager.ReadyCheck(ctx, player)
+	case readyAction:
+		return e.encounterManager.Ready(ctx, player)
+	case endAction:
+		encounter, err := e.encounterManager.End(ctx, player)
+		if err != nil {
+			return nil, err
+		}
+
+		e.cancelTurnTimer(ctx, encounter.ID)
+
+		return encounter, nil
+	case closeAction:
+		return e.encounterManager.Archive(ctx, player)
+	default:
+		return nil, dnderr.NewInvalidParameterError("action", action)
+	}
+}
+
+// handleStartButton hands the party off to a fight once everyone is ready, every player shares
+// one new room and it will not start while any of them is still in another fight
+func (e *Encounter) handleStartButton(s *discordgo.Session, i *discordgo.InteractionCreate, args []string) {
+	if len(args) != 1 {
+		return
+	}
+
+	ctx := context.Background()
+	encounter, err := e.encounterManager.Get(ctx, args[0])
+	if err != nil {
+		respondEncounterError(s, i, err)
+		return
+	}
+
+	err = encounter.CanStart(i.Member.User.ID)
+	if err != nil {
+		respondEncounterError(s, i, err)
+		return
+	}
+
+	fight, err := e.roomManager.CreatePartyRoom(ctx, &rooms.CreatePartyRoomInput{
+		PlayerIDs: encounter.Players,
+		Stealth:   encounter.Stealth,
+	})
+	if err != nil {
+		var conflictErr *dnderr.ConflictError
+		if errors.As(err, &conflictErr) {
+			respondError(s, i, fmt.Sprintf("Could not start the fight, %s", err.Error()))
+			return
+		}
+
+		log.Println(err)
+		respondError(s, i, "Could not start the fight, try again")
+		return
+	}
+
+	start := &encounters.StartInput{
+		EncounterID: encounter.ID,
+		GMID:        i.Member.User.ID,
+		RoomID:      fight.Room.ID,
+	}
+	if fight.Surprise != nil {
+		start.Surprised = fight.Surprise.Names()
+	}
+
+	started, err := e.encounterManager.Start(ctx, start)
+	if err != nil {
+		// no encounter points at the room, leaving it open would keep the party out of every
+		// other fight
+		_, abandonErr := e.roomManager.Abandon(ctx, &rooms.AbandonInput{
+			PlayerID: encounter.Players[0],
+		})
+		if abandonErr != nil {
+			log.Println(abandonErr)
+		}
+
+		respondEncounterError(s, i, err)
+		return
+	}
+
+	e.scheduleTurnTimer(ctx, started.ID)
+	e.updateEncounterMessage(s, i, started, strings.Join(fight.Log, "\n"))
+}
+
+// handleTurnButton shows the player the fight so they can play their turn, once the fight is over
+// the encounter is resolved
+func (e *Encounter) handleTurnButton(s *discordgo.Session, i *discordgo.InteractionCreate, args []string) {
+	if len(args) != 1 {
+		return
+	}
+
+	ctx := context.Background()
+	encounter, err := e.encounterManager.Get(ctx, args[0])
+	if err != nil {
+		respondEncounterError(s, i, err)
+		return
+	}
+
+	if !encounter.HasPlayer(i.Member.User.ID) {
+		respondError(s, i, "You are not in this encounter")
+		return
+	}
+
+	turn, err := e.roomManager.CurrentTurn(ctx, &rooms.CurrentTurnInput{
+		RoomID: encounter.RoomID,
+	})
+	if err != nil {
+		respondEncounterError(s, i, err)
+		return
+	}
+
+	if !turn.Active {
+		encounter, err = e.encounterManager.Resolve(ctx, encounter.ID, string(turn.Outcome))
+		if err != nil {
+			respondEncounterError(s, i, err)
+			return
+		}
+
+		e.cancelTurnTimer(ctx, encounter.ID)
+		e.updateEncounterMessage(s, i, encounter, "The fight is over")
+		return
+	}
+
+	// the fight may have moved on without the timer noticing yet
+	e.scheduleTurnTimer(ctx, encounter.ID)
+
+	// the encounter's room, the player may have been in other fights since it started
+	fight, err := e.roomManager.GetRoom(ctx, &rooms.GetRoomInput{
+		RoomID: encounter.RoomID,
+	})
+	if err != nil {
+		respondEncounterError(s, i, err)
+		return
+	}
+
+	data := dungeonComponent.RoomResponse(i.Member.User.ID, fight.Room, []string{})
+	data.Flags = discordgo.MessageFlagsEphemeral
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// updateEncounterMessage redraws the encounter message the button was pressed on
+func (e *Encounter) updateEncounterMessage(s *discordgo.Session, i *discordgo.InteractionCreate, encounter *entities.Encounter, content string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Embeds:     []*discordgo.MessageEmbed{encounterEmbed(encounter)},
+			Components: e.encounterComponents(encounter),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// refreshEncounterMessage edits the stored encounter message after a change made by a command
+func (e *Encounter) refreshEncounterMessage(s *discordgo.Session, encounter *entities.Encounter) {
+	if encounter.MessageID == "" || encounter.ChannelID == "" {
+		return
+	}
+
+	embeds := []*discordgo.MessageEmbed{encounterEmbed(encounter)}
+	components := e.encounterComponents(encounter)
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         encounter.MessageID,
+		Channel:    encounter.ChannelID,
+		Embeds:     &embeds,
+		Components: &components,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func encounterEmbed(encounter *entities.Encounter) *discordgo.MessageEmbed {
+	players := strings.Builder{}
+	for _, playerID := range encounter.Players {
+		players.WriteString(fmt.Sprintf("<@%s>", playerID))
+		if encounter.GetStatus() == entities.EncounterStatusReadyCheck && slices.Contains(encounter.Ready, playerID) {
+			players.WriteString(" ✅")
+		}
+
+		players.WriteString("\n")
+	}
+
+	if players.Len() == 0 {
+		players.WriteString("No one yet")
+	}
+
+	size := fmt.Sprintf("%d", len(encounter.Players))
+	if encounter.MaxPlayers > 0 {
+		size = fmt.Sprintf("%d/%d", len(encounter.Players), encounter.MaxPlayers)
+	}
+
+	status := statusNames[encounter.GetStatus()]
+	if outcome, ok := outcomeNames[rooms.Outcome(encounter.Outcome)]; ok {
+		status = fmt.Sprintf("%s, %s", status, outcome)
+	}
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   "Status",
+			Value:  status,
+			Inline: true,
+		},
+	}
+
+	if encounter.GMID != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "GM",
+			Value:  fmt.Sprintf("<@%s>", encounter.GMID),
+			Inline: true,
+		})
+	}
+
+	if encounter.TurnTimeout > 0 {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Turn Timer",
+			Value:  fmt.Sprintf("%ds, reminder after %ds", encounter.TurnTimeout, encounter.PingAfter),
+			Inline: true,
+		})
+	}
+
+	if encounter.Stealth {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "Stealth",
+			Value:  surpriseValue(encounter),
+			Inline: true,
+		})
+	}
+
+	fields = append(fields, &discordgo.MessageEmbedField{
+		Name:  fmt.Sprintf("Players (%s)", size),
+		Value: players.String(),
+	})
+
+	return &discordgo.MessageEmbed{
+		Title:  "Encounter",
+		Fields: fields,
+	}
+}
+
+// surpriseValue is who the party caught off guard once the fight has started
+func surpriseValue(encounter *entities.Encounter) string {
+	switch {
+	case encounter.RoomID == "":
+		return "The party will sneak up on the monsters"
+	case len(encounter.Surprised) == 0:
+		return "The monsters noticed the party"
+	default:
+		return fmt.Sprintf("Surprised: %s", strings.Join(encounter.Surprised, ", "))
+	}
+}
+
+// encounterComponents are the buttons for where the encounter is at, everyone sees the GM's
+// buttons and the encounter refuses anyone else who presses them
+func (e *Encounter) encounterComponents(encounter *entities.Encounter) []discordgo.MessageComponent {
+	button := func(label, action string, style discordgo.ButtonStyle) discordgo.MessageComponent {
+		return discordgo.Button{
+			Label:    label,
+			Style:    style,
+			CustomID: customID(action, encounter.ID),
+		}
+	}
+
+	var buttons []discordgo.MessageComponent
+	gathering := false
+	switch encounter.GetStatus() {
+	case entities.EncounterStatusOpen:
+		gathering = true
+		buttons = []discordgo.MessageComponent{
+			button("Join", joinAction, discordgo.SuccessButton),
+			button("Leave", leaveAction, discordgo.SecondaryButton),
+			button("Ready Check", readyCheckAction, discordgo.PrimaryButton),
+			button("Close", closeAction, discordgo.DangerButton),
+		}
+	case entities.EncounterStatusReadyCheck:
+		gathering = true
+		buttons = []discordgo.MessageComponent{
+			button("Ready", readyAction, discordgo.SuccessButton),
+			button("Join", joinAction, discordgo.SecondaryButton),
+			button("Leave", leaveAction, discordgo.SecondaryButton),
+			button("Start", startAction, discordgo.PrimaryButton),
+			button("Close", closeAction, discordgo.DangerButton),
+		}
+	case entities.EncounterStatusInProgress:
+		buttons = []discordgo.MessageComponent{
+			button("Take your turn", turnAction, discordgo.PrimaryButton),
+			button("End", endAction, discordgo.DangerButton),
+		}
+	case entities.EncounterStatusResolved:
+		buttons = []discordgo.MessageComponent{
+			button("Close", closeAction, discordgo.DangerButton),
+		}
+	default:
+		return []discordgo.MessageComponent{}
+	}
+
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: buttons,
+		},
+	}
+
+	if !gathering || len(encounter.Players) == 0 {
+		return components
+	}
+
+	options := make([]discordgo.SelectMenuOption, len(encounter.Players))
+	for idx, playerID := range encounter.Players {
+		label := playerID
+		char, err := e.characterManager.Get(context.Background(), playerID)
+		if err == nil {
+			label = char.Name
+		}
+
+		options[idx] = discordgo.SelectMenuOption{
+			Label: label,
+			Value: playerID,
+		}
+	}
+
+	return append(components, discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{
+				CustomID:    customID(kickAction, encounter.ID),
+				Placeholder: "Kick a player",
+				Options:     options,
+			},
+		},
+	})
+}
+
+// respondEncounterError tells the player why the encounter turned them down
+func respondEncounterError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	var notFoundErr *dnderr.NotFoundError
+	var exhaustedErr *dnderr.ResourceExhaustedError
+	var existsErr *dnderr.AlreadyExistsError
+	var conflictErr *dnderr.ConflictError
+	var deniedErr *dnderr.PermissionDeniedError
+	var invalidErr *dnderr.InvalidEntityError
+	switch {
+	case errors.As(err, &notFoundErr), errors.As(err, &exhaustedErr), errors.As(err, &existsErr),
+		errors.As(err, &conflictErr), errors.As(err, &deniedErr), errors.As(err, &invalidErr):
+		respondError(s, i, fmt.Sprintf("Sorry, %s", err.Error()))
+	default:
+		log.Println(err)
+		respondError(s, i, "Something went wrong with the encounter, try again")
+	}
+}
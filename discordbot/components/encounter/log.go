@@ -0,0 +1,264 @@
+package encounter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	logPageSize = 15
+	// replayDelay spaces out the turns of a replay so they can be followed
+	replayDelay = 2 * time.Second
+)
+
+// fightLog is the JSON export of a fight
+type fightLog struct {
+	RoomID     string          `json:"room_id"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Events     []*combat.Event `json:"events"`
+}
+
+// handleLogCommand shows the page of the log asked for, the first one by default
+func (e *Encounter) handleLogCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	page := 1
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		if option.Name == "page" {
+			page = int(option.IntValue())
+		}
+	}
+
+	e.handleLog(s, i, "", page, discordgo.InteractionResponseChannelMessageWithSource)
+}
+
+// handleLogPage turns the page of the log the buttons are on, the buttons are log:<room id>:<page>
+func (e *Encounter) handleLogPage(s *discordgo.Session, i *discordgo.InteractionCreate, args []string) {
+	if len(args) != 2 {
+		return
+	}
+
+	page, err := strconv.Atoi(args[1])
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	e.handleLog(s, i, args[0], page, discordgo.InteractionResponseUpdateMessage)
+}
+
+func (e *Encounter) handleLog(s *discordgo.Session, i *discordgo.InteractionCreate, roomID string, page int, responseType discordgo.InteractionResponseType) {
+	page = max(page, 1)
+
+	result, err := e.roomManager.CombatLog(context.Background(), &rooms.CombatLogInput{
+		PlayerID: i.Member.User.ID,
+		RoomID:   roomID,
+		Offset:   (page - 1) * logPageSize,
+		Limit:    logPageSize,
+	})
+	if err != nil {
+		e.respondLogError(s, i, err)
+		return
+	}
+
+	pages := max((result.Total+logPageSize-1)/logPageSize, 1)
+	if page > pages {
+		respondError(s, i, fmt.Sprintf("The log only has %d page(s)", pages))
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: responseType,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{logEmbed(result, page, pages)},
+			Components: logComponents(result.RoomID, page, pages),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// handleReplay posts the fight again one turn per message
+func (e *Encounter) handleReplay(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := e.roomManager.CombatLog(context.Background(), &rooms.CombatLogInput{
+		PlayerID: i.Member.User.ID,
+	})
+	if err != nil {
+		e.respondLogError(s, i, err)
+		return
+	}
+
+	if len(result.Events) == 0 {
+		respondError(s, i, "Nothing happened in your last fight")
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("Replaying the fight, %d events...", len(result.Events)),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	go func() {
+		for _, turn := range turns(result.Events) {
+			time.Sleep(replayDelay)
+
+			_, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+				Content: turnMessage(turn),
+			})
+			if err != nil {
+				log.Println(err)
+				return
+			}
+		}
+	}()
+}
+
+func (e *Encounter) handleExport(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := e.roomManager.CombatLog(context.Background(), &rooms.CombatLogInput{
+		PlayerID: i.Member.User.ID,
+	})
+	if err != nil {
+		e.respondLogError(s, i, err)
+		return
+	}
+
+	buf, err := json.MarshalIndent(&fightLog{
+		RoomID:     result.RoomID,
+		ExportedAt: time.Now().UTC(),
+		Events:     result.Events,
+	}, "", "  ")
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("The log of your last fight, %d events", len(result.Events)),
+			Files: []*discordgo.File{{
+				Name:        fmt.Sprintf("fight-%s.json", result.RoomID),
+				ContentType: "application/json",
+				Reader:      bytes.NewReader(buf),
+			}},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (e *Encounter) respondLogError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	var notFoundErr *dnderr.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		respondError(s, i, "You have not fought anything yet, try `/dungeon enter`")
+		return
+	}
+
+	log.Println(err)
+	respondError(s, i, "Could not load the fight, try again")
+}
+
+// turns groups the events into the initiative roll, each combatant's turn and the end of the fight
+func turns(events []*combat.Event) [][]*combat.Event {
+	out := make([][]*combat.Event, 0)
+	previous := ""
+	for _, event := range events {
+		key := previous
+		switch event.Type {
+		case combat.EventTypeInitiative:
+			key = "initiative"
+		case combat.EventTypeAttack:
+			key = fmt.Sprintf("turn:%d:%s", event.Round, event.ActorID)
+		case combat.EventTypeCondition:
+			key = fmt.Sprintf("condition:%d", event.Round)
+		case combat.EventTypeEnd:
+			key = "end"
+		}
+
+		if key != previous || len(out) == 0 {
+			out = append(out, make([]*combat.Event, 0))
+		}
+
+		out[len(out)-1] = append(out[len(out)-1], event)
+		previous = key
+	}
+
+	return out
+}
+
+func turnMessage(turn []*combat.Event) string {
+	msg := strings.Builder{}
+	if turn[0].Round > 0 && turn[0].Type != combat.EventTypeInitiative {
+		msg.WriteString(fmt.Sprintf("**Round %d**\n", turn[0].Round))
+	}
+
+	for _, event := range turn {
+		msg.WriteString(event.Text)
+		msg.WriteString("\n")
+	}
+
+	return msg.String()
+}
+
+func logEmbed(result *rooms.CombatLogOutput, page, pages int) *discordgo.MessageEmbed {
+	lines := make([]string, len(result.Events))
+	for idx, event := range result.Events {
+		lines[idx] = fmt.Sprintf("`%d` R%d %s", event.Seq+1, event.Round, event.Text)
+	}
+
+	description := strings.Join(lines, "\n")
+	if description == "" {
+		description = "Nothing happened in this fight"
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Combat log",
+		Description: description,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Page %d of %d, %d events", page, pages, result.Total),
+		},
+	}
+}
+
+func logComponents(roomID string, page, pages int) []discordgo.MessageComponent {
+	if pages < 2 {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Previous",
+					Style:    discordgo.SecondaryButton,
+					Disabled: page <= 1,
+					CustomID: customID(logAction, roomID, strconv.Itoa(page-1)),
+				},
+				discordgo.Button{
+					Label:    "Next",
+					Style:    discordgo.SecondaryButton,
+					Disabled: page >= pages,
+					CustomID: customID(logAction, roomID, strconv.Itoa(page+1)),
+				},
+			},
+		},
+	}
+}
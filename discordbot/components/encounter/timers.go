@@ -0,0 +1,87 @@
+package encounter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/turntimers"
+	"github.com/bwmarrin/discordgo"
+)
+
+// RunTurnTimers checks the turn timers every interval until ctx is done. The deadlines live in
+// redis so the timers pick up where they left off after a restart.
+func (e *Encounter) RunTurnTimers(ctx context.Context, s *discordgo.Session, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := e.turnTimerManager.Tick(ctx)
+			if err != nil {
+				log.Println(err)
+			}
+
+			if result != nil {
+				e.postTurnTimers(ctx, s, result)
+			}
+		}
+	}
+}
+
+// postTurnTimers reminds the players whose turn is running out and tells the channel about the
+// turns that were skipped
+func (e *Encounter) postTurnTimers(ctx context.Context, s *discordgo.Session, result *turntimers.TickOutput) {
+	for _, failure := range result.Failures {
+		log.Printf("turn timer for encounter %s: %v", failure.Timer.EncounterID, failure.Err)
+	}
+
+	for _, timer := range result.Pings {
+		_, err := s.ChannelMessageSend(timer.ChannelID,
+			fmt.Sprintf("<@%s> it is your turn, you have until <t:%d:R>", timer.PlayerID, timer.Deadline.Unix()))
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	for _, skip := range result.Skips {
+		_, err := s.ChannelMessageSend(skip.Timer.ChannelID, strings.Join(skip.Result.Log, "\n"))
+		if err != nil {
+			log.Println(err)
+		}
+
+		if skip.Result.Outcome == rooms.OutcomeUnset {
+			continue
+		}
+
+		encounter, err := e.encounterManager.Get(ctx, skip.Timer.EncounterID)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		e.refreshEncounterMessage(s, encounter)
+	}
+}
+
+// scheduleTurnTimer starts the timer for the turn being played, a fight going on without its timer
+// is better than one that does not go on
+func (e *Encounter) scheduleTurnTimer(ctx context.Context, encounterID string) {
+	_, err := e.turnTimerManager.Schedule(ctx, encounterID)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (e *Encounter) cancelTurnTimer(ctx context.Context, encounterID string) {
+	err := e.turnTimerManager.Cancel(ctx, encounterID)
+	if err != nil {
+		log.Println(err)
+	}
+}
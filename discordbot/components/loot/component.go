@@ -0,0 +1,226 @@
+package loot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	lootManager "github.com/KirkDiggler/dnd-bot-go/internal/managers/loot"
+	"github.com/bwmarrin/discordgo"
+)
+
+// button custom ids are loot:<loot id>:<item index>:<choice>, anyone sharing the loot can use them
+
+type Loot struct {
+	lootManager lootManager.Manager
+}
+
+type LootConfig struct {
+	LootManager lootManager.Manager
+}
+
+func NewLoot(cfg *LootConfig) (*Loot, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.LootManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.LootManager")
+	}
+
+	return &Loot{
+		lootManager: cfg.LootManager,
+	}, nil
+}
+
+func (l *Loot) HandleInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	parts := strings.Split(i.MessageComponentData().CustomID, ":")
+	if len(parts) != 4 || parts[0] != "loot" {
+		return
+	}
+
+	index, err := strconv.Atoi(parts[2])
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	result, err := l.lootManager.Choose(context.Background(), &lootManager.ChooseInput{
+		LootID:   parts[1],
+		PlayerID: i.Member.User.ID,
+		Index:    index,
+		Choice:   entities.LootChoice(parts[3]),
+	})
+	if err != nil {
+		var invalidErr *dnderr.InvalidParameterError
+		if errors.As(err, &invalidErr) {
+			respondError(s, i, "You are not sharing this loot")
+			return
+		}
+
+		var conflictErr *dnderr.ConflictError
+		if errors.As(err, &conflictErr) {
+			respondError(s, i, fmt.Sprintf("Too late, %s", conflictErr.Error()))
+			return
+		}
+
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	content := fmt.Sprintf("<@%s> chose %s", i.Member.User.ID, parts[3])
+	if result.Item != nil {
+		content = awardMessage(result.Item)
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Embeds:     []*discordgo.MessageEmbed{Embed(result.Loot)},
+			Components: Components(result.Loot),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func awardMessage(item *entities.LootItem) string {
+	if item.WinnerID == "" {
+		return fmt.Sprintf("Everyone passed on the %s", item.Name)
+	}
+
+	roll := item.Rolls[item.WinnerID]
+
+	return fmt.Sprintf("<@%s> wins the %s with a %s roll of %d", item.WinnerID, item.Name, roll.Choice, roll.Roll)
+}
+
+// Send posts the loot as a follow up to an interaction that has already been responded to
+func Send(s *discordgo.Session, i *discordgo.InteractionCreate, loot *entities.Loot) {
+	if loot == nil {
+		return
+	}
+
+	_, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds:     []*discordgo.MessageEmbed{Embed(loot)},
+		Components: Components(loot),
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// Embed shows the coins each player got and who each item went to
+func Embed(loot *entities.Loot) *discordgo.MessageEmbed {
+	coins := "no coins"
+	if loot.Coins != nil && !loot.Coins.IsZero() {
+		coins = loot.Coins.String()
+		if len(loot.PlayerIDs) > 1 {
+			coins = fmt.Sprintf("%s, split between %d players", coins, len(loot.PlayerIDs))
+		}
+	}
+
+	fields := []*discordgo.MessageEmbedField{{
+		Name:  "Coins",
+		Value: coins,
+	}}
+
+	for _, item := range loot.Items {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  item.Name,
+			Value: itemStatus(loot, item),
+		})
+	}
+
+	footer := "Need beats greed, the highest roll wins"
+	if loot.Mode == entities.LootModeRoundRobin {
+		footer = "Items are handed out in turn"
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:  "Treasure",
+		Color:  0xf1c40f,
+		Fields: fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: footer,
+		},
+	}
+}
+
+func itemStatus(loot *entities.Loot, item *entities.LootItem) string {
+	if item.Awarded {
+		if item.WinnerID == "" {
+			return "Everyone passed"
+		}
+
+		return fmt.Sprintf("Goes to <@%s>", item.WinnerID)
+	}
+
+	chosen := make([]string, 0, len(item.Rolls))
+	for _, playerID := range loot.PlayerIDs {
+		if roll, ok := item.Rolls[playerID]; ok {
+			chosen = append(chosen, fmt.Sprintf("<@%s> %s", playerID, roll.Choice))
+		}
+	}
+
+	if len(chosen) == 0 {
+		return "Up for grabs"
+	}
+
+	return fmt.Sprintf("Up for grabs, %s", strings.Join(chosen, ", "))
+}
+
+// Components has need, greed and pass buttons for each item still up for grabs
+func Components(loot *entities.Loot) []discordgo.MessageComponent {
+	rows := make([]discordgo.MessageComponent, 0, len(loot.Items))
+	for idx, item := range loot.Items {
+		if item.Awarded {
+			continue
+		}
+
+		rows = append(rows, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    fmt.Sprintf("Need %s", item.Name),
+					Style:    discordgo.SuccessButton,
+					CustomID: fmt.Sprintf("loot:%s:%d:%s", loot.ID, idx, entities.LootChoiceNeed),
+				},
+				discordgo.Button{
+					Label:    "Greed",
+					Style:    discordgo.PrimaryButton,
+					CustomID: fmt.Sprintf("loot:%s:%d:%s", loot.ID, idx, entities.LootChoiceGreed),
+				},
+				discordgo.Button{
+					Label:    "Pass",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("loot:%s:%d:%s", loot.ID, idx, entities.LootChoicePass),
+				},
+			},
+		})
+	}
+
+	return rows
+}
+
+func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, msg string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: msg,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,301 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/houserules"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	minCritRange = 15
+	maxCritRange = 20
+)
+
+// commandHandler plays a /rules subcommand
+type commandHandler func(s *discordgo.Session, i *discordgo.InteractionCreate)
+
+// Rules shows and changes the guild's house rules for attacks, anyone can look but only members
+// who can manage the server can change them
+type Rules struct {
+	houseRulesRepo houserules.Repository
+
+	commands map[string]commandHandler
+}
+
+type RulesConfig struct {
+	HouseRulesRepo houserules.Repository
+}
+
+func NewRules(cfg *RulesConfig) (*Rules, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.HouseRulesRepo == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.HouseRulesRepo")
+	}
+
+	r := &Rules{
+		houseRulesRepo: cfg.HouseRulesRepo,
+	}
+
+	r.commands = map[string]commandHandler{
+		"view":  r.handleView,
+		"set":   r.handleSet,
+		"reset": r.handleReset,
+	}
+
+	return r, nil
+}
+
+func (r *Rules) GetApplicationCommand() *discordgo.ApplicationCommand {
+	minValue := float64(minCritRange)
+
+	return &discordgo.ApplicationCommand{
+		Name:        "rules",
+		Description: "House rules for critical hits, fumbles and flanking",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "view",
+				Description: "Show the house rules the server plays by",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			}, {
+				Name:        "set",
+				Description: "Change the house rules, only server managers can",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "crit_damage",
+						Description: "How a critical hit adds damage",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Roll the damage dice twice", Value: string(attack.CritDamageDice)},
+							{Name: "Add the most the dice could roll", Value: string(attack.CritDamageMax)},
+						},
+					}, {
+						Name:        "crit_range",
+						Description: "Lowest natural roll that is a critical hit",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minValue,
+						MaxValue:    maxCritRange,
+					}, {
+						Name:        "confirm_crits",
+						Description: "A critical hit has to hit again to do critical damage",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+					}, {
+						Name:        "fumbles",
+						Description: "What a natural 1 does",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Only misses", Value: string(attack.FumbleMiss)},
+							{Name: "Misses and rolls on the fumble table", Value: string(attack.FumbleTable)},
+						},
+					}, {
+						Name:        "flanking",
+						Description: "Melee attacks have advantage with an ally on the other side of the target",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+					},
+				},
+			}, {
+				Name:        "reset",
+				Description: "Go back to the SRD rules, only server managers can",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	}
+}
+
+func (r *Rules) HandleInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	if i.ApplicationCommandData().Name != "rules" {
+		return
+	}
+
+	handler, ok := r.commands[i.ApplicationCommandData().Options[0].Name]
+	if !ok {
+		return
+	}
+
+	handler(s, i)
+}
+
+// getRules loads the guild's house rules, a guild that has not set any plays by the SRD
+func (r *Rules) getRules(guildID string) (*attack.Rules, error) {
+	rules, err := r.houseRulesRepo.Get(context.Background(), guildID)
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return attack.DefaultRules(), nil
+		}
+
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *Rules) handleView(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	rules, err := r.getRules(i.GuildID)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	respondRules(s, i, "", rules)
+}
+
+func (r *Rules) handleSet(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !canManage(i) {
+		respondError(s, i, "Only server managers can change the house rules")
+		return
+	}
+
+	rules, err := r.getRules(i.GuildID)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		switch option.Name {
+		case "crit_damage":
+			rules.CritDamage = attack.CritDamage(option.StringValue())
+		case "crit_range":
+			rules.CritRange = int(option.IntValue())
+		case "confirm_crits":
+			rules.ConfirmCrits = option.BoolValue()
+		case "fumbles":
+			rules.Fumbles = attack.FumbleMode(option.StringValue())
+		case "flanking":
+			rules.Flanking = option.BoolValue()
+		}
+	}
+
+	rules, err = r.houseRulesRepo.Put(context.Background(), i.GuildID, rules)
+	if err != nil {
+		var invalidErr *dnderr.InvalidParameterError
+		if errors.As(err, &invalidErr) {
+			respondError(s, i, fmt.Sprintf("Those are not rules we can play by, %s", invalidErr.Error()))
+			return
+		}
+
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	respondRules(s, i, fmt.Sprintf("<@%s> changed the house rules", i.Member.User.ID), rules)
+}
+
+func (r *Rules) handleReset(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !canManage(i) {
+		respondError(s, i, "Only server managers can change the house rules")
+		return
+	}
+
+	err := r.houseRulesRepo.Delete(context.Background(), i.GuildID)
+	if err != nil {
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	respondRules(s, i, fmt.Sprintf("<@%s> put the server back on the SRD rules", i.Member.User.ID), attack.DefaultRules())
+}
+
+// canManage is true for members who can manage the server
+func canManage(i *discordgo.InteractionCreate) bool {
+	return i.Member != nil && i.Member.Permissions&discordgo.PermissionManageServer != 0
+}
+
+func respondRules(s *discordgo.Session, i *discordgo.InteractionCreate, content string, rules *attack.Rules) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Embeds:  []*discordgo.MessageEmbed{Embed(rules)},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// Embed lists each house rule and what it does at the table
+func Embed(rules *attack.Rules) *discordgo.MessageEmbed {
+	critDamage := "The damage dice are rolled twice"
+	if rules.GetCritDamage() == attack.CritDamageMax {
+		critDamage = "The most the damage dice could roll is added to the roll"
+	}
+
+	critRange := "A natural 20"
+	if rules.GetCritRange() < maxCritRange {
+		critRange = fmt.Sprintf("A natural %d-20", rules.GetCritRange())
+	}
+
+	confirm := "No, every critical hit counts"
+	if rules.ConfirmCrits {
+		confirm = "Yes, the attack is rolled again and has to hit for critical damage"
+	}
+
+	fumbles := "A natural 1 misses"
+	if rules.GetFumbles() == attack.FumbleTable {
+		fumbles = "A natural 1 misses and rolls a d6, 1-2 loses the reaction and 3-4 the rest of the movement"
+	}
+
+	flanking := "No"
+	if rules.Flanking {
+		flanking = "Yes, melee attacks have advantage with an ally on the other side of the target"
+	}
+
+	footer := "House rules"
+	if *rules == *attack.DefaultRules() || *rules == (attack.Rules{}) {
+		footer = "Playing by the SRD"
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: "Combat Rules",
+		Color: 0x9b59b6,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  "Critical Damage",
+				Value: critDamage,
+			}, {
+				Name:  "Critical Range",
+				Value: critRange,
+			}, {
+				Name:  "Confirm Critical Hits",
+				Value: confirm,
+			}, {
+				Name:  "Fumbles",
+				Value: fumbles,
+			}, {
+				Name:  "Flanking",
+				Value: flanking,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: footer,
+		},
+	}
+}
+
+func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, msg string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: msg,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
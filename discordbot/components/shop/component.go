@@ -0,0 +1,491 @@
+package shop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/characters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/shops"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// buy menus are shop:buy:<merchant key>, anyone looking at the shop can use them
+	buyAction = "buy"
+	// sell menus are shop:sell:<merchant key>, they are only shown to the player selling
+	sellAction = "sell"
+
+	// maxSelectOptions is the most options discord allows in a select menu
+	maxSelectOptions = 25
+)
+
+// commandHandler plays a /shop subcommand
+type commandHandler func(s *discordgo.Session, i *discordgo.InteractionCreate)
+
+// Shop lets players buy SRD equipment from the guild's merchants and sell their own for half
+// of what it is worth, server managers set what each merchant charges
+type Shop struct {
+	shopManager      shops.Manager
+	characterManager characters.Manager
+
+	commands map[string]commandHandler
+}
+
+type ShopConfig struct {
+	ShopManager      shops.Manager
+	CharacterManager characters.Manager
+}
+
+func NewShop(cfg *ShopConfig) (*Shop, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.ShopManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.ShopManager")
+	}
+
+	if cfg.CharacterManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.CharacterManager")
+	}
+
+	sh := &Shop{
+		shopManager:      cfg.ShopManager,
+		characterManager: cfg.CharacterManager,
+	}
+
+	sh.commands = map[string]commandHandler{
+		"browse": sh.handleBrowse,
+		"sell":   sh.handleSell,
+		"prices": sh.handlePrices,
+	}
+
+	return sh, nil
+}
+
+func (sh *Shop) GetApplicationCommand() *discordgo.ApplicationCommand {
+	minValue := float64(shops.MinPriceModifier)
+
+	merchantChoices := make([]*discordgo.ApplicationCommandOptionChoice, len(entities.Merchants()))
+	for idx, merchant := range entities.Merchants() {
+		merchantChoices[idx] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  merchant.Name,
+			Value: merchant.Key,
+		}
+	}
+
+	merchantOption := func() *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Name:        "merchant",
+			Description: "Who to trade with",
+			Type:        discordgo.ApplicationCommandOptionString,
+			Required:    true,
+			Choices:     merchantChoices,
+		}
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:        "shop",
+		Description: "Buy and sell equipment with the town's merchants",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "browse",
+				Description: "See what a merchant has for sale and buy it",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options:     []*discordgo.ApplicationCommandOption{merchantOption()},
+			}, {
+				Name:        "sell",
+				Description: "Sell your equipment for half of what it is worth",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options:     []*discordgo.ApplicationCommandOption{merchantOption()},
+			}, {
+				Name:        "prices",
+				Description: "Change what a merchant charges, only server managers can",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					merchantOption(),
+					{
+						Name:        "percent",
+						Description: "Percent of the SRD price the merchant buys and sells at, 100 is the SRD price",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    true,
+						MinValue:    &minValue,
+						MaxValue:    shops.MaxPriceModifier,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (sh *Shop) HandleInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		if i.ApplicationCommandData().Name != "shop" {
+			return
+		}
+
+		handler, ok := sh.commands[i.ApplicationCommandData().Options[0].Name]
+		if !ok {
+			return
+		}
+
+		handler(s, i)
+	case discordgo.InteractionMessageComponent:
+		parts := strings.Split(i.MessageComponentData().CustomID, ":")
+		if len(parts) != 3 || parts[0] != "shop" {
+			return
+		}
+
+		switch parts[1] {
+		case buyAction:
+			sh.handleBuy(s, i, parts[2])
+		case sellAction:
+			sh.handleSellItem(s, i, parts[2])
+		}
+	}
+}
+
+func (sh *Shop) handleBrowse(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	result, err := sh.shopManager.Browse(context.Background(), &shops.BrowseInput{
+		GuildID:     i.GuildID,
+		MerchantKey: optionValue(i, "merchant"),
+	})
+	if err != nil {
+		respondShopError(s, i, err)
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{Embed(result)},
+			Components: buyComponents(result),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (sh *Shop) handleBuy(s *discordgo.Session, i *discordgo.InteractionCreate, merchantKey string) {
+	values := i.MessageComponentData().Values
+	if len(values) == 0 {
+		return
+	}
+
+	result, err := sh.shopManager.Buy(context.Background(), &shops.TradeInput{
+		GuildID:     i.GuildID,
+		MerchantKey: merchantKey,
+		PlayerID:    i.Member.User.ID,
+		ItemKey:     values[0],
+	})
+	if err != nil {
+		respondShopError(s, i, err)
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("%s buys the %s from %s for %s, %s left in the purse",
+				result.Character.Name, result.Name, result.Shop.Merchant.Name, result.Price.String(), result.Character.Coins.String()),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// handleSell shows the player what the merchant would pay for each item they carry
+func (sh *Shop) handleSell(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	merchantKey := optionValue(i, "merchant")
+	result, err := sh.shopManager.Browse(context.Background(), &shops.BrowseInput{
+		GuildID:     i.GuildID,
+		MerchantKey: merchantKey,
+	})
+	if err != nil {
+		respondShopError(s, i, err)
+		return
+	}
+
+	char, err := sh.characterManager.Get(context.Background(), i.Member.User.ID)
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			respondError(s, i, "You need a character to sell anything, try `/character random`")
+			return
+		}
+
+		log.Println(err)
+		return // TODO handle error
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    sellContent(result, char),
+			Components: sellComponents(result, char),
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (sh *Shop) handleSellItem(s *discordgo.Session, i *discordgo.InteractionCreate, merchantKey string) {
+	values := i.MessageComponentData().Values
+	if len(values) == 0 {
+		return
+	}
+
+	result, err := sh.shopManager.Sell(context.Background(), &shops.TradeInput{
+		GuildID:     i.GuildID,
+		MerchantKey: merchantKey,
+		PlayerID:    i.Member.User.ID,
+		ItemKey:     values[0],
+	})
+	if err != nil {
+		respondShopError(s, i, err)
+		return
+	}
+
+	sold := fmt.Sprintf("You sold the %s for %s.", result.Name, result.Price.String())
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    fmt.Sprintf("%s %s", sold, sellContent(result.Shop, result.Character)),
+			Components: sellComponents(result.Shop, result.Character),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (sh *Shop) handlePrices(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !canManage(i) {
+		respondError(s, i, "Only server managers can change what merchants charge")
+		return
+	}
+
+	var percent int
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		if option.Name == "percent" {
+			percent = int(option.IntValue())
+		}
+	}
+
+	result, err := sh.shopManager.SetPriceModifier(context.Background(), &shops.SetPriceModifierInput{
+		GuildID:       i.GuildID,
+		MerchantKey:   optionValue(i, "merchant"),
+		PriceModifier: percent,
+	})
+	if err != nil {
+		var invalidErr *dnderr.InvalidParameterError
+		if errors.As(err, &invalidErr) {
+			respondError(s, i, fmt.Sprintf("Those prices won't do, %s", invalidErr.Error()))
+			return
+		}
+
+		respondShopError(s, i, err)
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: fmt.Sprintf("<@%s> changed the prices at %s", i.Member.User.ID, result.Merchant.Name),
+			Embeds:  []*discordgo.MessageEmbed{Embed(result)},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// Embed lists the merchant's stock by category with what they charge
+func Embed(shop *entities.Shop) *discordgo.MessageEmbed {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(shop.Merchant.Categories))
+	for _, category := range shop.Merchant.Categories {
+		lines := make([]string, 0)
+		for _, item := range shop.Items {
+			if item.Category == category {
+				lines = append(lines, fmt.Sprintf("%s: %s", item.Name, entities.CoinsFromCopper(shop.BuyPrice(item.Cost)).String()))
+			}
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  categoryName(category),
+			Value: strings.Join(lines, "\n"),
+		})
+	}
+
+	footer := "SRD prices, merchants pay half of what an item is worth"
+	if shop.GetPriceModifier() != entities.DefaultPriceModifier {
+		footer = fmt.Sprintf("%d%% of SRD prices, merchants pay half of that for an item", shop.GetPriceModifier())
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       shop.Merchant.Name,
+		Description: shop.Merchant.Description,
+		Color:       0xe67e22,
+		Fields:      fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: footer,
+		},
+	}
+}
+
+// buyComponents is a select menu for each category the merchant stocks
+func buyComponents(shop *entities.Shop) []discordgo.MessageComponent {
+	rows := make([]discordgo.MessageComponent, 0, len(shop.Merchant.Categories))
+	for _, category := range shop.Merchant.Categories {
+		options := make([]discordgo.SelectMenuOption, 0)
+		for _, item := range shop.Items {
+			if item.Category != category || len(options) == maxSelectOptions {
+				continue
+			}
+
+			options = append(options, discordgo.SelectMenuOption{
+				Label:       item.Name,
+				Value:       item.Key,
+				Description: entities.CoinsFromCopper(shop.BuyPrice(item.Cost)).String(),
+			})
+		}
+
+		if len(options) == 0 {
+			continue
+		}
+
+		rows = append(rows, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    fmt.Sprintf("shop:%s:%s", buyAction, shop.Merchant.Key),
+					Placeholder: fmt.Sprintf("Buy %s", strings.ToLower(categoryName(category))),
+					Options:     options,
+				},
+			},
+		})
+	}
+
+	return rows
+}
+
+func sellContent(shop *entities.Shop, char *entities.Character) string {
+	if len(sellOptions(shop, char)) == 0 {
+		return fmt.Sprintf("%s has nothing %s will buy, %s in the purse.", char.Name, shop.Merchant.Name, char.Coins.String())
+	}
+
+	return fmt.Sprintf("What will %s sell to %s? %s in the purse.", char.Name, shop.Merchant.Name, char.Coins.String())
+}
+
+func sellComponents(shop *entities.Shop, char *entities.Character) []discordgo.MessageComponent {
+	options := sellOptions(shop, char)
+	if len(options) == 0 {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    fmt.Sprintf("shop:%s:%s", sellAction, shop.Merchant.Key),
+					Placeholder: "Sell an item",
+					Options:     options,
+				},
+			},
+		},
+	}
+}
+
+// sellOptions has one option for each item the character carries that the merchant pays for
+func sellOptions(shop *entities.Shop, char *entities.Character) []discordgo.SelectMenuOption {
+	options := make([]discordgo.SelectMenuOption, 0)
+	seen := make(map[string]bool)
+	for _, items := range char.Inventory {
+		for _, item := range items {
+			price := shop.SellPrice(entities.EquipmentCost(item))
+			if seen[item.GetKey()] || price == 0 {
+				continue
+			}
+
+			seen[item.GetKey()] = true
+			options = append(options, discordgo.SelectMenuOption{
+				Label:       item.GetName(),
+				Value:       item.GetKey(),
+				Description: entities.CoinsFromCopper(price).String(),
+			})
+		}
+	}
+
+	slices.SortFunc(options, func(a, b discordgo.SelectMenuOption) int {
+		return strings.Compare(a.Label, b.Label)
+	})
+
+	return options[:min(len(options), maxSelectOptions)]
+}
+
+// categoryName turns an SRD category key such as martial-weapons into Martial Weapons
+func categoryName(category string) string {
+	words := strings.Split(category, "-")
+	for idx, word := range words {
+		if word != "" {
+			words[idx] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+func optionValue(i *discordgo.InteractionCreate, name string) string {
+	for _, option := range i.ApplicationCommandData().Options[0].Options {
+		if option.Name == name {
+			return option.StringValue()
+		}
+	}
+
+	return ""
+}
+
+// canManage is true for members who can manage the server
+func canManage(i *discordgo.InteractionCreate) bool {
+	return i.Member != nil && i.Member.Permissions&discordgo.PermissionManageServer != 0
+}
+
+// respondShopError tells the player why the merchant turned them down
+func respondShopError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	var notFoundErr *dnderr.NotFoundError
+	var invalidErr *dnderr.InvalidEntityError
+	switch {
+	case errors.As(err, &notFoundErr), errors.As(err, &invalidErr):
+		respondError(s, i, fmt.Sprintf("Sorry, %s", err.Error()))
+	default:
+		log.Println(err)
+		respondError(s, i, "Something went wrong at the shop, try again")
+	}
+}
+
+func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, msg string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: msg,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
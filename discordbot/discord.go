@@ -1,8 +1,22 @@
 package discordbot
 
 import (
+	"context"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/discordbot/components/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/discordbot/components/encounter"
+	"github.com/KirkDiggler/dnd-bot-go/discordbot/components/loot"
 	"github.com/KirkDiggler/dnd-bot-go/discordbot/components/ronnie"
+	"github.com/KirkDiggler/dnd-bot-go/discordbot/components/rules"
+	"github.com/KirkDiggler/dnd-bot-go/discordbot/components/shop"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	lootManager "github.com/KirkDiggler/dnd-bot-go/internal/managers/loot"
 	"github.com/KirkDiggler/dnd-bot-go/internal/managers/ronnied_actions"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/shops"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/turntimers"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/houserules"
 	"log"
 
 	"github.com/KirkDiggler/dnd-bot-go/discordbot/components/character"
@@ -28,8 +42,18 @@ type bot struct {
 	characterComponent *character.Character
 	ronnieDComponent   *ronnie.RonnieD
 	ronnieDAtcions     ronnied_actions.Interface
+	dungeonComponent   *dungeon.Dungeon
+	encounterComponent *encounter.Encounter
+	lootComponent      *loot.Loot
+	rulesComponent     *rules.Rules
+	shopComponent      *shop.Shop
+	// stopTimers stops the turn timers running in the background
+	stopTimers context.CancelFunc
 }
 
+// turnTimerInterval is how often the turn timers are checked
+const turnTimerInterval = 5 * time.Second
+
 type Config struct {
 	Token          string
 	GuildID        string
@@ -38,6 +62,17 @@ type Config struct {
 	PartyRepo      party.Interface
 	CharacterRepo  characters.Manager
 	RonnieDActions ronnied_actions.Interface
+	RoomManager    rooms.Manager
+	// EncounterManager builds the monster groups for /encounter
+	EncounterManager encounters.Manager
+	// LootManager shares out the treasure found in fights and dungeons
+	LootManager lootManager.Manager
+	// TurnTimerManager keeps encounter fights moving when a player walks away
+	TurnTimerManager turntimers.Manager
+	// HouseRulesRepo holds the house rules /rules shows and changes
+	HouseRulesRepo houserules.Repository
+	// ShopManager runs the merchants /shop buys from and sells to
+	ShopManager shops.Manager
 }
 
 func New(cfg *Config) (*bot, error) {
@@ -65,6 +100,30 @@ func New(cfg *Config) (*bot, error) {
 		return nil, dnderr.NewMissingParameterError("cfg.PartyRepo")
 	}
 
+	if cfg.RoomManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.RoomManager")
+	}
+
+	if cfg.EncounterManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.EncounterManager")
+	}
+
+	if cfg.LootManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.LootManager")
+	}
+
+	if cfg.TurnTimerManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.TurnTimerManager")
+	}
+
+	if cfg.HouseRulesRepo == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.HouseRulesRepo")
+	}
+
+	if cfg.ShopManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.ShopManager")
+	}
+
 	session, err := discordgo.New("Bot " + cfg.Token)
 	if err != nil {
 		return nil, err
@@ -97,6 +156,45 @@ func New(cfg *Config) (*bot, error) {
 		return nil, err
 	}
 
+	dungeonComponent, err := dungeon.NewDungeon(&dungeon.DungeonConfig{
+		RoomManager: cfg.RoomManager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	encounterComponent, err := encounter.NewEncounter(&encounter.EncounterConfig{
+		EncounterManager: cfg.EncounterManager,
+		CharacterManager: cfg.CharacterRepo,
+		RoomManager:      cfg.RoomManager,
+		TurnTimerManager: cfg.TurnTimerManager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lootComponent, err := loot.NewLoot(&loot.LootConfig{
+		LootManager: cfg.LootManager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rulesComponent, err := rules.NewRules(&rules.RulesConfig{
+		HouseRulesRepo: cfg.HouseRulesRepo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shopComponent, err := shop.NewShop(&shop.ShopConfig{
+		ShopManager:      cfg.ShopManager,
+		CharacterManager: cfg.CharacterRepo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return &bot{
 		session:            session,
 		appID:              cfg.AppID,
@@ -106,6 +204,11 @@ func New(cfg *Config) (*bot, error) {
 		partyComponent:     partyComponent,
 		characterComponent: characterComponent,
 		ronnieDComponent:   ronniedComponent,
+		dungeonComponent:   dungeonComponent,
+		encounterComponent: encounterComponent,
+		lootComponent:      lootComponent,
+		rulesComponent:     rulesComponent,
+		shopComponent:      shopComponent,
 	}, nil
 }
 
@@ -147,15 +250,67 @@ func (b *bot) Start() error {
 	}
 
 	b.registeredCommands = append(b.registeredCommands, charCmd)
+
+	// Dungeon commands
+	b.session.AddHandler(b.dungeonComponent.HandleInteractionCreate)
+	dungeonCmd := b.dungeonComponent.GetApplicationCommand()
+	_, err = b.session.ApplicationCommandCreate(b.appID, b.guildID, dungeonCmd)
+	if err != nil {
+		return err
+	}
+
+	b.registeredCommands = append(b.registeredCommands, dungeonCmd)
+
+	// Encounter commands
+	b.session.AddHandler(b.encounterComponent.HandleInteractionCreate)
+	encounterCmd := b.encounterComponent.GetApplicationCommand()
+	_, err = b.session.ApplicationCommandCreate(b.appID, b.guildID, encounterCmd)
+	if err != nil {
+		return err
+	}
+
+	b.registeredCommands = append(b.registeredCommands, encounterCmd)
+
+	// House rules commands
+	b.session.AddHandler(b.rulesComponent.HandleInteractionCreate)
+	rulesCmd := b.rulesComponent.GetApplicationCommand()
+	_, err = b.session.ApplicationCommandCreate(b.appID, b.guildID, rulesCmd)
+	if err != nil {
+		return err
+	}
+
+	b.registeredCommands = append(b.registeredCommands, rulesCmd)
+
+	// Shop commands
+	b.session.AddHandler(b.shopComponent.HandleInteractionCreate)
+	shopCmd := b.shopComponent.GetApplicationCommand()
+	_, err = b.session.ApplicationCommandCreate(b.appID, b.guildID, shopCmd)
+	if err != nil {
+		return err
+	}
+
+	b.registeredCommands = append(b.registeredCommands, shopCmd)
+
+	// Loot buttons
+	b.session.AddHandler(b.lootComponent.HandleInteractionCreate)
+
 	err = b.session.Open()
 	if err != nil {
 		return err
 	}
 
+	ctx, stop := context.WithCancel(context.Background())
+	b.stopTimers = stop
+	go b.encounterComponent.RunTurnTimers(ctx, b.session, turnTimerInterval)
+
 	return nil
 }
 
 func (b *bot) Close() error {
+	if b.stopTimers != nil {
+		b.stopTimers()
+	}
+
 	for _, v := range b.registeredCommands {
 		log.Printf("Removing command '%v'...", v.Name)
 
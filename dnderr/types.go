@@ -87,3 +87,33 @@ func NewInvalidParameterError(param string, msg interface{}) error {
 		Msg:   fmt.Sprintf("%+v", msg),
 	}
 }
+
+// ConflictError is returned when a versioned write loses a race with another writer
+type ConflictError struct {
+	Msg string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Msg
+}
+
+func NewConflictError(msg string) error {
+	return &ConflictError{
+		Msg: msg,
+	}
+}
+
+// PermissionDeniedError is returned when the player is not allowed to do something
+type PermissionDeniedError struct {
+	Msg string
+}
+
+func (e *PermissionDeniedError) Error() string {
+	return e.Msg
+}
+
+func NewPermissionDeniedError(msg string) error {
+	return &PermissionDeniedError{
+		Msg: msg,
+	}
+}
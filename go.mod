@@ -5,7 +5,9 @@ go 1.22
 require (
 	github.com/bwmarrin/discordgo v0.28.1
 	github.com/fadedpez/dnd5e-api v0.0.0-20230205072901-b67777537667
+	github.com/go-redis/redismock/v9 v9.2.0
 	github.com/google/uuid v1.3.0
+	github.com/redis/go-redis/v9 v9.5.1
 	github.com/stretchr/testify v1.8.1
 	golang.org/x/sync v0.1.0
 )
@@ -14,10 +16,8 @@ require (
 	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
-	github.com/go-redis/redismock/v9 v9.2.0 // indirect
 	github.com/gorilla/websocket v1.5.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/redis/go-redis/v9 v9.5.1 // indirect
 	github.com/stretchr/objx v0.5.0 // indirect
 	golang.org/x/crypto v0.5.0 // indirect
 	golang.org/x/sys v0.4.0 // indirect
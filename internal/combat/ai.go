@@ -0,0 +1,101 @@
+package combat
+
+import (
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+// TargetStrategy is how an automatic turn picks who to attack
+type TargetStrategy string
+
+const (
+	TargetLowestHP TargetStrategy = "lowest-hp"
+	TargetRandom   TargetStrategy = "random"
+	TargetNearest  TargetStrategy = "nearest"
+)
+
+// DistanceFunc returns the distance in feet between two participants
+type DistanceFunc func(fromID, toID string) int
+
+// Opponents returns the standing participants on other sides than the participant
+func (e *Engine) Opponents(participant *Participant) []*Participant {
+	opponents := make([]*Participant, 0)
+	for _, other := range e.state.Order {
+		if other.Side != participant.Side && other.combatant != nil && !other.combatant.IsDown() {
+			opponents = append(opponents, other)
+		}
+	}
+
+	return opponents
+}
+
+// ChooseTarget picks an opponent of the current participant using the strategy, ties go to
+// whoever is first in the initiative order
+func (e *Engine) ChooseTarget(strategy TargetStrategy) (*Participant, error) {
+	current, err := e.activeTurn()
+	if err != nil {
+		return nil, err
+	}
+
+	opponents := e.Opponents(current)
+	if len(opponents) == 0 {
+		return nil, dnderr.NewNotFoundError("there is no one left to attack")
+	}
+
+	switch strategy {
+	case TargetRandom:
+		roll, err := e.roller.Roll(1, len(opponents), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return opponents[roll.Total-1], nil
+	case TargetNearest:
+		nearest := opponents[0]
+		for _, opponent := range opponents[1:] {
+			if e.Distance(current.ID, opponent.ID) < e.Distance(current.ID, nearest.ID) {
+				nearest = opponent
+			}
+		}
+
+		return nearest, nil
+	case TargetLowestHP, "":
+		lowest := opponents[0]
+		lowestHP, _ := lowest.combatant.HitPoints()
+		for _, opponent := range opponents[1:] {
+			hp, _ := opponent.combatant.HitPoints()
+			if hp < lowestHP {
+				lowest = opponent
+				lowestHP = hp
+			}
+		}
+
+		return lowest, nil
+	default:
+		return nil, dnderr.NewInvalidParameterError("strategy", string(strategy))
+	}
+}
+
+// TakeTurn plays the current participant's turn automatically. It moves toward a target picked
+// by the strategy, attacks when it gets in reach and ends the turn unless the attack ended the fight.
+func (e *Engine) TakeTurn(strategy TargetStrategy) (*AttackOutcome, error) {
+	target, err := e.ChooseTarget(strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome, err := e.Engage(target.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.IsOver() {
+		return outcome, nil
+	}
+
+	_, err = e.EndTurn()
+	if err != nil {
+		return nil, err
+	}
+
+	return outcome, nil
+}
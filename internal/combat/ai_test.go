@@ -0,0 +1,99 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteAI struct {
+	suite.Suite
+
+	roller  *dice.MockRoller
+	fixture *Engine
+
+	fighter *fixedCombatant
+	wizard  *fixedCombatant
+	goblin  *fixedCombatant
+}
+
+func (s *suiteAI) SetupTest() {
+	s.roller = &dice.MockRoller{}
+	s.fighter = &fixedCombatant{id: "fighter", ac: 16, initiative: 1, hp: 12, maxHP: 12}
+	s.wizard = &fixedCombatant{id: "wizard", ac: 12, initiative: 2, hp: 6, maxHP: 8}
+	s.goblin = &fixedCombatant{id: "goblin", ac: 15, initiative: 3, hp: 7, maxHP: 7, attacks: []*attack.Result{hit(14, 5)}}
+	s.goblin.attacks[0].Name = "Scimitar"
+
+	distances := map[string]int{"fighter": 5, "wizard": 30}
+	s.fixture, _ = New(&Config{
+		Roller: s.roller,
+		Distance: func(fromID, toID string) int {
+			return distances[toID]
+		},
+	})
+
+	s.NoError(s.fixture.Join(s.fighter, SideParty))
+	s.NoError(s.fixture.Join(s.wizard, SideParty))
+	s.NoError(s.fixture.Join(s.goblin, SideMonsters))
+
+	s.roller.On("Roll", 1, 20, 1).Return(&dice.RollResult{Total: 5}, nil)
+	s.roller.On("Roll", 1, 20, 2).Return(&dice.RollResult{Total: 4}, nil)
+	s.roller.On("Roll", 1, 20, 3).Return(&dice.RollResult{Total: 20}, nil)
+
+	_, err := s.fixture.RollInitiative()
+	s.Require().NoError(err)
+}
+
+func (s *suiteAI) TestLowestHP() {
+	target, err := s.fixture.ChooseTarget(TargetLowestHP)
+	s.NoError(err)
+	s.Equal("wizard", target.ID)
+}
+
+func (s *suiteAI) TestNearest() {
+	target, err := s.fixture.ChooseTarget(TargetNearest)
+	s.NoError(err)
+	s.Equal("fighter", target.ID)
+}
+
+func (s *suiteAI) TestRandom() {
+	s.roller.On("Roll", 1, 2, 0).Return(&dice.RollResult{Total: 2}, nil)
+
+	target, err := s.fixture.ChooseTarget(TargetRandom)
+	s.NoError(err)
+	s.Equal("wizard", target.ID)
+}
+
+func (s *suiteAI) TestSkipsDownedTargets() {
+	s.wizard.hp = 0
+
+	target, err := s.fixture.ChooseTarget(TargetLowestHP)
+	s.NoError(err)
+	s.Equal("fighter", target.ID)
+}
+
+func (s *suiteAI) TestUnknownStrategy() {
+	_, err := s.fixture.ChooseTarget("closest")
+	s.Error(err)
+}
+
+func (s *suiteAI) TestTakeTurn() {
+	outcome, err := s.fixture.TakeTurn(TargetLowestHP)
+	s.NoError(err)
+
+	s.Equal(1, s.wizard.hp)
+	s.Equal([]string{
+		"goblin hits wizard with its Scimitar (14 vs AC 12).",
+		"wizard takes 5 slashing damage [1/8 HP]",
+	}, outcome.Lines())
+
+	current, err := s.fixture.Current()
+	s.NoError(err)
+	s.Equal("fighter", current.ID)
+}
+
+func TestSuiteAI(t *testing.T) {
+	suite.Run(t, new(suiteAI))
+}
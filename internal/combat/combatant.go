@@ -0,0 +1,116 @@
+package combat
+
+import (
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+)
+
+// Combatant is anything that can take part in a fight, characters and monsters both implement it
+type Combatant interface {
+	GetID() string
+	GetName() string
+	GetAC() int
+	// GetSpeed is the movement in feet for a turn
+	GetSpeed() int
+	InitiativeBonus() int
+	// HitPoints returns the current and max hit points
+	HitPoints() (int, int)
+	IsDown() bool
+	// Attack rolls everything the combatant does with the attack action
+	Attack() ([]*attack.Result, error)
+	TakeDamage(amount int, dmgType damage.Type) *damage.Report
+}
+
+// LongRanger is a ranged combatant that can shoot past its normal range with disadvantage, anyone
+// else reaches no farther than their attack range
+type LongRanger interface {
+	// LongRange returns the farthest the attack reaches in feet
+	LongRange() int
+}
+
+// Ranger is a combatant that knows how far its attack reaches, anyone else reaches 5 feet
+type Ranger interface {
+	// AttackRange returns the reach in feet and true when the attack is ranged and needs line of sight
+	AttackRange() (int, bool)
+}
+
+// attackRange is how far the combatant's attack reaches
+func attackRange(combatant Combatant) (int, bool) {
+	ranger, ok := combatant.(Ranger)
+	if !ok {
+		return MeleeReach, false
+	}
+
+	return ranger.AttackRange()
+}
+
+// longRange is the farthest the combatant's attack reaches
+func longRange(combatant Combatant) int {
+	reach, _ := attackRange(combatant)
+	if longRanger, ok := combatant.(LongRanger); ok {
+		return max(longRanger.LongRange(), reach)
+	}
+
+	return reach
+}
+
+type Side string
+
+const (
+	SideParty    Side = "party"
+	SideMonsters Side = "monsters"
+)
+
+// Budget is what a participant has left to spend, the action, bonus action and movement come
+// back at the start of their turn and so does the reaction
+type Budget struct {
+	Action      bool `json:"action"`
+	BonusAction bool `json:"bonus_action"`
+	Reaction    bool `json:"reaction"`
+	Movement    int  `json:"movement"`
+}
+
+func newBudget(speed int) *Budget {
+	return &Budget{
+		Action:      true,
+		BonusAction: true,
+		Reaction:    true,
+		Movement:    speed,
+	}
+}
+
+// Participant is a combatant's place in the initiative order
+type Participant struct {
+	ID         string  `json:"id"`
+	Side       Side    `json:"side"`
+	Initiative int     `json:"initiative"`
+	Budget     *Budget `json:"budget"`
+	// Dodging is set by the Dodge action until the participant's next turn
+	Dodging bool `json:"dodging,omitempty"`
+	// Disengaged is set by the Disengage action, moving away does not provoke opportunity attacks
+	Disengaged bool `json:"disengaged,omitempty"`
+	// Conditions are the conditions on the participant that change attack rolls, such as prone
+	Conditions []string `json:"conditions,omitempty"`
+	// HelpedBy is who took the Help action against the participant, the next attack on it by their
+	// side has advantage
+	HelpedBy string `json:"helped_by,omitempty"`
+	// Surprised is set before initiative is rolled, the participant loses their first turn and
+	// has no reaction until it has passed
+	Surprised bool `json:"surprised,omitempty"`
+
+	combatant Combatant
+}
+
+func (p *Participant) Combatant() Combatant {
+	return p.combatant
+}
+
+// State is everything needed to pick a fight back up, the combatants themselves are stored
+// by their owners and joined again by ID
+type State struct {
+	Round int            `json:"round"`
+	Turn  int            `json:"turn"`
+	Order []*Participant `json:"order"`
+	// Grid is the battle map, fights without one treat everyone as in reach
+	Grid *Grid `json:"grid,omitempty"`
+}
@@ -0,0 +1,214 @@
+package combat
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+)
+
+// The SRD conditions that change attack rolls, a participant keeps them until they are removed
+const (
+	ConditionBlinded     = "blinded"
+	ConditionFrightened  = "frightened"
+	ConditionInvisible   = "invisible"
+	ConditionParalyzed   = "paralyzed"
+	ConditionPoisoned    = "poisoned"
+	ConditionProne       = "prone"
+	ConditionRestrained  = "restrained"
+	ConditionStunned     = "stunned"
+	ConditionUnconscious = "unconscious"
+	// ConditionHidden is an attacker the target cannot see, it is lost when they attack
+	ConditionHidden = "hidden"
+	// ConditionSurprised is recorded when a surprised participant loses their first turn
+	ConditionSurprised = "surprised"
+	// ConditionHelped is recorded when a participant takes the Help action
+	ConditionHelped = "helped"
+)
+
+// conditionEffect is how a condition changes attack rolls made by the creature with it and
+// against it. Being prone is worked out by distance instead.
+type conditionEffect struct {
+	attacking attack.RollMode
+	attacked  attack.RollMode
+}
+
+var conditionEffects = map[string]conditionEffect{
+	ConditionBlinded:     {attacking: attack.RollDisadvantage, attacked: attack.RollAdvantage},
+	ConditionFrightened:  {attacking: attack.RollDisadvantage},
+	ConditionInvisible:   {attacking: attack.RollAdvantage, attacked: attack.RollDisadvantage},
+	ConditionHidden:      {attacking: attack.RollAdvantage},
+	ConditionParalyzed:   {attacked: attack.RollAdvantage},
+	ConditionPoisoned:    {attacking: attack.RollDisadvantage},
+	ConditionProne:       {attacking: attack.RollDisadvantage},
+	ConditionRestrained:  {attacking: attack.RollDisadvantage, attacked: attack.RollAdvantage},
+	ConditionStunned:     {attacked: attack.RollAdvantage},
+	ConditionUnconscious: {attacked: attack.RollAdvantage},
+}
+
+// HasCondition returns true when the participant has the condition
+func (p *Participant) HasCondition(condition string) bool {
+	return slices.Contains(p.Conditions, condition)
+}
+
+// AddCondition puts the condition on the participant until it is removed
+func (e *Engine) AddCondition(id, condition string) error {
+	participant := e.Participant(id)
+	if participant == nil || participant.combatant == nil {
+		return dnderr.NewNotFoundError(fmt.Sprintf("%s is not in the fight", id))
+	}
+
+	if _, ok := conditionEffects[condition]; !ok {
+		return dnderr.NewInvalidParameterError("condition", condition)
+	}
+
+	if participant.HasCondition(condition) {
+		return nil
+	}
+
+	participant.Conditions = append(participant.Conditions, condition)
+	e.RecordCondition(participant.ID, condition, fmt.Sprintf("%s is %s.", participant.combatant.GetName(), condition))
+
+	return nil
+}
+
+// RemoveCondition takes the condition off the participant
+func (e *Engine) RemoveCondition(id, condition string) error {
+	participant := e.Participant(id)
+	if participant == nil {
+		return dnderr.NewNotFoundError(fmt.Sprintf("%s is not in the fight", id))
+	}
+
+	participant.Conditions = slices.DeleteFunc(participant.Conditions, func(current string) bool {
+		return current == condition
+	})
+
+	return nil
+}
+
+// Help spends the current participant's action distracting an enemy within 5 feet, the next
+// attack on it by one of their side before their next turn has advantage
+func (e *Engine) Help(targetID string) error {
+	current, err := e.activeTurn()
+	if err != nil {
+		return err
+	}
+
+	if !current.Budget.Action {
+		return dnderr.NewResourceExhaustedError(fmt.Sprintf("%s has already used their action", current.combatant.GetName()))
+	}
+
+	target := e.Participant(targetID)
+	if target == nil || target.combatant == nil {
+		return dnderr.NewNotFoundError(fmt.Sprintf("target %s is not in the fight", targetID))
+	}
+
+	if target.Side == current.Side || target.combatant.IsDown() {
+		return dnderr.NewInvalidParameterError("targetID", fmt.Sprintf("%s is not an enemy standing", target.combatant.GetName()))
+	}
+
+	if distance := e.Distance(current.ID, target.ID); distance > MeleeReach {
+		return dnderr.NewInvalidParameterError("targetID", fmt.Sprintf("%s is %d feet away, too far to help against", target.combatant.GetName(), distance))
+	}
+
+	current.Budget.Action = false
+	target.HelpedBy = current.ID
+	e.RecordCondition(current.ID, ConditionHelped, fmt.Sprintf("%s takes the Help action against %s.", current.combatant.GetName(), target.combatant.GetName()))
+
+	return nil
+}
+
+// attackContext gathers everything giving the attacker advantage or disadvantage against the
+// target, the conditions on both of them, their distance apart and the house rules
+func (e *Engine) attackContext(attacker, target *Participant) *attack.Context {
+	ctx := &attack.Context{}
+	attackerName := attacker.combatant.GetName()
+	targetName := target.combatant.GetName()
+	distance := e.Distance(attacker.ID, target.ID)
+
+	for _, condition := range attacker.Conditions {
+		ctx.Add(conditionEffects[condition].attacking, attack.Source(fmt.Sprintf("%s is %s", attackerName, condition)))
+	}
+
+	for _, condition := range target.Conditions {
+		effect := conditionEffects[condition].attacked
+		if condition == ConditionProne {
+			// a prone target is easier to hit up close and harder from farther away
+			effect = attack.RollDisadvantage
+			if distance <= MeleeReach {
+				effect = attack.RollAdvantage
+			}
+		}
+
+		ctx.Add(effect, attack.Source(fmt.Sprintf("%s is %s", targetName, condition)))
+	}
+
+	if target.Dodging {
+		ctx.Add(attack.RollDisadvantage, attack.Source(fmt.Sprintf("%s is dodging", targetName)))
+	}
+
+	if target.HelpedBy != "" {
+		if helper := e.Participant(target.HelpedBy); helper != nil && helper.Side == attacker.Side {
+			ctx.Add(attack.RollAdvantage, attack.SourceHelp)
+		}
+	}
+
+	if reach, ranged := attackRange(attacker.combatant); ranged && distance > reach {
+		ctx.Add(attack.RollDisadvantage, attack.SourceLongRange)
+	}
+
+	if e.rules != nil && e.rules.Flanking && e.flanked(attacker, target) {
+		ctx.Add(attack.RollAdvantage, attack.SourceFlanking)
+	}
+
+	return ctx
+}
+
+// flanked returns true when the attacker is next to the target and an ally stands next to it on
+// the opposite side
+func (e *Engine) flanked(attacker, target *Participant) bool {
+	grid := e.state.Grid
+	if grid == nil {
+		return false
+	}
+
+	if _, ranged := attackRange(attacker.combatant); ranged {
+		return false
+	}
+
+	from, ok := grid.Position(attacker.ID)
+	if !ok {
+		return false
+	}
+
+	to, ok := grid.Position(target.ID)
+	if !ok || squares(from, to) != 1 {
+		return false
+	}
+
+	opposite := to.Offset(to.X-from.X, to.Y-from.Y)
+	for _, ally := range e.state.Order {
+		if ally.ID == attacker.ID || ally.Side != attacker.Side || ally.combatant == nil || ally.combatant.IsDown() {
+			continue
+		}
+
+		if pos, ok := grid.Position(ally.ID); ok && pos == opposite {
+			return true
+		}
+	}
+
+	return false
+}
+
+// afterAttack clears what attacking uses up, a hidden attacker is revealed and help against the
+// target is spent
+func (e *Engine) afterAttack(attacker, target *Participant) {
+	attacker.Conditions = slices.DeleteFunc(attacker.Conditions, func(condition string) bool {
+		return condition == ConditionHidden
+	})
+
+	if helper := e.Participant(target.HelpedBy); helper != nil && helper.Side == attacker.Side {
+		target.HelpedBy = ""
+	}
+}
@@ -0,0 +1,821 @@
+package combat
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+)
+
+// Engine runs a fight turn by turn in initiative order
+type Engine struct {
+	roller   dice.Roller
+	distance DistanceFunc
+	rules    *attack.Rules
+	state    *State
+	events   []*Event
+}
+
+type Config struct {
+	// Roller rolls initiative and the extra dice the house rules call for, defaults to
+	// dice.DefaultRoller
+	Roller dice.Roller
+	// Rules are the house rules attacks are resolved by, defaults to the SRD
+	Rules *attack.Rules
+	// State resumes a fight, the combatants still need to join
+	State *State
+	// Distance is used to find the nearest target, without it the grid is used and without a grid
+	// everyone is treated as in reach
+	Distance DistanceFunc
+	// Grid is the battle map for a new fight, a resumed fight keeps its map in the State
+	Grid *Grid
+}
+
+func New(cfg *Config) (*Engine, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	roller := cfg.Roller
+	if roller == nil {
+		roller = &dice.DefaultRoller{}
+	}
+
+	rules := cfg.Rules
+	if rules == nil {
+		rules = attack.DefaultRules()
+	}
+
+	state := cfg.State
+	if state == nil {
+		state = &State{
+			Order: make([]*Participant, 0),
+		}
+	}
+
+	if state.Grid == nil {
+		state.Grid = cfg.Grid
+	}
+
+	return &Engine{
+		roller:   roller,
+		distance: cfg.Distance,
+		rules:    rules,
+		state:    state,
+		events:   make([]*Event, 0),
+	}, nil
+}
+
+// State returns the state to store between turns
+func (e *Engine) State() *State {
+	return e.state
+}
+
+// IsStarted returns true once initiative has been rolled
+func (e *Engine) IsStarted() bool {
+	return e.state.Round > 0
+}
+
+// Join adds the combatant on the side. When resuming a fight the combatant takes back its place,
+// joining a fight that has started rolls initiative and slots it into the order.
+func (e *Engine) Join(combatant Combatant, side Side) error {
+	if combatant == nil {
+		return dnderr.NewMissingParameterError("combatant")
+	}
+
+	if combatant.GetID() == "" {
+		return dnderr.NewMissingParameterError("combatant.ID")
+	}
+
+	if existing := e.Participant(combatant.GetID()); existing != nil {
+		existing.combatant = combatant
+		return nil
+	}
+
+	participant := &Participant{
+		ID:        combatant.GetID(),
+		Side:      side,
+		Budget:    newBudget(combatant.GetSpeed()),
+		combatant: combatant,
+	}
+
+	if !e.IsStarted() {
+		e.state.Order = append(e.state.Order, participant)
+		return nil
+	}
+
+	err := e.rollInitiative(participant)
+	if err != nil {
+		return err
+	}
+
+	idx := sort.Search(len(e.state.Order), func(i int) bool {
+		return e.goesBefore(participant, e.state.Order[i])
+	})
+
+	e.state.Order = append(e.state.Order, nil)
+	copy(e.state.Order[idx+1:], e.state.Order[idx:])
+	e.state.Order[idx] = participant
+
+	if idx <= e.state.Turn {
+		e.state.Turn++
+	}
+
+	e.recordInitiative(participant)
+
+	return nil
+}
+
+// RollInitiative rolls a d20 plus the initiative bonus for everyone and starts the first round
+func (e *Engine) RollInitiative() ([]*Participant, error) {
+	if len(e.state.Order) == 0 {
+		return nil, dnderr.NewInvalidEntityError("no one has joined the fight")
+	}
+
+	err := e.checkCombatants()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, participant := range e.state.Order {
+		err := e.rollInitiative(participant)
+		if err != nil {
+			return nil, err
+		}
+
+		participant.Budget = newBudget(participant.combatant.GetSpeed())
+		participant.Budget.Reaction = !participant.Surprised
+	}
+
+	sort.SliceStable(e.state.Order, func(i, j int) bool {
+		return e.goesBefore(e.state.Order[i], e.state.Order[j])
+	})
+
+	e.state.Round = 1
+	e.state.Turn = 0
+
+	for _, participant := range e.state.Order {
+		e.recordInitiative(participant)
+	}
+
+	first := e.state.Order[0]
+	surprised := first.Surprised && !first.combatant.IsDown()
+	if surprised {
+		e.loseTurn(first)
+	}
+
+	if (first.combatant.IsDown() || surprised) && !e.IsOver() {
+		_, err = e.EndTurn()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return e.state.Order, nil
+}
+
+// Surprise catches the participants off guard, it has to happen before initiative is rolled
+func (e *Engine) Surprise(ids ...string) error {
+	if e.IsStarted() {
+		return dnderr.NewInvalidEntityError("initiative has already been rolled")
+	}
+
+	for _, id := range ids {
+		participant := e.Participant(id)
+		if participant == nil {
+			return dnderr.NewNotFoundError(fmt.Sprintf("%s is not in the fight", id))
+		}
+
+		participant.Surprised = true
+	}
+
+	return nil
+}
+
+// loseTurn passes over a surprised participant's first turn, they get their reaction back once
+// it is over
+func (e *Engine) loseTurn(participant *Participant) {
+	participant.Surprised = false
+	participant.Budget = newBudget(participant.combatant.GetSpeed())
+	e.RecordCondition(participant.ID, ConditionSurprised, fmt.Sprintf("%s is surprised and loses their turn.", participant.combatant.GetName()))
+}
+
+func (e *Engine) rollInitiative(participant *Participant) error {
+	roll, err := e.roller.Roll(1, 20, participant.combatant.InitiativeBonus())
+	if err != nil {
+		return err
+	}
+
+	participant.Initiative = roll.Total
+
+	return nil
+}
+
+// goesBefore orders by initiative, ties go to the higher initiative bonus
+func (e *Engine) goesBefore(a, b *Participant) bool {
+	if a.Initiative != b.Initiative {
+		return a.Initiative > b.Initiative
+	}
+
+	return a.combatant.InitiativeBonus() > b.combatant.InitiativeBonus()
+}
+
+func (e *Engine) checkCombatants() error {
+	for _, participant := range e.state.Order {
+		if participant.combatant == nil {
+			return dnderr.NewInvalidEntityError(fmt.Sprintf("%s has not joined the fight", participant.ID))
+		}
+	}
+
+	return nil
+}
+
+// Participant returns the participant with the id or nil
+func (e *Engine) Participant(id string) *Participant {
+	for _, participant := range e.state.Order {
+		if participant.ID == id {
+			return participant
+		}
+	}
+
+	return nil
+}
+
+// Participants returns everyone in initiative order
+func (e *Engine) Participants() []*Participant {
+	return e.state.Order
+}
+
+// Current returns the participant whose turn it is
+func (e *Engine) Current() (*Participant, error) {
+	if !e.IsStarted() {
+		return nil, dnderr.NewInvalidEntityError("initiative has not been rolled")
+	}
+
+	err := e.checkCombatants()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.state.Order[e.state.Turn], nil
+}
+
+// Round is the current round, starting at 1
+func (e *Engine) Round() int {
+	return e.state.Round
+}
+
+// Standing returns the participants on the side that are not down
+func (e *Engine) Standing(side Side) []*Participant {
+	standing := make([]*Participant, 0)
+	for _, participant := range e.state.Order {
+		if participant.Side == side && participant.combatant != nil && !participant.combatant.IsDown() {
+			standing = append(standing, participant)
+		}
+	}
+
+	return standing
+}
+
+// IsOver returns true when no more than one side has anyone standing
+func (e *Engine) IsOver() bool {
+	sides := make(map[Side]bool)
+	for _, participant := range e.state.Order {
+		if participant.combatant != nil && !participant.combatant.IsDown() {
+			sides[participant.Side] = true
+		}
+	}
+
+	return len(sides) < 2
+}
+
+// Winner returns the side left standing once the fight is over
+func (e *Engine) Winner() (Side, bool) {
+	if !e.IsOver() {
+		return "", false
+	}
+
+	for _, participant := range e.state.Order {
+		if participant.combatant != nil && !participant.combatant.IsDown() {
+			return participant.Side, true
+		}
+	}
+
+	return "", false
+}
+
+// Attack uses the current participant's action to attack the target, each attack roll is
+// compared to the target's AC and hits apply damage
+func (e *Engine) Attack(targetID string) (*AttackOutcome, error) {
+	current, err := e.activeTurn()
+	if err != nil {
+		return nil, err
+	}
+
+	if !current.Budget.Action {
+		return nil, dnderr.NewResourceExhaustedError(fmt.Sprintf("%s has already used their action", current.combatant.GetName()))
+	}
+
+	target := e.Participant(targetID)
+	if target == nil || target.combatant == nil {
+		return nil, dnderr.NewNotFoundError(fmt.Sprintf("target %s is not in the fight", targetID))
+	}
+
+	if target.ID == current.ID {
+		return nil, dnderr.NewInvalidParameterError("targetID", "cannot attack yourself")
+	}
+
+	if target.combatant.IsDown() {
+		return nil, dnderr.NewInvalidParameterError("targetID", fmt.Sprintf("%s is already down", target.combatant.GetName()))
+	}
+
+	err = e.checkReach(current, target)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := e.rollAttack(current, target)
+	if err != nil {
+		return nil, err
+	}
+
+	current.Budget.Action = false
+
+	return e.strike(current, target, results), nil
+}
+
+// rollAttack has the participant roll their attacks at the target under the house rules, with
+// advantage or disadvantage from everything going on around them
+func (e *Engine) rollAttack(attacker, target *Participant) ([]*attack.Result, error) {
+	results, err := attacker.combatant.Attack()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := e.attackContext(attacker, target)
+	for _, result := range results {
+		result.Context = ctx
+		err = e.rules.Resolve(e.roller, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// strike applies each attack roll to the target until it drops, a fumble costs the attacker the
+// rest of their turn's reaction or movement
+func (e *Engine) strike(attacker, target *Participant, results []*attack.Result) *AttackOutcome {
+	outcome := &AttackOutcome{
+		Side:     attacker.Side,
+		Attacker: attacker.combatant,
+		Target:   target.combatant,
+		Strikes:  make([]*Strike, 0, len(results)),
+	}
+
+	for _, result := range results {
+		if target.combatant.IsDown() {
+			break
+		}
+
+		ac := target.combatant.GetAC()
+		strike := &Strike{
+			Result: result,
+			Hit:    result.Hits(ac),
+		}
+
+		if strike.Hit {
+			strike.Report = target.combatant.TakeDamage(result.DamageAgainst(ac), result.AttackType)
+		}
+
+		if attacker.Budget != nil {
+			switch result.Fumble {
+			case attack.FumbleOffBalance:
+				attacker.Budget.Reaction = false
+			case attack.FumbleStumble:
+				attacker.Budget.Movement = 0
+			}
+		}
+
+		outcome.Strikes = append(outcome.Strikes, strike)
+	}
+
+	if target.combatant.IsDown() && e.state.Grid != nil {
+		e.state.Grid.Remove(target.ID)
+	}
+
+	e.afterAttack(attacker, target)
+
+	e.recordAttack(attacker, target, outcome)
+
+	return outcome
+}
+
+// UseAction spends the current participant's action on something other than an attack
+func (e *Engine) UseAction() error {
+	current, err := e.activeTurn()
+	if err != nil {
+		return err
+	}
+
+	if !current.Budget.Action {
+		return dnderr.NewResourceExhaustedError(fmt.Sprintf("%s has already used their action", current.combatant.GetName()))
+	}
+
+	current.Budget.Action = false
+
+	return nil
+}
+
+// Dodge spends the current participant's action on the Dodge action, they keep dodging until
+// their next turn
+func (e *Engine) Dodge() error {
+	current, err := e.activeTurn()
+	if err != nil {
+		return err
+	}
+
+	if !current.Budget.Action {
+		return dnderr.NewResourceExhaustedError(fmt.Sprintf("%s has already used their action", current.combatant.GetName()))
+	}
+
+	current.Budget.Action = false
+	current.Dodging = true
+	e.RecordCondition(current.ID, ConditionDodging, fmt.Sprintf("%s takes the Dodge action.", current.combatant.GetName()))
+
+	return nil
+}
+
+// Disengage spends the current participant's action so their movement does not provoke
+// opportunity attacks for the rest of the turn
+func (e *Engine) Disengage() error {
+	current, err := e.activeTurn()
+	if err != nil {
+		return err
+	}
+
+	if !current.Budget.Action {
+		return dnderr.NewResourceExhaustedError(fmt.Sprintf("%s has already used their action", current.combatant.GetName()))
+	}
+
+	current.Budget.Action = false
+	current.Disengaged = true
+	e.RecordCondition(current.ID, ConditionDisengaged, fmt.Sprintf("%s takes the Disengage action.", current.combatant.GetName()))
+
+	return nil
+}
+
+// OpportunityAttacks has every enemy with the participant in melee reach use their reaction to
+// make one attack as the participant leaves, unless the participant disengaged. Without a map
+// every enemy is in reach.
+func (e *Engine) OpportunityAttacks(id string) ([]*AttackOutcome, error) {
+	participant := e.Participant(id)
+	if participant == nil || participant.combatant == nil {
+		return nil, dnderr.NewNotFoundError(fmt.Sprintf("%s is not in the fight", id))
+	}
+
+	return e.provoke(participant, e.threatening(participant))
+}
+
+// threatening returns the enemies standing with a reaction left and the participant in the reach
+// of their melee attack
+func (e *Engine) threatening(participant *Participant) []*Participant {
+	threats := make([]*Participant, 0)
+	if participant.Disengaged {
+		return threats
+	}
+
+	for _, enemy := range e.state.Order {
+		if enemy.Side == participant.Side || enemy.combatant == nil || enemy.combatant.IsDown() {
+			continue
+		}
+
+		if enemy.Budget == nil || !enemy.Budget.Reaction {
+			continue
+		}
+
+		reach, ranged := attackRange(enemy.combatant)
+		if ranged || e.Distance(enemy.ID, participant.ID) > reach {
+			continue
+		}
+
+		threats = append(threats, enemy)
+	}
+
+	return threats
+}
+
+// provoke has each enemy attack the participant once with their reaction
+func (e *Engine) provoke(participant *Participant, enemies []*Participant) ([]*AttackOutcome, error) {
+	outcomes := make([]*AttackOutcome, 0, len(enemies))
+	for _, enemy := range enemies {
+		if participant.combatant.IsDown() {
+			break
+		}
+
+		results, err := e.rollAttack(enemy, participant)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(results) == 0 {
+			continue
+		}
+
+		enemy.Budget.Reaction = false
+
+		// an opportunity attack is a single attack, not the whole attack action
+		outcome := e.strike(enemy, participant, results[:1])
+		outcome.Opportunity = true
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, nil
+}
+
+// UseBonusAction spends the current participant's bonus action
+func (e *Engine) UseBonusAction() error {
+	current, err := e.activeTurn()
+	if err != nil {
+		return err
+	}
+
+	if !current.Budget.BonusAction {
+		return dnderr.NewResourceExhaustedError(fmt.Sprintf("%s has already used their bonus action", current.combatant.GetName()))
+	}
+
+	current.Budget.BonusAction = false
+
+	return nil
+}
+
+// UseReaction spends a participant's reaction, it can be used outside their own turn
+func (e *Engine) UseReaction(id string) error {
+	participant := e.Participant(id)
+	if participant == nil || participant.combatant == nil {
+		return dnderr.NewNotFoundError(fmt.Sprintf("%s is not in the fight", id))
+	}
+
+	if participant.combatant.IsDown() {
+		return dnderr.NewInvalidEntityError(fmt.Sprintf("%s is down", participant.combatant.GetName()))
+	}
+
+	if !participant.Budget.Reaction {
+		return dnderr.NewResourceExhaustedError(fmt.Sprintf("%s has already used their reaction", participant.combatant.GetName()))
+	}
+
+	participant.Budget.Reaction = false
+
+	return nil
+}
+
+// Move spends feet of the current participant's movement
+func (e *Engine) Move(feet int) error {
+	if feet < 0 {
+		return dnderr.NewInvalidParameterError("feet", "must not be negative")
+	}
+
+	current, err := e.activeTurn()
+	if err != nil {
+		return err
+	}
+
+	if feet > current.Budget.Movement {
+		return dnderr.NewResourceExhaustedError(fmt.Sprintf("%s only has %d feet of movement left", current.combatant.GetName(), current.Budget.Movement))
+	}
+
+	current.Budget.Movement -= feet
+
+	return nil
+}
+
+// MoveTo moves the current participant to the square on the grid, spending the feet it takes to
+// get there around walls and other participants. Leaving an enemy's reach provokes an
+// opportunity attack, a participant dropped by one stays where they were.
+func (e *Engine) MoveTo(pos Position) (int, []*AttackOutcome, error) {
+	current, err := e.activeTurn()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if e.state.Grid == nil {
+		return 0, nil, dnderr.NewInvalidEntityError("the fight has no map")
+	}
+
+	feet, err := e.state.Grid.PathCost(current.ID, pos)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if feet > current.Budget.Movement {
+		return 0, nil, dnderr.NewResourceExhaustedError(fmt.Sprintf("%s only has %d feet of movement left", current.combatant.GetName(), current.Budget.Movement))
+	}
+
+	// enemies whose reach the move leaves get their attack before the participant goes
+	provoked, err := e.provoke(current, e.leavingReach(current, pos))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if current.combatant.IsDown() {
+		return 0, provoked, nil
+	}
+
+	err = e.Move(feet)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	err = e.state.Grid.Place(current.ID, pos)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	e.recordMove(current, feet, "")
+
+	return feet, provoked, nil
+}
+
+// leavingReach returns the threatening enemies the participant would be out of reach of at the
+// square
+func (e *Engine) leavingReach(participant *Participant, pos Position) []*Participant {
+	leaving := make([]*Participant, 0)
+	for _, enemy := range e.threatening(participant) {
+		reach, _ := attackRange(enemy.combatant)
+		if to, ok := e.state.Grid.Position(enemy.ID); ok && squares(pos, to)*SquareFeet > reach {
+			leaving = append(leaving, enemy)
+		}
+	}
+
+	return leaving
+}
+
+// Engage moves the current participant toward the target until it is in reach and attacks it.
+// When their movement runs out first the outcome has the distance moved and no strikes. Leaving
+// another enemy's reach on the way provokes an opportunity attack the same as MoveTo, one that
+// drops the participant stops them where they were.
+func (e *Engine) Engage(targetID string) (*AttackOutcome, error) {
+	current, err := e.activeTurn()
+	if err != nil {
+		return nil, err
+	}
+
+	target := e.Participant(targetID)
+	if target == nil || target.combatant == nil {
+		return nil, dnderr.NewNotFoundError(fmt.Sprintf("target %s is not in the fight", targetID))
+	}
+
+	moved := 0
+	var provoked []*AttackOutcome
+	reach, ranged := attackRange(current.combatant)
+	if e.state.Grid != nil && (e.checkReach(current, target) != nil || e.Distance(current.ID, target.ID) > reach) {
+		pos, feet := e.state.Grid.Approach(current.ID, target.ID, current.Budget.Movement, reach, ranged)
+		if feet > 0 {
+			provoked, err = e.provoke(current, e.leavingReach(current, pos))
+			if err != nil {
+				return nil, err
+			}
+
+			if current.combatant.IsDown() {
+				return &AttackOutcome{
+					Side:     current.Side,
+					Attacker: current.combatant,
+					Target:   target.combatant,
+					Provoked: provoked,
+					Strikes:  make([]*Strike, 0),
+				}, nil
+			}
+
+			current.Budget.Movement -= feet
+			e.state.Grid.Positions[current.ID] = pos
+			e.recordMove(current, feet, target.combatant.GetName())
+			moved = feet
+		}
+
+		if e.checkReach(current, target) != nil {
+			return &AttackOutcome{
+				Side:       current.Side,
+				Attacker:   current.combatant,
+				Target:     target.combatant,
+				Moved:      moved,
+				OutOfReach: true,
+				Provoked:   provoked,
+				Strikes:    make([]*Strike, 0),
+			}, nil
+		}
+	}
+
+	outcome, err := e.Attack(target.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome.Moved = moved
+	outcome.Provoked = provoked
+
+	return outcome, nil
+}
+
+// Distance returns the feet between two participants
+func (e *Engine) Distance(fromID, toID string) int {
+	if e.distance != nil {
+		return e.distance(fromID, toID)
+	}
+
+	if e.state.Grid != nil {
+		return e.state.Grid.Distance(fromID, toID)
+	}
+
+	return 0
+}
+
+// checkReach returns an error when the target is farther away than the attacker's reach, a
+// ranged attack's long range, or out of sight for a ranged attack. Without a map everyone is in
+// reach.
+func (e *Engine) checkReach(attacker, target *Participant) error {
+	grid := e.state.Grid
+	if grid == nil {
+		return nil
+	}
+
+	from, ok := grid.Position(attacker.ID)
+	if !ok {
+		return nil
+	}
+
+	to, ok := grid.Position(target.ID)
+	if !ok {
+		return nil
+	}
+
+	name := target.combatant.GetName()
+	_, ranged := attackRange(attacker.combatant)
+	if distance := grid.Distance(attacker.ID, target.ID); distance > longRange(attacker.combatant) {
+		return dnderr.NewInvalidParameterError("targetID", fmt.Sprintf("%s is %d feet away, out of reach", name, distance))
+	}
+
+	if ranged && !grid.LineOfSight(from, to) {
+		return dnderr.NewInvalidParameterError("targetID", fmt.Sprintf("%s is out of sight", name))
+	}
+
+	return nil
+}
+
+// EndTurn moves to the next participant who is not down, starting a new round after the last.
+// The next participant gets their budget back.
+func (e *Engine) EndTurn() (*Participant, error) {
+	if _, err := e.Current(); err != nil {
+		return nil, err
+	}
+
+	if e.IsOver() {
+		return nil, dnderr.NewInvalidEntityError("the fight is over")
+	}
+
+	for {
+		e.state.Turn++
+		if e.state.Turn >= len(e.state.Order) {
+			e.state.Turn = 0
+			e.state.Round++
+		}
+
+		next := e.state.Order[e.state.Turn]
+		if next.combatant.IsDown() {
+			continue
+		}
+
+		if next.Surprised {
+			e.loseTurn(next)
+			continue
+		}
+
+		next.Budget = newBudget(next.combatant.GetSpeed())
+		next.Dodging = false
+		next.Disengaged = false
+
+		// help only lasts until the helper's next turn
+		for _, participant := range e.state.Order {
+			if participant.HelpedBy == next.ID {
+				participant.HelpedBy = ""
+			}
+		}
+
+		return next, nil
+	}
+}
+
+func (e *Engine) activeTurn() (*Participant, error) {
+	current, err := e.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	if e.IsOver() {
+		return nil, dnderr.NewInvalidEntityError("the fight is over")
+	}
+
+	return current, nil
+}
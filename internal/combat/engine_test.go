@@ -0,0 +1,839 @@
+package combat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+	"github.com/stretchr/testify/suite"
+)
+
+// fixedCombatant attacks with the same results every time
+type fixedCombatant struct {
+	id         string
+	ac         int
+	initiative int
+	hp         int
+	maxHP      int
+	attacks    []*attack.Result
+	// reach defaults to a 5 foot melee attack
+	reach  int
+	ranged bool
+	// long is how far a ranged attack reaches with disadvantage
+	long int
+}
+
+func (f *fixedCombatant) GetID() string         { return f.id }
+func (f *fixedCombatant) GetName() string       { return f.id }
+func (f *fixedCombatant) GetAC() int            { return f.ac }
+func (f *fixedCombatant) GetSpeed() int         { return 30 }
+func (f *fixedCombatant) InitiativeBonus() int  { return f.initiative }
+func (f *fixedCombatant) HitPoints() (int, int) { return f.hp, f.maxHP }
+func (f *fixedCombatant) IsDown() bool          { return f.hp <= 0 }
+
+func (f *fixedCombatant) AttackRange() (int, bool) {
+	if f.reach == 0 {
+		return MeleeReach, false
+	}
+
+	return f.reach, f.ranged
+}
+
+func (f *fixedCombatant) LongRange() int {
+	return f.long
+}
+
+func (f *fixedCombatant) Attack() ([]*attack.Result, error) {
+	return f.attacks, nil
+}
+
+func (f *fixedCombatant) TakeDamage(amount int, dmgType damage.Type) *damage.Report {
+	lost := min(amount, f.hp)
+	f.hp -= lost
+
+	return &damage.Report{Target: f.id, Type: dmgType, Rolled: amount, Dealt: amount, HPLost: lost, CurrentHP: f.hp, MaxHP: f.maxHP}
+}
+
+func hit(roll, dmg int) *attack.Result {
+	return &attack.Result{
+		AttackRoll:   roll,
+		DamageRoll:   dmg,
+		AttackType:   damage.TypeSlashing,
+		AttackResult: &dice.RollResult{Total: roll},
+	}
+}
+
+// rolled is a +5 attack with a 1d6+2 weapon that rolled the natural and damage dice given, for
+// the house rules to work out
+func rolled(natural, dmg int) *attack.Result {
+	return &attack.Result{
+		Name:         "sword",
+		AttackType:   damage.TypeSlashing,
+		AttackResult: &dice.RollResult{Total: natural},
+		DamageResult: &dice.RollResult{Total: dmg},
+		AttackBonus:  5,
+		DamageBonus:  2,
+		Damage:       &damage.Damage{DiceCount: 1, DiceSize: 6, DamageType: damage.TypeSlashing},
+	}
+}
+
+type suiteEngine struct {
+	suite.Suite
+
+	roller  *dice.MockRoller
+	fixture *Engine
+
+	fighter *fixedCombatant
+	rogue   *fixedCombatant
+	goblin  *fixedCombatant
+}
+
+func (s *suiteEngine) SetupTest() {
+	s.roller = &dice.MockRoller{}
+	s.fixture, _ = New(&Config{Roller: s.roller})
+
+	s.fighter = &fixedCombatant{id: "fighter", ac: 16, initiative: 1, hp: 12, maxHP: 12, attacks: []*attack.Result{hit(18, 7)}}
+	s.rogue = &fixedCombatant{id: "rogue", ac: 14, initiative: 3, hp: 9, maxHP: 9, attacks: []*attack.Result{hit(9, 4)}}
+	s.goblin = &fixedCombatant{id: "goblin", ac: 15, initiative: 2, hp: 7, maxHP: 7, attacks: []*attack.Result{hit(17, 5)}}
+
+	s.NoError(s.fixture.Join(s.fighter, SideParty))
+	s.NoError(s.fixture.Join(s.rogue, SideParty))
+	s.NoError(s.fixture.Join(s.goblin, SideMonsters))
+}
+
+func (s *suiteEngine) rollInitiative() {
+	s.roller.On("Roll", 1, 20, 1).Return(&dice.RollResult{Total: 10}, nil)
+	s.roller.On("Roll", 1, 20, 3).Return(&dice.RollResult{Total: 18}, nil)
+	s.roller.On("Roll", 1, 20, 2).Return(&dice.RollResult{Total: 10}, nil)
+
+	_, err := s.fixture.RollInitiative()
+	s.Require().NoError(err)
+}
+
+func (s *suiteEngine) order() []string {
+	ids := make([]string, 0)
+	for _, participant := range s.fixture.Participants() {
+		ids = append(ids, participant.ID)
+	}
+
+	return ids
+}
+
+func (s *suiteEngine) TestInitiativeOrder() {
+	s.rollInitiative()
+
+	// the goblin wins the tie with the fighter on its higher bonus
+	s.Equal([]string{"rogue", "goblin", "fighter"}, s.order())
+	s.Equal(1, s.fixture.Round())
+
+	current, err := s.fixture.Current()
+	s.NoError(err)
+	s.Equal("rogue", current.ID)
+}
+
+func (s *suiteEngine) TestCurrentBeforeInitiative() {
+	_, err := s.fixture.Current()
+	s.Error(err)
+}
+
+func (s *suiteEngine) TestAttackComparesAC() {
+	s.rollInitiative()
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.Len(outcome.Strikes, 1)
+	s.False(outcome.Strikes[0].Hit)
+	s.Nil(outcome.Strikes[0].Report)
+	s.Equal(7, s.goblin.hp)
+	s.Equal("rogue attacks goblin but misses (9 vs AC 15).", outcome.String())
+}
+
+func (s *suiteEngine) TestAttackUsesAction() {
+	s.rollInitiative()
+
+	_, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+
+	_, err = s.fixture.Attack("goblin")
+	s.IsType(&dnderr.ResourceExhaustedError{}, err)
+
+	s.NoError(s.fixture.UseBonusAction())
+	s.Error(s.fixture.UseBonusAction())
+}
+
+// withRules starts the fight over under the house rules
+func (s *suiteEngine) withRules(rules *attack.Rules) {
+	s.fixture, _ = New(&Config{Roller: s.roller, Rules: rules})
+
+	s.NoError(s.fixture.Join(s.fighter, SideParty))
+	s.NoError(s.fixture.Join(s.rogue, SideParty))
+	s.NoError(s.fixture.Join(s.goblin, SideMonsters))
+	s.rollInitiative()
+}
+
+func (s *suiteEngine) TestRulesMaxDamageCrits() {
+	s.goblin.hp, s.goblin.maxHP = 20, 20
+	s.rogue.attacks = []*attack.Result{rolled(19, 3)}
+	s.withRules(&attack.Rules{CritDamage: attack.CritDamageMax, CritRange: 19})
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.True(outcome.Strikes[0].Hit)
+	s.Equal(9, s.goblin.hp)
+	s.Equal("rogue lands a critical hit on goblin with their sword!", outcome.Lines()[0])
+}
+
+func (s *suiteEngine) TestRulesUnconfirmedCrit() {
+	s.goblin.hp, s.goblin.maxHP = 20, 20
+	s.rogue.attacks = []*attack.Result{rolled(20, 3)}
+	s.roller.On("Roll", 1, 6, 0).Return(&dice.RollResult{Total: 4}, nil)
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 6}, nil)
+	s.withRules(&attack.Rules{ConfirmCrits: true})
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.True(outcome.Strikes[0].Hit)
+	s.Equal(15, s.goblin.hp)
+	s.Equal("rogue hits goblin with their sword but fails to confirm the critical (11 vs AC 15).", outcome.Lines()[0])
+}
+
+func (s *suiteEngine) TestRulesFumbleTable() {
+	s.rogue.attacks = []*attack.Result{rolled(1, 3)}
+	s.roller.On("Roll", 1, 6, 0).Return(&dice.RollResult{Total: 3}, nil)
+	s.withRules(&attack.Rules{Fumbles: attack.FumbleTable})
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.False(outcome.Strikes[0].Hit)
+
+	current, err := s.fixture.Current()
+	s.NoError(err)
+	s.Equal(0, current.Budget.Movement)
+	s.Equal("rogue fumbles and stumbles, losing the rest of their movement.", outcome.Lines()[1])
+}
+
+func (s *suiteEngine) TestMovement() {
+	s.rollInitiative()
+
+	s.NoError(s.fixture.Move(20))
+	s.Error(s.fixture.Move(15))
+	s.NoError(s.fixture.Move(10))
+}
+
+func (s *suiteEngine) TestDodge() {
+	s.rollInitiative()
+
+	s.NoError(s.fixture.Dodge())
+	s.True(s.fixture.Participant("rogue").Dodging)
+	s.IsType(&dnderr.ResourceExhaustedError{}, s.fixture.Dodge())
+
+	events := s.fixture.Events()
+	s.Equal(EventTypeCondition, events[len(events)-1].Type)
+	s.Equal("rogue takes the Dodge action.", events[len(events)-1].Text)
+
+	// the rogue stops dodging when their next turn comes around
+	for range 3 {
+		_, err := s.fixture.EndTurn()
+		s.NoError(err)
+	}
+
+	s.False(s.fixture.Participant("rogue").Dodging)
+}
+
+func (s *suiteEngine) TestAttackAgainstDodging() {
+	s.rollInitiative()
+	s.fixture.Participant("goblin").Dodging = true
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 3}, nil)
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.Equal(attack.RollDisadvantage, outcome.Strikes[0].Result.Context.Mode())
+	s.Equal(3, outcome.Strikes[0].Result.UsedResult().Total)
+	s.Equal("rogue attacks goblin but misses (3 vs AC 15). Rolled 9 and 3 with disadvantage (goblin is dodging), kept the 3.", outcome.String())
+}
+
+func (s *suiteEngine) TestAdvantageAndDisadvantageCancel() {
+	s.rollInitiative()
+	s.NoError(s.fixture.AddCondition("goblin", ConditionProne))
+	s.NoError(s.fixture.AddCondition("rogue", ConditionPoisoned))
+	s.NoError(s.fixture.AddCondition("rogue", ConditionPoisoned))
+	s.Equal([]string{ConditionPoisoned}, s.fixture.Participant("rogue").Conditions)
+	s.IsType(&dnderr.InvalidParameterError{}, s.fixture.AddCondition("rogue", "sleepy"))
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.Nil(outcome.Strikes[0].Result.OtherResult)
+	s.Equal("rogue attacks goblin but misses (9 vs AC 15). Advantage (goblin is prone) and disadvantage (rogue is poisoned) cancel out.", outcome.String())
+	s.roller.AssertNotCalled(s.T(), "Roll", 1, 20, 0)
+
+	s.NoError(s.fixture.RemoveCondition("rogue", ConditionPoisoned))
+	s.Empty(s.fixture.Participant("rogue").Conditions)
+}
+
+func (s *suiteEngine) TestProneTargetFarAway() {
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.rogue.reach, s.rogue.ranged = 80, true
+	s.NoError(s.fixture.AddCondition("goblin", ConditionProne))
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 12}, nil)
+
+	_, _, err := s.fixture.MoveTo(Position{X: 1, Y: 1})
+	s.NoError(err)
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.Equal([]attack.Source{"goblin is prone"}, outcome.Strikes[0].Result.Context.Disadvantage)
+}
+
+func (s *suiteEngine) TestLongRange() {
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.rogue.reach, s.rogue.ranged, s.rogue.long = 10, true, 40
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 12}, nil)
+
+	_, _, err := s.fixture.MoveTo(Position{X: 1, Y: 1})
+	s.NoError(err)
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.Equal([]attack.Source{attack.SourceLongRange}, outcome.Strikes[0].Result.Context.Disadvantage)
+	s.Equal(9, outcome.Strikes[0].Result.AttackRoll)
+}
+
+func (s *suiteEngine) TestHelp() {
+	s.rollInitiative()
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 2}, nil)
+
+	s.IsType(&dnderr.InvalidParameterError{}, s.fixture.Help("fighter"))
+	s.NoError(s.fixture.Help("goblin"))
+	s.Equal("rogue", s.fixture.Participant("goblin").HelpedBy)
+	s.IsType(&dnderr.ResourceExhaustedError{}, s.fixture.Help("goblin"))
+
+	// the goblin's own attack does not use up the help
+	_, err := s.fixture.EndTurn()
+	s.NoError(err)
+	outcome, err := s.fixture.Attack("fighter")
+	s.NoError(err)
+	s.Nil(outcome.Strikes[0].Result.Context.Advantage)
+
+	_, err = s.fixture.EndTurn()
+	s.NoError(err)
+	outcome, err = s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.Equal([]attack.Source{attack.SourceHelp}, outcome.Strikes[0].Result.Context.Advantage)
+	s.Equal(18, outcome.Strikes[0].Result.UsedResult().Total)
+	s.Empty(s.fixture.Participant("goblin").HelpedBy)
+}
+
+func (s *suiteEngine) TestHiddenAttackerIsRevealed() {
+	s.rollInitiative()
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 16}, nil)
+	s.NoError(s.fixture.AddCondition("rogue", ConditionHidden))
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.True(outcome.Strikes[0].Hit)
+	s.Equal("rogue hits goblin (16 vs AC 15). Rolled 9 and 16 with advantage (rogue is hidden), kept the 16.", outcome.Lines()[0])
+	s.False(s.fixture.Participant("rogue").HasCondition(ConditionHidden))
+}
+
+func (s *suiteEngine) TestFlanking() {
+	s.withRules(&attack.Rules{Flanking: true})
+	s.fixture.State().Grid = &Grid{
+		Width:   5,
+		Height:  4,
+		Terrain: []string{".....", ".....", ".....", "....."},
+		Positions: map[string]Position{
+			"rogue":   {X: 1, Y: 1},
+			"goblin":  {X: 2, Y: 2},
+			"fighter": {X: 3, Y: 0},
+		},
+	}
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 2}, nil)
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.Nil(outcome.Strikes[0].Result.Context.Advantage)
+
+	s.fixture.State().Grid.Positions["fighter"] = Position{X: 3, Y: 3}
+	s.fixture.Participant("rogue").Budget.Action = true
+
+	outcome, err = s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.Equal([]attack.Source{attack.SourceFlanking}, outcome.Strikes[0].Result.Context.Advantage)
+}
+
+func (s *suiteEngine) TestReactionOutsideTurn() {
+	s.rollInitiative()
+
+	s.NoError(s.fixture.UseReaction("fighter"))
+	s.Error(s.fixture.UseReaction("fighter"))
+
+	// the reaction comes back at the start of the fighter's turn
+	_, err := s.fixture.EndTurn()
+	s.NoError(err)
+	next, err := s.fixture.EndTurn()
+	s.NoError(err)
+	s.Equal("fighter", next.ID)
+	s.True(next.Budget.Reaction)
+}
+
+func (s *suiteEngine) TestEndTurnStartsNewRound() {
+	s.rollInitiative()
+	s.NoError(s.fixture.Move(30))
+
+	for range 3 {
+		_, err := s.fixture.EndTurn()
+		s.NoError(err)
+	}
+
+	current, err := s.fixture.Current()
+	s.NoError(err)
+	s.Equal("rogue", current.ID)
+	s.Equal(2, s.fixture.Round())
+	s.Equal(30, current.Budget.Movement)
+	s.True(current.Budget.Action)
+}
+
+func (s *suiteEngine) TestEndTurnSkipsDowned() {
+	s.rollInitiative()
+	s.fighter.hp = 5
+
+	_, err := s.fixture.EndTurn()
+	s.NoError(err)
+
+	outcome, err := s.fixture.Attack("fighter")
+	s.NoError(err)
+	s.True(outcome.Strikes[0].Hit)
+	s.Equal(0, s.fighter.hp)
+
+	next, err := s.fixture.EndTurn()
+	s.NoError(err)
+	s.Equal("rogue", next.ID)
+	s.Equal(2, s.fixture.Round())
+}
+
+func (s *suiteEngine) TestSurprisedLoseFirstTurn() {
+	s.NoError(s.fixture.Surprise("rogue", "goblin"))
+	s.rollInitiative()
+
+	current, err := s.fixture.Current()
+	s.NoError(err)
+	s.Equal("fighter", current.ID)
+	s.Equal(1, s.fixture.Round())
+
+	goblin := s.fixture.Participant("goblin")
+	s.False(goblin.Surprised)
+	s.True(goblin.Budget.Reaction)
+
+	texts := make([]string, 0)
+	for _, event := range s.fixture.Events() {
+		texts = append(texts, event.Text)
+	}
+
+	s.Contains(texts, "rogue rolls 18 for initiative and is surprised.")
+	s.Contains(texts, "goblin is surprised and loses their turn.")
+
+	next, err := s.fixture.EndTurn()
+	s.NoError(err)
+	s.Equal("rogue", next.ID)
+	s.Equal(2, s.fixture.Round())
+}
+
+func (s *suiteEngine) TestSurprisedHaveNoReaction() {
+	s.NoError(s.fixture.Surprise("fighter"))
+	s.rollInitiative()
+
+	s.False(s.fixture.Participant("fighter").Budget.Reaction)
+	s.True(s.fixture.Participant("goblin").Budget.Reaction)
+}
+
+func (s *suiteEngine) TestSurpriseAfterInitiative() {
+	s.rollInitiative()
+
+	s.Error(s.fixture.Surprise("goblin"))
+}
+
+func (s *suiteEngine) TestFightIsOver() {
+	s.rollInitiative()
+	_, err := s.fixture.EndTurn()
+	s.NoError(err)
+	_, err = s.fixture.EndTurn()
+	s.NoError(err)
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.True(outcome.Strikes[0].Hit)
+	s.True(s.goblin.IsDown())
+
+	s.True(s.fixture.IsOver())
+	winner, ok := s.fixture.Winner()
+	s.True(ok)
+	s.Equal(SideParty, winner)
+
+	_, err = s.fixture.EndTurn()
+	s.Error(err)
+}
+
+func (s *suiteEngine) TestResumeFromState() {
+	s.rollInitiative()
+	_, err := s.fixture.EndTurn()
+	s.NoError(err)
+
+	buf, err := json.Marshal(s.fixture.State())
+	s.Require().NoError(err)
+	state := &State{}
+	s.Require().NoError(json.Unmarshal(buf, state))
+
+	resumed, err := New(&Config{Roller: s.roller, State: state})
+	s.NoError(err)
+	s.NoError(resumed.Join(s.goblin, SideMonsters))
+	s.NoError(resumed.Join(s.rogue, SideParty))
+
+	_, err = resumed.Current()
+	s.Error(err)
+
+	s.NoError(resumed.Join(s.fighter, SideParty))
+	current, err := resumed.Current()
+	s.NoError(err)
+	s.Equal("goblin", current.ID)
+}
+
+func (s *suiteEngine) TestJoinAfterStart() {
+	s.rollInitiative()
+	_, err := s.fixture.EndTurn()
+	s.NoError(err)
+
+	wolf := &fixedCombatant{id: "wolf", ac: 13, initiative: 4, hp: 11, maxHP: 11}
+	s.roller.On("Roll", 1, 20, 4).Return(&dice.RollResult{Total: 20}, nil)
+
+	s.NoError(s.fixture.Join(wolf, SideMonsters))
+	s.Equal([]string{"wolf", "rogue", "goblin", "fighter"}, s.order())
+
+	current, err := s.fixture.Current()
+	s.NoError(err)
+	s.Equal("goblin", current.ID)
+}
+
+func (s *suiteEngine) TestEventsRecordTheFight() {
+	s.rollInitiative()
+	s.goblin.hp = 4
+
+	_, err := s.fixture.EndTurn()
+	s.NoError(err)
+	_, err = s.fixture.EndTurn()
+	s.NoError(err)
+
+	s.fighter.attacks = []*attack.Result{hit(18, 7)}
+	s.goblin.attacks = nil
+	_, err = s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.fixture.RecordEnd("The party wins.")
+
+	events := s.fixture.Events()
+	types := make([]EventType, len(events))
+	for idx, event := range events {
+		types[idx] = event.Type
+	}
+
+	s.Equal([]EventType{
+		EventTypeInitiative, EventTypeInitiative, EventTypeInitiative,
+		EventTypeAttack, EventTypeDamage, EventTypeEnd,
+	}, types)
+	s.Equal("rogue rolls 18 for initiative.", events[0].Text)
+	s.Equal("fighter", events[3].ActorID)
+	s.Equal("goblin", events[3].TargetID)
+	s.True(events[3].Hit)
+	s.Equal(7, events[3].Attack.DamageRoll)
+	s.Equal(4, events[4].Damage.HPLost)
+	s.Equal(1, events[5].Round)
+}
+
+func (s *suiteEngine) TestEventsRecordDeath() {
+	s.rollInitiative()
+
+	s.fixture.recordAttack(s.fixture.Participant("rogue"), s.fixture.Participant("goblin"), &AttackOutcome{
+		Side:     SideParty,
+		Attacker: s.rogue,
+		Target:   s.goblin,
+		Strikes: []*Strike{{
+			Result: hit(19, 7),
+			Hit:    true,
+			Report: &damage.Report{Target: "goblin", Dealt: 7, Dropped: true},
+		}},
+	})
+
+	events := s.fixture.Events()
+	last := events[len(events)-1]
+	s.Equal(EventTypeDeath, last.Type)
+	s.Equal("goblin", last.ActorID)
+	s.Equal("goblin falls to rogue.", last.Text)
+}
+
+// placeOnGrid puts the rogue in the top left of a grid with a wall and the goblin on the right
+//
+//	r.#.g
+//	.....
+//	f....
+func (s *suiteEngine) placeOnGrid() {
+	s.fixture.State().Grid = &Grid{
+		Width:   5,
+		Height:  3,
+		Terrain: []string{"..#..", ".....", "....."},
+		Positions: map[string]Position{
+			"rogue":   {X: 0, Y: 0},
+			"goblin":  {X: 4, Y: 0},
+			"fighter": {X: 0, Y: 2},
+		},
+	}
+}
+
+func (s *suiteEngine) TestAttackOutOfReach() {
+	s.rollInitiative()
+	s.placeOnGrid()
+
+	_, err := s.fixture.Attack("goblin")
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+	s.EqualError(err, "Invalid parameter: targetID - goblin is 20 feet away, out of reach")
+}
+
+func (s *suiteEngine) TestRangedAttackNeedsSight() {
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.rogue.reach = 80
+	s.rogue.ranged = true
+
+	_, err := s.fixture.Attack("goblin")
+	s.EqualError(err, "Invalid parameter: targetID - goblin is out of sight")
+
+	_, _, err = s.fixture.MoveTo(Position{X: 1, Y: 1})
+	s.NoError(err)
+
+	outcome, err := s.fixture.Attack("goblin")
+	s.NoError(err)
+	s.Len(outcome.Strikes, 1)
+}
+
+func (s *suiteEngine) TestMoveToSpendsMovement() {
+	s.rollInitiative()
+	s.placeOnGrid()
+
+	feet, provoked, err := s.fixture.MoveTo(Position{X: 1, Y: 1})
+	s.NoError(err)
+	s.Equal(5, feet)
+	s.Empty(provoked)
+	s.Equal(25, s.fixture.Participant("rogue").Budget.Movement)
+	s.Equal(Position{X: 1, Y: 1}, s.fixture.State().Grid.Positions["rogue"])
+
+	events := s.fixture.Events()
+	last := events[len(events)-1]
+	s.Equal(EventTypeMove, last.Type)
+	s.Equal("rogue moves 5 feet.", last.Text)
+	s.Equal(&Position{X: 1, Y: 1}, last.Position)
+
+	_, _, err = s.fixture.MoveTo(Position{X: 0, Y: 2})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	s.fixture.Participant("rogue").Budget.Movement = 5
+	_, _, err = s.fixture.MoveTo(Position{X: 3, Y: 1})
+	s.IsType(&dnderr.ResourceExhaustedError{}, err)
+}
+
+func (s *suiteEngine) TestMoveOutOfReachProvokes() {
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.fixture.State().Grid.Positions["rogue"] = Position{X: 3, Y: 1}
+	s.fixture.State().Grid.Positions["goblin"] = Position{X: 4, Y: 1}
+
+	// staying next to the goblin is safe
+	_, provoked, err := s.fixture.MoveTo(Position{X: 3, Y: 2})
+	s.NoError(err)
+	s.Empty(provoked)
+
+	_, provoked, err = s.fixture.MoveTo(Position{X: 2, Y: 2})
+	s.NoError(err)
+	s.Require().Len(provoked, 1)
+	s.True(provoked[0].Opportunity)
+	s.Equal("goblin makes an opportunity attack as rogue leaves.", provoked[0].Lines()[0])
+	s.Equal(4, s.rogue.hp)
+	s.False(s.fixture.Participant("goblin").Budget.Reaction)
+
+	// the goblin has used its reaction for the round
+	_, _, err = s.fixture.MoveTo(Position{X: 3, Y: 1})
+	s.NoError(err)
+	_, provoked, err = s.fixture.MoveTo(Position{X: 2, Y: 1})
+	s.NoError(err)
+	s.Empty(provoked)
+}
+
+func (s *suiteEngine) TestOpportunityAttackDropsMover() {
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.fixture.State().Grid.Positions["rogue"] = Position{X: 3, Y: 1}
+	s.fixture.State().Grid.Positions["goblin"] = Position{X: 4, Y: 1}
+	s.rogue.hp = 3
+
+	feet, provoked, err := s.fixture.MoveTo(Position{X: 2, Y: 1})
+	s.NoError(err)
+	s.Equal(0, feet)
+	s.Len(provoked, 1)
+	s.True(s.rogue.IsDown())
+	s.Equal(30, s.fixture.Participant("rogue").Budget.Movement)
+}
+
+func (s *suiteEngine) TestDisengage() {
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.fixture.State().Grid.Positions["rogue"] = Position{X: 3, Y: 1}
+	s.fixture.State().Grid.Positions["goblin"] = Position{X: 4, Y: 1}
+
+	s.NoError(s.fixture.Disengage())
+	s.True(s.fixture.Participant("rogue").Disengaged)
+	s.False(s.fixture.Participant("rogue").Budget.Action)
+	s.IsType(&dnderr.ResourceExhaustedError{}, s.fixture.Disengage())
+
+	events := s.fixture.Events()
+	s.Equal(ConditionDisengaged, events[len(events)-1].Condition)
+
+	_, provoked, err := s.fixture.MoveTo(Position{X: 1, Y: 1})
+	s.NoError(err)
+	s.Empty(provoked)
+	s.Equal(9, s.rogue.hp)
+
+	for range 3 {
+		_, err = s.fixture.EndTurn()
+		s.NoError(err)
+	}
+
+	s.False(s.fixture.Participant("rogue").Disengaged)
+}
+
+func (s *suiteEngine) TestOpportunityAttacks() {
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.fixture.State().Grid.Positions["fighter"] = Position{X: 3, Y: 0}
+	s.goblin.attacks = []*attack.Result{hit(17, 5), hit(17, 5)}
+
+	// the goblin only reaches the fighter, and only gets the one attack
+	outcomes, err := s.fixture.OpportunityAttacks("rogue")
+	s.NoError(err)
+	s.Empty(outcomes)
+
+	outcomes, err = s.fixture.OpportunityAttacks("fighter")
+	s.NoError(err)
+	s.Require().Len(outcomes, 1)
+	s.Len(outcomes[0].Strikes, 1)
+	s.Equal(7, s.fighter.hp)
+
+	// ranged monsters do not make opportunity attacks
+	s.fixture.Participant("goblin").Budget.Reaction = true
+	s.goblin.reach = 80
+	s.goblin.ranged = true
+	outcomes, err = s.fixture.OpportunityAttacks("fighter")
+	s.NoError(err)
+	s.Empty(outcomes)
+
+	_, err = s.fixture.OpportunityAttacks("wizard")
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *suiteEngine) TestMoveToWithoutGrid() {
+	s.rollInitiative()
+
+	_, _, err := s.fixture.MoveTo(Position{X: 1, Y: 1})
+	s.IsType(&dnderr.InvalidEntityError{}, err)
+}
+
+func (s *suiteEngine) TestEngageMovesIntoReach() {
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.rogue.attacks = []*attack.Result{hit(19, 9)}
+
+	outcome, err := s.fixture.Engage("goblin")
+	s.NoError(err)
+	s.Equal(15, outcome.Moved)
+	s.Len(outcome.Strikes, 1)
+	s.Equal("rogue moves 15 feet toward goblin.", outcome.Lines()[0])
+	s.Equal(15, s.fixture.Participant("rogue").Budget.Movement)
+
+	// the fallen goblin no longer takes up its square
+	_, ok := s.fixture.State().Grid.Position("goblin")
+	s.False(ok)
+}
+
+func (s *suiteEngine) TestEngageOutOfReach() {
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.fixture.Participant("rogue").Budget.Movement = 5
+
+	outcome, err := s.fixture.Engage("goblin")
+	s.NoError(err)
+	s.True(outcome.OutOfReach)
+	s.Empty(outcome.Strikes)
+	s.Equal([]string{"rogue moves 5 feet toward goblin.", "rogue cannot reach goblin this turn."}, outcome.Lines())
+	s.True(s.fixture.Participant("rogue").Budget.Action)
+	s.Equal(7, s.goblin.hp)
+}
+
+// orcBesideRogue puts an orc next to the rogue, the goblin is across the map
+func (s *suiteEngine) orcBesideRogue() *fixedCombatant {
+	orc := &fixedCombatant{id: "orc", ac: 13, hp: 15, maxHP: 15, attacks: []*attack.Result{hit(17, 4)}}
+	s.NoError(s.fixture.Join(orc, SideMonsters))
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 5}, nil)
+
+	s.rollInitiative()
+	s.placeOnGrid()
+	s.fixture.State().Grid.Positions["orc"] = Position{X: 0, Y: 0}
+	s.fixture.State().Grid.Positions["rogue"] = Position{X: 0, Y: 1}
+	s.fixture.State().Grid.Positions["goblin"] = Position{X: 4, Y: 1}
+	s.fixture.State().Grid.Positions["fighter"] = Position{X: 4, Y: 2}
+
+	return orc
+}
+
+func (s *suiteEngine) TestEngageLeavingReachProvokes() {
+	orc := s.orcBesideRogue()
+	s.rogue.attacks = []*attack.Result{hit(19, 9)}
+
+	outcome, err := s.fixture.Engage("goblin")
+	s.NoError(err)
+	s.Require().Len(outcome.Provoked, 1)
+	s.Equal(orc, outcome.Provoked[0].Attacker)
+	s.Equal("orc makes an opportunity attack as rogue leaves.", outcome.Lines()[0])
+	s.Equal(5, s.rogue.hp)
+	s.False(s.fixture.Participant("orc").Budget.Reaction)
+	s.Equal(15, outcome.Moved)
+	s.Len(outcome.Strikes, 1)
+	s.Equal(0, s.goblin.hp)
+}
+
+func (s *suiteEngine) TestEngageOpportunityAttackDropsAttacker() {
+	s.orcBesideRogue()
+	s.rogue.hp = 4
+
+	outcome, err := s.fixture.Engage("goblin")
+	s.NoError(err)
+	s.Len(outcome.Provoked, 1)
+	s.Equal(0, outcome.Moved)
+	s.Empty(outcome.Strikes)
+	s.True(s.rogue.IsDown())
+	s.Equal(30, s.fixture.Participant("rogue").Budget.Movement)
+}
+
+func (s *suiteEngine) TestEngageAfterDisengage() {
+	s.orcBesideRogue()
+	s.fixture.Participant("rogue").Disengaged = true
+
+	outcome, err := s.fixture.Engage("goblin")
+	s.NoError(err)
+	s.Empty(outcome.Provoked)
+	s.Equal(9, s.rogue.hp)
+	s.True(s.fixture.Participant("orc").Budget.Reaction)
+}
+
+func TestSuiteEngine(t *testing.T) {
+	suite.Run(t, new(suiteEngine))
+}
@@ -0,0 +1,164 @@
+package combat
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+)
+
+type EventType string
+
+const (
+	EventTypeInitiative EventType = "initiative"
+	EventTypeMove       EventType = "move"
+	EventTypeAttack     EventType = "attack"
+	EventTypeDamage     EventType = "damage"
+	EventTypeCondition  EventType = "condition"
+	EventTypeDeath      EventType = "death"
+	// EventTypeEnd is how the fight finished, it is recorded by whoever runs the fight
+	EventTypeEnd EventType = "end"
+)
+
+const (
+	// ConditionDodging is recorded when a participant takes the Dodge action
+	ConditionDodging = "dodging"
+	// ConditionDisengaged is recorded when a participant takes the Disengage action
+	ConditionDisengaged = "disengaged"
+)
+
+// Event is one thing that happened in a fight, Text narrates it for replays
+type Event struct {
+	// Seq is the event's place in the fight's log, it is set when the event is stored
+	Seq        int            `json:"seq"`
+	Round      int            `json:"round"`
+	Type       EventType      `json:"type"`
+	ActorID    string         `json:"actor_id,omitempty"`
+	ActorName  string         `json:"actor_name,omitempty"`
+	TargetID   string         `json:"target_id,omitempty"`
+	TargetName string         `json:"target_name,omitempty"`
+	Initiative int            `json:"initiative,omitempty"`
+	Attack     *attack.Result `json:"attack,omitempty"`
+	Hit        bool           `json:"hit,omitempty"`
+	Damage     *damage.Report `json:"damage,omitempty"`
+	Condition  string         `json:"condition,omitempty"`
+	// Position is where a move ended on the map
+	Position *Position `json:"position,omitempty"`
+	Text     string    `json:"text"`
+}
+
+// Events returns what has happened since the engine was created
+func (e *Engine) Events() []*Event {
+	return e.events
+}
+
+// RecordCondition notes a condition applied to a participant, such as fleeing or surrendering
+func (e *Engine) RecordCondition(id, condition, text string) {
+	event := &Event{
+		Type:      EventTypeCondition,
+		Condition: condition,
+		Text:      text,
+	}
+
+	if participant := e.Participant(id); participant != nil && participant.combatant != nil {
+		event.ActorID = participant.ID
+		event.ActorName = participant.combatant.GetName()
+	}
+
+	e.record(event)
+}
+
+// RecordEnd notes how the fight finished
+func (e *Engine) RecordEnd(text string) {
+	e.record(&Event{
+		Type: EventTypeEnd,
+		Text: text,
+	})
+}
+
+func (e *Engine) record(event *Event) {
+	event.Round = e.state.Round
+	e.events = append(e.events, event)
+}
+
+func (e *Engine) recordInitiative(participant *Participant) {
+	name := participant.combatant.GetName()
+	text := fmt.Sprintf("%s rolls %d for initiative.", name, participant.Initiative)
+	if participant.Surprised {
+		text = fmt.Sprintf("%s rolls %d for initiative and is surprised.", name, participant.Initiative)
+	}
+
+	e.record(&Event{
+		Type:       EventTypeInitiative,
+		ActorID:    participant.ID,
+		ActorName:  name,
+		Initiative: participant.Initiative,
+		Text:       text,
+	})
+}
+
+// recordAttack adds an attack event for each strike, followed by the damage it did and the
+// target dropping
+func (e *Engine) recordAttack(attacker, target *Participant, outcome *AttackOutcome) {
+	for _, strike := range outcome.Strikes {
+		lines := outcome.strikeLines(strike)
+		e.record(&Event{
+			Type:       EventTypeAttack,
+			ActorID:    attacker.ID,
+			ActorName:  outcome.Attacker.GetName(),
+			TargetID:   target.ID,
+			TargetName: outcome.Target.GetName(),
+			Attack:     strike.Result,
+			Hit:        strike.Hit,
+			Text:       lines[0],
+		})
+
+		if strike.Report == nil {
+			continue
+		}
+
+		e.record(&Event{
+			Type:       EventTypeDamage,
+			ActorID:    attacker.ID,
+			ActorName:  outcome.Attacker.GetName(),
+			TargetID:   target.ID,
+			TargetName: outcome.Target.GetName(),
+			Damage:     strike.Report,
+			Text:       lines[1],
+		})
+
+		if strike.Report.Dropped {
+			e.record(&Event{
+				Type:       EventTypeDeath,
+				ActorID:    target.ID,
+				ActorName:  outcome.Target.GetName(),
+				TargetID:   attacker.ID,
+				TargetName: outcome.Attacker.GetName(),
+				Text:       fmt.Sprintf("%s falls to %s.", outcome.Target.GetName(), outcome.Attacker.GetName()),
+			})
+		}
+	}
+}
+
+// recordMove adds a move event ending where the participant now stands, toward names who they
+// moved toward when they had a target
+func (e *Engine) recordMove(participant *Participant, feet int, toward string) {
+	name := participant.combatant.GetName()
+	text := fmt.Sprintf("%s moves %d feet.", name, feet)
+	if toward != "" {
+		text = fmt.Sprintf("%s moves %d feet toward %s.", name, feet, toward)
+	}
+
+	event := &Event{
+		Type:      EventTypeMove,
+		ActorID:   participant.ID,
+		ActorName: name,
+		Text:      text,
+	}
+
+	if pos, ok := e.state.Grid.Position(participant.ID); ok {
+		event.Position = &pos
+	}
+
+	e.record(event)
+}
@@ -0,0 +1,420 @@
+package combat
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+// Terrain is what fills a square on the grid, it is also how the square is drawn
+type Terrain byte
+
+const (
+	TerrainOpen Terrain = '.'
+	// TerrainDifficult costs double movement to enter
+	TerrainDifficult Terrain = '~'
+	// TerrainWall blocks movement and line of sight
+	TerrainWall Terrain = '#'
+)
+
+const (
+	// SquareFeet is the size of a square on the grid
+	SquareFeet = 5
+	// MeleeReach is how far an attack reaches when the combatant does not say
+	MeleeReach = 5
+
+	DefaultGridWidth  = 10
+	DefaultGridHeight = 6
+	maxGridSize       = 20
+	// difficultChance and wallChance are the percent chance of a generated square being that terrain
+	difficultChance = 12
+	wallChance      = 8
+)
+
+// Position is a square on the grid, y grows going down
+type Position struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Offset returns the position moved by dx and dy squares
+func (p Position) Offset(dx, dy int) Position {
+	return Position{X: p.X + dx, Y: p.Y + dy}
+}
+
+// Grid is the battle map, each row of Terrain is a line of squares and Positions is where each
+// participant stands by ID
+type Grid struct {
+	Width     int                 `json:"width"`
+	Height    int                 `json:"height"`
+	Terrain   []string            `json:"terrain"`
+	Positions map[string]Position `json:"positions"`
+}
+
+// NewGrid returns an open grid of width by height squares
+func NewGrid(width, height int) (*Grid, error) {
+	if width < 2 || width > maxGridSize {
+		return nil, dnderr.NewInvalidParameterError("width", fmt.Sprintf("must be between 2 and %d", maxGridSize))
+	}
+
+	if height < 1 || height > maxGridSize {
+		return nil, dnderr.NewInvalidParameterError("height", fmt.Sprintf("must be between 1 and %d", maxGridSize))
+	}
+
+	terrain := make([]string, height)
+	for y := range height {
+		terrain[y] = strings.Repeat(string(TerrainOpen), width)
+	}
+
+	return &Grid{
+		Width:     width,
+		Height:    height,
+		Terrain:   terrain,
+		Positions: make(map[string]Position),
+	}, nil
+}
+
+// GenerateGrid scatters difficult terrain and walls over the grid, the first and last columns are
+// kept open for the two sides to line up in. The same seed always builds the same grid.
+func GenerateGrid(seed int64, width, height int) (*Grid, error) {
+	grid, err := NewGrid(width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for y := range height {
+		row := []byte(grid.Terrain[y])
+		for x := 1; x < width-1; x++ {
+			roll := rng.Intn(100)
+			switch {
+			case roll < wallChance:
+				row[x] = byte(TerrainWall)
+			case roll < wallChance+difficultChance:
+				row[x] = byte(TerrainDifficult)
+			}
+		}
+
+		grid.Terrain[y] = string(row)
+	}
+
+	return grid, nil
+}
+
+// InBounds returns true when the position is on the grid
+func (g *Grid) InBounds(pos Position) bool {
+	return pos.X >= 0 && pos.X < g.Width && pos.Y >= 0 && pos.Y < g.Height
+}
+
+// TerrainAt returns the terrain of the square, anything off the grid is a wall
+func (g *Grid) TerrainAt(pos Position) Terrain {
+	if !g.InBounds(pos) || pos.Y >= len(g.Terrain) || pos.X >= len(g.Terrain[pos.Y]) {
+		return TerrainWall
+	}
+
+	return Terrain(g.Terrain[pos.Y][pos.X])
+}
+
+// Occupant returns the ID of whoever stands on the square, empty when no one does
+func (g *Grid) Occupant(pos Position) string {
+	for id, at := range g.Positions {
+		if at == pos {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// Position returns where the participant stands
+func (g *Grid) Position(id string) (Position, bool) {
+	pos, ok := g.Positions[id]
+	return pos, ok
+}
+
+// Place puts the participant on an open square, moving them if they are already on the grid
+func (g *Grid) Place(id string, pos Position) error {
+	if id == "" {
+		return dnderr.NewMissingParameterError("id")
+	}
+
+	if !g.InBounds(pos) {
+		return dnderr.NewInvalidParameterError("pos", "is off the map")
+	}
+
+	if g.TerrainAt(pos) == TerrainWall {
+		return dnderr.NewInvalidParameterError("pos", "is a wall")
+	}
+
+	if occupant := g.Occupant(pos); occupant != "" && occupant != id {
+		return dnderr.NewInvalidParameterError("pos", fmt.Sprintf("is taken by %s", occupant))
+	}
+
+	if g.Positions == nil {
+		g.Positions = make(map[string]Position)
+	}
+
+	g.Positions[id] = pos
+
+	return nil
+}
+
+// PlaceNear puts the participant on the free square closest to pos, searching outward a ring
+// at a time
+func (g *Grid) PlaceNear(id string, pos Position) error {
+	for radius := range max(g.Width, g.Height) {
+		for dy := -radius; dy <= radius; dy++ {
+			for dx := -radius; dx <= radius; dx++ {
+				if max(abs(dx), abs(dy)) != radius {
+					continue
+				}
+
+				spot := pos.Offset(dx, dy)
+				if !g.InBounds(spot) || g.TerrainAt(spot) == TerrainWall || g.Occupant(spot) != "" {
+					continue
+				}
+
+				return g.Place(id, spot)
+			}
+		}
+	}
+
+	return dnderr.NewResourceExhaustedError("there is no room left on the map")
+}
+
+// Remove takes the participant off the grid, the fallen do not block anyone
+func (g *Grid) Remove(id string) {
+	delete(g.Positions, id)
+}
+
+// Distance returns the feet between two participants, diagonals count as one square. Anyone not
+// on the grid is treated as in reach.
+func (g *Grid) Distance(fromID, toID string) int {
+	from, ok := g.Positions[fromID]
+	if !ok {
+		return 0
+	}
+
+	to, ok := g.Positions[toID]
+	if !ok {
+		return 0
+	}
+
+	return squares(from, to) * SquareFeet
+}
+
+// LineOfSight returns true when no wall lies on the line between the two squares
+func (g *Grid) LineOfSight(from, to Position) bool {
+	dx, dy := abs(to.X-from.X), -abs(to.Y-from.Y)
+	sx, sy := sign(to.X-from.X), sign(to.Y-from.Y)
+	err := dx + dy
+
+	x, y := from.X, from.Y
+	for x != to.X || y != to.Y {
+		double := 2 * err
+		if double >= dy {
+			err += dy
+			x += sx
+		}
+
+		if double <= dx {
+			err += dx
+			y += sy
+		}
+
+		if (x != to.X || y != to.Y) && g.TerrainAt(Position{X: x, Y: y}) == TerrainWall {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Reachable returns the cheapest cost in feet to every square the participant can get to. Walls
+// and other participants block the way, difficult terrain costs double to enter.
+func (g *Grid) Reachable(id string) map[Position]int {
+	start, ok := g.Positions[id]
+	if !ok {
+		return map[Position]int{}
+	}
+
+	costs := map[Position]int{start: 0}
+	queue := &squareQueue{{pos: start}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(square)
+		if current.cost > costs[current.pos] {
+			continue
+		}
+
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				next := current.pos.Offset(dx, dy)
+				if next == current.pos || !g.InBounds(next) {
+					continue
+				}
+
+				terrain := g.TerrainAt(next)
+				if terrain == TerrainWall {
+					continue
+				}
+
+				if occupant := g.Occupant(next); occupant != "" && occupant != id {
+					continue
+				}
+
+				cost := current.cost + SquareFeet
+				if terrain == TerrainDifficult {
+					cost += SquareFeet
+				}
+
+				if known, ok := costs[next]; ok && known <= cost {
+					continue
+				}
+
+				costs[next] = cost
+				heap.Push(queue, square{pos: next, cost: cost})
+			}
+		}
+	}
+
+	return costs
+}
+
+// PathCost returns the feet it takes the participant to get to the square
+func (g *Grid) PathCost(id string, to Position) (int, error) {
+	if _, ok := g.Positions[id]; !ok {
+		return 0, dnderr.NewNotFoundError(fmt.Sprintf("%s is not on the map", id))
+	}
+
+	if !g.InBounds(to) {
+		return 0, dnderr.NewInvalidParameterError("to", "is off the map")
+	}
+
+	cost, ok := g.Reachable(id)[to]
+	if !ok {
+		return 0, dnderr.NewInvalidParameterError("to", "cannot be reached")
+	}
+
+	return cost, nil
+}
+
+// Approach returns the cheapest square within feet of movement that puts the target in reach,
+// with sight also needing line of sight to it. When no square does it returns the one that gets
+// closest, staying put when nothing gets any closer.
+func (g *Grid) Approach(id, targetID string, feet, reach int, sight bool) (Position, int) {
+	from := g.Positions[id]
+	target, ok := g.Positions[targetID]
+	if !ok {
+		return from, 0
+	}
+
+	inReach := func(pos Position) bool {
+		return squares(pos, target)*SquareFeet <= reach && (!sight || g.LineOfSight(pos, target))
+	}
+
+	best, bestCost := from, 0
+	for pos, cost := range g.Reachable(id) {
+		if cost > feet {
+			continue
+		}
+
+		if inReach(pos) != inReach(best) {
+			if inReach(pos) {
+				best, bestCost = pos, cost
+			}
+
+			continue
+		}
+
+		// out of reach the closest square wins, in reach the cheapest one does
+		closer := !inReach(pos) && squares(pos, target) < squares(best, target)
+		level := inReach(pos) || squares(pos, target) == squares(best, target)
+		cheaper := level && cost < bestCost
+		// ties go to the top left so the same grid always plays the same way
+		tied := level && cost == bestCost && (pos.Y < best.Y || pos.Y == best.Y && pos.X < best.X)
+		if closer || cheaper || tied {
+			best, bestCost = pos, cost
+		}
+	}
+
+	return best, bestCost
+}
+
+// Render draws the grid with each participant's label on their square, a label is a single
+// character
+//
+//	1.~..a
+//	2.#...
+func (g *Grid) Render(labels map[string]string) string {
+	rows := make([][]string, g.Height)
+	for y := range g.Height {
+		rows[y] = make([]string, g.Width)
+		for x := range g.Width {
+			rows[y][x] = string(g.TerrainAt(Position{X: x, Y: y}))
+		}
+	}
+
+	for id, pos := range g.Positions {
+		label, ok := labels[id]
+		if !ok || !g.InBounds(pos) {
+			continue
+		}
+
+		rows[pos.Y][pos.X] = label
+	}
+
+	lines := make([]string, 0, g.Height)
+	for _, row := range rows {
+		lines = append(lines, strings.Join(row, ""))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// squares is the distance counting diagonals as one square
+func squares(from, to Position) int {
+	return max(abs(to.X-from.X), abs(to.Y-from.Y))
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
+
+func sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type square struct {
+	pos  Position
+	cost int
+}
+
+// squareQueue pops the cheapest square first
+type squareQueue []square
+
+func (q squareQueue) Len() int           { return len(q) }
+func (q squareQueue) Less(i, j int) bool { return q[i].cost < q[j].cost }
+func (q squareQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *squareQueue) Push(x any)        { *q = append(*q, x.(square)) }
+
+func (q *squareQueue) Pop() any {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return last
+}
@@ -0,0 +1,120 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteGrid struct {
+	suite.Suite
+
+	fixture *Grid
+}
+
+func (s *suiteGrid) SetupTest() {
+	s.fixture = &Grid{
+		Width:  5,
+		Height: 3,
+		Terrain: []string{
+			"..#..",
+			".~#..",
+			".....",
+		},
+		Positions: map[string]Position{
+			"fighter": {X: 0, Y: 0},
+			"goblin":  {X: 4, Y: 0},
+		},
+	}
+}
+
+func (s *suiteGrid) TestNewGridValidatesSize() {
+	_, err := NewGrid(1, 3)
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	grid, err := NewGrid(3, 2)
+	s.NoError(err)
+	s.Equal([]string{"...", "..."}, grid.Terrain)
+}
+
+func (s *suiteGrid) TestGenerateGridIsSeeded() {
+	first, err := GenerateGrid(7, DefaultGridWidth, DefaultGridHeight)
+	s.Require().NoError(err)
+
+	second, err := GenerateGrid(7, DefaultGridWidth, DefaultGridHeight)
+	s.Require().NoError(err)
+	s.Equal(first, second)
+
+	for _, row := range first.Terrain {
+		s.Equal(TerrainOpen, Terrain(row[0]))
+		s.Equal(TerrainOpen, Terrain(row[DefaultGridWidth-1]))
+	}
+}
+
+func (s *suiteGrid) TestDistanceCountsDiagonalsAsOneSquare() {
+	s.fixture.Positions["goblin"] = Position{X: 3, Y: 2}
+
+	s.Equal(15, s.fixture.Distance("fighter", "goblin"))
+	s.Equal(0, s.fixture.Distance("fighter", "missing"))
+}
+
+func (s *suiteGrid) TestPathCostGoesAroundWalls() {
+	cost, err := s.fixture.PathCost("fighter", Position{X: 3, Y: 0})
+	s.NoError(err)
+	// around the wall through the bottom row
+	s.Equal(25, cost)
+}
+
+func (s *suiteGrid) TestPathCostDifficultTerrain() {
+	cost, err := s.fixture.PathCost("fighter", Position{X: 1, Y: 1})
+	s.NoError(err)
+	s.Equal(10, cost)
+}
+
+func (s *suiteGrid) TestPathCostBlocked() {
+	_, err := s.fixture.PathCost("fighter", Position{X: 2, Y: 0})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	_, err = s.fixture.PathCost("fighter", Position{X: 4, Y: 0})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+}
+
+func (s *suiteGrid) TestLineOfSight() {
+	s.False(s.fixture.LineOfSight(Position{X: 0, Y: 0}, Position{X: 4, Y: 0}))
+	s.True(s.fixture.LineOfSight(Position{X: 0, Y: 2}, Position{X: 4, Y: 2}))
+	s.True(s.fixture.LineOfSight(Position{X: 0, Y: 0}, Position{X: 1, Y: 0}))
+}
+
+func (s *suiteGrid) TestApproachStopsInReach() {
+	pos, feet := s.fixture.Approach("fighter", "goblin", 30, MeleeReach, false)
+	s.Equal(Position{X: 3, Y: 1}, pos)
+	s.Equal(20, feet)
+}
+
+func (s *suiteGrid) TestApproachGetsClosest() {
+	pos, feet := s.fixture.Approach("fighter", "goblin", 10, MeleeReach, false)
+	s.Equal(Position{X: 1, Y: 0}, pos)
+	s.Equal(5, feet)
+}
+
+func (s *suiteGrid) TestApproachNeedsSight() {
+	pos, feet := s.fixture.Approach("fighter", "goblin", 30, 80, true)
+	s.Equal(Position{X: 2, Y: 2}, pos)
+	s.Equal(15, feet)
+}
+
+func (s *suiteGrid) TestPlaceNearSkipsTakenSquares() {
+	s.NoError(s.fixture.PlaceNear("rogue", Position{X: 0, Y: 0}))
+	s.Equal(Position{X: 1, Y: 0}, s.fixture.Positions["rogue"])
+
+	s.IsType(&dnderr.InvalidParameterError{}, s.fixture.Place("wizard", Position{X: 2, Y: 1}))
+}
+
+func (s *suiteGrid) TestRender() {
+	s.Equal("1.#.a\n.~#..\n.....", s.fixture.Render(map[string]string{"fighter": "1", "goblin": "a"}))
+}
+
+func TestGrid(t *testing.T) {
+	suite.Run(t, new(suiteGrid))
+}
@@ -0,0 +1,128 @@
+package combat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+)
+
+// Strike is one attack roll against the target
+type Strike struct {
+	Result *attack.Result
+	Hit    bool
+	// Report is set when the strike hit
+	Report *damage.Report
+}
+
+// AttackOutcome is the result of an attack action
+type AttackOutcome struct {
+	// Side is the attacker's side
+	Side     Side
+	Attacker Combatant
+	Target   Combatant
+	// Moved is the feet the attacker moved toward the target
+	Moved int
+	// OutOfReach is true when the attacker could not get in reach and did not attack
+	OutOfReach bool
+	// Opportunity is true for an attack made with a reaction as the target left the attacker's reach
+	Opportunity bool
+	// Provoked are the opportunity attacks made on the attacker as they moved toward the target
+	Provoked []*AttackOutcome
+	Strikes  []*Strike
+}
+
+// Lines narrates the opportunity attacks the attacker provoked, their approach and each strike
+// followed by the damage it did
+func (o *AttackOutcome) Lines() []string {
+	attacker := o.Attacker.GetName()
+	target := o.Target.GetName()
+
+	lines := make([]string, 0, len(o.Strikes)+1)
+	for _, provoked := range o.Provoked {
+		lines = append(lines, provoked.Lines()...)
+	}
+
+	if o.Moved > 0 {
+		lines = append(lines, fmt.Sprintf("%s moves %d feet toward %s.", attacker, o.Moved, target))
+	}
+
+	if o.OutOfReach {
+		lines = append(lines, fmt.Sprintf("%s cannot reach %s this turn.", attacker, target))
+	}
+
+	if o.Opportunity {
+		lines = append(lines, fmt.Sprintf("%s makes an opportunity attack as %s leaves.", attacker, target))
+	}
+
+	for _, strike := range o.Strikes {
+		lines = append(lines, o.strikeLines(strike)...)
+	}
+
+	return lines
+}
+
+// strikeLines narrates the strike and the damage it did
+func (o *AttackOutcome) strikeLines(strike *Strike) []string {
+	pronoun := "their"
+	if o.Side == SideMonsters {
+		pronoun = "its"
+	}
+
+	attacker := o.Attacker.GetName()
+	target := o.Target.GetName()
+
+	weapon := ""
+	if strike.Result.Name != "" {
+		weapon = fmt.Sprintf(" with %s %s", pronoun, strike.Result.Name)
+	}
+
+	ac := o.Target.GetAC()
+	lines := make([]string, 0, 3)
+	switch {
+	case strike.Hit && strike.Result.CriticalAgainst(ac):
+		lines = append(lines, fmt.Sprintf("%s lands a critical hit on %s%s!", attacker, target, weapon))
+	case strike.Hit && strike.Result.IsCritical():
+		lines = append(lines, fmt.Sprintf("%s hits %s%s but fails to confirm the critical (%d vs AC %d).", attacker, target, weapon, strike.Result.ConfirmResult.Total+strike.Result.AttackBonus, ac))
+	case strike.Hit:
+		lines = append(lines, fmt.Sprintf("%s hits %s%s (%d vs AC %d).", attacker, target, weapon, strike.Result.AttackRoll, ac))
+	default:
+		lines = append(lines, fmt.Sprintf("%s attacks %s%s but misses (%d vs AC %d).", attacker, target, weapon, strike.Result.AttackRoll, ac))
+	}
+
+	lines[0] += rollNote(strike.Result)
+
+	if strike.Report != nil {
+		lines = append(lines, strike.Report.String())
+	}
+
+	switch strike.Result.Fumble {
+	case attack.FumbleOffBalance:
+		lines = append(lines, fmt.Sprintf("%s fumbles and is thrown off balance, losing %s reaction.", attacker, pronoun))
+	case attack.FumbleStumble:
+		lines = append(lines, fmt.Sprintf("%s fumbles and stumbles, losing the rest of %s movement.", attacker, pronoun))
+	}
+
+	return lines
+}
+
+// rollNote explains an attack roll with advantage or disadvantage, the d20s rolled, the one that
+// counted and why
+func rollNote(result *attack.Result) string {
+	ctx := result.Context
+	if ctx.IsCancelled() {
+		note := ctx.String()
+		return fmt.Sprintf(" %s%s.", strings.ToUpper(note[:1]), note[1:])
+	}
+
+	if ctx.Mode() == attack.RollNormal || result.OtherResult == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" Rolled %d and %d with %s, kept the %d.", result.AttackResult.Total, result.OtherResult.Total, ctx.String(), result.UsedResult().Total)
+}
+
+func (o *AttackOutcome) String() string {
+	return strings.Join(o.Lines(), "\n")
+}
@@ -0,0 +1,29 @@
+package dice
+
+import "github.com/stretchr/testify/mock"
+
+// Roller rolls dice, it lets callers swap in fixed rolls
+type Roller interface {
+	Roll(count, size, bonus int) (*RollResult, error)
+}
+
+// DefaultRoller rolls with Roll
+type DefaultRoller struct{}
+
+func (r *DefaultRoller) Roll(count, size, bonus int) (*RollResult, error) {
+	return Roll(count, size, bonus)
+}
+
+type MockRoller struct {
+	mock.Mock
+}
+
+func (m *MockRoller) Roll(count, size, bonus int) (*RollResult, error) {
+	args := m.Called(count, size, bonus)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*RollResult), nil
+}
@@ -0,0 +1,89 @@
+package attack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RollMode is how many d20s an attack roll takes and which one counts
+type RollMode string
+
+const (
+	RollNormal RollMode = ""
+	// RollAdvantage rolls two d20s and keeps the higher
+	RollAdvantage RollMode = "advantage"
+	// RollDisadvantage rolls two d20s and keeps the lower
+	RollDisadvantage RollMode = "disadvantage"
+)
+
+// Source is why an attack roll has advantage or disadvantage, "goblin is prone"
+type Source string
+
+const (
+	SourceLongRange Source = "long range"
+	SourceHelp      Source = "help"
+	SourceFlanking  Source = "flanking"
+)
+
+// Context is everything giving an attack roll advantage or disadvantage. However many sources
+// there are of each, having both cancels out to a normal roll.
+type Context struct {
+	Advantage    []Source `json:"advantage,omitempty"`
+	Disadvantage []Source `json:"disadvantage,omitempty"`
+}
+
+// Add gives the attack advantage or disadvantage from the source
+func (c *Context) Add(mode RollMode, source Source) {
+	switch mode {
+	case RollAdvantage:
+		c.Advantage = append(c.Advantage, source)
+	case RollDisadvantage:
+		c.Disadvantage = append(c.Disadvantage, source)
+	}
+}
+
+// Mode is how the attack is rolled, a nil context is a normal roll
+func (c *Context) Mode() RollMode {
+	if c == nil {
+		return RollNormal
+	}
+
+	switch {
+	case len(c.Advantage) > 0 && len(c.Disadvantage) > 0:
+		return RollNormal
+	case len(c.Advantage) > 0:
+		return RollAdvantage
+	case len(c.Disadvantage) > 0:
+		return RollDisadvantage
+	default:
+		return RollNormal
+	}
+}
+
+// IsCancelled returns true when advantage and disadvantage cancel each other out
+func (c *Context) IsCancelled() bool {
+	return c != nil && len(c.Advantage) > 0 && len(c.Disadvantage) > 0
+}
+
+func joinSources(sources []Source) string {
+	parts := make([]string, len(sources))
+	for idx, source := range sources {
+		parts[idx] = string(source)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// String explains the roll, "advantage (goblin is prone)"
+func (c *Context) String() string {
+	switch {
+	case c.IsCancelled():
+		return fmt.Sprintf("advantage (%s) and disadvantage (%s) cancel out", joinSources(c.Advantage), joinSources(c.Disadvantage))
+	case c.Mode() == RollAdvantage:
+		return fmt.Sprintf("advantage (%s)", joinSources(c.Advantage))
+	case c.Mode() == RollDisadvantage:
+		return fmt.Sprintf("disadvantage (%s)", joinSources(c.Disadvantage))
+	default:
+		return ""
+	}
+}
@@ -3,23 +3,131 @@ package attack
 import (
 	"fmt"
 
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
 	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
 )
 
 type Result struct {
+	// Name is the weapon or action the attack was made with
+	Name         string
 	AttackRoll   int
 	AttackType   damage.Type
 	DamageRoll   int
 	AttackResult *dice.RollResult
 	DamageResult *dice.RollResult
+	// AttackBonus and DamageBonus are added to the dice, with Damage they let the house rules
+	// work the rolls out again
+	AttackBonus int
+	DamageBonus int
+	Damage      *damage.Damage
+	// Critical is set when the natural roll is in the crit range
+	Critical bool
+	// CritResult is the second roll of the damage dice for a critical hit
+	CritResult *dice.RollResult
+	// ConfirmResult is the roll to confirm a critical hit when the house rules ask for one
+	ConfirmResult *dice.RollResult
+	// NormalDamage is the damage without the critical hit's extra dice
+	NormalDamage int
+	// Fumble is what went wrong on a natural 1 rolled on the fumble table
+	Fumble Fumble
+	// Context is what gave the attack advantage or disadvantage
+	Context *Context
+	// OtherResult is the second d20 of an attack with advantage or disadvantage, AttackResult is
+	// the first
+	OtherResult *dice.RollResult
 }
 
 func (r *Result) String() string {
 	return fmt.Sprintf("attack: %d, type: %s, damage: %d", r.AttackRoll, r.AttackType, r.DamageRoll)
 }
 
+// natural is the d20 roll that counts before any bonus
+func (r *Result) natural() int {
+	if used := r.UsedResult(); used != nil {
+		return used.Total
+	}
+
+	return 0
+}
+
+// UsedResult is the d20 that counts, the higher of the two with advantage and the lower with
+// disadvantage
+func (r *Result) UsedResult() *dice.RollResult {
+	if r.OtherResult == nil || r.AttackResult == nil {
+		return r.AttackResult
+	}
+
+	switch r.Context.Mode() {
+	case RollAdvantage:
+		if r.OtherResult.Total > r.AttackResult.Total {
+			return r.OtherResult
+		}
+	case RollDisadvantage:
+		if r.OtherResult.Total < r.AttackResult.Total {
+			return r.OtherResult
+		}
+	}
+
+	return r.AttackResult
+}
+
+// Hits returns true if the attack beats the armor class, a natural 20 or a confirmed critical hit
+// always hits and a natural 1 always misses
+func (r *Result) Hits(ac int) bool {
+	switch {
+	case r.natural() == naturalCrit:
+		return true
+	case r.natural() == 1:
+		return false
+	case r.CriticalAgainst(ac):
+		return true
+	}
+
+	return r.AttackRoll >= ac
+}
+
+// IsCritical returns true if the attack roll was in the crit range
+func (r *Result) IsCritical() bool {
+	return r.Critical || r.natural() == naturalCrit
+}
+
+// CriticalAgainst returns true if the attack is a critical hit on the armor class. A critical hit
+// that has to be confirmed needs the confirmation roll to hit as well, and a threat below a
+// natural 20 has to hit before it can be confirmed at all.
+func (r *Result) CriticalAgainst(ac int) bool {
+	if !r.IsCritical() {
+		return false
+	}
+
+	if r.ConfirmResult == nil {
+		return true
+	}
+
+	if r.natural() != naturalCrit && r.AttackRoll < ac {
+		return false
+	}
+
+	return r.ConfirmResult.Total+r.AttackBonus >= ac
+}
+
+// DamageAgainst is the damage the attack does when it hits the armor class, a critical hit that
+// is not confirmed does normal damage
+func (r *Result) DamageAgainst(ac int) int {
+	if r.IsCritical() && !r.CriticalAgainst(ac) {
+		return r.NormalDamage
+	}
+
+	return r.DamageRoll
+}
+
+// RollAttack rolls the attack and damage dice by the SRD rules. It rolls a single d20, the second
+// one for advantage or disadvantage is rolled when the result is resolved with a Context.
 func RollAttack(attackBonus, damageBonus int, dmg *damage.Damage) (*Result, error) {
+	if dmg == nil {
+		return nil, dnderr.NewMissingParameterError("dmg")
+	}
+
 	attackResult, err := dice.Roll(1, 20, 0)
 	if err != nil {
 		return nil, err
@@ -29,29 +137,20 @@ func RollAttack(attackBonus, damageBonus int, dmg *damage.Damage) (*Result, erro
 	if err != nil {
 		return nil, err
 	}
-	dmgValue := dmgResult.Total
-	attackRoll := attackResult.Total
-	switch attackResult.Total {
-	case 20:
-		critDmg, err := dice.Roll(dmg.DiceCount, dmg.DiceSize, 0)
-		if err != nil {
-			return nil, err
-		}
-
-		dmgValue = dmgValue + critDmg.Total
-		attackRoll = attackRoll + attackBonus
-	case 1:
-		attackRoll = 0
-	default:
-		attackRoll = attackRoll + attackBonus
 
-	}
-
-	return &Result{
-		AttackRoll:   attackRoll,
+	result := &Result{
 		AttackType:   dmg.DamageType,
-		DamageRoll:   damageBonus + dmgValue,
 		AttackResult: attackResult,
 		DamageResult: dmgResult,
-	}, nil
+		AttackBonus:  attackBonus,
+		DamageBonus:  damageBonus,
+		Damage:       dmg,
+	}
+
+	err = DefaultRules().Resolve(&dice.DefaultRoller{}, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
@@ -0,0 +1,209 @@
+package attack
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+)
+
+// CritDamage is how the extra damage of a critical hit is worked out
+type CritDamage string
+
+const (
+	// CritDamageDice rolls the damage dice a second time, the SRD rule
+	CritDamageDice CritDamage = "dice"
+	// CritDamageMax adds the most the damage dice could roll instead of rolling them again
+	CritDamageMax CritDamage = "max"
+)
+
+// FumbleMode is what a natural 1 does
+type FumbleMode string
+
+const (
+	// FumbleMiss only misses, the SRD rule
+	FumbleMiss FumbleMode = "miss"
+	// FumbleTable misses and rolls on the fumble table
+	FumbleTable FumbleMode = "table"
+)
+
+// Fumble is what went wrong on a natural 1 rolled on the fumble table
+type Fumble string
+
+const (
+	// FumbleNone is a natural 1 that only misses
+	FumbleNone Fumble = ""
+	// FumbleOffBalance costs the attacker their reaction
+	FumbleOffBalance Fumble = "off-balance"
+	// FumbleStumble costs the attacker the rest of their movement
+	FumbleStumble Fumble = "stumble"
+)
+
+const (
+	// fumbleDie is rolled on the fumble table, the high half of it is a close call
+	fumbleDie = 6
+	// minCritRange is the widest crit range a table can play with
+	minCritRange = 15
+	naturalCrit  = 20
+)
+
+// Rules are the house rules attacks are rolled by, the zero value plays by the SRD
+type Rules struct {
+	CritDamage CritDamage `json:"crit_damage,omitempty"`
+	// CritRange is the lowest natural roll that is a critical hit, 0 is a natural 20 only. It
+	// widens the range for everyone the way Improved Critical does for a champion.
+	CritRange int `json:"crit_range,omitempty"`
+	// ConfirmCrits has a critical hit roll to hit again, one that misses the second time is a
+	// normal hit
+	ConfirmCrits bool       `json:"confirm_crits,omitempty"`
+	Fumbles      FumbleMode `json:"fumbles,omitempty"`
+	// Flanking gives a melee attack advantage when an ally of the attacker stands on the opposite
+	// side of the target
+	Flanking bool `json:"flanking,omitempty"`
+}
+
+// DefaultRules are the SRD rules
+func DefaultRules() *Rules {
+	return &Rules{
+		CritDamage: CritDamageDice,
+		CritRange:  naturalCrit,
+		Fumbles:    FumbleMiss,
+	}
+}
+
+// Validate returns an error for a setting the attack pipeline does not know
+func (r *Rules) Validate() error {
+	switch r.CritDamage {
+	case "", CritDamageDice, CritDamageMax:
+	default:
+		return dnderr.NewInvalidParameterError("rules.CritDamage", string(r.CritDamage))
+	}
+
+	if r.CritRange != 0 && (r.CritRange < minCritRange || r.CritRange > naturalCrit) {
+		return dnderr.NewInvalidParameterError("rules.CritRange", fmt.Sprintf("must be between %d and %d", minCritRange, naturalCrit))
+	}
+
+	switch r.Fumbles {
+	case "", FumbleMiss, FumbleTable:
+	default:
+		return dnderr.NewInvalidParameterError("rules.Fumbles", string(r.Fumbles))
+	}
+
+	return nil
+}
+
+// GetCritDamage defaults to the SRD's second roll of the dice
+func (r *Rules) GetCritDamage() CritDamage {
+	if r.CritDamage == "" {
+		return CritDamageDice
+	}
+
+	return r.CritDamage
+}
+
+// GetCritRange defaults to a natural 20
+func (r *Rules) GetCritRange() int {
+	if r.CritRange == 0 {
+		return naturalCrit
+	}
+
+	return r.CritRange
+}
+
+// GetFumbles defaults to a plain miss
+func (r *Rules) GetFumbles() FumbleMode {
+	if r.Fumbles == "" {
+		return FumbleMiss
+	}
+
+	return r.Fumbles
+}
+
+// Resolve works out the attack and damage rolls of the result from its dice under the rules and
+// its Context. The extra dice are only rolled the first time they are needed so a result can be
+// resolved again under other rules. The damage of results that were not rolled with RollAttack is
+// left as it is.
+func (r *Rules) Resolve(roller dice.Roller, result *Result) error {
+	if result == nil || result.AttackResult == nil {
+		return nil
+	}
+
+	if result.Context.Mode() != RollNormal && result.OtherResult == nil {
+		other, err := roller.Roll(1, 20, 0)
+		if err != nil {
+			return err
+		}
+
+		result.OtherResult = other
+	}
+
+	natural := result.natural()
+	result.Critical = natural >= r.GetCritRange()
+	result.AttackRoll = natural + result.AttackBonus
+	result.Fumble = FumbleNone
+
+	if natural == 1 {
+		result.AttackRoll = 0
+
+		if r.GetFumbles() == FumbleTable {
+			fumble, err := roller.Roll(1, fumbleDie, 0)
+			if err != nil {
+				return err
+			}
+
+			result.Fumble = fumbleFor(fumble.Total)
+		}
+	}
+
+	switch {
+	case !result.Critical || !r.ConfirmCrits:
+		result.ConfirmResult = nil
+	case result.ConfirmResult == nil:
+		confirm, err := roller.Roll(1, 20, 0)
+		if err != nil {
+			return err
+		}
+
+		result.ConfirmResult = confirm
+	}
+
+	if result.Damage == nil || result.DamageResult == nil {
+		return nil
+	}
+
+	result.NormalDamage = result.DamageResult.Total + result.DamageBonus
+	result.DamageRoll = result.NormalDamage
+	if !result.Critical {
+		return nil
+	}
+
+	switch r.GetCritDamage() {
+	case CritDamageMax:
+		result.DamageRoll += result.Damage.DiceCount * result.Damage.DiceSize
+	default:
+		if result.CritResult == nil {
+			crit, err := roller.Roll(result.Damage.DiceCount, result.Damage.DiceSize, 0)
+			if err != nil {
+				return err
+			}
+
+			result.CritResult = crit
+		}
+
+		result.DamageRoll += result.CritResult.Total
+	}
+
+	return nil
+}
+
+// fumbleFor reads the fumble table
+func fumbleFor(roll int) Fumble {
+	switch {
+	case roll <= 2:
+		return FumbleOffBalance
+	case roll <= 4:
+		return FumbleStumble
+	default:
+		return FumbleNone
+	}
+}
@@ -0,0 +1,171 @@
+package attack
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteRules struct {
+	suite.Suite
+
+	roller *dice.MockRoller
+}
+
+func (s *suiteRules) SetupTest() {
+	s.roller = &dice.MockRoller{}
+}
+
+// rolled is a +5 attack with a 1d8+3 weapon that rolled the natural and damage dice given
+func rolled(natural, dmg int) *Result {
+	return &Result{
+		AttackType:   damage.TypeSlashing,
+		AttackResult: &dice.RollResult{Total: natural},
+		DamageResult: &dice.RollResult{Total: dmg},
+		AttackBonus:  5,
+		DamageBonus:  3,
+		Damage:       &damage.Damage{DiceCount: 1, DiceSize: 8, DamageType: damage.TypeSlashing},
+	}
+}
+
+func (s *suiteRules) TestSRD() {
+	s.roller.On("Roll", 1, 8, 0).Return(&dice.RollResult{Total: 6}, nil).Once()
+
+	result := rolled(20, 4)
+	s.NoError(DefaultRules().Resolve(s.roller, result))
+	s.True(result.Critical)
+	s.Equal(25, result.AttackRoll)
+	s.Equal(7, result.NormalDamage)
+	s.Equal(13, result.DamageRoll)
+
+	// the crit dice are kept when the result is resolved again
+	s.NoError((&Rules{}).Resolve(s.roller, result))
+	s.Equal(13, result.DamageRoll)
+	s.roller.AssertExpectations(s.T())
+}
+
+func (s *suiteRules) TestMaxDamageCrits() {
+	result := rolled(20, 4)
+	s.NoError((&Rules{CritDamage: CritDamageMax}).Resolve(s.roller, result))
+	s.Equal(15, result.DamageRoll)
+	s.Nil(result.CritResult)
+}
+
+func (s *suiteRules) TestCritRange() {
+	s.roller.On("Roll", 1, 8, 0).Return(&dice.RollResult{Total: 2}, nil)
+
+	result := rolled(19, 4)
+	s.NoError((&Rules{CritRange: 19}).Resolve(s.roller, result))
+	s.True(result.IsCritical())
+	s.True(result.Hits(30))
+	s.Equal(9, result.DamageAgainst(30))
+
+	s.NoError(DefaultRules().Resolve(s.roller, result))
+	s.False(result.IsCritical())
+	s.False(result.Hits(30))
+}
+
+func (s *suiteRules) TestConfirmCrits() {
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 9}, nil).Once()
+
+	result := rolled(19, 4)
+	s.NoError((&Rules{CritRange: 19, CritDamage: CritDamageMax, ConfirmCrits: true}).Resolve(s.roller, result))
+	s.Equal(15, result.DamageRoll)
+
+	// 9+5 confirms against AC 14 but not AC 15, where the 24 is still a normal hit
+	s.True(result.CriticalAgainst(14))
+	s.Equal(15, result.DamageAgainst(14))
+	s.False(result.CriticalAgainst(15))
+	s.True(result.Hits(15))
+	s.Equal(7, result.DamageAgainst(15))
+	s.False(result.Hits(25))
+}
+
+func (s *suiteRules) TestConfirmCritsNeedsTheThreatToHit() {
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 20}, nil).Once()
+
+	result := rolled(19, 4)
+	s.NoError((&Rules{CritRange: 19, CritDamage: CritDamageMax, ConfirmCrits: true}).Resolve(s.roller, result))
+
+	// the 24 misses AC 25, the 25 on the confirmation roll can't turn it into a hit
+	s.False(result.CriticalAgainst(25))
+	s.False(result.Hits(25))
+	s.True(result.Hits(24))
+}
+
+func (s *suiteRules) TestFumbleTable() {
+	rules := &Rules{Fumbles: FumbleTable}
+	for roll, fumble := range map[int]Fumble{1: FumbleOffBalance, 4: FumbleStumble, 6: FumbleNone} {
+		roller := &dice.MockRoller{}
+		roller.On("Roll", 1, fumbleDie, 0).Return(&dice.RollResult{Total: roll}, nil)
+
+		result := rolled(1, 4)
+		s.NoError(rules.Resolve(roller, result))
+		s.Equal(0, result.AttackRoll)
+		s.Equal(fumble, result.Fumble)
+		s.False(result.Hits(1))
+	}
+}
+
+func (s *suiteRules) TestFumbleMissOnlyMisses() {
+	result := rolled(1, 4)
+	s.NoError(DefaultRules().Resolve(s.roller, result))
+	s.Equal(0, result.AttackRoll)
+	s.Equal(FumbleNone, result.Fumble)
+}
+
+func (s *suiteRules) TestAdvantage() {
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 20}, nil).Once()
+	s.roller.On("Roll", 1, 8, 0).Return(&dice.RollResult{Total: 5}, nil).Once()
+
+	result := rolled(4, 4)
+	result.Context = &Context{Advantage: []Source{SourceHelp}}
+	s.NoError(DefaultRules().Resolve(s.roller, result))
+	s.Equal(20, result.UsedResult().Total)
+	s.True(result.Critical)
+	s.Equal(25, result.AttackRoll)
+	s.Equal(12, result.DamageRoll)
+
+	// the same two dice with disadvantage keep the 4
+	result.Context = &Context{Disadvantage: []Source{SourceLongRange}}
+	s.NoError(DefaultRules().Resolve(s.roller, result))
+	s.Equal(4, result.UsedResult().Total)
+	s.False(result.Critical)
+	s.Equal(9, result.AttackRoll)
+	s.roller.AssertExpectations(s.T())
+}
+
+func (s *suiteRules) TestContextMode() {
+	var ctx *Context
+	s.Equal(RollNormal, ctx.Mode())
+	s.Equal("", ctx.String())
+
+	ctx = &Context{}
+	ctx.Add(RollAdvantage, SourceHelp)
+	ctx.Add(RollAdvantage, SourceFlanking)
+	ctx.Add(RollNormal, "ignored")
+	s.Equal(RollAdvantage, ctx.Mode())
+	s.Equal("advantage (help, flanking)", ctx.String())
+
+	// any disadvantage cancels any amount of advantage
+	ctx.Add(RollDisadvantage, SourceLongRange)
+	s.Equal(RollNormal, ctx.Mode())
+	s.True(ctx.IsCancelled())
+	s.Equal("advantage (help, flanking) and disadvantage (long range) cancel out", ctx.String())
+}
+
+func (s *suiteRules) TestValidate() {
+	s.NoError((&Rules{}).Validate())
+	s.NoError(DefaultRules().Validate())
+	s.IsType(&dnderr.InvalidParameterError{}, (&Rules{CritDamage: "triple"}).Validate())
+	s.IsType(&dnderr.InvalidParameterError{}, (&Rules{CritRange: 14}).Validate())
+	s.IsType(&dnderr.InvalidParameterError{}, (&Rules{CritRange: 21}).Validate())
+	s.IsType(&dnderr.InvalidParameterError{}, (&Rules{Fumbles: "explode"}).Validate())
+}
+
+func TestSuiteRules(t *testing.T) {
+	suite.Run(t, new(suiteRules))
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
 )
 
 type Slot string
@@ -21,22 +22,34 @@ const (
 )
 
 type Character struct {
-	ID                 string
-	OwnerID            string
-	Name               string
-	Speed              int
-	Race               *Race
-	Class              *Class
+	ID string
+	// Version is the stored version the character was loaded from, used to reject stale writes
+	Version int
+	OwnerID string
+	Name    string
+	Speed   int
+	Race    *Race
+	// Class is the class the character started with, it decides saving throws and starting equipment
+	Class *Class
+	// Classes holds the levels taken in each class, starting class first
+	Classes            []*ClassLevel
 	Attribues          map[Attribute]*AbilityScore
 	Rolls              []*dice.RollResult
 	Proficiencies      map[ProficiencyType][]*Proficiency
 	ProficiencyChoices []*Choice
 	Inventory          map[EquipmentType][]Equipment
+	Coins              Coins
+	Feats              []*Feat
+	// PendingImprovements is the number of ability score improvements earned but not yet chosen
+	PendingImprovements int
+	// PendingLevels is the number of levels earned with experience but not yet taken in a class
+	PendingLevels int
 
 	HitDie           int
 	AC               int
 	MaxHitPoints     int
 	CurrentHitPoints int
+	TempHitPoints    int
 	Level            int
 	Experience       int
 	NextLevel        int
@@ -109,21 +122,28 @@ func (c *Character) Attack() ([]*attack.Result, error) {
 }
 
 func (c *Character) improvisedMelee() (*attack.Result, error) {
-	bonus := c.Attribues[AttributeStrength].Bonus
-	attackRoll, err := dice.Roll(1, 20, 0)
+	bonus := c.attributeBonus(AttributeStrength)
+	result, err := attack.RollAttack(bonus, bonus, &damage.Damage{
+		DiceCount:  1,
+		DiceSize:   4,
+		DamageType: damage.TypeBludgeoning,
+	})
 	if err != nil {
 		return nil, err
 	}
-	damageRoll, err := dice.Roll(1, 4, 0)
-	if err != nil {
-		return nil, err
+
+	result.Name = "Improvised Weapon"
+
+	return result, nil
+}
+
+// attributeBonus returns the bonus for the attribute, or 0 if it has not been set
+func (c *Character) attributeBonus(attr Attribute) int {
+	if c.Attribues == nil || c.Attribues[attr] == nil {
+		return 0
 	}
 
-	return &attack.Result{
-		AttackRoll: attackRoll.Total + bonus,
-		DamageRoll: damageRoll.Total + bonus,
-		AttackType: "bludgening",
-	}, nil
+	return c.Attribues[attr].Bonus
 }
 
 func (c *Character) getEquipment(key string) Equipment {
@@ -172,7 +192,9 @@ func (c *Character) Equip(key string) bool {
 
 func (c *Character) calculateAC() {
 	c.AC = 10
-	for _, e := range c.EquippedSlots {
+	// Body armor replaces the base AC so it has to be applied before shields
+	for _, slot := range []Slot{SlotBody, SlotMainHand, SlotOffHand, SlotTwoHanded} {
+		e := c.EquippedSlots[slot]
 		if e == nil {
 			continue
 		}
@@ -186,17 +208,23 @@ func (c *Character) calculateAC() {
 				c.AC = armor.ArmorClass.Base
 				if armor.ArmorClass.DexBonus {
 					// TODO: load max and bonus and limit id applicable
-					c.AC += c.Attribues[AttributeDexterity].Bonus
+					c.AC += c.attributeBonus(AttributeDexterity)
 				}
 				continue
 			}
 
 			c.AC += armor.ArmorClass.Base
 			if armor.ArmorClass.DexBonus {
-				c.AC += c.Attribues[AttributeDexterity].Bonus
+				c.AC += c.attributeBonus(AttributeDexterity)
 			}
 		}
 	}
+
+	_, mainOk := c.EquippedSlots[SlotMainHand].(*Weapon)
+	_, offOk := c.EquippedSlots[SlotOffHand].(*Weapon)
+	if mainOk && offOk {
+		c.AC += c.featDualWieldACBonus()
+	}
 }
 
 func (c *Character) SetHitpoints() {
@@ -229,33 +257,41 @@ func (c *Character) AddAttribute(attr Attribute, score int) {
 		Score: score,
 		Bonus: bonus,
 	}
+	abilityScore.Bonus += modifierForScore(score)
+
+	c.Attribues[attr] = abilityScore
+}
+
+// modifierForScore is the SRD modifier for scores from 1 to 20
+func modifierForScore(score int) int {
 	switch {
 	case score == 1:
-		abilityScore.Bonus += -5
+		return -5
 	case score < 4 && score > 1:
-		abilityScore.Bonus += -4
+		return -4
 	case score < 6 && score > 3:
-		abilityScore.Bonus += -3
+		return -3
 	case score < 8 && score > 5:
-		abilityScore.Bonus += -2
+		return -2
 	case score < 10 && score >= 8:
-		abilityScore.Bonus += -1
+		return -1
 	case score < 12 && score > 9:
-		abilityScore.Bonus += 0
+		return 0
 	case score < 14 && score > 11:
-		abilityScore.Bonus += 1
+		return 1
 	case score < 16 && score > 13:
-		abilityScore.Bonus += 2
+		return 2
 	case score < 18 && score > 15:
-		abilityScore.Bonus += 3
+		return 3
 	case score < 20 && score > 17:
-		abilityScore.Bonus += 4
+		return 4
 	case score == 20:
-		abilityScore.Bonus += 5
+		return 5
 	}
 
-	c.Attribues[attr] = abilityScore
+	return 0
 }
+
 func (c *Character) AddAbilityBonus(ab *AbilityBonus) {
 	if c.Attribues == nil {
 		c.Attribues = make(map[Attribute]*AbilityScore)
@@ -269,11 +305,11 @@ func (c *Character) AddAbilityBonus(ab *AbilityBonus) {
 }
 
 func (c *Character) AddInventory(e Equipment) {
+	c.mu.Lock()
 	if c.Inventory == nil {
 		c.Inventory = make(map[EquipmentType][]Equipment)
 	}
 
-	c.mu.Lock()
 	if c.Inventory[e.GetEquipmentType()] == nil {
 		c.Inventory[e.GetEquipmentType()] = make([]Equipment, 0)
 	}
@@ -282,17 +318,78 @@ func (c *Character) AddInventory(e Equipment) {
 	c.mu.Unlock()
 }
 
+// RemoveInventory takes one of the item out of the inventory, it is unequipped when it was the
+// last one. Nil when the character does not have it.
+func (c *Character) RemoveInventory(key string) Equipment {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for equipmentType, items := range c.Inventory {
+		for idx, item := range items {
+			if item.GetKey() != key {
+				continue
+			}
+
+			c.Inventory[equipmentType] = append(items[:idx:idx], items[idx+1:]...)
+			if c.getEquipment(key) == nil {
+				c.unequip(key)
+			}
+
+			return item
+		}
+	}
+
+	return nil
+}
+
+// unequip empties the slots holding the item
+func (c *Character) unequip(key string) {
+	unequipped := false
+	for slot, item := range c.EquippedSlots {
+		if item != nil && item.GetKey() == key {
+			c.EquippedSlots[slot] = nil
+			unequipped = true
+		}
+	}
+
+	if unequipped {
+		c.calculateAC()
+	}
+}
+
 func (c *Character) AddProficiency(p *Proficiency) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.Proficiencies == nil {
 		c.Proficiencies = make(map[ProficiencyType][]*Proficiency)
 	}
-	c.mu.Lock()
+
 	if c.Proficiencies[p.Type] == nil {
 		c.Proficiencies[p.Type] = make([]*Proficiency, 0)
 	}
 
+	// The same proficiency can be granted by both race and class, only keep one
+	for _, existing := range c.Proficiencies[p.Type] {
+		if existing.Key == p.Key {
+			return
+		}
+	}
+
 	c.Proficiencies[p.Type] = append(c.Proficiencies[p.Type], p)
-	c.mu.Unlock()
+}
+
+// HasProficiency returns true if the character has a proficiency with the given key
+func (c *Character) HasProficiency(key string) bool {
+	for _, profs := range c.Proficiencies {
+		for _, p := range profs {
+			if p.Key == key {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 func (c *Character) AddAbilityScoreBonus(attr Attribute, bonus int) {
@@ -308,18 +405,20 @@ func (c *Character) NameString() string {
 		return "Character not fully created"
 	}
 
-	return fmt.Sprintf("%s the %s %s", c.Name, c.Race.Name, c.Class.Name)
+	return fmt.Sprintf("%s the %s %s", c.Name, c.Race.Name, c.ClassString())
 }
 
 func (c *Character) StatsString() string {
 	msg := strings.Builder{}
 	msg.WriteString(fmt.Sprintf("  -  Speed: %d\n", c.Speed))
-	msg.WriteString(fmt.Sprintf("  -  Hit Die: %d\n", c.HitDie))
+	msg.WriteString(fmt.Sprintf("  -  Hit Dice: %s\n", c.HitDiceString()))
 	msg.WriteString(fmt.Sprintf("  -  AC: %d\n", c.AC))
 	msg.WriteString(fmt.Sprintf("  -  Max Hit Points: %d\n", c.MaxHitPoints))
 	msg.WriteString(fmt.Sprintf("  -  Current Hit Points: %d\n", c.CurrentHitPoints))
-	msg.WriteString(fmt.Sprintf("  -  Level: %d\n", c.Level))
+	msg.WriteString(fmt.Sprintf("  -  Level: %d\n", c.TotalLevel()))
+	msg.WriteString(fmt.Sprintf("  -  Proficiency Bonus: %+d\n", c.ProficiencyBonus()))
 	msg.WriteString(fmt.Sprintf("  -  Experience: %d\n", c.Experience))
+	msg.WriteString(fmt.Sprintf("  -  Coins: %s\n", c.Coins.String()))
 
 	return msg.String()
 }
@@ -330,7 +429,7 @@ func (c *Character) String() string {
 		return "Character not fully created"
 	}
 
-	msg.WriteString(fmt.Sprintf("%s the %s %s\n", c.Name, c.Race.Name, c.Class.Name))
+	msg.WriteString(fmt.Sprintf("%s the %s %s\n", c.Name, c.Race.Name, c.ClassString()))
 
 	msg.WriteString("**Rolls**:\n")
 	for _, roll := range c.Rolls {
@@ -339,12 +438,14 @@ func (c *Character) String() string {
 	msg.WriteString("\n")
 	msg.WriteString("\n**Stats**:\n")
 	msg.WriteString(fmt.Sprintf("  -  Speed: %d\n", c.Speed))
-	msg.WriteString(fmt.Sprintf("  -  Hit Die: %d\n", c.HitDie))
+	msg.WriteString(fmt.Sprintf("  -  Hit Dice: %s\n", c.HitDiceString()))
 	msg.WriteString(fmt.Sprintf("  -  AC: %d\n", c.AC))
 	msg.WriteString(fmt.Sprintf("  -  Max Hit Points: %d\n", c.MaxHitPoints))
 	msg.WriteString(fmt.Sprintf("  -  Current Hit Points: %d\n", c.CurrentHitPoints))
-	msg.WriteString(fmt.Sprintf("  -  Level: %d\n", c.Level))
+	msg.WriteString(fmt.Sprintf("  -  Level: %d\n", c.TotalLevel()))
+	msg.WriteString(fmt.Sprintf("  -  Proficiency Bonus: %+d\n", c.ProficiencyBonus()))
 	msg.WriteString(fmt.Sprintf("  -  Experience: %d\n", c.Experience))
+	msg.WriteString(fmt.Sprintf("  -  Coins: %s\n", c.Coins.String()))
 
 	msg.WriteString("\n**Attributes**:\n")
 	for _, attr := range Attributes {
@@ -366,6 +467,13 @@ func (c *Character) String() string {
 		}
 	}
 
+	if len(c.Feats) > 0 {
+		msg.WriteString("\n**Feats**:\n")
+		for _, feat := range c.Feats {
+			msg.WriteString(fmt.Sprintf("  -  %s\n", feat))
+		}
+	}
+
 	msg.WriteString("\n**Inventory**:\n")
 	for key := range c.Inventory {
 		if c.Inventory[key] == nil {
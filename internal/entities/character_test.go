@@ -304,6 +304,56 @@ func (s *suiteEquip) TestTwoHandedOverwritesSlots() {
 	s.Nil(s.char.EquippedSlots[SlotMainHand])
 }
 
+func (s *suiteEquip) TestAttackRangeFollowsTheWeapon() {
+	reach, ranged := s.char.AttackRange()
+	s.Equal(5, reach)
+	s.False(ranged)
+
+	s.char.Inventory[EquipmentTypeWeapon] = append(s.char.Inventory[EquipmentTypeWeapon], &Weapon{
+		Base:        BasicEquipment{Key: "longbow", Name: "Longbow"},
+		WeaponRange: "Ranged",
+		Range:       150,
+		Properties:  []*ReferenceItem{{Key: "two-handed"}},
+	}, &Weapon{
+		Base:        BasicEquipment{Key: "glaive", Name: "Glaive"},
+		WeaponRange: "Melee",
+		Properties:  []*ReferenceItem{{Key: "two-handed"}, {Key: "reach"}},
+	})
+
+	s.char.Equip("longbow")
+	reach, ranged = s.char.AttackRange()
+	s.Equal(150, reach)
+	s.True(ranged)
+
+	s.char.Equip("glaive")
+	reach, ranged = s.char.AttackRange()
+	s.Equal(10, reach)
+	s.False(ranged)
+}
+
 func TestSuiteEquip(t *testing.T) {
 	suite.Run(t, new(suiteEquip))
 }
+
+func TestCharacter_AddProficiencyIgnoresDuplicates(t *testing.T) {
+	c := &Character{}
+	c.AddProficiency(&Proficiency{Key: "skill-stealth", Type: ProficiencyTypeSkill})
+	c.AddProficiency(&Proficiency{Key: "skill-stealth", Type: ProficiencyTypeSkill})
+
+	if len(c.Proficiencies[ProficiencyTypeSkill]) != 1 {
+		t.Errorf("expected 1 proficiency, got %d", len(c.Proficiencies[ProficiencyTypeSkill]))
+	}
+}
+
+func TestCharacter_AttackWithoutAttributes(t *testing.T) {
+	c := &Character{}
+
+	attacks, err := c.Attack()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(attacks) != 1 {
+		t.Errorf("expected 1 attack, got %d", len(attacks))
+	}
+}
@@ -0,0 +1,35 @@
+package entities
+
+import "strings"
+
+const (
+	// passiveBase is the 10 a passive check starts from
+	passiveBase = 10
+	// perceptionSkill is the SRD key of the Perception proficiency
+	perceptionSkill = "skill-perception"
+	// savingThrowPrefix starts the SRD key of a saving throw proficiency, "saving-throw-dex"
+	savingThrowPrefix = "saving-throw-"
+)
+
+// CheckBonus is what the character adds to an ability check, the proficiency bonus counts once
+// when they are proficient with any of the skills or tools given
+func (c *Character) CheckBonus(attr Attribute, proficiencies ...string) int {
+	bonus := c.attributeBonus(attr)
+	for _, key := range proficiencies {
+		if c.HasProficiency(key) {
+			return bonus + c.ProficiencyBonus()
+		}
+	}
+
+	return bonus
+}
+
+// SaveBonus is what the character adds to a saving throw of the ability
+func (c *Character) SaveBonus(attr Attribute) int {
+	return c.CheckBonus(attr, savingThrowPrefix+strings.ToLower(string(attr)))
+}
+
+// PassivePerception is what the character notices without trying
+func (c *Character) PassivePerception() int {
+	return passiveBase + c.CheckBonus(AttributeWisdom, perceptionSkill)
+}
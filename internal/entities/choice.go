@@ -30,6 +30,8 @@ const (
 	ChoiceTypeProficiency ChoiceType = "proficiency"
 	ChoiceTypeLanguage    ChoiceType = "language"
 	ChoiceTypeEquipment   ChoiceType = "equipment"
+
+	ChoiceTypeAbilityScoreImprovement ChoiceType = "ability-score-improvement"
 )
 
 type Choice struct {
@@ -0,0 +1,45 @@
+package entities
+
+import "github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+
+// ClassFeature is an SRD class feature the bot applies once the character reaches its level
+// in the class
+type ClassFeature struct {
+	Key   string
+	Name  string
+	Class string
+	Level int
+	// Defenses are the damage defenses the feature grants
+	Defenses *damage.Defenses
+}
+
+var classFeatures = []*ClassFeature{{
+	Key:      "purity-of-body",
+	Name:     "Purity of Body",
+	Class:    "monk",
+	Level:    10,
+	Defenses: &damage.Defenses{Immunities: []damage.Type{damage.TypePoison}},
+}}
+
+// ClassFeatures returns the features the character has from the levels in their classes
+func (c *Character) ClassFeatures() []*ClassFeature {
+	classes := c.Classes
+	if len(classes) == 0 && c.Class != nil {
+		classes = []*ClassLevel{{Class: c.Class, Level: max(c.Level, 1)}}
+	}
+
+	out := make([]*ClassFeature, 0)
+	for _, classLevel := range classes {
+		if classLevel.Class == nil {
+			continue
+		}
+
+		for _, feature := range classFeatures {
+			if feature.Class == classLevel.Class.Key && feature.Level <= classLevel.Level {
+				out = append(out, feature)
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,140 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Coins is a purse of SRD coins
+type Coins struct {
+	Copper   int `json:"cp"`
+	Silver   int `json:"sp"`
+	Electrum int `json:"ep"`
+	Gold     int `json:"gp"`
+	Platinum int `json:"pp"`
+}
+
+// Add puts the other coins in the purse
+func (c *Coins) Add(other *Coins) {
+	if other == nil {
+		return
+	}
+
+	c.Copper += other.Copper
+	c.Silver += other.Silver
+	c.Electrum += other.Electrum
+	c.Gold += other.Gold
+	c.Platinum += other.Platinum
+}
+
+// CoinsFromCopper counts the copper out in as much gold and silver as it makes, the way a
+// merchant gives change
+func CoinsFromCopper(copper int) *Coins {
+	if copper <= 0 {
+		return &Coins{}
+	}
+
+	return &Coins{
+		Gold:   copper / coinValues["gp"],
+		Silver: copper % coinValues["gp"] / coinValues["sp"],
+		Copper: copper % coinValues["sp"],
+	}
+}
+
+// Value is what the purse is worth in copper
+func (c *Coins) Value() int {
+	return c.Copper*coinValues["cp"] + c.Silver*coinValues["sp"] + c.Electrum*coinValues["ep"] +
+		c.Gold*coinValues["gp"] + c.Platinum*coinValues["pp"]
+}
+
+// Spend pays the price in copper out of the purse. The smallest coins go first and the change
+// comes back in gold, silver and copper, a purse that is short is left alone and false returned.
+func (c *Coins) Spend(copper int) bool {
+	if copper > c.Value() {
+		return false
+	}
+
+	owed := copper
+	for _, coin := range []struct {
+		amount *int
+		value  int
+	}{
+		{&c.Copper, coinValues["cp"]},
+		{&c.Silver, coinValues["sp"]},
+		{&c.Electrum, coinValues["ep"]},
+		{&c.Gold, coinValues["gp"]},
+		{&c.Platinum, coinValues["pp"]},
+	} {
+		if owed <= 0 {
+			break
+		}
+
+		taken := min(*coin.amount, (owed+coin.value-1)/coin.value)
+		*coin.amount -= taken
+		owed -= taken * coin.value
+	}
+
+	c.Add(CoinsFromCopper(-owed))
+
+	return true
+}
+
+func (c *Coins) IsZero() bool {
+	return c.Copper == 0 && c.Silver == 0 && c.Electrum == 0 && c.Gold == 0 && c.Platinum == 0
+}
+
+// Split shares the coins between count purses, the coins left over go one at a time to the
+// first purses
+func (c *Coins) Split(count int) []*Coins {
+	if count < 1 {
+		return []*Coins{}
+	}
+
+	out := make([]*Coins, count)
+	for idx := range out {
+		out[idx] = &Coins{}
+	}
+
+	split := func(amount int, field func(*Coins) *int) {
+		for idx, purse := range out {
+			share := amount / count
+			if idx < amount%count {
+				share++
+			}
+
+			*field(purse) += share
+		}
+	}
+
+	split(c.Copper, func(p *Coins) *int { return &p.Copper })
+	split(c.Silver, func(p *Coins) *int { return &p.Silver })
+	split(c.Electrum, func(p *Coins) *int { return &p.Electrum })
+	split(c.Gold, func(p *Coins) *int { return &p.Gold })
+	split(c.Platinum, func(p *Coins) *int { return &p.Platinum })
+
+	return out
+}
+
+func (c *Coins) String() string {
+	if c.IsZero() {
+		return "no coins"
+	}
+
+	parts := make([]string, 0, 5)
+	for _, coin := range []struct {
+		amount int
+		unit   string
+	}{
+		{c.Platinum, "pp"},
+		{c.Gold, "gp"},
+		{c.Electrum, "ep"},
+		{c.Silver, "sp"},
+		{c.Copper, "cp"},
+	} {
+		if coin.amount > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", coin.amount, coin.unit))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
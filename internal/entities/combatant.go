@@ -0,0 +1,145 @@
+package entities
+
+const (
+	// defaultSpeed is used when a race or monster does not list a walking speed
+	defaultSpeed = 30
+	// meleeReach is how far a melee attack reaches without the reach property
+	meleeReach = 5
+	// defaultRange is used for ranged weapons stored before their range was, a shortbow's range
+	defaultRange = 80
+	// longRangeFactor is how much farther most SRD ranged weapons shoot at long range, the API only
+	// has the normal range
+	longRangeFactor = 4
+)
+
+func (c *Character) GetID() string {
+	return c.ID
+}
+
+func (c *Character) GetName() string {
+	return c.Name
+}
+
+func (c *Character) GetAC() int {
+	return c.AC
+}
+
+// GetSpeed is the race's walking speed in feet
+func (c *Character) GetSpeed() int {
+	if c.Race == nil || c.Race.Speed == 0 {
+		return defaultSpeed
+	}
+
+	return c.Race.Speed
+}
+
+// AttackRange is how far the character's attack reaches, a ranged weapon in hand reaches its
+// normal range and needs line of sight
+func (c *Character) AttackRange() (int, bool) {
+	for _, slot := range []Slot{SlotMainHand, SlotTwoHanded} {
+		if weapon, ok := c.EquippedSlots[slot].(*Weapon); ok {
+			return weapon.Reach()
+		}
+	}
+
+	return meleeReach, false
+}
+
+// LongRange is the farthest the character's attack reaches, a ranged weapon can shoot past its
+// normal range with disadvantage
+func (c *Character) LongRange() int {
+	for _, slot := range []Slot{SlotMainHand, SlotTwoHanded} {
+		if weapon, ok := c.EquippedSlots[slot].(*Weapon); ok {
+			return weapon.LongReach()
+		}
+	}
+
+	return meleeReach
+}
+
+// HitPoints returns the current and max hit points
+func (c *Character) HitPoints() (int, int) {
+	return c.CurrentHitPoints, c.MaxHitPoints
+}
+
+// IsDown returns true when the character has no hit points left
+func (c *Character) IsDown() bool {
+	return c.CurrentHitPoints <= 0
+}
+
+func (m *Monster) GetID() string {
+	return m.ID
+}
+
+func (m *Monster) GetName() string {
+	return m.name()
+}
+
+func (m *Monster) GetAC() int {
+	if m.Template == nil {
+		return 0
+	}
+
+	return m.Template.ArmorClass
+}
+
+// GetSpeed is the template's walking speed in feet
+func (m *Monster) GetSpeed() int {
+	if m.Template == nil || m.Template.Speed == 0 {
+		return defaultSpeed
+	}
+
+	return m.Template.Speed
+}
+
+// AttackRange is how far the first attack in the monster's plan reaches, read from the action's
+// description
+func (m *Monster) AttackRange() (int, bool) {
+	if m.Template == nil {
+		return meleeReach, false
+	}
+
+	plan := m.Template.AttackPlan()
+	if len(plan) == 0 {
+		return meleeReach, false
+	}
+
+	return plan[0].Reach()
+}
+
+// LongRange is the farthest the first attack in the monster's plan reaches
+func (m *Monster) LongRange() int {
+	if m.Template == nil {
+		return meleeReach
+	}
+
+	plan := m.Template.AttackPlan()
+	if len(plan) == 0 {
+		return meleeReach
+	}
+
+	return plan[0].LongReach()
+}
+
+// InitiativeBonus is the dexterity modifier from the template
+func (m *Monster) InitiativeBonus() int {
+	if m.Template == nil {
+		return 0
+	}
+
+	return modifierForScore(m.Template.Dexterity)
+}
+
+// HitPoints returns the current and max hit points
+func (m *Monster) HitPoints() (int, int) {
+	if m.Template == nil {
+		return m.CurrentHP, 0
+	}
+
+	return m.CurrentHP, m.Template.HitPoints
+}
+
+// IsDown returns true when the monster has no hit points left
+func (m *Monster) IsDown() bool {
+	return m.CurrentHP <= 0
+}
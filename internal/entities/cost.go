@@ -1,6 +1,38 @@
 package entities
 
+// coinValues is what each SRD coin is worth in copper
+var coinValues = map[string]int{
+	"cp": 1,
+	"sp": 10,
+	"ep": 50,
+	"gp": 100,
+	"pp": 1000,
+}
+
 type Cost struct {
 	Quantity int    `json:"quantity"`
 	Unit     string `json:"unit"`
 }
+
+// Copper is the cost in copper pieces, 0 when there is no cost
+func (c *Cost) Copper() int {
+	if c == nil {
+		return 0
+	}
+
+	return c.Quantity * coinValues[c.Unit]
+}
+
+// EquipmentCost is the SRD cost of the item, nil when it has none
+func EquipmentCost(e Equipment) *Cost {
+	switch item := e.(type) {
+	case *Weapon:
+		return item.Base.Cost
+	case *Armor:
+		return item.Base.Cost
+	case *BasicEquipment:
+		return item.Cost
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package damage
+
+import "strings"
+
+// Types lists every damage type that can be dealt
+var Types = []Type{
+	TypeAcid,
+	TypeCold,
+	TypeFire,
+	TypeForce,
+	TypeLightning,
+	TypeNecrotic,
+	TypePoison,
+	TypePsychic,
+	TypeRadiant,
+	TypeThunder,
+	TypeBludgeoning,
+	TypePiercing,
+	TypeSlashing,
+}
+
+type Modifier string
+
+const (
+	ModifierNone       Modifier = ""
+	ModifierResisted   Modifier = "resisted"
+	ModifierVulnerable Modifier = "vulnerable"
+	ModifierImmune     Modifier = "immune"
+)
+
+// Defenses are the damage types a creature resists, is vulnerable to or is immune to
+type Defenses struct {
+	Resistances     []Type `json:"resistances"`
+	Vulnerabilities []Type `json:"vulnerabilities"`
+	Immunities      []Type `json:"immunities"`
+}
+
+// Adjust applies the defenses to an amount of damage. Immunity stops all of it, resistance
+// halves it rounding down and vulnerability doubles it, resistance is applied first.
+func (d *Defenses) Adjust(amount int, dmgType Type) (int, Modifier) {
+	if d == nil || amount <= 0 {
+		return max(amount, 0), ModifierNone
+	}
+
+	if hasType(d.Immunities, dmgType) {
+		return 0, ModifierImmune
+	}
+
+	resisted := hasType(d.Resistances, dmgType)
+	vulnerable := hasType(d.Vulnerabilities, dmgType)
+
+	switch {
+	case resisted && vulnerable:
+		return amount / 2 * 2, ModifierNone
+	case resisted:
+		return amount / 2, ModifierResisted
+	case vulnerable:
+		return amount * 2, ModifierVulnerable
+	}
+
+	return amount, ModifierNone
+}
+
+// Merge returns the combined defenses, nil defenses are skipped
+func Merge(defenses ...*Defenses) *Defenses {
+	out := &Defenses{}
+	for _, d := range defenses {
+		if d == nil {
+			continue
+		}
+
+		out.Resistances = appendUnique(out.Resistances, d.Resistances...)
+		out.Vulnerabilities = appendUnique(out.Vulnerabilities, d.Vulnerabilities...)
+		out.Immunities = appendUnique(out.Immunities, d.Immunities...)
+	}
+
+	return out
+}
+
+// qualifiers mark SRD descriptions that only apply to some attacks, such as "bludgeoning,
+// piercing, and slashing from nonmagical attacks that aren't silvered"
+var qualifiers = []string{"nonmagical", "that aren't"}
+
+// ParseTypes finds the damage types named in SRD descriptions such as "cold" or "fire, poison".
+// Qualified descriptions are skipped, the attacks do not track what they are made with so they
+// would turn into full resistance or immunity.
+func ParseTypes(descriptions []string) []Type {
+	var out []Type
+	for _, description := range descriptions {
+		lower := strings.ToLower(description)
+		if isQualified(lower) {
+			continue
+		}
+
+		for _, t := range Types {
+			if strings.Contains(lower, string(t)) {
+				out = appendUnique(out, t)
+			}
+		}
+	}
+
+	return out
+}
+
+func isQualified(description string) bool {
+	for _, qualifier := range qualifiers {
+		if strings.Contains(description, qualifier) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasType(types []Type, dmgType Type) bool {
+	for _, t := range types {
+		if t == dmgType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appendUnique(types []Type, add ...Type) []Type {
+	for _, t := range add {
+		if !hasType(types, t) {
+			types = append(types, t)
+		}
+	}
+
+	return types
+}
@@ -0,0 +1,94 @@
+package damage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteDefenses struct {
+	suite.Suite
+
+	defenses *Defenses
+}
+
+func (s *suiteDefenses) SetupTest() {
+	s.defenses = &Defenses{
+		Resistances:     []Type{TypeFire, TypeCold},
+		Vulnerabilities: []Type{TypeRadiant, TypeCold},
+		Immunities:      []Type{TypePoison},
+	}
+}
+
+func (s *suiteDefenses) TestAdjust() {
+	cases := []struct {
+		dmgType  Type
+		amount   int
+		expected int
+		modifier Modifier
+	}{
+		{TypeFire, 9, 4, ModifierResisted},
+		{TypeRadiant, 5, 10, ModifierVulnerable},
+		{TypePoison, 12, 0, ModifierImmune},
+		{TypeCold, 7, 6, ModifierNone},
+		{TypeSlashing, 7, 7, ModifierNone},
+	}
+
+	for _, c := range cases {
+		dealt, modifier := s.defenses.Adjust(c.amount, c.dmgType)
+		s.Equal(c.expected, dealt, c.dmgType)
+		s.Equal(c.modifier, modifier, c.dmgType)
+	}
+}
+
+func (s *suiteDefenses) TestAdjustNilDefenses() {
+	var defenses *Defenses
+
+	dealt, modifier := defenses.Adjust(6, TypeFire)
+	s.Equal(6, dealt)
+	s.Equal(ModifierNone, modifier)
+}
+
+func (s *suiteDefenses) TestParseTypes() {
+	types := ParseTypes([]string{"cold", "fire, poison"})
+
+	s.Equal([]Type{TypeCold, TypeFire, TypePoison}, types)
+}
+
+func (s *suiteDefenses) TestParseTypesSkipsQualified() {
+	types := ParseTypes([]string{
+		"lightning",
+		"bludgeoning, piercing, and slashing from nonmagical attacks",
+		"bludgeoning, piercing, and slashing from nonmagical attacks that aren't silvered",
+	})
+
+	s.Equal([]Type{TypeLightning}, types)
+}
+
+func (s *suiteDefenses) TestMerge() {
+	merged := Merge(s.defenses, nil, &Defenses{Resistances: []Type{TypeFire, TypeAcid}})
+
+	s.Equal([]Type{TypeFire, TypeCold, TypeAcid}, merged.Resistances)
+	s.Equal([]Type{TypePoison}, merged.Immunities)
+}
+
+func (s *suiteDefenses) TestReportString() {
+	report := &Report{
+		Target:           "Goblin",
+		Type:             TypeFire,
+		Rolled:           9,
+		Modifier:         ModifierResisted,
+		Dealt:            4,
+		AbsorbedByTempHP: 1,
+		HPLost:           3,
+		CurrentHP:        0,
+		MaxHP:            3,
+		Dropped:          true,
+	}
+
+	s.Equal("Goblin takes 4 fire damage (resisted, 9 rolled), 1 absorbed by temporary hit points [0/3 HP] and Goblin drops!", report.String())
+}
+
+func TestSuiteDefenses(t *testing.T) {
+	suite.Run(t, new(suiteDefenses))
+}
@@ -0,0 +1,58 @@
+package damage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Report describes what happened when damage was taken
+type Report struct {
+	Target string `json:"target"`
+	Type   Type   `json:"type"`
+	// Rolled is the damage before defenses
+	Rolled   int      `json:"rolled"`
+	Modifier Modifier `json:"modifier"`
+	// Dealt is the damage after defenses
+	Dealt int `json:"dealt"`
+	// AbsorbedByTempHP is the part of the damage taken from temporary hit points
+	AbsorbedByTempHP int  `json:"absorbed_by_temp_hp"`
+	HPLost           int  `json:"hp_lost"`
+	CurrentHP        int  `json:"current_hp"`
+	MaxHP            int  `json:"max_hp"`
+	TempHP           int  `json:"temp_hp"`
+	Dropped          bool `json:"dropped"`
+}
+
+func (r *Report) String() string {
+	msg := strings.Builder{}
+	msg.WriteString(fmt.Sprintf("%s takes %d", r.Target, r.Dealt))
+	if r.Type != "" && r.Type != TypeNone {
+		msg.WriteString(fmt.Sprintf(" %s", r.Type))
+	}
+	msg.WriteString(" damage")
+
+	switch r.Modifier {
+	case ModifierImmune:
+		msg.WriteString(fmt.Sprintf(" (immune, %d prevented)", r.Rolled))
+	case ModifierResisted:
+		msg.WriteString(fmt.Sprintf(" (resisted, %d rolled)", r.Rolled))
+	case ModifierVulnerable:
+		msg.WriteString(fmt.Sprintf(" (vulnerable, %d rolled)", r.Rolled))
+	}
+
+	if r.AbsorbedByTempHP > 0 {
+		msg.WriteString(fmt.Sprintf(", %d absorbed by temporary hit points", r.AbsorbedByTempHP))
+	}
+
+	msg.WriteString(fmt.Sprintf(" [%d/%d HP", r.CurrentHP, r.MaxHP))
+	if r.TempHP > 0 {
+		msg.WriteString(fmt.Sprintf(" +%d temp", r.TempHP))
+	}
+	msg.WriteString("]")
+
+	if r.Dropped {
+		msg.WriteString(fmt.Sprintf(" and %s drops!", r.Target))
+	}
+
+	return msg.String()
+}
@@ -0,0 +1,175 @@
+package dungeon
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+type Status string
+
+const (
+	StatusActive Status = "active"
+	// StatusComplete is set once every room with monsters is cleared
+	StatusComplete Status = "complete"
+	// StatusFailed is set when the party is defeated
+	StatusFailed Status = "failed"
+)
+
+type Direction string
+
+const (
+	DirectionNorth Direction = "north"
+	DirectionSouth Direction = "south"
+	DirectionEast  Direction = "east"
+	DirectionWest  Direction = "west"
+)
+
+var Directions = []Direction{DirectionNorth, DirectionEast, DirectionSouth, DirectionWest}
+
+// Opposite is the direction that leads back
+func (d Direction) Opposite() Direction {
+	switch d {
+	case DirectionNorth:
+		return DirectionSouth
+	case DirectionSouth:
+		return DirectionNorth
+	case DirectionEast:
+		return DirectionWest
+	case DirectionWest:
+		return DirectionEast
+	default:
+		return ""
+	}
+}
+
+type Contents string
+
+const (
+	ContentsEntrance Contents = "entrance"
+	ContentsEmpty    Contents = "empty"
+	ContentsMonsters Contents = "monsters"
+	ContentsTrap     Contents = "trap"
+	ContentsTreasure Contents = "treasure"
+)
+
+// Position is a room's place on the map, north is y - 1
+type Position struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Step is the position one room over in the direction
+func (p Position) Step(direction Direction) Position {
+	switch direction {
+	case DirectionNorth:
+		return Position{X: p.X, Y: p.Y - 1}
+	case DirectionSouth:
+		return Position{X: p.X, Y: p.Y + 1}
+	case DirectionEast:
+		return Position{X: p.X + 1, Y: p.Y}
+	case DirectionWest:
+		return Position{X: p.X - 1, Y: p.Y}
+	default:
+		return p
+	}
+}
+
+// Room is a room on the dungeon map, the fight in it is an entities.Room
+type Room struct {
+	Position Position    `json:"position"`
+	Contents Contents    `json:"contents"`
+	Exits    []Direction `json:"exits"`
+	Visited  bool        `json:"visited"`
+	// Cleared is set once the contents are dealt with, the monsters defeated or the treasure taken
+	Cleared bool `json:"cleared"`
+	// TrapID is the trap or skill challenge in a trap room, set the first time the party enters
+	TrapID string `json:"trap_id,omitempty"`
+}
+
+// HasExit returns true when a door leads out of the room in the direction
+func (r *Room) HasExit(direction Direction) bool {
+	return slices.Contains(r.Exits, direction)
+}
+
+type Dungeon struct {
+	ID        string   `json:"id"`
+	Seed      int64    `json:"seed"`
+	Status    Status   `json:"status"`
+	PlayerIDs []string `json:"player_ids"`
+	Rooms     []*Room  `json:"rooms"`
+	Position  Position `json:"position"`
+	// Previous is where the party came from, fleeing a fight goes back to it
+	Previous Position `json:"previous"`
+	// Version is the stored version the dungeon was loaded from, used to reject stale writes
+	Version int `json:"version"`
+}
+
+// Room returns the room at the position, nil when there is no room there
+func (d *Dungeon) Room(position Position) *Room {
+	for _, room := range d.Rooms {
+		if room.Position == position {
+			return room
+		}
+	}
+
+	return nil
+}
+
+// RoomWithTrap returns the room the trap was set up in, nil when no room has it
+func (d *Dungeon) RoomWithTrap(trapID string) *Room {
+	for _, room := range d.Rooms {
+		if room.TrapID == trapID {
+			return room
+		}
+	}
+
+	return nil
+}
+
+// Current is the room the party is in
+func (d *Dungeon) Current() *Room {
+	return d.Room(d.Position)
+}
+
+// Move takes the party through the door in the direction and marks the room visited
+func (d *Dungeon) Move(direction Direction) (*Room, error) {
+	current := d.Current()
+	if current == nil {
+		return nil, dnderr.NewInvalidEntityError("party is not in a room")
+	}
+
+	if !current.HasExit(direction) {
+		return nil, dnderr.NewInvalidParameterError("direction", fmt.Sprintf("there is no door to the %s", direction))
+	}
+
+	next := d.Room(d.Position.Step(direction))
+	if next == nil {
+		return nil, dnderr.NewInvalidEntityError(fmt.Sprintf("door to the %s leads nowhere", direction))
+	}
+
+	d.Previous = d.Position
+	d.Position = next.Position
+	next.Visited = true
+
+	return next, nil
+}
+
+// Retreat sends the party back to the room they came from
+func (d *Dungeon) Retreat() *Room {
+	d.Position, d.Previous = d.Previous, d.Position
+
+	return d.Current()
+}
+
+// IsComplete returns true when there are no rooms with monsters left
+func (d *Dungeon) IsComplete() bool {
+	for _, room := range d.Rooms {
+		if room.Contents == ContentsMonsters && !room.Cleared {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,124 @@
+package dungeon
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteDungeon struct {
+	suite.Suite
+
+	fixture *Dungeon
+}
+
+func (s *suiteDungeon) SetupTest() {
+	// [E]-[M]
+	//      |
+	//     [$]
+	s.fixture = &Dungeon{
+		Status: StatusActive,
+		Rooms: []*Room{
+			{Position: Position{X: 0, Y: 0}, Contents: ContentsEntrance, Exits: []Direction{DirectionEast}, Visited: true, Cleared: true},
+			{Position: Position{X: 1, Y: 0}, Contents: ContentsMonsters, Exits: []Direction{DirectionWest, DirectionSouth}},
+			{Position: Position{X: 1, Y: 1}, Contents: ContentsTreasure, Exits: []Direction{DirectionNorth}},
+		},
+	}
+}
+
+func (s *suiteDungeon) TestGenerateIsSeeded() {
+	first, err := Generate(42, DefaultSize)
+	s.NoError(err)
+
+	second, err := Generate(42, DefaultSize)
+	s.NoError(err)
+
+	s.Equal(first, second)
+
+	other, err := Generate(7, DefaultSize)
+	s.NoError(err)
+	s.NotEqual(first.Rooms, other.Rooms)
+}
+
+func (s *suiteDungeon) TestGenerateConnectsEveryRoom() {
+	for seed := int64(0); seed < 20; seed++ {
+		result, err := Generate(seed, DefaultSize)
+		s.NoError(err)
+		s.Len(result.Rooms, DefaultSize)
+		s.Equal(ContentsEntrance, result.Current().Contents)
+		s.False(result.IsComplete(), "seed %d has no monsters", seed)
+
+		// every door has a matching door on the other side
+		for _, room := range result.Rooms {
+			for _, direction := range room.Exits {
+				neighbour := result.Room(room.Position.Step(direction))
+				s.Require().NotNil(neighbour, "seed %d", seed)
+				s.True(neighbour.HasExit(direction.Opposite()), "seed %d", seed)
+			}
+		}
+
+		// every room can be reached from the entrance
+		reached := map[Position]bool{{}: true}
+		queue := []Position{{}}
+		for len(queue) > 0 {
+			room := result.Room(queue[0])
+			queue = queue[1:]
+			for _, direction := range room.Exits {
+				next := room.Position.Step(direction)
+				if !reached[next] {
+					reached[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+
+		s.Len(reached, DefaultSize, "seed %d", seed)
+	}
+}
+
+func (s *suiteDungeon) TestGenerateSize() {
+	_, err := Generate(1, 1)
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	_, err = Generate(1, maxSize+1)
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+}
+
+func (s *suiteDungeon) TestMove() {
+	room, err := s.fixture.Move(DirectionEast)
+	s.NoError(err)
+	s.Equal(ContentsMonsters, room.Contents)
+	s.True(room.Visited)
+	s.Equal(Position{X: 1, Y: 0}, s.fixture.Position)
+	s.Equal(Position{X: 0, Y: 0}, s.fixture.Previous)
+
+	_, err = s.fixture.Move(DirectionEast)
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	room = s.fixture.Retreat()
+	s.Equal(ContentsEntrance, room.Contents)
+}
+
+func (s *suiteDungeon) TestIsComplete() {
+	s.False(s.fixture.IsComplete())
+
+	s.fixture.Rooms[1].Cleared = true
+	s.True(s.fixture.IsComplete())
+}
+
+func (s *suiteDungeon) TestRender() {
+	s.Equal("[@]-[?]", Render(s.fixture))
+
+	_, err := s.fixture.Move(DirectionEast)
+	s.NoError(err)
+	s.Equal("[E]-[@]\n     |\n    [?]", Render(s.fixture))
+
+	_, err = s.fixture.Move(DirectionSouth)
+	s.NoError(err)
+	s.Equal("[E]-[M]\n     |\n    [@]", Render(s.fixture))
+}
+
+func TestDungeon(t *testing.T) {
+	suite.Run(t, new(suiteDungeon))
+}
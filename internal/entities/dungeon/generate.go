@@ -0,0 +1,132 @@
+package dungeon
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+const (
+	DefaultSize = 10
+	maxSize     = 25
+	// loopChance is the percent chance a new room also opens a door to each of its other neighbours
+	loopChance = 20
+)
+
+type contentsWeight struct {
+	contents Contents
+	weight   int
+}
+
+var contentsWeights = []contentsWeight{
+	{ContentsMonsters, 40},
+	{ContentsEmpty, 25},
+	{ContentsTreasure, 20},
+	{ContentsTrap, 15},
+}
+
+// Generate grows a dungeon of size rooms out from the entrance, the same seed always builds the
+// same dungeon so it can be shared or played again
+func Generate(seed int64, size int) (*Dungeon, error) {
+	if size < 2 || size > maxSize {
+		return nil, dnderr.NewInvalidParameterError("size", fmt.Sprintf("must be between 2 and %d", maxSize))
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+
+	entrance := &Room{
+		Contents: ContentsEntrance,
+		Visited:  true,
+		Cleared:  true,
+	}
+
+	out := &Dungeon{
+		Seed:   seed,
+		Status: StatusActive,
+		Rooms:  []*Room{entrance},
+	}
+
+	for len(out.Rooms) < size {
+		from := out.Rooms[rng.Intn(len(out.Rooms))]
+		direction := Directions[rng.Intn(len(Directions))]
+
+		position := from.Position.Step(direction)
+		if out.Room(position) != nil {
+			continue
+		}
+
+		room := &Room{
+			Position: position,
+			Contents: pickContents(rng),
+		}
+		connect(from, room, direction)
+
+		// extra doors give the map loops instead of only dead ends
+		for _, other := range Directions {
+			neighbour := out.Room(position.Step(other))
+			if neighbour == nil || neighbour == from {
+				continue
+			}
+
+			if rng.Intn(100) < loopChance {
+				connect(room, neighbour, other)
+			}
+		}
+
+		out.Rooms = append(out.Rooms, room)
+	}
+
+	// the room farthest from the entrance always has monsters to fight
+	farthest := out.Rooms[1]
+	for _, room := range out.Rooms[2:] {
+		if distance(room.Position) > distance(farthest.Position) {
+			farthest = room
+		}
+	}
+
+	farthest.Contents = ContentsMonsters
+
+	return out, nil
+}
+
+func pickContents(rng *rand.Rand) Contents {
+	total := 0
+	for _, w := range contentsWeights {
+		total += w.weight
+	}
+
+	roll := rng.Intn(total)
+	for _, w := range contentsWeights {
+		if roll < w.weight {
+			return w.contents
+		}
+
+		roll -= w.weight
+	}
+
+	return ContentsEmpty
+}
+
+// connect puts a door between two rooms next to each other
+func connect(from, to *Room, direction Direction) {
+	if !from.HasExit(direction) {
+		from.Exits = append(from.Exits, direction)
+	}
+
+	if !to.HasExit(direction.Opposite()) {
+		to.Exits = append(to.Exits, direction.Opposite())
+	}
+}
+
+func distance(position Position) int {
+	return abs(position.X) + abs(position.Y)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,109 @@
+package dungeon
+
+import (
+	"strings"
+)
+
+// Render draws the part of the map the party knows about. Visited rooms show their contents,
+// rooms behind their doors show as ? and the party is @.
+//
+//	[E]-[@]
+//	     |
+//	    [?]
+func Render(d *Dungeon) string {
+	known := d.knownRooms()
+	if len(known) == 0 {
+		return ""
+	}
+
+	minX, maxX := known[0].Position.X, known[0].Position.X
+	minY, maxY := known[0].Position.Y, known[0].Position.Y
+	for _, room := range known {
+		minX = min(minX, room.Position.X)
+		maxX = max(maxX, room.Position.X)
+		minY = min(minY, room.Position.Y)
+		maxY = max(maxY, room.Position.Y)
+	}
+
+	isKnown := make(map[Position]bool)
+	for _, room := range known {
+		isKnown[room.Position] = true
+	}
+
+	lines := make([]string, 0, (maxY-minY)*2+1)
+	for y := minY; y <= maxY; y++ {
+		rooms := strings.Builder{}
+		doors := strings.Builder{}
+		for x := minX; x <= maxX; x++ {
+			position := Position{X: x, Y: y}
+			room := d.Room(position)
+			if room == nil || !isKnown[position] {
+				rooms.WriteString("    ")
+				doors.WriteString("    ")
+				continue
+			}
+
+			rooms.WriteString("[" + d.symbol(room) + "]")
+			if room.HasExit(DirectionEast) && isKnown[position.Step(DirectionEast)] {
+				rooms.WriteString("-")
+			} else {
+				rooms.WriteString(" ")
+			}
+
+			if room.HasExit(DirectionSouth) && isKnown[position.Step(DirectionSouth)] {
+				doors.WriteString(" |  ")
+			} else {
+				doors.WriteString("    ")
+			}
+		}
+
+		lines = append(lines, strings.TrimRight(rooms.String(), " "))
+		if y < maxY {
+			lines = append(lines, strings.TrimRight(doors.String(), " "))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// knownRooms are the visited rooms and the rooms through their doors
+func (d *Dungeon) knownRooms() []*Room {
+	known := make([]*Room, 0, len(d.Rooms))
+	for _, room := range d.Rooms {
+		if room.Visited {
+			known = append(known, room)
+			continue
+		}
+
+		for _, direction := range room.Exits {
+			neighbour := d.Room(room.Position.Step(direction))
+			if neighbour != nil && neighbour.Visited {
+				known = append(known, room)
+				break
+			}
+		}
+	}
+
+	return known
+}
+
+func (d *Dungeon) symbol(room *Room) string {
+	switch {
+	case room.Position == d.Position:
+		return "@"
+	case !room.Visited:
+		return "?"
+	case room.Contents == ContentsEntrance:
+		return "E"
+	case room.Cleared:
+		return " "
+	case room.Contents == ContentsMonsters:
+		return "M"
+	case room.Contents == ContentsTrap:
+		return "T"
+	case room.Contents == ContentsTreasure:
+		return "$"
+	default:
+		return " "
+	}
+}
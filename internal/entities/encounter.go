@@ -1,13 +1,287 @@
 package entities
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+type EncounterStatus string
+
+const (
+	// EncounterStatusOpen takes players joining and leaving
+	EncounterStatusOpen EncounterStatus = "open"
+	// EncounterStatusReadyCheck waits for every player to say they are ready
+	EncounterStatusReadyCheck EncounterStatus = "ready-check"
+	// EncounterStatusInProgress has handed the party off to a fight
+	EncounterStatusInProgress EncounterStatus = "in-progress"
+	EncounterStatusResolved   EncounterStatus = "resolved"
+	EncounterStatusArchived   EncounterStatus = "archived"
+)
+
+const (
+	// DefaultMaxPlayers caps an encounter when the GM does not pick a size
+	DefaultMaxPlayers = 4
+	// DefaultTurnTimeout is the seconds a player has to take their turn
+	DefaultTurnTimeout = 120
+	// DefaultPingAfter is the seconds before a player is reminded it is their turn
+	DefaultPingAfter = 60
+)
 
 type Encounter struct {
 	ID        string
 	MessageID string
-	Players   []string
+	// ChannelID is where the encounter message was posted
+	ChannelID string
+	// GMID created the encounter, only they can kick, start and close it
+	GMID   string
+	Status EncounterStatus
+	// MaxPlayers caps the party, 0 leaves it open to anyone
+	MaxPlayers int
+	Players    []string
+	// Ready are the players who answered the ready check
+	Ready []string
+	// RoomID is the fight the party was handed off to
+	RoomID string
+	// Outcome is how the fight in the room ended, set once the encounter is resolved
+	Outcome string
+	// TurnTimeout is the seconds a player has before their turn is skipped, 0 turns the timer off
+	TurnTimeout int
+	// PingAfter is the seconds before the player whose turn it is gets a reminder
+	PingAfter int
+	// Stealth has the party try to sneak up on the monsters when the fight starts
+	Stealth bool
+	// Surprised are the monsters the party caught off guard, they lose their first turn
+	Surprised []string
+	// Version is the stored version the encounter was loaded from, used to reject stale writes
+	Version int
 }
 
 func (e *Encounter) MarshallJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
+
+// GetStatus is the encounter's status, encounters stored before they had one are open
+func (e *Encounter) GetStatus() EncounterStatus {
+	if e.Status == "" {
+		return EncounterStatusOpen
+	}
+
+	return e.Status
+}
+
+// HasPlayer returns true when the player has joined
+func (e *Encounter) HasPlayer(playerID string) bool {
+	return slices.Contains(e.Players, playerID)
+}
+
+// IsGM returns true when the player runs the encounter, encounters stored without a GM let anyone
+// run them
+func (e *Encounter) IsGM(playerID string) bool {
+	return e.GMID == "" || e.GMID == playerID
+}
+
+// IsReady returns true when there are players and every one of them is ready
+func (e *Encounter) IsReady() bool {
+	if len(e.Players) == 0 {
+		return false
+	}
+
+	for _, playerID := range e.Players {
+		if !slices.Contains(e.Ready, playerID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Join adds the player while the encounter has not started and has room
+func (e *Encounter) Join(playerID string) error {
+	if err := e.checkGathering(); err != nil {
+		return err
+	}
+
+	if e.HasPlayer(playerID) {
+		return dnderr.NewAlreadyExistsError("you have already joined")
+	}
+
+	if e.MaxPlayers > 0 && len(e.Players) >= e.MaxPlayers {
+		return dnderr.NewResourceExhaustedError(fmt.Sprintf("the encounter is full with %d players", e.MaxPlayers))
+	}
+
+	e.Players = append(e.Players, playerID)
+
+	return nil
+}
+
+// Leave takes the player out before the encounter starts
+func (e *Encounter) Leave(playerID string) error {
+	if err := e.checkGathering(); err != nil {
+		return err
+	}
+
+	if !e.HasPlayer(playerID) {
+		return dnderr.NewNotFoundError("you have not joined")
+	}
+
+	e.remove(playerID)
+
+	return nil
+}
+
+// Kick lets the GM take a player out before the encounter starts
+func (e *Encounter) Kick(gmID, playerID string) error {
+	if err := e.checkGM(gmID, "kick players"); err != nil {
+		return err
+	}
+
+	if err := e.checkGathering(); err != nil {
+		return err
+	}
+
+	if !e.HasPlayer(playerID) {
+		return dnderr.NewNotFoundError("that player has not joined")
+	}
+
+	e.remove(playerID)
+
+	return nil
+}
+
+// StartReadyCheck asks everyone who joined to say they are ready
+func (e *Encounter) StartReadyCheck(gmID string) error {
+	if err := e.checkGM(gmID, "call a ready check"); err != nil {
+		return err
+	}
+
+	if e.GetStatus() != EncounterStatusOpen {
+		return dnderr.NewConflictError(fmt.Sprintf("the encounter is %s", e.GetStatus()))
+	}
+
+	if len(e.Players) == 0 {
+		return dnderr.NewInvalidEntityError("no one has joined yet")
+	}
+
+	e.Status = EncounterStatusReadyCheck
+	e.Ready = []string{}
+
+	return nil
+}
+
+// MarkReady answers the ready check for the player
+func (e *Encounter) MarkReady(playerID string) error {
+	if e.GetStatus() != EncounterStatusReadyCheck {
+		return dnderr.NewConflictError("there is no ready check")
+	}
+
+	if !e.HasPlayer(playerID) {
+		return dnderr.NewNotFoundError("you have not joined")
+	}
+
+	if !slices.Contains(e.Ready, playerID) {
+		e.Ready = append(e.Ready, playerID)
+	}
+
+	return nil
+}
+
+// CanStart returns an error unless the GM can start the fight, everyone has to be ready
+func (e *Encounter) CanStart(gmID string) error {
+	if err := e.checkGM(gmID, "start the encounter"); err != nil {
+		return err
+	}
+
+	if e.GetStatus() != EncounterStatusReadyCheck {
+		return dnderr.NewConflictError("call a ready check before starting")
+	}
+
+	if !e.IsReady() {
+		return dnderr.NewConflictError("not everyone is ready")
+	}
+
+	return nil
+}
+
+// Start hands the party off to the fight in the room
+func (e *Encounter) Start(gmID, roomID string) error {
+	if err := e.CanStart(gmID); err != nil {
+		return err
+	}
+
+	e.Status = EncounterStatusInProgress
+	e.RoomID = roomID
+
+	return nil
+}
+
+// Resolve marks the fight as over with how it ended
+func (e *Encounter) Resolve(outcome string) error {
+	if e.GetStatus() != EncounterStatusInProgress {
+		return dnderr.NewConflictError("the encounter has not started")
+	}
+
+	e.Status = EncounterStatusResolved
+	e.Outcome = outcome
+
+	return nil
+}
+
+// Archive lets the GM close the encounter, an encounter that never started can be closed too
+func (e *Encounter) Archive(gmID string) error {
+	if err := e.checkGM(gmID, "close the encounter"); err != nil {
+		return err
+	}
+
+	if e.GetStatus() == EncounterStatusInProgress {
+		return dnderr.NewConflictError("the fight is still going")
+	}
+
+	if e.GetStatus() == EncounterStatusArchived {
+		return dnderr.NewConflictError("the encounter is already closed")
+	}
+
+	e.Status = EncounterStatusArchived
+
+	return nil
+}
+
+// End lets the GM close the encounter wherever it is at, a fight still going is resolved first
+func (e *Encounter) End(gmID string) error {
+	if err := e.checkGM(gmID, "end the encounter"); err != nil {
+		return err
+	}
+
+	if e.GetStatus() == EncounterStatusInProgress {
+		e.Status = EncounterStatusResolved
+	}
+
+	return e.Archive(gmID)
+}
+
+// checkGathering returns an error once the encounter has started
+func (e *Encounter) checkGathering() error {
+	switch e.GetStatus() {
+	case EncounterStatusOpen, EncounterStatusReadyCheck:
+		return nil
+	default:
+		return dnderr.NewConflictError(fmt.Sprintf("the encounter is %s", e.GetStatus()))
+	}
+}
+
+// checkGM returns an error unless the player runs the encounter
+func (e *Encounter) checkGM(playerID, action string) error {
+	if !e.IsGM(playerID) {
+		return dnderr.NewPermissionDeniedError(fmt.Sprintf("only the GM can %s", action))
+	}
+
+	return nil
+}
+
+// remove takes the player out of the party and the ready check
+func (e *Encounter) remove(playerID string) {
+	e.Players = slices.DeleteFunc(e.Players, func(id string) bool { return id == playerID })
+	e.Ready = slices.DeleteFunc(e.Ready, func(id string) bool { return id == playerID })
+}
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteEncounter struct {
+	suite.Suite
+
+	fixture *Encounter
+}
+
+func (s *suiteEncounter) SetupTest() {
+	s.fixture = &Encounter{
+		ID:         "encounter-1",
+		GMID:       "gm",
+		Status:     EncounterStatusOpen,
+		MaxPlayers: 2,
+		Players:    []string{},
+	}
+}
+
+func (s *suiteEncounter) TestJoin() {
+	s.NoError(s.fixture.Join("player-1"))
+	s.IsType(&dnderr.AlreadyExistsError{}, s.fixture.Join("player-1"))
+	s.NoError(s.fixture.Join("player-2"))
+	s.IsType(&dnderr.ResourceExhaustedError{}, s.fixture.Join("player-3"))
+	s.Equal([]string{"player-1", "player-2"}, s.fixture.Players)
+}
+
+func (s *suiteEncounter) TestLeaveAndKick() {
+	s.fixture.Players = []string{"player-1", "player-2"}
+
+	s.NoError(s.fixture.Leave("player-1"))
+	s.IsType(&dnderr.NotFoundError{}, s.fixture.Leave("player-1"))
+	s.IsType(&dnderr.PermissionDeniedError{}, s.fixture.Kick("player-2", "player-2"))
+	s.NoError(s.fixture.Kick("gm", "player-2"))
+	s.Empty(s.fixture.Players)
+}
+
+func (s *suiteEncounter) TestReadyCheckToStart() {
+	s.IsType(&dnderr.InvalidEntityError{}, s.fixture.StartReadyCheck("gm"))
+
+	s.fixture.Players = []string{"player-1", "player-2"}
+	s.IsType(&dnderr.PermissionDeniedError{}, s.fixture.StartReadyCheck("player-1"))
+	s.NoError(s.fixture.StartReadyCheck("gm"))
+	s.Equal(EncounterStatusReadyCheck, s.fixture.Status)
+
+	s.NoError(s.fixture.MarkReady("player-1"))
+	s.IsType(&dnderr.ConflictError{}, s.fixture.Start("gm", "room-1"))
+
+	s.NoError(s.fixture.MarkReady("player-2"))
+	s.True(s.fixture.IsReady())
+	s.IsType(&dnderr.PermissionDeniedError{}, s.fixture.Start("player-1", "room-1"))
+	s.NoError(s.fixture.Start("gm", "room-1"))
+	s.Equal(EncounterStatusInProgress, s.fixture.Status)
+	s.Equal("room-1", s.fixture.RoomID)
+
+	s.IsType(&dnderr.ConflictError{}, s.fixture.Join("player-3"))
+	s.IsType(&dnderr.ConflictError{}, s.fixture.Leave("player-1"))
+}
+
+func (s *suiteEncounter) TestLeavingDropsReady() {
+	s.fixture.Players = []string{"player-1", "player-2"}
+	s.NoError(s.fixture.StartReadyCheck("gm"))
+	s.NoError(s.fixture.MarkReady("player-1"))
+
+	s.NoError(s.fixture.Leave("player-1"))
+	s.Empty(s.fixture.Ready)
+	s.False(s.fixture.IsReady())
+}
+
+func (s *suiteEncounter) TestResolveAndArchive() {
+	s.IsType(&dnderr.ConflictError{}, s.fixture.Resolve("victory"))
+
+	s.fixture.Status = EncounterStatusInProgress
+	s.IsType(&dnderr.ConflictError{}, s.fixture.Archive("gm"))
+	s.NoError(s.fixture.Resolve("fled"))
+	s.Equal("fled", s.fixture.Outcome)
+	s.IsType(&dnderr.PermissionDeniedError{}, s.fixture.Archive("player-1"))
+	s.NoError(s.fixture.Archive("gm"))
+	s.Equal(EncounterStatusArchived, s.fixture.Status)
+	s.IsType(&dnderr.ConflictError{}, s.fixture.Archive("gm"))
+}
+
+func (s *suiteEncounter) TestEndResolvesTheFight() {
+	s.fixture.Status = EncounterStatusInProgress
+
+	s.IsType(&dnderr.PermissionDeniedError{}, s.fixture.End("player-1"))
+	s.NoError(s.fixture.End("gm"))
+	s.Equal(EncounterStatusArchived, s.fixture.Status)
+}
+
+func (s *suiteEncounter) TestLegacyEncounterIsOpen() {
+	legacy := &Encounter{ID: "old", Players: []string{"player-1"}}
+
+	s.Equal(EncounterStatusOpen, legacy.GetStatus())
+	s.NoError(legacy.Join("player-2"))
+	s.NoError(legacy.StartReadyCheck("anyone"))
+}
+
+func TestSuiteEncounter(t *testing.T) {
+	suite.Run(t, new(suiteEncounter))
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+// experienceThresholds is the SRD experience needed to reach each level, starting at level 1
+var experienceThresholds = [maxCharacterLevel]int{
+	0, 300, 900, 2700, 6500, 14000, 23000, 34000, 48000, 64000,
+	85000, 100000, 120000, 140000, 165000, 195000, 225000, 265000, 305000, 355000,
+}
+
+// ExperienceForLevel returns the experience needed to reach the level, 0 for levels past the max
+func ExperienceForLevel(level int) int {
+	if level < 1 || level > maxCharacterLevel {
+		return 0
+	}
+
+	return experienceThresholds[level-1]
+}
+
+// AddExperience adds the experience and earns a level for each threshold the character passes, it
+// returns the number of levels earned. The levels are pending until the player takes each one in
+// a class with TakeLevel. NextLevel is left at 0 once the character has earned the max level.
+func (c *Character) AddExperience(xp int) (int, error) {
+	if xp > 0 {
+		c.Experience += xp
+	}
+
+	gained := 0
+	for c.Class != nil && c.earnedLevel() < maxCharacterLevel && c.Experience >= ExperienceForLevel(c.earnedLevel()+1) {
+		c.PendingLevels++
+		gained++
+	}
+
+	c.NextLevel = ExperienceForLevel(max(c.earnedLevel(), 1) + 1)
+
+	return gained, nil
+}
+
+// TakeLevel spends a pending level in the class, a class the character does not have yet is
+// taken as a multiclass
+func (c *Character) TakeLevel(class *Class) error {
+	if c.PendingLevels < 1 {
+		return dnderr.NewResourceExhaustedError(fmt.Sprintf("%s has no levels to take, earn more experience", c.Name))
+	}
+
+	err := c.AddClassLevel(class)
+	if err != nil {
+		return err
+	}
+
+	c.PendingLevels--
+
+	return nil
+}
+
+// earnedLevel is the level the character's experience has reached, counting the pending levels
+func (c *Character) earnedLevel() int {
+	return c.TotalLevel() + c.PendingLevels
+}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteExperience struct {
+	suite.Suite
+
+	char *Character
+}
+
+func (s *suiteExperience) SetupTest() {
+	s.char = &Character{
+		Name:             "Tester",
+		Class:            &Class{Key: "fighter", Name: "Fighter", HitDie: 10},
+		Level:            1,
+		MaxHitPoints:     10,
+		CurrentHitPoints: 10,
+	}
+	s.char.AddAttribute(AttributeConstitution, 10)
+}
+
+func (s *suiteExperience) TestBelowThreshold() {
+	gained, err := s.char.AddExperience(250)
+	s.NoError(err)
+	s.Equal(0, gained)
+	s.Equal(250, s.char.Experience)
+	s.Equal(300, s.char.NextLevel)
+	s.Equal(1, s.char.TotalLevel())
+}
+
+func (s *suiteExperience) TestEarnsPendingLevels() {
+	gained, err := s.char.AddExperience(1000)
+	s.NoError(err)
+	s.Equal(2, gained)
+	s.Equal(2, s.char.PendingLevels)
+	s.Equal(1, s.char.TotalLevel())
+	s.Equal(2700, s.char.NextLevel)
+
+	gained, err = s.char.AddExperience(0)
+	s.NoError(err)
+	s.Equal(0, gained)
+	s.Equal(2, s.char.PendingLevels)
+}
+
+func (s *suiteExperience) TestTakeLevel() {
+	fighter := s.char.Class
+	wizard := &Class{Key: "wizard", Name: "Wizard", HitDie: 6}
+	s.char.AddAttribute(AttributeStrength, 13)
+	s.char.AddAttribute(AttributeIntelligence, 13)
+	_, err := s.char.AddExperience(1000)
+	s.Require().NoError(err)
+
+	s.NoError(s.char.TakeLevel(fighter))
+	s.NoError(s.char.TakeLevel(wizard))
+	s.Equal(0, s.char.PendingLevels)
+	s.Equal(3, s.char.TotalLevel())
+	s.Equal(2, s.char.ClassLevel("fighter"))
+	s.Equal(1, s.char.ClassLevel("wizard"))
+	s.Equal(2700, s.char.NextLevel)
+
+	s.IsType(&dnderr.ResourceExhaustedError{}, s.char.TakeLevel(fighter))
+}
+
+func (s *suiteExperience) TestTakeLevelKeepsThePendingLevelWhenRefused() {
+	s.char.PendingLevels = 1
+
+	err := s.char.TakeLevel(&Class{Key: "wizard", Name: "Wizard", HitDie: 6})
+	s.IsType(&dnderr.InvalidEntityError{}, err)
+	s.Equal(1, s.char.PendingLevels)
+	s.Equal(1, s.char.TotalLevel())
+}
+
+func (s *suiteExperience) TestStopsAtMaxLevel() {
+	gained, err := s.char.AddExperience(400000)
+	s.NoError(err)
+	s.Equal(19, gained)
+	s.Equal(20, s.char.earnedLevel())
+	s.Equal(0, s.char.NextLevel)
+}
+
+func (s *suiteExperience) TestWithoutClass() {
+	s.char.Class = nil
+
+	gained, err := s.char.AddExperience(1000)
+	s.NoError(err)
+	s.Equal(0, gained)
+	s.Equal(1000, s.char.Experience)
+	s.Equal(300, s.char.NextLevel)
+}
+
+func TestSuiteExperience(t *testing.T) {
+	suite.Run(t, new(suiteExperience))
+}
@@ -0,0 +1,213 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+)
+
+// Feat is a talent a character can take in place of an ability score improvement
+type Feat struct {
+	Key         string
+	Name        string
+	Description string
+	// Prerequisites are the minimum ability scores needed to take the feat
+	Prerequisites map[Attribute]int
+	// RequiredProficiency is a proficiency the character needs before taking the feat
+	RequiredProficiency string
+	// AbilityIncreases are added to ability scores when the feat is taken
+	AbilityIncreases map[Attribute]int
+	Proficiencies    []*Proficiency
+	// InitiativeBonus is added to initiative rolls
+	InitiativeBonus int
+	// HitPointsPerLevel is added to max hit points for every character level
+	HitPointsPerLevel int
+	// DualWieldACBonus is added to AC while holding a weapon in each hand
+	DualWieldACBonus int
+	// AllowHeavyDualWield lets the character dual wield weapons that are not light
+	AllowHeavyDualWield bool
+	// Defenses are the damage defenses the feat grants
+	Defenses *damage.Defenses
+}
+
+var feats = []*Feat{{
+	Key:             "alert",
+	Name:            "Alert",
+	Description:     "+5 to initiative",
+	InitiativeBonus: 5,
+}, {
+	Key:                 "dual-wielder",
+	Name:                "Dual Wielder",
+	Description:         "+1 AC while wielding a weapon in each hand, and the weapons do not need to be light",
+	DualWieldACBonus:    1,
+	AllowHeavyDualWield: true,
+}, {
+	Key:           "grappler",
+	Name:          "Grappler",
+	Description:   "Advantage on attack rolls against a creature you are grappling",
+	Prerequisites: map[Attribute]int{AttributeStrength: 13},
+}, {
+	Key:                 "heavily-armored",
+	Name:                "Heavily Armored",
+	Description:         "+1 Str and proficiency with heavy armor",
+	RequiredProficiency: "medium-armor",
+	AbilityIncreases:    map[Attribute]int{AttributeStrength: 1},
+	Proficiencies: []*Proficiency{
+		{Key: "heavy-armor", Name: "Heavy Armor", Type: ProficiencyTypeArmor},
+	},
+}, {
+	Key:              "lightly-armored",
+	Name:             "Lightly Armored",
+	Description:      "+1 Dex and proficiency with light armor",
+	AbilityIncreases: map[Attribute]int{AttributeDexterity: 1},
+	Proficiencies: []*Proficiency{
+		{Key: "light-armor", Name: "Light Armor", Type: ProficiencyTypeArmor},
+	},
+}, {
+	Key:                 "moderately-armored",
+	Name:                "Moderately Armored",
+	Description:         "+1 Str and proficiency with medium armor and shields",
+	RequiredProficiency: "light-armor",
+	AbilityIncreases:    map[Attribute]int{AttributeStrength: 1},
+	Proficiencies: []*Proficiency{
+		{Key: "medium-armor", Name: "Medium Armor", Type: ProficiencyTypeArmor},
+		{Key: "shields", Name: "Shields", Type: ProficiencyTypeArmor},
+	},
+}, {
+	Key:               "tough",
+	Name:              "Tough",
+	Description:       "+2 hit points for every level",
+	HitPointsPerLevel: 2,
+}, {
+	Key:              "weapon-master",
+	Name:             "Weapon Master",
+	Description:      "+1 Str and proficiency with martial weapons",
+	AbilityIncreases: map[Attribute]int{AttributeStrength: 1},
+	Proficiencies: []*Proficiency{
+		{Key: "martial-weapons", Name: "Martial Weapons", Type: ProficiencyTypeWeapon},
+	},
+}}
+
+// ListFeats returns the feat catalog
+func ListFeats() []*Feat {
+	return feats
+}
+
+// GetFeat returns the feat with the key or nil if it is not in the catalog
+func GetFeat(key string) *Feat {
+	for _, feat := range feats {
+		if feat.Key == key {
+			return feat
+		}
+	}
+
+	return nil
+}
+
+func (f *Feat) String() string {
+	return fmt.Sprintf("%s: %s", f.Name, f.Description)
+}
+
+// CanTakeFeat checks the feat prerequisites and that the character does not already have it
+func (c *Character) CanTakeFeat(feat *Feat) bool {
+	if feat == nil || c.HasFeat(feat.Key) {
+		return false
+	}
+
+	for attr, score := range feat.Prerequisites {
+		if c.Attribues[attr] == nil || c.Attribues[attr].Score < score {
+			return false
+		}
+	}
+
+	if feat.RequiredProficiency != "" && !c.HasProficiency(feat.RequiredProficiency) {
+		return false
+	}
+
+	return true
+}
+
+// HasFeat returns true if the character has taken the feat
+func (c *Character) HasFeat(key string) bool {
+	for _, feat := range c.Feats {
+		if feat.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddFeat applies the feat's ability increases, proficiencies and hit points
+func (c *Character) AddFeat(feat *Feat) error {
+	if feat == nil {
+		return dnderr.NewMissingParameterError("feat")
+	}
+
+	if !c.CanTakeFeat(feat) {
+		return dnderr.NewInvalidEntityError(fmt.Sprintf("%s cannot take the %s feat", c.Name, feat.Name))
+	}
+
+	for attr, amount := range feat.AbilityIncreases {
+		if c.abilityScore(attr)+amount > maxImprovedAbilityScore {
+			return dnderr.NewInvalidEntityError(fmt.Sprintf("%s would raise %s above %d", feat.Name, attr, maxImprovedAbilityScore))
+		}
+	}
+
+	for attr, amount := range feat.AbilityIncreases {
+		c.IncreaseAbilityScore(attr, amount)
+	}
+
+	for _, prof := range feat.Proficiencies {
+		prof := *prof
+		c.AddProficiency(&prof)
+	}
+
+	gained := feat.HitPointsPerLevel * max(c.TotalLevel(), 1)
+	c.MaxHitPoints += gained
+	c.CurrentHitPoints += gained
+
+	c.Feats = append(c.Feats, feat)
+
+	return nil
+}
+
+// InitiativeBonus is the dexterity bonus plus any bonus from feats
+func (c *Character) InitiativeBonus() int {
+	bonus := c.attributeBonus(AttributeDexterity)
+	for _, feat := range c.Feats {
+		bonus += feat.InitiativeBonus
+	}
+
+	return bonus
+}
+
+// AllowsHeavyDualWield returns true if a feat lets the character dual wield weapons that are not light
+func (c *Character) AllowsHeavyDualWield() bool {
+	for _, feat := range c.Feats {
+		if feat.AllowHeavyDualWield {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Character) featHitPointsPerLevel() int {
+	total := 0
+	for _, feat := range c.Feats {
+		total += feat.HitPointsPerLevel
+	}
+
+	return total
+}
+
+func (c *Character) featDualWieldACBonus() int {
+	total := 0
+	for _, feat := range c.Feats {
+		total += feat.DualWieldACBonus
+	}
+
+	return total
+}
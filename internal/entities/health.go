@@ -0,0 +1,123 @@
+package entities
+
+import "github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+
+// hitPoints points at the hit point fields of a character or monster so damage and
+// healing are worked out the same way for both
+type hitPoints struct {
+	current *int
+	max     int
+	temp    *int
+}
+
+// takeDamage adjusts the damage for the defenses then takes it from temporary hit points
+// before current hit points, which never go below 0
+func (hp *hitPoints) takeDamage(name string, defenses *damage.Defenses, amount int, dmgType damage.Type) *damage.Report {
+	dealt, modifier := defenses.Adjust(amount, dmgType)
+
+	report := &damage.Report{
+		Target:   name,
+		Type:     dmgType,
+		Rolled:   amount,
+		Modifier: modifier,
+		Dealt:    dealt,
+		MaxHP:    hp.max,
+	}
+
+	remaining := dealt
+	if *hp.temp > 0 {
+		report.AbsorbedByTempHP = min(*hp.temp, remaining)
+		*hp.temp -= report.AbsorbedByTempHP
+		remaining -= report.AbsorbedByTempHP
+	}
+
+	wasUp := *hp.current > 0
+	report.HPLost = min(*hp.current, remaining)
+	*hp.current -= report.HPLost
+
+	report.CurrentHP = *hp.current
+	report.TempHP = *hp.temp
+	report.Dropped = wasUp && *hp.current == 0
+
+	return report
+}
+
+// heal restores hit points up to the maximum and returns how many were restored
+func (hp *hitPoints) heal(amount int) int {
+	if amount <= 0 {
+		return 0
+	}
+
+	healed := min(amount, hp.max-*hp.current)
+	if healed < 0 {
+		return 0
+	}
+
+	*hp.current += healed
+
+	return healed
+}
+
+// grantTemp replaces temporary hit points when the new amount is higher, they do not stack
+func (hp *hitPoints) grantTemp(amount int) int {
+	if amount > *hp.temp {
+		*hp.temp = amount
+	}
+
+	return *hp.temp
+}
+
+func (c *Character) hitPoints() *hitPoints {
+	return &hitPoints{
+		current: &c.CurrentHitPoints,
+		max:     c.MaxHitPoints,
+		temp:    &c.TempHitPoints,
+	}
+}
+
+// DamageDefenses are the resistances, vulnerabilities and immunities from the character's race
+// traits, class features and feats
+func (c *Character) DamageDefenses() *damage.Defenses {
+	defenses := make([]*damage.Defenses, 0)
+	if c.Race != nil {
+		for _, ref := range c.Race.Traits {
+			if trait := GetTrait(ref.Key); trait != nil {
+				defenses = append(defenses, trait.Defenses)
+			}
+		}
+	}
+
+	for _, feature := range c.ClassFeatures() {
+		defenses = append(defenses, feature.Defenses)
+	}
+
+	for _, feat := range c.Feats {
+		defenses = append(defenses, feat.Defenses)
+	}
+
+	return damage.Merge(defenses...)
+}
+
+// TakeDamage applies damage of the type to the character
+func (c *Character) TakeDamage(amount int, dmgType damage.Type) *damage.Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hitPoints().takeDamage(c.Name, c.DamageDefenses(), amount, dmgType)
+}
+
+// Heal restores hit points up to the character's max and returns how many were restored
+func (c *Character) Heal(amount int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hitPoints().heal(amount)
+}
+
+// GrantTempHP gives temporary hit points, keeping the current amount if it is higher
+func (c *Character) GrantTempHP(amount int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hitPoints().grantTemp(amount)
+}
@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteHealth struct {
+	suite.Suite
+
+	char    *Character
+	monster *Monster
+}
+
+func (s *suiteHealth) SetupTest() {
+	s.char = &Character{
+		Name:             "Tester",
+		Race:             &Race{Key: "dwarf", Traits: []*ReferenceItem{{Key: "dwarven-resilience"}}},
+		MaxHitPoints:     12,
+		CurrentHitPoints: 12,
+	}
+
+	s.monster = &Monster{
+		Key: "skeleton",
+		Template: &MonsterTemplate{
+			Name:      "Skeleton",
+			HitPoints: 13,
+			Defenses: &damage.Defenses{
+				Vulnerabilities: []damage.Type{damage.TypeBludgeoning},
+				Immunities:      []damage.Type{damage.TypePoison},
+			},
+		},
+		CurrentHP: 13,
+	}
+}
+
+func (s *suiteHealth) TestCharacterResistsFromRace() {
+	report := s.char.TakeDamage(7, damage.TypePoison)
+
+	s.Equal(3, report.Dealt)
+	s.Equal(damage.ModifierResisted, report.Modifier)
+	s.Equal(9, s.char.CurrentHitPoints)
+}
+
+func (s *suiteHealth) TestCharacterDefensesFromClassFeatures() {
+	s.char.Class = &Class{Key: "monk", Name: "Monk", HitDie: 8}
+	s.char.Classes = []*ClassLevel{{Class: s.char.Class, Level: 9}}
+	s.Equal(damage.ModifierResisted, s.char.TakeDamage(4, damage.TypePoison).Modifier)
+
+	s.char.Classes[0].Level = 10
+	report := s.char.TakeDamage(4, damage.TypePoison)
+	s.Equal(damage.ModifierImmune, report.Modifier)
+	s.Equal(0, report.Dealt)
+}
+
+func (s *suiteHealth) TestCharacterDefensesFromFeats() {
+	s.char.Feats = []*Feat{{
+		Key:      "storm-touched",
+		Name:     "Storm Touched",
+		Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeLightning}},
+	}}
+
+	report := s.char.TakeDamage(9, damage.TypeLightning)
+	s.Equal(damage.ModifierResisted, report.Modifier)
+	s.Equal(4, report.Dealt)
+}
+
+func (s *suiteHealth) TestCharacterIgnoresTraitsWithoutRules() {
+	s.char.Race.Traits = []*ReferenceItem{{Key: "darkvision"}}
+
+	report := s.char.TakeDamage(7, damage.TypePoison)
+	s.Equal(damage.ModifierNone, report.Modifier)
+	s.Equal(7, report.Dealt)
+}
+
+func (s *suiteHealth) TestTempHPAbsorbsFirst() {
+	s.Equal(5, s.char.GrantTempHP(5))
+	s.Equal(5, s.char.GrantTempHP(3))
+
+	report := s.char.TakeDamage(8, damage.TypeSlashing)
+
+	s.Equal(5, report.AbsorbedByTempHP)
+	s.Equal(3, report.HPLost)
+	s.Equal(0, s.char.TempHitPoints)
+	s.Equal(9, s.char.CurrentHitPoints)
+}
+
+func (s *suiteHealth) TestCharacterDrops() {
+	report := s.char.TakeDamage(20, damage.TypeSlashing)
+
+	s.True(report.Dropped)
+	s.Equal(12, report.HPLost)
+	s.Equal(0, s.char.CurrentHitPoints)
+	s.True(s.char.IsDown())
+}
+
+func (s *suiteHealth) TestHealCapsAtMax() {
+	s.char.CurrentHitPoints = 4
+
+	s.Equal(8, s.char.Heal(10))
+	s.Equal(12, s.char.CurrentHitPoints)
+	s.Equal(0, s.char.Heal(-2))
+}
+
+func (s *suiteHealth) TestMonsterDefenses() {
+	report := s.monster.TakeDamage(5, damage.TypePoison)
+	s.Equal(damage.ModifierImmune, report.Modifier)
+	s.Equal(13, s.monster.CurrentHP)
+
+	report = s.monster.TakeDamage(7, damage.TypeBludgeoning)
+	s.Equal(14, report.Dealt)
+	s.True(report.Dropped)
+	s.True(s.monster.IsDown())
+}
+
+func (s *suiteHealth) TestMonsterHealAndTempHP() {
+	s.monster.CurrentHP = 5
+	s.monster.GrantTempHP(4)
+
+	s.Equal(8, s.monster.Heal(20))
+	report := s.monster.TakeDamage(6, damage.TypeSlashing)
+	s.Equal(4, report.AbsorbedByTempHP)
+	s.Equal(11, s.monster.CurrentHP)
+}
+
+func TestSuiteHealth(t *testing.T) {
+	suite.Run(t, new(suiteHealth))
+}
@@ -0,0 +1,231 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+const (
+	// maxImprovedAbilityScore is the highest an ability score improvement or feat can raise a score
+	maxImprovedAbilityScore = 20
+
+	ImprovementOptionPlusTwo = "plus-two"
+	ImprovementOptionPlusOne = "plus-one"
+	ImprovementOptionFeat    = "feat"
+)
+
+// improvementLevels are the class levels that grant an ability score improvement
+var improvementLevels = map[int]bool{4: true, 8: true, 12: true, 16: true, 19: true}
+
+// extraImprovementLevels are the classes that get improvements on top of the usual levels
+var extraImprovementLevels = map[string]map[int]bool{
+	"fighter": {6: true, 14: true},
+	"rogue":   {10: true},
+}
+
+// AbilityScoreImprovement is either two ability increases of 1, which can be the same ability, or a feat
+type AbilityScoreImprovement struct {
+	Attributes []Attribute
+	FeatKey    string
+}
+
+func isImprovementLevel(classKey string, classLevel int) bool {
+	return improvementLevels[classLevel] || extraImprovementLevels[classKey][classLevel]
+}
+
+func (c *Character) abilityScore(attr Attribute) int {
+	if c.Attribues == nil || c.Attribues[attr] == nil {
+		return 0
+	}
+
+	return c.Attribues[attr].Score
+}
+
+// IncreaseAbilityScore raises the score and recomputes the modifier through AddAttribute,
+// keeping any bonus that did not come from the score itself
+func (c *Character) IncreaseAbilityScore(attr Attribute, amount int) {
+	if c.Attribues == nil || c.Attribues[attr] == nil {
+		c.AddAttribute(attr, amount)
+		return
+	}
+
+	current := c.Attribues[attr]
+	extraBonus := current.Bonus - modifierForScore(current.Score)
+	c.Attribues[attr] = &AbilityScore{Score: current.Score, Bonus: extraBonus}
+
+	c.AddAttribute(attr, current.Score+amount)
+}
+
+// ApplyImprovement uses one of the character's pending ability score improvements
+func (c *Character) ApplyImprovement(improvement *AbilityScoreImprovement) error {
+	if improvement == nil {
+		return dnderr.NewMissingParameterError("improvement")
+	}
+
+	if c.PendingImprovements < 1 {
+		return dnderr.NewInvalidEntityError(fmt.Sprintf("%s has no ability score improvements to use", c.Name))
+	}
+
+	if improvement.FeatKey != "" {
+		feat := GetFeat(improvement.FeatKey)
+		if feat == nil {
+			return dnderr.NewNotFoundError(fmt.Sprintf("feat %s not found", improvement.FeatKey))
+		}
+
+		err := c.AddFeat(feat)
+		if err != nil {
+			return err
+		}
+
+		c.PendingImprovements--
+
+		return nil
+	}
+
+	if len(improvement.Attributes) != 2 {
+		return dnderr.NewInvalidParameterError("improvement.Attributes", "an improvement is two increases of 1")
+	}
+
+	increases := make(map[Attribute]int)
+	for _, attr := range improvement.Attributes {
+		increases[attr]++
+	}
+
+	for attr, amount := range increases {
+		if c.abilityScore(attr)+amount > maxImprovedAbilityScore {
+			return dnderr.NewInvalidEntityError(fmt.Sprintf("%s cannot be raised above %d", attr, maxImprovedAbilityScore))
+		}
+	}
+
+	for _, attr := range Attributes {
+		if increases[attr] > 0 {
+			c.IncreaseAbilityScore(attr, increases[attr])
+		}
+	}
+
+	c.PendingImprovements--
+
+	return nil
+}
+
+// NewImprovementChoice builds the choice between +2 to one ability, +1 to two, or a feat.
+// Abilities already at the maximum and feats the character cannot take are left out.
+func NewImprovementChoice(c *Character) *Choice {
+	plusTwo := &Choice{
+		Name:  "+2 to one ability score",
+		Key:   ImprovementOptionPlusTwo,
+		Type:  ChoiceTypeAbilityScoreImprovement,
+		Count: 1,
+	}
+
+	plusOne := &Choice{
+		Name:  "+1 to two ability scores",
+		Key:   ImprovementOptionPlusOne,
+		Type:  ChoiceTypeAbilityScoreImprovement,
+		Count: 2,
+	}
+
+	for _, attr := range Attributes {
+		score := c.abilityScore(attr)
+		reference := &ReferenceItem{
+			Key:  string(attr),
+			Name: fmt.Sprintf("%s (%d)", attr, score),
+			Type: ReferenceTypeAbilityScore,
+		}
+
+		if score+2 <= maxImprovedAbilityScore {
+			plusTwo.Options = append(plusTwo.Options, &ReferenceOption{Reference: reference})
+		}
+
+		if score+1 <= maxImprovedAbilityScore {
+			plusOne.Options = append(plusOne.Options, &ReferenceOption{Reference: reference})
+		}
+	}
+
+	feat := &Choice{
+		Name:  "Take a feat",
+		Key:   ImprovementOptionFeat,
+		Type:  ChoiceTypeAbilityScoreImprovement,
+		Count: 1,
+	}
+
+	for _, f := range ListFeats() {
+		if !c.CanTakeFeat(f) {
+			continue
+		}
+
+		feat.Options = append(feat.Options, &ReferenceOption{
+			Reference: &ReferenceItem{
+				Key:  f.Key,
+				Name: f.Name,
+				Type: ReferenceTypeFeat,
+			},
+		})
+	}
+
+	choice := &Choice{
+		Name:  "Ability Score Improvement",
+		Key:   "ability-score-improvement",
+		Type:  ChoiceTypeAbilityScoreImprovement,
+		Count: 1,
+	}
+
+	for _, option := range []*Choice{plusTwo, plusOne, feat} {
+		if len(option.Options) >= option.Count {
+			choice.Options = append(choice.Options, option)
+		}
+	}
+
+	return choice
+}
+
+// ImprovementFromChoice reads the improvement out of a choice built by NewImprovementChoice
+// once its options have been selected
+func ImprovementFromChoice(choice *Choice) (*AbilityScoreImprovement, error) {
+	if choice == nil {
+		return nil, dnderr.NewMissingParameterError("choice")
+	}
+
+	for _, option := range choice.Options {
+		if option.GetOptionType() != OptionTypeChoice || option.GetStatus() != ChoiceStatusSelected {
+			continue
+		}
+
+		selected := make([]string, 0)
+		for _, item := range option.(*Choice).Options {
+			if item.GetStatus() == ChoiceStatusSelected {
+				selected = append(selected, item.GetKey())
+			}
+		}
+
+		switch option.GetKey() {
+		case ImprovementOptionPlusTwo:
+			if len(selected) != 1 {
+				return nil, dnderr.NewInvalidEntityError("select one ability score")
+			}
+
+			return &AbilityScoreImprovement{
+				Attributes: []Attribute{Attribute(selected[0]), Attribute(selected[0])},
+			}, nil
+		case ImprovementOptionPlusOne:
+			if len(selected) != 2 {
+				return nil, dnderr.NewInvalidEntityError("select two ability scores")
+			}
+
+			return &AbilityScoreImprovement{
+				Attributes: []Attribute{Attribute(selected[0]), Attribute(selected[1])},
+			}, nil
+		case ImprovementOptionFeat:
+			if len(selected) != 1 {
+				return nil, dnderr.NewInvalidEntityError("select one feat")
+			}
+
+			return &AbilityScoreImprovement{
+				FeatKey: selected[0],
+			}, nil
+		}
+	}
+
+	return nil, dnderr.NewInvalidEntityError("no improvement has been selected")
+}
@@ -0,0 +1,156 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteImprovement struct {
+	suite.Suite
+
+	char    *Character
+	fighter *Class
+}
+
+func (s *suiteImprovement) SetupTest() {
+	s.fighter = &Class{Key: "fighter", Name: "Fighter", HitDie: 10}
+	s.char = &Character{
+		Name:                "Tester",
+		Class:               s.fighter,
+		Classes:             []*ClassLevel{{Class: s.fighter, Level: 3}},
+		Level:               3,
+		MaxHitPoints:        28,
+		CurrentHitPoints:    28,
+		PendingImprovements: 1,
+	}
+	s.char.AddAttribute(AttributeStrength, 15)
+	s.char.AddAttribute(AttributeDexterity, 13)
+	s.char.AddAttribute(AttributeConstitution, 14)
+	s.char.AddAttribute(AttributeIntelligence, 10)
+	s.char.AddAttribute(AttributeWisdom, 12)
+	s.char.AddAttribute(AttributeCharisma, 19)
+}
+
+func (s *suiteImprovement) TestLevelFourGrantsImprovement() {
+	s.char.PendingImprovements = 0
+
+	err := s.char.AddClassLevel(s.fighter)
+	s.NoError(err)
+	s.Equal(1, s.char.PendingImprovements)
+}
+
+func (s *suiteImprovement) TestIncreaseKeepsRacialBonus() {
+	s.char.AddAbilityBonus(&AbilityBonus{Attribute: AttributeStrength, Bonus: 1})
+	s.Equal(3, s.char.Attribues[AttributeStrength].Bonus)
+
+	s.char.IncreaseAbilityScore(AttributeStrength, 2)
+
+	s.Equal(17, s.char.Attribues[AttributeStrength].Score)
+	s.Equal(4, s.char.Attribues[AttributeStrength].Bonus)
+}
+
+func (s *suiteImprovement) TestPlusTwo() {
+	err := s.char.ApplyImprovement(&AbilityScoreImprovement{
+		Attributes: []Attribute{AttributeStrength, AttributeStrength},
+	})
+	s.NoError(err)
+
+	s.Equal(17, s.char.Attribues[AttributeStrength].Score)
+	s.Equal(3, s.char.Attribues[AttributeStrength].Bonus)
+	s.Equal(0, s.char.PendingImprovements)
+}
+
+func (s *suiteImprovement) TestPlusOneToTwo() {
+	err := s.char.ApplyImprovement(&AbilityScoreImprovement{
+		Attributes: []Attribute{AttributeStrength, AttributeDexterity},
+	})
+	s.NoError(err)
+
+	s.Equal(16, s.char.Attribues[AttributeStrength].Score)
+	s.Equal(14, s.char.Attribues[AttributeDexterity].Score)
+	s.Equal(2, s.char.Attribues[AttributeDexterity].Bonus)
+}
+
+func (s *suiteImprovement) TestCannotRaiseAboveTwenty() {
+	err := s.char.ApplyImprovement(&AbilityScoreImprovement{
+		Attributes: []Attribute{AttributeCharisma, AttributeCharisma},
+	})
+	s.Error(err)
+	s.Equal(19, s.char.Attribues[AttributeCharisma].Score)
+	s.Equal(1, s.char.PendingImprovements)
+}
+
+func (s *suiteImprovement) TestNoPendingImprovement() {
+	s.char.PendingImprovements = 0
+
+	err := s.char.ApplyImprovement(&AbilityScoreImprovement{
+		Attributes: []Attribute{AttributeStrength, AttributeStrength},
+	})
+	s.Error(err)
+}
+
+func (s *suiteImprovement) TestToughFeat() {
+	err := s.char.ApplyImprovement(&AbilityScoreImprovement{FeatKey: "tough"})
+	s.NoError(err)
+
+	s.True(s.char.HasFeat("tough"))
+	s.Equal(34, s.char.MaxHitPoints)
+
+	err = s.char.AddClassLevel(s.fighter)
+	s.NoError(err)
+	s.Equal(34+6+2+2, s.char.MaxHitPoints)
+}
+
+func (s *suiteImprovement) TestFeatPrerequisite() {
+	err := s.char.ApplyImprovement(&AbilityScoreImprovement{FeatKey: "heavily-armored"})
+	s.Error(err)
+	s.Equal(1, s.char.PendingImprovements)
+
+	s.char.AddProficiency(&Proficiency{Key: "medium-armor", Type: ProficiencyTypeArmor})
+	err = s.char.ApplyImprovement(&AbilityScoreImprovement{FeatKey: "heavily-armored"})
+	s.NoError(err)
+	s.True(s.char.HasProficiency("heavy-armor"))
+	s.Equal(16, s.char.Attribues[AttributeStrength].Score)
+}
+
+func (s *suiteImprovement) TestDualWielderAddsAC() {
+	s.char.Feats = []*Feat{GetFeat("dual-wielder")}
+	sword := &Weapon{Base: BasicEquipment{Key: "longsword"}, Damage: &damage.Damage{DiceCount: 1, DiceSize: 8}}
+	axe := &Weapon{Base: BasicEquipment{Key: "handaxe"}, Damage: &damage.Damage{DiceCount: 1, DiceSize: 6}}
+	s.char.AddInventory(sword)
+	s.char.AddInventory(axe)
+
+	s.char.Equip("longsword")
+	s.char.Equip("handaxe")
+
+	s.Equal(11, s.char.AC)
+	s.True(s.char.AllowsHeavyDualWield())
+}
+
+func (s *suiteImprovement) TestChoiceLeavesOutMaxedScores() {
+	choice := NewImprovementChoice(s.char)
+
+	s.Len(choice.Options, 3)
+	plusTwo := choice.Options[0].(*Choice)
+	plusOne := choice.Options[1].(*Choice)
+	s.Len(plusTwo.Options, 5)
+	s.Len(plusOne.Options, 6)
+}
+
+func (s *suiteImprovement) TestImprovementFromChoice() {
+	choice := NewImprovementChoice(s.char)
+	plusOne := choice.Options[1].(*Choice)
+	plusOne.Status = ChoiceStatusSelected
+	plusOne.Options[0].SetStatus(ChoiceStatusSelected)
+	plusOne.Options[2].SetStatus(ChoiceStatusSelected)
+
+	improvement, err := ImprovementFromChoice(choice)
+	s.NoError(err)
+	s.Equal([]Attribute{AttributeStrength, AttributeConstitution}, improvement.Attributes)
+}
+
+func TestSuiteImprovement(t *testing.T) {
+	suite.Run(t, new(suiteImprovement))
+}
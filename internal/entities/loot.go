@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"slices"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+// LootMode is how the items in a pile of loot are shared
+type LootMode string
+
+const (
+	// LootModeNeedGreed offers each item to everyone, need beats greed and the highest roll wins
+	LootModeNeedGreed LootMode = "need-greed"
+	// LootModeRoundRobin hands the items out in turn
+	LootModeRoundRobin LootMode = "round-robin"
+)
+
+type LootChoice string
+
+const (
+	LootChoiceNeed  LootChoice = "need"
+	LootChoiceGreed LootChoice = "greed"
+	LootChoicePass  LootChoice = "pass"
+)
+
+type LootRoll struct {
+	Choice LootChoice `json:"choice"`
+	Roll   int        `json:"roll"`
+}
+
+type LootItem struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	// Rolls holds each player's choice for the item by player id
+	Rolls map[string]*LootRoll `json:"rolls"`
+	// WinnerID is the player the item went to, empty while it is offered or when everyone passed
+	WinnerID string `json:"winner_id"`
+	Awarded  bool   `json:"awarded"`
+}
+
+// Loot is the treasure from a fight or a treasure room, the coins are split as soon as it is
+// found and the items are handed out by the mode
+type Loot struct {
+	ID        string      `json:"id"`
+	PlayerIDs []string    `json:"player_ids"`
+	Mode      LootMode    `json:"mode"`
+	Coins     *Coins      `json:"coins"`
+	Items     []*LootItem `json:"items"`
+	// Version is the stored version the loot was loaded from, used to reject stale writes
+	Version int `json:"version"`
+}
+
+// IsSettled returns true once every item has been handed out
+func (l *Loot) IsSettled() bool {
+	for _, item := range l.Items {
+		if !item.Awarded {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Choose records the player's choice and roll for the item. Once everyone has chosen the item is
+// awarded and returned, nil is returned while others still have to choose.
+func (l *Loot) Choose(playerID string, index int, choice LootChoice, roll int) (*LootItem, error) {
+	if !slices.Contains(l.PlayerIDs, playerID) {
+		return nil, dnderr.NewInvalidParameterError("playerID", "player is not sharing this loot")
+	}
+
+	if index < 0 || index >= len(l.Items) {
+		return nil, dnderr.NewInvalidParameterError("index", "no item at the index")
+	}
+
+	item := l.Items[index]
+	if item.Awarded {
+		return nil, dnderr.NewConflictError("the item has already been handed out")
+	}
+
+	if item.Rolls == nil {
+		item.Rolls = make(map[string]*LootRoll)
+	}
+
+	if _, ok := item.Rolls[playerID]; ok {
+		return nil, dnderr.NewConflictError("you have already chosen for this item")
+	}
+
+	if choice == LootChoicePass {
+		roll = 0
+	}
+
+	item.Rolls[playerID] = &LootRoll{Choice: choice, Roll: roll}
+	if len(item.Rolls) < len(l.PlayerIDs) {
+		return nil, nil
+	}
+
+	item.WinnerID = l.winner(item)
+	item.Awarded = true
+
+	return item, nil
+}
+
+// winner is the highest need roll, then the highest greed roll, ties go to the player listed first
+func (l *Loot) winner(item *LootItem) string {
+	for _, choice := range []LootChoice{LootChoiceNeed, LootChoiceGreed} {
+		winnerID := ""
+		best := 0
+		for _, playerID := range l.PlayerIDs {
+			roll := item.Rolls[playerID]
+			if roll == nil || roll.Choice != choice {
+				continue
+			}
+
+			if winnerID == "" || roll.Roll > best {
+				winnerID = playerID
+				best = roll.Roll
+			}
+		}
+
+		if winnerID != "" {
+			return winnerID
+		}
+	}
+
+	return ""
+}
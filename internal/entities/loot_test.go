@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteLoot struct {
+	suite.Suite
+
+	loot *Loot
+}
+
+func (s *suiteLoot) SetupTest() {
+	s.loot = &Loot{
+		ID:        "loot-1",
+		PlayerIDs: []string{"player-1", "player-2", "player-3"},
+		Mode:      LootModeNeedGreed,
+		Items:     []*LootItem{{Key: "longsword", Name: "Longsword"}},
+	}
+}
+
+func (s *suiteLoot) TestSplitGivesLeftoversToFirstPurses() {
+	coins := &Coins{Gold: 10, Silver: 2, Platinum: 3}
+
+	purses := coins.Split(3)
+	s.Equal([]*Coins{
+		{Gold: 4, Silver: 1, Platinum: 1},
+		{Gold: 3, Silver: 1, Platinum: 1},
+		{Gold: 3, Platinum: 1},
+	}, purses)
+	s.Equal("1 pp, 3 gp", purses[2].String())
+	s.Equal("no coins", (&Coins{}).String())
+}
+
+func (s *suiteLoot) TestNeedBeatsGreed() {
+	item, err := s.loot.Choose("player-1", 0, LootChoiceGreed, 95)
+	s.NoError(err)
+	s.Nil(item)
+
+	item, err = s.loot.Choose("player-2", 0, LootChoiceNeed, 12)
+	s.NoError(err)
+	s.Nil(item)
+
+	item, err = s.loot.Choose("player-3", 0, LootChoicePass, 80)
+	s.NoError(err)
+	s.Equal("player-2", item.WinnerID)
+	s.Equal(0, item.Rolls["player-3"].Roll)
+	s.True(s.loot.IsSettled())
+}
+
+func (s *suiteLoot) TestTieGoesToFirstPlayer() {
+	_, _ = s.loot.Choose("player-2", 0, LootChoiceGreed, 50)
+	_, _ = s.loot.Choose("player-1", 0, LootChoiceGreed, 50)
+
+	item, err := s.loot.Choose("player-3", 0, LootChoiceGreed, 20)
+	s.NoError(err)
+	s.Equal("player-1", item.WinnerID)
+}
+
+func (s *suiteLoot) TestEveryonePasses() {
+	for _, playerID := range s.loot.PlayerIDs {
+		_, err := s.loot.Choose(playerID, 0, LootChoicePass, 0)
+		s.NoError(err)
+	}
+
+	s.Empty(s.loot.Items[0].WinnerID)
+	s.True(s.loot.Items[0].Awarded)
+}
+
+func (s *suiteLoot) TestChooseErrors() {
+	_, err := s.loot.Choose("stranger", 0, LootChoiceNeed, 10)
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	_, err = s.loot.Choose("player-1", 3, LootChoiceNeed, 10)
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	_, err = s.loot.Choose("player-1", 0, LootChoiceNeed, 10)
+	s.NoError(err)
+
+	_, err = s.loot.Choose("player-1", 0, LootChoiceGreed, 10)
+	s.IsType(&dnderr.ConflictError{}, err)
+}
+
+func TestSuiteLoot(t *testing.T) {
+	suite.Run(t, new(suiteLoot))
+}
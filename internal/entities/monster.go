@@ -1,13 +1,24 @@
 package entities
 
-import "github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+)
 
 type Monster struct {
 	ID          string           `json:"id"`
 	Template    *MonsterTemplate `json:"template"`
 	CharacterID string           `json:"character_id"`
 	CurrentHP   int              `json:"current_hp"`
+	TempHP      int              `json:"temp_hp"`
 	Key         string           `json:"key"`
+	// Name tells apart monsters of the same kind in a room, the template name is used when empty
+	Name string `json:"name,omitempty"`
 }
 
 type MonsterTemplate struct {
@@ -19,12 +30,159 @@ type MonsterTemplate struct {
 	HitDice         string           `json:"hit_dice"`
 	Actions         []*MonsterAction `json:"actions"`
 	XP              int              `json:"xp"`
+	Dexterity       int              `json:"dexterity"`
+	Wisdom          int              `json:"wisdom"`
+	Speed           int              `json:"speed"`
 	ChallengeRating float32          `json:"challenge_rating"`
+	Defenses        *damage.Defenses `json:"defenses"`
+	Senses          *MonsterSenses   `json:"senses"`
+}
+
+// MonsterSenses is what the monster notices without trying
+type MonsterSenses struct {
+	PassivePerception int `json:"passive_perception"`
+}
+
+// PassivePerception is what a sneaking party has to beat, templates without senses work it out
+// from Wisdom
+func (m *Monster) PassivePerception() int {
+	if m.Template == nil {
+		return passiveBase
+	}
+
+	if m.Template.Senses != nil && m.Template.Senses.PassivePerception > 0 {
+		return m.Template.Senses.PassivePerception
+	}
+
+	if m.Template.Wisdom == 0 {
+		return passiveBase
+	}
+
+	return passiveBase + modifierForScore(m.Template.Wisdom)
+}
+
+func (m *Monster) hitPoints() *hitPoints {
+	maxHP := 0
+	if m.Template != nil {
+		maxHP = m.Template.HitPoints
+	}
+
+	return &hitPoints{
+		current: &m.CurrentHP,
+		max:     maxHP,
+		temp:    &m.TempHP,
+	}
+}
+
+func (m *Monster) name() string {
+	if m.Name != "" {
+		return m.Name
+	}
+
+	if m.Template == nil {
+		return m.Key
+	}
+
+	return m.Template.Name
+}
+
+// TakeDamage applies damage of the type to the monster using the SRD defenses on its template
+func (m *Monster) TakeDamage(amount int, dmgType damage.Type) *damage.Report {
+	var defenses *damage.Defenses
+	if m.Template != nil {
+		defenses = m.Template.Defenses
+	}
+
+	return m.hitPoints().takeDamage(m.name(), defenses, amount, dmgType)
+}
+
+// Heal restores hit points up to the template's max and returns how many were restored
+func (m *Monster) Heal(amount int) int {
+	return m.hitPoints().heal(amount)
 }
 
+// GrantTempHP gives temporary hit points, keeping the current amount if it is higher
+func (m *Monster) GrantTempHP(amount int) int {
+	return m.hitPoints().grantTemp(amount)
+}
+
+// Attack rolls every attack in the monster's attack plan
+func (m *Monster) Attack() ([]*attack.Result, error) {
+	if m.Template == nil {
+		return nil, dnderr.NewMissingParameterError("monster.Template")
+	}
+
+	plan := m.Template.AttackPlan()
+	if len(plan) == 0 {
+		return nil, dnderr.NewNotFoundError(fmt.Sprintf("%s has no attack actions", m.name()))
+	}
+
+	results := make([]*attack.Result, len(plan))
+	for idx, action := range plan {
+		dmg := action.Damage[0]
+
+		result, err := attack.RollAttack(action.AttackBonus, dmg.Bonus, dmg)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Name = action.Name
+		results[idx] = result
+	}
+
+	return results, nil
+}
+
+var (
+	// actionRangePattern finds the normal and long range of a ranged attack, "range 80/320 ft."
+	actionRangePattern = regexp.MustCompile(`range (\d+)(?:/(\d+))? ft`)
+	// actionReachPattern finds the reach of a melee attack, "reach 10 ft."
+	actionReachPattern = regexp.MustCompile(`reach (\d+) ft`)
+)
+
 type MonsterAction struct {
 	Name        string           `json:"name"`
 	AttackBonus int              `json:"attack_bonus"`
 	Description string           `json:"desc"`
 	Damage      []*damage.Damage `json:"damage"`
 }
+
+// Reach is how far the action attacks in feet and true when it is ranged, it defaults to a 5
+// foot melee attack when the description does not say
+func (a *MonsterAction) Reach() (int, bool) {
+	if match := actionRangePattern.FindStringSubmatch(a.Description); match != nil {
+		feet, err := strconv.Atoi(match[1])
+		if err == nil {
+			return feet, true
+		}
+	}
+
+	if match := actionReachPattern.FindStringSubmatch(a.Description); match != nil {
+		feet, err := strconv.Atoi(match[1])
+		if err == nil {
+			return feet, false
+		}
+	}
+
+	return meleeReach, false
+}
+
+// LongReach is the farthest the action attacks in feet, the long range of a ranged attack or its
+// reach when it does not have one
+func (a *MonsterAction) LongReach() int {
+	if match := actionRangePattern.FindStringSubmatch(a.Description); match != nil && match[2] != "" {
+		feet, err := strconv.Atoi(match[2])
+		if err == nil {
+			return feet
+		}
+	}
+
+	reach, _ := a.Reach()
+
+	return reach
+}
+
+// IsAttack returns true if the action rolls dice for damage
+func (a *MonsterAction) IsAttack() bool {
+	return len(a.Damage) > 0 && a.Damage[0] != nil && a.Damage[0].DiceCount > 0 && a.Damage[0].DiceSize > 0
+}
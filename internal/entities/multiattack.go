@@ -0,0 +1,121 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+)
+
+const multiattackActionName = "multiattack"
+
+var numberWords = map[string]int{
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+}
+
+var (
+	// multiattackCountPattern finds the total, "makes two attacks" or "makes three melee attacks"
+	multiattackCountPattern = regexp.MustCompile(`makes (one|two|three|four|five|six) (?:\w+ )?attacks?`)
+	// multiattackSplitPattern finds each part of a mixed attack, "one with its bite and two with its claws"
+	multiattackSplitPattern = regexp.MustCompile(`(one|two|three|four|five|six) with its (\w+)`)
+	// multiattackWeaponPattern finds a single weapon used for every attack, "two attacks with its scimitar"
+	multiattackWeaponPattern = regexp.MustCompile(`attacks? with its (\w+)`)
+)
+
+// ParseMultiattack reads an SRD Multiattack description into the attack actions it makes.
+// When the description does not name the actions the first attack action is repeated.
+func ParseMultiattack(description string, actions []*MonsterAction) []*MonsterAction {
+	lower := strings.ToLower(description)
+
+	plan := make([]*MonsterAction, 0)
+	for _, match := range multiattackSplitPattern.FindAllStringSubmatch(lower, -1) {
+		action := findAttackAction(match[2], actions)
+		if action == nil {
+			continue
+		}
+
+		for range numberWords[match[1]] {
+			plan = append(plan, action)
+		}
+	}
+
+	if len(plan) > 0 {
+		return plan
+	}
+
+	countMatch := multiattackCountPattern.FindStringSubmatch(lower)
+	if countMatch == nil {
+		return plan
+	}
+
+	var action *MonsterAction
+	if weaponMatch := multiattackWeaponPattern.FindStringSubmatch(lower); weaponMatch != nil {
+		action = findAttackAction(weaponMatch[1], actions)
+	}
+
+	if action == nil {
+		action = firstAttackAction(actions)
+	}
+
+	if action == nil {
+		return plan
+	}
+
+	for range numberWords[countMatch[1]] {
+		plan = append(plan, action)
+	}
+
+	return plan
+}
+
+// findAttackAction matches a word from a description to an action, "claws" finds Claw
+func findAttackAction(word string, actions []*MonsterAction) *MonsterAction {
+	word = strings.TrimSuffix(word, "s")
+	for _, action := range actions {
+		if !action.IsAttack() {
+			continue
+		}
+
+		name := strings.ToLower(action.Name)
+		if strings.HasPrefix(name, word) || strings.HasPrefix(word, name) {
+			return action
+		}
+	}
+
+	return nil
+}
+
+func firstAttackAction(actions []*MonsterAction) *MonsterAction {
+	for _, action := range actions {
+		if action.IsAttack() {
+			return action
+		}
+	}
+
+	return nil
+}
+
+// AttackPlan is the attack actions the monster makes on its turn, its Multiattack when it has
+// one or else its first attack
+func (t *MonsterTemplate) AttackPlan() []*MonsterAction {
+	for _, action := range t.Actions {
+		if strings.ToLower(action.Name) != multiattackActionName {
+			continue
+		}
+
+		plan := ParseMultiattack(action.Description, t.Actions)
+		if len(plan) > 0 {
+			return plan
+		}
+	}
+
+	action := firstAttackAction(t.Actions)
+	if action == nil {
+		return nil
+	}
+
+	return []*MonsterAction{action}
+}
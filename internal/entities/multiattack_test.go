@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteMultiattack struct {
+	suite.Suite
+
+	bite    *MonsterAction
+	claw    *MonsterAction
+	actions []*MonsterAction
+}
+
+func (s *suiteMultiattack) SetupTest() {
+	s.bite = &MonsterAction{
+		Name:        "Bite",
+		AttackBonus: 7,
+		Damage:      []*damage.Damage{{DiceCount: 1, DiceSize: 10, Bonus: 5, DamageType: damage.TypePiercing}},
+	}
+	s.claw = &MonsterAction{
+		Name:        "Claws",
+		AttackBonus: 7,
+		Damage:      []*damage.Damage{{DiceCount: 2, DiceSize: 8, Bonus: 5, DamageType: damage.TypeSlashing}},
+	}
+	s.actions = []*MonsterAction{
+		{Name: "Multiattack", Description: "The owlbear makes two attacks: one with its beak and one with its claws."},
+		s.bite,
+		s.claw,
+	}
+}
+
+func (s *suiteMultiattack) TestMixedAttacks() {
+	plan := ParseMultiattack("The dragon can use its Frightful Presence. It then makes three attacks: one with its bite and two with its claws.", s.actions)
+
+	s.Equal([]*MonsterAction{s.bite, s.claw, s.claw}, plan)
+}
+
+func (s *suiteMultiattack) TestSingleWeapon() {
+	plan := ParseMultiattack("The goblin boss makes two attacks with its claws. The second attack has disadvantage.", s.actions)
+
+	s.Equal([]*MonsterAction{s.claw, s.claw}, plan)
+}
+
+func (s *suiteMultiattack) TestUnnamedAttacks() {
+	plan := ParseMultiattack("The bugbear chief makes two melee attacks.", s.actions)
+
+	s.Equal([]*MonsterAction{s.bite, s.bite}, plan)
+}
+
+func (s *suiteMultiattack) TestNoAttacks() {
+	plan := ParseMultiattack("The mage casts a spell.", s.actions)
+
+	s.Empty(plan)
+}
+
+func (s *suiteMultiattack) TestAttackPlanUsesMultiattack() {
+	template := &MonsterTemplate{Actions: s.actions}
+
+	// the beak is not an action so only the claws are made
+	s.Equal([]*MonsterAction{s.claw}, template.AttackPlan())
+}
+
+func (s *suiteMultiattack) TestAttackPlanWithoutMultiattack() {
+	template := &MonsterTemplate{Actions: s.actions[1:]}
+
+	s.Equal([]*MonsterAction{s.bite}, template.AttackPlan())
+}
+
+func (s *suiteMultiattack) TestMonsterAttackRollsThePlan() {
+	mon := &Monster{Template: &MonsterTemplate{Name: "Owlbear", Actions: []*MonsterAction{
+		{Name: "Multiattack", Description: "The owlbear makes two attacks: one with its bite and one with its claws."},
+		s.bite,
+		s.claw,
+	}}}
+
+	results, err := mon.Attack()
+	s.NoError(err)
+	s.Len(results, 2)
+	s.Equal("Bite", results[0].Name)
+	s.Equal(damage.TypePiercing, results[0].AttackType)
+	s.Equal("Claws", results[1].Name)
+}
+
+func (s *suiteMultiattack) TestActionReach() {
+	reach, ranged := (&MonsterAction{Description: "Ranged Weapon Attack: +4 to hit, range 80/320 ft., one target."}).Reach()
+	s.Equal(80, reach)
+	s.True(ranged)
+
+	reach, ranged = (&MonsterAction{Description: "Melee Weapon Attack: +7 to hit, reach 10 ft., one target."}).Reach()
+	s.Equal(10, reach)
+	s.False(ranged)
+
+	reach, ranged = s.bite.Reach()
+	s.Equal(5, reach)
+	s.False(ranged)
+}
+
+func (s *suiteMultiattack) TestActionLongReach() {
+	s.Equal(320, (&MonsterAction{Description: "Ranged Weapon Attack: +4 to hit, range 80/320 ft., one target."}).LongReach())
+	s.Equal(30, (&MonsterAction{Description: "Ranged Spell Attack: +5 to hit, range 30 ft., one target."}).LongReach())
+	s.Equal(10, (&MonsterAction{Description: "Melee Weapon Attack: +7 to hit, reach 10 ft., one target."}).LongReach())
+}
+
+func TestSuiteMultiattack(t *testing.T) {
+	suite.Run(t, new(suiteMultiattack))
+}
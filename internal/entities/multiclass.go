@@ -0,0 +1,286 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+const (
+	// multiclassMinimumScore is the score the SRD requires in each prerequisite ability
+	multiclassMinimumScore = 13
+	maxCharacterLevel      = 20
+)
+
+// ClassLevel is the number of levels a character has taken in a class
+type ClassLevel struct {
+	Class *Class
+	Level int
+}
+
+// multiclassPrerequisite lists the abilities a class needs to multiclass into or out of.
+// AnyOf is met when any one of the abilities is high enough, otherwise all are required.
+type multiclassPrerequisite struct {
+	Attributes []Attribute
+	AnyOf      bool
+}
+
+var multiclassPrerequisites = map[string]*multiclassPrerequisite{
+	"barbarian": {Attributes: []Attribute{AttributeStrength}},
+	"bard":      {Attributes: []Attribute{AttributeCharisma}},
+	"cleric":    {Attributes: []Attribute{AttributeWisdom}},
+	"druid":     {Attributes: []Attribute{AttributeWisdom}},
+	"fighter":   {Attributes: []Attribute{AttributeStrength, AttributeDexterity}, AnyOf: true},
+	"monk":      {Attributes: []Attribute{AttributeDexterity, AttributeWisdom}},
+	"paladin":   {Attributes: []Attribute{AttributeStrength, AttributeCharisma}},
+	"ranger":    {Attributes: []Attribute{AttributeDexterity, AttributeWisdom}},
+	"rogue":     {Attributes: []Attribute{AttributeDexterity}},
+	"sorcerer":  {Attributes: []Attribute{AttributeCharisma}},
+	"warlock":   {Attributes: []Attribute{AttributeCharisma}},
+	"wizard":    {Attributes: []Attribute{AttributeIntelligence}},
+}
+
+// multiclassProficiencies are the proficiencies gained when a class is taken after the first.
+// Skill and instrument choices are left to the proficiency choice flow.
+var multiclassProficiencies = map[string][]*Proficiency{
+	"barbarian": {
+		{Key: "shields", Name: "Shields", Type: ProficiencyTypeArmor},
+		{Key: "simple-weapons", Name: "Simple Weapons", Type: ProficiencyTypeWeapon},
+		{Key: "martial-weapons", Name: "Martial Weapons", Type: ProficiencyTypeWeapon},
+	},
+	"bard": {
+		{Key: "light-armor", Name: "Light Armor", Type: ProficiencyTypeArmor},
+	},
+	"cleric": {
+		{Key: "light-armor", Name: "Light Armor", Type: ProficiencyTypeArmor},
+		{Key: "medium-armor", Name: "Medium Armor", Type: ProficiencyTypeArmor},
+		{Key: "shields", Name: "Shields", Type: ProficiencyTypeArmor},
+	},
+	"druid": {
+		{Key: "light-armor", Name: "Light Armor", Type: ProficiencyTypeArmor},
+		{Key: "medium-armor", Name: "Medium Armor", Type: ProficiencyTypeArmor},
+		{Key: "shields", Name: "Shields", Type: ProficiencyTypeArmor},
+	},
+	"fighter": {
+		{Key: "light-armor", Name: "Light Armor", Type: ProficiencyTypeArmor},
+		{Key: "medium-armor", Name: "Medium Armor", Type: ProficiencyTypeArmor},
+		{Key: "shields", Name: "Shields", Type: ProficiencyTypeArmor},
+		{Key: "simple-weapons", Name: "Simple Weapons", Type: ProficiencyTypeWeapon},
+		{Key: "martial-weapons", Name: "Martial Weapons", Type: ProficiencyTypeWeapon},
+	},
+	"monk": {
+		{Key: "simple-weapons", Name: "Simple Weapons", Type: ProficiencyTypeWeapon},
+		{Key: "shortswords", Name: "Shortswords", Type: ProficiencyTypeWeapon},
+	},
+	"paladin": {
+		{Key: "light-armor", Name: "Light Armor", Type: ProficiencyTypeArmor},
+		{Key: "medium-armor", Name: "Medium Armor", Type: ProficiencyTypeArmor},
+		{Key: "shields", Name: "Shields", Type: ProficiencyTypeArmor},
+		{Key: "simple-weapons", Name: "Simple Weapons", Type: ProficiencyTypeWeapon},
+		{Key: "martial-weapons", Name: "Martial Weapons", Type: ProficiencyTypeWeapon},
+	},
+	"ranger": {
+		{Key: "light-armor", Name: "Light Armor", Type: ProficiencyTypeArmor},
+		{Key: "medium-armor", Name: "Medium Armor", Type: ProficiencyTypeArmor},
+		{Key: "shields", Name: "Shields", Type: ProficiencyTypeArmor},
+		{Key: "simple-weapons", Name: "Simple Weapons", Type: ProficiencyTypeWeapon},
+		{Key: "martial-weapons", Name: "Martial Weapons", Type: ProficiencyTypeWeapon},
+	},
+	"rogue": {
+		{Key: "light-armor", Name: "Light Armor", Type: ProficiencyTypeArmor},
+		{Key: "thieves-tools", Name: "Thieves' Tools", Type: ProficiencyTypeTool},
+	},
+	"warlock": {
+		{Key: "light-armor", Name: "Light Armor", Type: ProficiencyTypeArmor},
+		{Key: "simple-weapons", Name: "Simple Weapons", Type: ProficiencyTypeWeapon},
+	},
+}
+
+// MeetsMulticlassPrerequisite returns true if the character has the ability scores to
+// take or leave the class
+func (c *Character) MeetsMulticlassPrerequisite(classKey string) bool {
+	prereq, ok := multiclassPrerequisites[classKey]
+	if !ok {
+		return true
+	}
+
+	for _, attr := range prereq.Attributes {
+		met := c.Attribues[attr] != nil && c.Attribues[attr].Score >= multiclassMinimumScore
+		if met && prereq.AnyOf {
+			return true
+		}
+
+		if !met && !prereq.AnyOf {
+			return false
+		}
+	}
+
+	return !prereq.AnyOf
+}
+
+// AddClassLevel adds a level in the class. Taking a new class checks the SRD multiclass
+// prerequisites for both the new class and the classes the character already has.
+func (c *Character) AddClassLevel(class *Class) error {
+	if class == nil {
+		return dnderr.NewMissingParameterError("class")
+	}
+
+	c.ensureClassLevels()
+
+	if c.TotalLevel() >= maxCharacterLevel {
+		return dnderr.NewInvalidEntityError(fmt.Sprintf("%s is already level %d", c.Name, maxCharacterLevel))
+	}
+
+	if existing := c.getClassLevel(class.Key); existing != nil {
+		existing.Level++
+		c.Level = c.TotalLevel()
+		c.addHitDie(class.HitDie)
+
+		if isImprovementLevel(class.Key, existing.Level) {
+			c.PendingImprovements++
+		}
+
+		return nil
+	}
+
+	if len(c.Classes) == 0 {
+		c.Class = class
+		c.HitDie = class.HitDie
+		c.Classes = append(c.Classes, &ClassLevel{Class: class, Level: 1})
+		c.Level = c.TotalLevel()
+
+		return nil
+	}
+
+	required := []*Class{class}
+	for _, classLevel := range c.Classes {
+		required = append(required, classLevel.Class)
+	}
+
+	for _, req := range required {
+		if !c.MeetsMulticlassPrerequisite(req.Key) {
+			return dnderr.NewInvalidEntityError(fmt.Sprintf("%s does not meet the ability score prerequisites to multiclass with %s", c.Name, req.Name))
+		}
+	}
+
+	c.Classes = append(c.Classes, &ClassLevel{Class: class, Level: 1})
+	c.Level = c.TotalLevel()
+	c.addHitDie(class.HitDie)
+
+	for _, prof := range multiclassProficiencies[class.Key] {
+		prof := *prof
+		c.AddProficiency(&prof)
+	}
+
+	return nil
+}
+
+// TotalLevel is the sum of the character's class levels
+func (c *Character) TotalLevel() int {
+	if len(c.Classes) == 0 {
+		return c.Level
+	}
+
+	total := 0
+	for _, classLevel := range c.Classes {
+		total += classLevel.Level
+	}
+
+	return total
+}
+
+// ClassLevel returns the number of levels the character has in the class
+func (c *Character) ClassLevel(classKey string) int {
+	classLevel := c.getClassLevel(classKey)
+	if classLevel == nil {
+		return 0
+	}
+
+	return classLevel.Level
+}
+
+// ProficiencyBonus is driven by total character level, not the level in any one class
+func (c *Character) ProficiencyBonus() int {
+	level := c.TotalLevel()
+	if level < 1 {
+		level = 1
+	}
+
+	return 2 + (level-1)/4
+}
+
+// HitDiceString lists the hit dice from each class, for example "3d10 + 2d6"
+func (c *Character) HitDiceString() string {
+	if len(c.Classes) == 0 {
+		return fmt.Sprintf("%dd%d", max(c.Level, 1), c.HitDie)
+	}
+
+	counts := make(map[int]int)
+	order := make([]int, 0)
+	for _, classLevel := range c.Classes {
+		if _, ok := counts[classLevel.Class.HitDie]; !ok {
+			order = append(order, classLevel.Class.HitDie)
+		}
+
+		counts[classLevel.Class.HitDie] += classLevel.Level
+	}
+
+	parts := make([]string, len(order))
+	for idx, die := range order {
+		parts[idx] = fmt.Sprintf("%dd%d", counts[die], die)
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// ClassString names each class with its level, for example "Fighter 3 / Wizard 2"
+func (c *Character) ClassString() string {
+	if len(c.Classes) <= 1 {
+		if c.Class == nil {
+			return ""
+		}
+
+		return c.Class.Name
+	}
+
+	parts := make([]string, len(c.Classes))
+	for idx, classLevel := range c.Classes {
+		parts[idx] = fmt.Sprintf("%s %d", classLevel.Class.Name, classLevel.Level)
+	}
+
+	return strings.Join(parts, " / ")
+}
+
+func (c *Character) getClassLevel(classKey string) *ClassLevel {
+	for _, classLevel := range c.Classes {
+		if classLevel.Class != nil && classLevel.Class.Key == classKey {
+			return classLevel
+		}
+	}
+
+	return nil
+}
+
+// ensureClassLevels fills in the class list for characters saved before multiclassing
+func (c *Character) ensureClassLevels() {
+	if len(c.Classes) > 0 || c.Class == nil {
+		return
+	}
+
+	c.Classes = []*ClassLevel{{Class: c.Class, Level: max(c.Level, 1)}}
+}
+
+// addHitDie raises max hit points by the fixed average of the hit die plus the constitution
+// bonus and any hit points feats give per level
+func (c *Character) addHitDie(hitDie int) {
+	gained := hitDie/2 + 1 + c.attributeBonus(AttributeConstitution)
+	if gained < 1 {
+		gained = 1
+	}
+
+	gained += c.featHitPointsPerLevel()
+
+	c.MaxHitPoints += gained
+	c.CurrentHitPoints += gained
+}
@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteMulticlass struct {
+	suite.Suite
+
+	char    *Character
+	fighter *Class
+	wizard  *Class
+	monk    *Class
+}
+
+func (s *suiteMulticlass) SetupTest() {
+	s.fighter = &Class{Key: "fighter", Name: "Fighter", HitDie: 10}
+	s.wizard = &Class{Key: "wizard", Name: "Wizard", HitDie: 6}
+	s.monk = &Class{Key: "monk", Name: "Monk", HitDie: 8}
+
+	s.char = &Character{
+		Name:             "Tester",
+		Class:            s.fighter,
+		HitDie:           10,
+		Level:            1,
+		MaxHitPoints:     12,
+		CurrentHitPoints: 12,
+	}
+	s.char.AddAttribute(AttributeStrength, 15)
+	s.char.AddAttribute(AttributeDexterity, 12)
+	s.char.AddAttribute(AttributeConstitution, 14)
+	s.char.AddAttribute(AttributeIntelligence, 13)
+	s.char.AddAttribute(AttributeWisdom, 10)
+	s.char.AddAttribute(AttributeCharisma, 8)
+}
+
+func (s *suiteMulticlass) TestLevelUpInSameClass() {
+	err := s.char.AddClassLevel(s.fighter)
+	s.NoError(err)
+
+	s.Equal(2, s.char.Level)
+	s.Equal(2, s.char.ClassLevel("fighter"))
+	s.Equal(20, s.char.MaxHitPoints)
+	s.Equal("2d10", s.char.HitDiceString())
+}
+
+func (s *suiteMulticlass) TestMulticlass() {
+	err := s.char.AddClassLevel(s.wizard)
+	s.NoError(err)
+
+	s.Equal(2, s.char.TotalLevel())
+	s.Equal(1, s.char.ClassLevel("wizard"))
+	s.Equal("Fighter 1 / Wizard 1", s.char.ClassString())
+	s.Equal("1d10 + 1d6", s.char.HitDiceString())
+	s.Equal(18, s.char.MaxHitPoints)
+}
+
+func (s *suiteMulticlass) TestMulticlassMissingPrerequisite() {
+	err := s.char.AddClassLevel(s.monk)
+	s.Error(err)
+	s.IsType(&dnderr.InvalidEntityError{}, err)
+	s.Equal(0, s.char.ClassLevel("monk"))
+	s.Equal(1, s.char.Level)
+}
+
+func (s *suiteMulticlass) TestMulticlassChecksCurrentClass() {
+	s.char.AddAttribute(AttributeStrength, 10)
+
+	err := s.char.AddClassLevel(s.wizard)
+	s.Error(err)
+}
+
+func (s *suiteMulticlass) TestFighterPrerequisiteIsEither() {
+	s.char.AddAttribute(AttributeStrength, 8)
+	s.char.AddAttribute(AttributeDexterity, 14)
+
+	s.True(s.char.MeetsMulticlassPrerequisite("fighter"))
+}
+
+func (s *suiteMulticlass) TestMulticlassGrantsProficiencies() {
+	s.char.Class = s.wizard
+	s.char.HitDie = 6
+
+	err := s.char.AddClassLevel(s.fighter)
+	s.NoError(err)
+
+	s.True(s.char.HasProficiency("martial-weapons"))
+	s.True(s.char.HasProficiency("shields"))
+}
+
+func (s *suiteMulticlass) TestProficiencyBonusUsesTotalLevel() {
+	s.char.Classes = []*ClassLevel{
+		{Class: s.fighter, Level: 3},
+		{Class: s.wizard, Level: 2},
+	}
+	s.char.Level = 5
+
+	s.Equal(3, s.char.ProficiencyBonus())
+}
+
+func (s *suiteMulticlass) TestMaxLevel() {
+	s.char.Classes = []*ClassLevel{{Class: s.fighter, Level: 20}}
+	s.char.Level = 20
+
+	err := s.char.AddClassLevel(s.fighter)
+	s.Error(err)
+}
+
+func TestSuiteMulticlass(t *testing.T) {
+	suite.Run(t, new(suiteMulticlass))
+}
@@ -7,4 +7,5 @@ type Race struct {
 	StartingProficiencyOptions *Choice          `json:"proficiency_choices"`
 	StartingProficiencies      []*ReferenceItem `json:"proficiencies"`
 	AbilityBonuses             []*AbilityBonus  `json:"ability_bonuses"`
+	Traits                     []*ReferenceItem `json:"traits"`
 }
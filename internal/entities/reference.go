@@ -9,6 +9,8 @@ const (
 	ReferenceTypeLanguage       ReferenceType = "language"
 	ReferenceTypeSkill          ReferenceType = "skill"
 	ReferenceTypeWeaponProperty ReferenceType = "weapon-properties"
+	ReferenceTypeFeat           ReferenceType = "feat"
+	ReferenceTypeMonster        ReferenceType = "monster"
 	ReferenceTypeUnset          ReferenceType = ""
 )
 
@@ -1,5 +1,9 @@
 package entities
 
+import (
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+)
+
 type RoomStatus string
 
 const (
@@ -9,8 +13,32 @@ const (
 )
 
 type Room struct {
-	ID        string
-	Status    RoomStatus
-	Character *Character
-	Monster   *Monster
+	ID         string
+	Status     RoomStatus
+	Characters []*Character
+	Monsters   []*Monster
+	// Grid is the battle map of the fight, nil for a fight without one
+	Grid *combat.Grid
+}
+
+// Character returns the character in the room with the id, nil when they are not in it
+func (r *Room) Character(id string) *Character {
+	for _, char := range r.Characters {
+		if char.ID == id {
+			return char
+		}
+	}
+
+	return nil
+}
+
+// Monster returns the monster in the room with the id, nil when it is not in it
+func (r *Room) Monster(id string) *Monster {
+	for _, monster := range r.Monsters {
+		if monster.ID == id {
+			return monster
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+const (
+	// DefaultPriceModifier sells at the SRD price
+	DefaultPriceModifier = 100
+	// sellDivisor is the SRD rule that merchants pay half of what an item is worth
+	sellDivisor = 2
+)
+
+// Merchant is an NPC who stocks the equipment in a few SRD equipment categories
+type Merchant struct {
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories"`
+}
+
+// ShopItem is a piece of equipment for sale at its SRD cost
+type ShopItem struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Cost     *Cost  `json:"cost"`
+}
+
+// Shop is a merchant's stock in one guild, the GM can change what the merchant charges
+type Shop struct {
+	GuildID  string    `json:"guild_id"`
+	Merchant *Merchant `json:"merchant"`
+	// PriceModifier is the percent of the SRD price the merchant buys and sells at, 0 is the SRD price
+	PriceModifier int         `json:"price_modifier"`
+	Items         []*ShopItem `json:"items"`
+}
+
+// GetPriceModifier is the percent of the SRD price, shops that have not set one use the SRD price
+func (s *Shop) GetPriceModifier() int {
+	if s.PriceModifier == 0 {
+		return DefaultPriceModifier
+	}
+
+	return s.PriceModifier
+}
+
+// Item finds the item in the merchant's stock, nil when they do not sell it
+func (s *Shop) Item(key string) *ShopItem {
+	for _, item := range s.Items {
+		if item.Key == key {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// BuyPrice is what the merchant charges in copper, never less than a copper
+func (s *Shop) BuyPrice(cost *Cost) int {
+	return max(cost.Copper()*s.GetPriceModifier()/100, 1)
+}
+
+// SellPrice is what the merchant pays in copper, half of what the item is worth
+func (s *Shop) SellPrice(cost *Cost) int {
+	return cost.Copper() * s.GetPriceModifier() / 100 / sellDivisor
+}
+
+// merchants are the NPCs a guild can shop with, each stocks the SRD equipment in their categories
+var merchants = []*Merchant{
+	{
+		Key:         "blacksmith",
+		Name:        "Brenna's Forge",
+		Description: "Brenna Ironhand hammers out blades and axes while you browse.",
+		Categories:  []string{"simple-weapons", "martial-weapons"},
+	}, {
+		Key:         "armorer",
+		Name:        "The Steel Shell",
+		Description: "Old Tobin fits armor and shields with a tape measure and a frown.",
+		Categories:  []string{"light-armor", "medium-armor", "heavy-armor", "shields"},
+	}, {
+		Key:         "general-store",
+		Name:        "Pellham's Provisions",
+		Description: "Shelves of rope, rations, torches and tools, Mara Pellham knows where everything is.",
+		Categories:  []string{"adventuring-gear", "tools"},
+	},
+}
+
+// Merchants are the merchants a shop can be opened with
+func Merchants() []*Merchant {
+	return merchants
+}
+
+// GetMerchant finds the merchant by key
+func GetMerchant(key string) (*Merchant, error) {
+	for _, merchant := range merchants {
+		if merchant.Key == key {
+			return merchant, nil
+		}
+	}
+
+	return nil, dnderr.NewNotFoundError(fmt.Sprintf("merchant %s not found", key))
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteShop struct {
+	suite.Suite
+
+	shop *Shop
+}
+
+func (s *suiteShop) SetupTest() {
+	s.shop = &Shop{
+		GuildID: "guild-1",
+		Items: []*ShopItem{
+			{Key: "longsword", Name: "Longsword", Cost: &Cost{Quantity: 15, Unit: "gp"}},
+			{Key: "torch", Name: "Torch", Cost: &Cost{Quantity: 1, Unit: "cp"}},
+		},
+	}
+}
+
+func (s *suiteShop) TestPricesFollowTheSRD() {
+	s.Equal(1500, s.shop.BuyPrice(s.shop.Item("longsword").Cost))
+	s.Equal(750, s.shop.SellPrice(s.shop.Item("longsword").Cost))
+	s.Equal(0, s.shop.SellPrice(s.shop.Item("torch").Cost))
+	s.Nil(s.shop.Item("greatsword"))
+}
+
+func (s *suiteShop) TestPriceModifier() {
+	s.shop.PriceModifier = 150
+
+	s.Equal(2250, s.shop.BuyPrice(s.shop.Item("longsword").Cost))
+	s.Equal(1125, s.shop.SellPrice(s.shop.Item("longsword").Cost))
+
+	s.shop.PriceModifier = 10
+	s.Equal(1, s.shop.BuyPrice(s.shop.Item("torch").Cost))
+}
+
+func (s *suiteShop) TestSpendMakesChange() {
+	purse := &Coins{Gold: 10, Copper: 3}
+
+	s.True(purse.Spend(15))
+	s.Equal(&Coins{Gold: 9, Silver: 8, Copper: 8}, purse)
+	s.Equal(988, purse.Value())
+
+	s.False(purse.Spend(1000))
+	s.Equal(&Coins{Gold: 9, Silver: 8, Copper: 8}, purse)
+
+	s.True(purse.Spend(988))
+	s.True(purse.IsZero())
+}
+
+func (s *suiteShop) TestSpendBreaksPlatinum() {
+	purse := &Coins{Platinum: 1, Gold: 5, Electrum: 1}
+
+	s.True(purse.Spend(1500))
+	s.Equal(&Coins{Silver: 5}, purse)
+
+	purse = &Coins{Platinum: 2}
+	s.True(purse.Spend(1500))
+	s.Equal(&Coins{Gold: 5}, purse)
+}
+
+func (s *suiteShop) TestRemoveInventoryUnequipsTheLastOne() {
+	sword := &Weapon{Base: BasicEquipment{Key: "longsword", Name: "Longsword"}}
+	spare := &Weapon{Base: BasicEquipment{Key: "longsword", Name: "Longsword"}}
+	char := &Character{}
+	char.AddInventory(sword)
+	char.AddInventory(spare)
+	s.True(char.Equip("longsword"))
+
+	s.Equal(sword, char.RemoveInventory("longsword"))
+	s.Equal(sword, char.EquippedSlots[SlotMainHand])
+
+	s.Equal(spare, char.RemoveInventory("longsword"))
+	s.Nil(char.EquippedSlots[SlotMainHand])
+	s.Empty(char.Inventory[sword.GetEquipmentType()])
+	s.Nil(char.RemoveInventory("longsword"))
+}
+
+func (s *suiteShop) TestGetMerchant() {
+	merchant, err := GetMerchant("blacksmith")
+	s.NoError(err)
+	s.Equal([]string{"simple-weapons", "martial-weapons"}, merchant.Categories)
+
+	_, err = GetMerchant("fence")
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func TestShop(t *testing.T) {
+	suite.Run(t, new(suiteShop))
+}
@@ -0,0 +1,16 @@
+package entities
+
+// stealthSkill is the SRD key of the Stealth proficiency
+const stealthSkill = "skill-stealth"
+
+// StealthBonus is what the character adds to a Dexterity (Stealth) check
+func (c *Character) StealthBonus() int {
+	return c.CheckBonus(AttributeDexterity, stealthSkill)
+}
+
+// StealthDisadvantage is true when the character's body armor is too noisy to sneak in
+func (c *Character) StealthDisadvantage() bool {
+	armor, ok := c.EquippedSlots[SlotBody].(*Armor)
+
+	return ok && armor.StealthDisadvantage
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteStealth struct {
+	suite.Suite
+
+	char *Character
+}
+
+func (s *suiteStealth) SetupTest() {
+	s.char = &Character{
+		Level: 5,
+		Inventory: map[EquipmentType][]Equipment{
+			EquipmentTypeArmor: {
+				&Armor{
+					Base:          BasicEquipment{Key: "leather-armor", Name: "Leather Armor"},
+					ArmorCategory: ArmorCategoryLight,
+					ArmorClass:    &ArmorClass{Base: 11, DexBonus: true},
+				},
+				&Armor{
+					Base:                BasicEquipment{Key: "chain-mail", Name: "Chain Mail"},
+					ArmorCategory:       ArmorCategoryHeavy,
+					ArmorClass:          &ArmorClass{Base: 16},
+					StealthDisadvantage: true,
+				},
+			},
+		},
+	}
+	s.char.AddAttribute(AttributeDexterity, 16)
+}
+
+func (s *suiteStealth) TestStealthBonus() {
+	s.Equal(3, s.char.StealthBonus())
+
+	s.char.AddProficiency(&Proficiency{Key: "skill-stealth", Type: ProficiencyTypeSkill})
+	s.Equal(6, s.char.StealthBonus())
+}
+
+func (s *suiteStealth) TestStealthDisadvantage() {
+	s.False(s.char.StealthDisadvantage())
+
+	s.char.Equip("leather-armor")
+	s.False(s.char.StealthDisadvantage())
+
+	s.char.Equip("chain-mail")
+	s.True(s.char.StealthDisadvantage())
+}
+
+func (s *suiteStealth) TestPassivePerception() {
+	mon := &Monster{}
+	s.Equal(10, mon.PassivePerception())
+
+	mon.Template = &MonsterTemplate{Wisdom: 8}
+	s.Equal(9, mon.PassivePerception())
+
+	mon.Template.Senses = &MonsterSenses{PassivePerception: 13}
+	s.Equal(13, mon.PassivePerception())
+}
+
+func TestSuiteStealth(t *testing.T) {
+	suite.Run(t, new(suiteStealth))
+}
@@ -0,0 +1,72 @@
+package entities
+
+import "github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+
+// Trait is the rules for an SRD racial trait the bot applies, races reference traits by key
+type Trait struct {
+	Key  string
+	Name string
+	// Defenses are the damage defenses the trait grants
+	Defenses *damage.Defenses
+}
+
+var traits = []*Trait{{
+	Key:      "dwarven-resilience",
+	Name:     "Dwarven Resilience",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypePoison}},
+}, {
+	Key:      "hellish-resistance",
+	Name:     "Hellish Resistance",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeFire}},
+}, {
+	Key:      "draconic-ancestry-black",
+	Name:     "Draconic Ancestry (Black)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeAcid}},
+}, {
+	Key:      "draconic-ancestry-blue",
+	Name:     "Draconic Ancestry (Blue)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeLightning}},
+}, {
+	Key:      "draconic-ancestry-brass",
+	Name:     "Draconic Ancestry (Brass)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeFire}},
+}, {
+	Key:      "draconic-ancestry-bronze",
+	Name:     "Draconic Ancestry (Bronze)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeLightning}},
+}, {
+	Key:      "draconic-ancestry-copper",
+	Name:     "Draconic Ancestry (Copper)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeAcid}},
+}, {
+	Key:      "draconic-ancestry-gold",
+	Name:     "Draconic Ancestry (Gold)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeFire}},
+}, {
+	Key:      "draconic-ancestry-green",
+	Name:     "Draconic Ancestry (Green)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypePoison}},
+}, {
+	Key:      "draconic-ancestry-red",
+	Name:     "Draconic Ancestry (Red)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeFire}},
+}, {
+	Key:      "draconic-ancestry-silver",
+	Name:     "Draconic Ancestry (Silver)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeCold}},
+}, {
+	Key:      "draconic-ancestry-white",
+	Name:     "Draconic Ancestry (White)",
+	Defenses: &damage.Defenses{Resistances: []damage.Type{damage.TypeCold}},
+}}
+
+// GetTrait returns the trait with the key or nil if the bot has no rules for it
+func GetTrait(key string) *Trait {
+	for _, trait := range traits {
+		if trait.Key == key {
+			return trait
+		}
+	}
+
+	return nil
+}
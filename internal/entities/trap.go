@@ -0,0 +1,199 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+)
+
+type TrapKind string
+
+const (
+	// TrapKindTrap is hidden until someone spots it and goes off on the whole party
+	TrapKindTrap TrapKind = "trap"
+	// TrapKindChallenge is an obstacle in plain sight, each failed check hurts the one who tried
+	TrapKindChallenge TrapKind = "challenge"
+)
+
+type TrapStatus string
+
+const (
+	TrapStatusArmed    TrapStatus = "armed"
+	TrapStatusDisarmed TrapStatus = "disarmed"
+	TrapStatusSprung   TrapStatus = "sprung"
+)
+
+// TrapOutcome is what came of a check against a trap
+type TrapOutcome string
+
+const (
+	TrapOutcomeDisarmed TrapOutcome = "disarmed"
+	// TrapOutcomeFailed missed the check without setting anything off
+	TrapOutcomeFailed TrapOutcome = "failed"
+	// TrapOutcomeSprung set the trap off on the whole party
+	TrapOutcomeSprung TrapOutcome = "sprung"
+	// TrapOutcomeHurt failed a challenge, only the character who tried takes the damage
+	TrapOutcomeHurt TrapOutcome = "hurt"
+)
+
+// sprungMargin is how far a disarm check has to miss by to set the trap off
+const sprungMargin = 5
+
+// Trap is a trap or skill challenge in a dungeon room
+type Trap struct {
+	ID          string   `json:"id"`
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Kind        TrapKind `json:"kind"`
+	// DetectDC is the passive Perception that spots the trap, 0 is in plain sight
+	DetectDC int `json:"detect_dc"`
+	DisarmDC int `json:"disarm_dc"`
+	// DisarmAttribute is the ability the disarm check is made with
+	DisarmAttribute Attribute `json:"disarm_attribute"`
+	// DisarmWith are the skill and tool proficiencies that add the proficiency bonus to the check
+	DisarmWith []string `json:"disarm_with"`
+	// SaveAttribute and SaveDC are the saving throw against the damage, a save takes half
+	SaveAttribute Attribute      `json:"save_attribute"`
+	SaveDC        int            `json:"save_dc"`
+	Damage        *damage.Damage `json:"damage"`
+	Status        TrapStatus     `json:"status"`
+	Detected      bool           `json:"detected"`
+	// Version is the stored version the trap was loaded from, used to reject stale writes
+	Version int `json:"version"`
+}
+
+// IsArmed returns true until the trap is disarmed or has gone off
+func (t *Trap) IsArmed() bool {
+	return t.Status == "" || t.Status == TrapStatusArmed
+}
+
+// Spot returns true when the passive Perception is enough to notice the trap, it stays detected
+func (t *Trap) Spot(perception int) bool {
+	if t.DetectDC == 0 || perception >= t.DetectDC {
+		t.Detected = true
+	}
+
+	return t.Detected
+}
+
+// Spring sets the trap off, it does not go off again
+func (t *Trap) Spring() {
+	t.Status = TrapStatusSprung
+}
+
+// Disarm works out the check to disarm the trap or overcome the challenge
+func (t *Trap) Disarm(check int) (TrapOutcome, error) {
+	if !t.IsArmed() {
+		return "", dnderr.NewConflictError("the trap has already been dealt with")
+	}
+
+	if check >= t.DisarmDC {
+		t.Status = TrapStatusDisarmed
+		return TrapOutcomeDisarmed, nil
+	}
+
+	if t.Kind == TrapKindChallenge {
+		return TrapOutcomeHurt, nil
+	}
+
+	if t.DisarmDC-check >= sprungMargin {
+		t.Spring()
+		return TrapOutcomeSprung, nil
+	}
+
+	return TrapOutcomeFailed, nil
+}
+
+// trapTemplates are the SRD traps and a few obstacles, NewTrap copies one for a room
+var trapTemplates = []*Trap{
+	{
+		Key:             "poison-darts",
+		Name:            "Poison Darts",
+		Description:     "Tiny holes in the walls hide darts coated in poison, a pressure plate sets them off.",
+		Kind:            TrapKindTrap,
+		DetectDC:        15,
+		DisarmDC:        15,
+		DisarmAttribute: AttributeDexterity,
+		DisarmWith:      []string{"thieves-tools"},
+		SaveAttribute:   AttributeConstitution,
+		SaveDC:          15,
+		Damage:          &damage.Damage{DiceCount: 2, DiceSize: 10, DamageType: damage.TypePoison},
+	}, {
+		Key:             "collapsing-roof",
+		Name:            "Collapsing Roof",
+		Description:     "A tripwire holds up the rotten supports of the ceiling.",
+		Kind:            TrapKindTrap,
+		DetectDC:        11,
+		DisarmDC:        15,
+		DisarmAttribute: AttributeDexterity,
+		DisarmWith:      []string{"thieves-tools"},
+		SaveAttribute:   AttributeDexterity,
+		SaveDC:          15,
+		Damage:          &damage.Damage{DiceCount: 4, DiceSize: 10, DamageType: damage.TypeBludgeoning},
+	}, {
+		Key:             "fire-breathing-statue",
+		Name:            "Fire-Breathing Statue",
+		Description:     "A magical pressure plate makes the statue at the end of the hall breathe fire.",
+		Kind:            TrapKindTrap,
+		DetectDC:        15,
+		DisarmDC:        13,
+		DisarmAttribute: AttributeIntelligence,
+		DisarmWith:      []string{"skill-arcana", "thieves-tools"},
+		SaveAttribute:   AttributeDexterity,
+		SaveDC:          13,
+		Damage:          &damage.Damage{DiceCount: 4, DiceSize: 10, DamageType: damage.TypeFire},
+	}, {
+		Key:             "rope-bridge",
+		Name:            "Rope Bridge",
+		Description:     "A frayed rope bridge sways over a chasm.",
+		Kind:            TrapKindChallenge,
+		DisarmDC:        12,
+		DisarmAttribute: AttributeDexterity,
+		DisarmWith:      []string{"skill-acrobatics"},
+		SaveAttribute:   AttributeDexterity,
+		SaveDC:          12,
+		Damage:          &damage.Damage{DiceCount: 2, DiceSize: 6, DamageType: damage.TypeBludgeoning},
+	}, {
+		Key:             "flooded-tunnel",
+		Name:            "Flooded Tunnel",
+		Description:     "Cold water rushes through a tunnel the party has to swim.",
+		Kind:            TrapKindChallenge,
+		DisarmDC:        13,
+		DisarmAttribute: AttributeStrength,
+		DisarmWith:      []string{"skill-athletics"},
+		SaveAttribute:   AttributeConstitution,
+		SaveDC:          13,
+		Damage:          &damage.Damage{DiceCount: 2, DiceSize: 6, DamageType: damage.TypeCold},
+	},
+}
+
+// TrapKeys are the traps NewTrap knows
+func TrapKeys() []string {
+	keys := make([]string, len(trapTemplates))
+	for idx, template := range trapTemplates {
+		keys[idx] = template.Key
+	}
+
+	return keys
+}
+
+// NewTrap is an armed copy of the trap
+func NewTrap(key string) (*Trap, error) {
+	for _, template := range trapTemplates {
+		if template.Key != key {
+			continue
+		}
+
+		dmg := *template.Damage
+		out := *template
+		out.Damage = &dmg
+		out.DisarmWith = append([]string{}, template.DisarmWith...)
+		out.Status = TrapStatusArmed
+
+		return &out, nil
+	}
+
+	return nil, dnderr.NewNotFoundError(fmt.Sprintf("trap %s not found", key))
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteTrap struct {
+	suite.Suite
+
+	trap *Trap
+}
+
+func (s *suiteTrap) SetupTest() {
+	var err error
+	s.trap, err = NewTrap("poison-darts")
+	s.Require().NoError(err)
+}
+
+func (s *suiteTrap) TestNewTrapCopiesTheTemplate() {
+	s.Equal(TrapStatusArmed, s.trap.Status)
+
+	s.trap.Damage.DiceCount = 10
+	s.trap.DisarmWith[0] = "skill-arcana"
+
+	other, err := NewTrap("poison-darts")
+	s.NoError(err)
+	s.Equal(2, other.Damage.DiceCount)
+	s.Equal([]string{"thieves-tools"}, other.DisarmWith)
+
+	_, err = NewTrap("sphere-of-annihilation")
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *suiteTrap) TestSpot() {
+	s.False(s.trap.Spot(14))
+	s.True(s.trap.Spot(15))
+	s.True(s.trap.Spot(3))
+
+	bridge, err := NewTrap("rope-bridge")
+	s.NoError(err)
+	s.True(bridge.Spot(0))
+}
+
+func (s *suiteTrap) TestDisarm() {
+	outcome, err := s.trap.Disarm(12)
+	s.NoError(err)
+	s.Equal(TrapOutcomeFailed, outcome)
+	s.True(s.trap.IsArmed())
+
+	outcome, err = s.trap.Disarm(15)
+	s.NoError(err)
+	s.Equal(TrapOutcomeDisarmed, outcome)
+	s.Equal(TrapStatusDisarmed, s.trap.Status)
+
+	_, err = s.trap.Disarm(20)
+	s.IsType(&dnderr.ConflictError{}, err)
+}
+
+func (s *suiteTrap) TestDisarmSpringsOnABadMiss() {
+	outcome, err := s.trap.Disarm(10)
+	s.NoError(err)
+	s.Equal(TrapOutcomeSprung, outcome)
+	s.False(s.trap.IsArmed())
+}
+
+func (s *suiteTrap) TestChallengeHurtsWhoTried() {
+	bridge, err := NewTrap("rope-bridge")
+	s.NoError(err)
+
+	outcome, err := bridge.Disarm(2)
+	s.NoError(err)
+	s.Equal(TrapOutcomeHurt, outcome)
+	s.True(bridge.IsArmed())
+}
+
+func (s *suiteTrap) TestCheckBonuses() {
+	char := &Character{Level: 1}
+	char.AddAttribute(AttributeDexterity, 14)
+	char.AddAttribute(AttributeWisdom, 12)
+
+	s.Equal(2, char.CheckBonus(AttributeDexterity, s.trap.DisarmWith...))
+	s.Equal(2, char.SaveBonus(AttributeDexterity))
+	s.Equal(11, char.PassivePerception())
+
+	char.AddProficiency(&Proficiency{Key: "thieves-tools", Type: ProficiencyTypeTool})
+	char.AddProficiency(&Proficiency{Key: "saving-throw-dex", Type: ProficiencyTypeSavingThrow})
+	char.AddProficiency(&Proficiency{Key: "skill-perception", Type: ProficiencyTypeSkill})
+
+	s.Equal(4, char.CheckBonus(AttributeDexterity, s.trap.DisarmWith...))
+	s.Equal(4, char.SaveBonus(AttributeDexterity))
+	s.Equal(13, char.PassivePerception())
+}
+
+func TestSuiteTrap(t *testing.T) {
+	suite.Run(t, new(suiteTrap))
+}
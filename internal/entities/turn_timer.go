@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+// TurnTimer counts down the turn of the player whose turn it is in an encounter's fight, Round and
+// Turn tell the turn it was started for apart from the turns after it
+type TurnTimer struct {
+	EncounterID string `json:"encounter_id"`
+	RoomID      string `json:"room_id"`
+	// ChannelID is where the player is reminded
+	ChannelID string    `json:"channel_id"`
+	PlayerID  string    `json:"player_id"`
+	Round     int       `json:"round"`
+	Turn      int       `json:"turn"`
+	PingAt    time.Time `json:"ping_at"`
+	Deadline  time.Time `json:"deadline"`
+	Pinged    bool      `json:"pinged"`
+	// Failures counts the ticks in a row that failed on the timer, RetryAt holds it back until the
+	// next try
+	Failures int       `json:"failures,omitempty"`
+	RetryAt  time.Time `json:"retry_at,omitempty"`
+}
+
+// NextAt is when the timer next needs looking at, the reminder until it is sent then the deadline.
+// A timer that is backing off after a failure waits until its retry.
+func (t *TurnTimer) NextAt() time.Time {
+	next := t.Deadline
+	if !t.Pinged && t.PingAt.Before(t.Deadline) {
+		next = t.PingAt
+	}
+
+	if t.RetryAt.After(next) {
+		return t.RetryAt
+	}
+
+	return next
+}
+
+// IsFor returns true when the timer was started for the player's turn in that round
+func (t *TurnTimer) IsFor(playerID string, round, turn int) bool {
+	return t.PlayerID == playerID && t.Round == round && t.Turn == turn
+}
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"strings"
+
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
 )
@@ -19,22 +21,38 @@ type Weapon struct {
 func (w *Weapon) Attack(char *Character) (*attack.Result, error) {
 	var bonus int
 	if w.WeaponRange == "Ranged" {
-		bonus = char.Attribues[AttributeDexterity].Bonus
+		bonus = char.attributeBonus(AttributeDexterity)
 	} else if w.WeaponRange == "Melee" {
 
-		bonus = char.Attribues[AttributeStrength].Bonus
+		bonus = char.attributeBonus(AttributeStrength)
 	}
 
-	// TODO: check proficiency
-	if w.IsTwoHanded() {
-		if w.TwoHandedDamage == nil {
-			return attack.RollAttack(bonus, bonus, w.Damage)
-		}
+	attackBonus := bonus
+	if w.isProficient(char) {
+		attackBonus += char.ProficiencyBonus()
+	}
 
-		return attack.RollAttack(bonus, bonus, w.TwoHandedDamage)
+	dmg := w.Damage
+	if w.IsTwoHanded() && w.TwoHandedDamage != nil {
+		dmg = w.TwoHandedDamage
 	}
 
-	return attack.RollAttack(bonus, bonus, w.Damage)
+	result, err := attack.RollAttack(attackBonus, bonus, dmg)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Name = w.Base.Name
+
+	return result, nil
+}
+
+// isProficient checks the weapon category and the weapon itself, proficiencies for a single
+// weapon are plural in the SRD (longswords)
+func (w *Weapon) isProficient(char *Character) bool {
+	category := strings.ToLower(w.WeaponCategory) + "-weapons"
+
+	return char.HasProficiency(category) || char.HasProficiency(w.Base.Key) || char.HasProficiency(w.Base.Key+"s")
 }
 
 func (w *Weapon) IsRanged() bool {
@@ -45,11 +63,44 @@ func (w *Weapon) IsMelee() bool {
 	return w.WeaponRange == "Melee"
 }
 
+// Reach is how far the weapon attacks in feet and true when it is ranged, melee weapons with the
+// reach property add 5 feet
+func (w *Weapon) Reach() (int, bool) {
+	if w.IsRanged() {
+		if w.Range == 0 {
+			return defaultRange, true
+		}
+
+		return w.Range, true
+	}
+
+	if w.hasProperty("reach") {
+		return meleeReach * 2, false
+	}
+
+	return meleeReach, false
+}
+
+// LongReach is the farthest the weapon attacks in feet, ranged weapons shoot past their normal
+// range with disadvantage
+func (w *Weapon) LongReach() int {
+	reach, ranged := w.Reach()
+	if !ranged {
+		return reach
+	}
+
+	return reach * longRangeFactor
+}
+
 func (w *Weapon) IsSimple() bool {
 	return w.hasProperty("simple")
 
 }
 
+func (w *Weapon) IsLight() bool {
+	return w.hasProperty("light")
+}
+
 func (w *Weapon) IsTwoHanded() bool {
 	return w.hasProperty("two-handed")
 }
@@ -4,18 +4,25 @@ import (
 	"context"
 
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/validation"
 )
 
+// UpdateFunc applies a change to a freshly loaded character, it may be called more than
+// once if the write conflicts with another writer
+type UpdateFunc func(char *entities.Character) error
+
 type Manager interface {
 	AddProficiency(ctx context.Context, char *entities.Character, reference *entities.ReferenceItem) (*entities.Character, error)
 	Put(ctx context.Context, character *entities.Character) (*entities.Character, error)
 	Get(ctx context.Context, id string) (*entities.Character, error)
+	Update(ctx context.Context, id string, update UpdateFunc) (*entities.Character, error)
+	Validate(ctx context.Context, character *entities.Character) (*validation.Result, error)
+	AddClassLevel(ctx context.Context, id string, classKey string) (*entities.Character, error)
+	StartImprovement(ctx context.Context, char *entities.Character) (*entities.Choice, error)
+	ApplyImprovement(ctx context.Context, id string, improvement *entities.AbilityScoreImprovement) (*entities.Character, error)
 	GetChoices(ctx context.Context, characterID string, choiceType entities.ChoiceType) ([]*entities.Choice, error)
 	SaveChoices(ctx context.Context, characterID string, choiceType entities.ChoiceType, choices []*entities.Choice) error
 	SaveState(ctx context.Context, state *entities.CharacterCreation) (*entities.CharacterCreation, error)
 	GetState(ctx context.Context, id string) (*entities.CharacterCreation, error)
 	AddInventory(ctx context.Context, char *entities.Character, key string) (*entities.Character, error)
-	CreateEncounter(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error)
-	UpdateEncounter(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error)
-	GetEncounter(ctx context.Context, id string) (*entities.Encounter, error)
 }
@@ -2,7 +2,7 @@ package characters
 
 import (
 	"context"
-	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/encounter"
+	"errors"
 
 	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/choice"
 
@@ -12,14 +12,18 @@ import (
 	"github.com/KirkDiggler/dnd-bot-go/dnderr"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
 	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/character"
+	"github.com/KirkDiggler/dnd-bot-go/internal/validation"
 )
 
+// maxUpdateAttempts is how many times Update will reload and reapply a change after a conflict
+const maxUpdateAttempts = 3
+
 type manager struct {
-	charRepo      character.Repository
-	stateRepo     character_creation.Repository
-	choiceRepo    choice.Repository
-	encounterRepo encounter.Repository
-	client        dnd5e.Client
+	charRepo   character.Repository
+	stateRepo  character_creation.Repository
+	choiceRepo choice.Repository
+	client     dnd5e.Client
+	validator  *validation.Validator
 }
 
 type Config struct {
@@ -27,7 +31,8 @@ type Config struct {
 	StateRepo     character_creation.Repository
 	ChoiceRepo    choice.Repository
 	Client        dnd5e.Client
-	EncounterRepo encounter.Repository
+	// RuleSet is the rule set characters are validated against, defaults to SRD strict
+	RuleSet *validation.RuleSet
 }
 
 func New(cfg *Config) (Manager, error) {
@@ -50,64 +55,26 @@ func New(cfg *Config) (Manager, error) {
 	if cfg.ChoiceRepo == nil {
 		return nil, dnderr.NewMissingParameterError("cfg.ChoiceRepo")
 	}
-	if cfg.EncounterRepo == nil {
-		return nil, dnderr.NewMissingParameterError("cfg.EncounterRepo")
-	}
-
-	return &manager{
-		charRepo:      cfg.CharacterRepo,
-		stateRepo:     cfg.StateRepo,
-		choiceRepo:    cfg.ChoiceRepo,
-		client:        cfg.Client,
-		encounterRepo: cfg.EncounterRepo,
-	}, nil
-}
-
-func (m *manager) CreateEncounter(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error) {
-	if encounter == nil {
-		return nil, dnderr.NewMissingParameterError("encounter")
-	}
-
-	if encounter.ID != "" {
-		return nil, dnderr.NewInvalidParameterError("encounter.ID", encounter.ID)
-	}
-
-	result, err := m.encounterRepo.Create(ctx, encounter)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
-}
-
-func (m *manager) GetEncounter(ctx context.Context, id string) (*entities.Encounter, error) {
-	if id == "" {
-		return nil, dnderr.NewMissingParameterError("id")
-	}
-
-	result, err := m.encounterRepo.Get(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
-}
-
-func (m *manager) UpdateEncounter(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error) {
-	if encounter == nil {
-		return nil, dnderr.NewMissingParameterError("encounter")
-	}
 
-	if encounter.ID == "" {
-		return nil, dnderr.NewMissingParameterError("encounter.ID")
+	ruleSet := cfg.RuleSet
+	if ruleSet == nil {
+		ruleSet = validation.SRDStrict()
 	}
 
-	result, err := m.encounterRepo.Update(ctx, encounter)
+	validator, err := validation.New(&validation.Config{
+		RuleSet: ruleSet,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return &manager{
+		charRepo:   cfg.CharacterRepo,
+		stateRepo:  cfg.StateRepo,
+		choiceRepo: cfg.ChoiceRepo,
+		client:     cfg.Client,
+		validator:  validator,
+	}, nil
 }
 
 func (m *manager) AddProficiency(ctx context.Context, char *entities.Character, reference *entities.ReferenceItem) (*entities.Character, error) {
@@ -217,16 +184,131 @@ func (m *manager) Put(ctx context.Context, character *entities.Character) (*enti
 		return nil, dnderr.NewMissingParameterError("character.Class")
 	}
 
+	result, err := m.Validate(ctx, character)
+	if err != nil {
+		return nil, err
+	}
+
+	err = result.Err()
+	if err != nil {
+		return nil, err
+	}
+
 	data, err := m.charRepo.Put(ctx, character)
 	if err != nil {
 		return nil, err
 	}
 
 	character.ID = data.ID
+	character.Version = data.Version
 
 	return character, nil
 }
 
+// Update loads the character, applies the update and saves it, retrying from a fresh
+// copy when another write lands first
+func (m *manager) Update(ctx context.Context, id string, update UpdateFunc) (*entities.Character, error) {
+	if id == "" {
+		return nil, dnderr.NewMissingParameterError("id")
+	}
+
+	if update == nil {
+		return nil, dnderr.NewMissingParameterError("update")
+	}
+
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		var char *entities.Character
+		char, err = m.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		err = update(char)
+		if err != nil {
+			return nil, err
+		}
+
+		char, err = m.Put(ctx, char)
+		if err == nil {
+			return char, nil
+		}
+
+		var conflictErr *dnderr.ConflictError
+		if !errors.As(err, &conflictErr) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+// AddClassLevel spends one of the character's pending levels in the class, multiclassing if it
+// is a class the character does not have yet
+func (m *manager) AddClassLevel(ctx context.Context, id string, classKey string) (*entities.Character, error) {
+	if id == "" {
+		return nil, dnderr.NewMissingParameterError("id")
+	}
+
+	if classKey == "" {
+		return nil, dnderr.NewMissingParameterError("classKey")
+	}
+
+	class, err := m.client.GetClass(classKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Update(ctx, id, func(char *entities.Character) error {
+		return char.TakeLevel(class)
+	})
+}
+
+// StartImprovement builds and saves the ability score improvement choice for a character
+// that has an improvement to use
+func (m *manager) StartImprovement(ctx context.Context, char *entities.Character) (*entities.Choice, error) {
+	if char == nil {
+		return nil, dnderr.NewMissingParameterError("char")
+	}
+
+	if char.PendingImprovements < 1 {
+		return nil, dnderr.NewResourceExhaustedError("no ability score improvements to use")
+	}
+
+	choice := entities.NewImprovementChoice(char)
+
+	err := m.SaveChoices(ctx, char.ID, entities.ChoiceTypeAbilityScoreImprovement, []*entities.Choice{choice})
+	if err != nil {
+		return nil, err
+	}
+
+	return choice, nil
+}
+
+// ApplyImprovement uses one of the character's pending ability score improvements
+func (m *manager) ApplyImprovement(ctx context.Context, id string, improvement *entities.AbilityScoreImprovement) (*entities.Character, error) {
+	if id == "" {
+		return nil, dnderr.NewMissingParameterError("id")
+	}
+
+	if improvement == nil {
+		return nil, dnderr.NewMissingParameterError("improvement")
+	}
+
+	return m.Update(ctx, id, func(char *entities.Character) error {
+		return char.ApplyImprovement(improvement)
+	})
+}
+
+// Validate checks the character against the configured rule set
+func (m *manager) Validate(ctx context.Context, character *entities.Character) (*validation.Result, error) {
+	if character == nil {
+		return nil, dnderr.NewMissingParameterError("character")
+	}
+
+	return m.validator.Validate(character), nil
+}
+
 func (m *manager) Get(ctx context.Context, id string) (*entities.Character, error) {
 	if id == "" {
 		return nil, dnderr.NewMissingParameterError("id")
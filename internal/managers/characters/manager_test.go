@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
 	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
 
 	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/character_creation"
@@ -14,6 +15,8 @@ import (
 	"github.com/KirkDiggler/dnd-bot-go/clients/dnd5e"
 
 	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/character"
+	"github.com/KirkDiggler/dnd-bot-go/internal/validation"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -64,10 +67,14 @@ func (s *managerSuite) SetupTest() {
 	}
 
 	s.characterData = characterToData(s.character)
+	validator, _ := validation.New(&validation.Config{
+		RuleSet: validation.SRDStrict(),
+	})
 	s.fixture = &manager{
 		charRepo:  s.mockRepo,
 		client:    s.mockClient,
 		stateRepo: s.mockStateRepo,
+		validator: validator,
 	}
 }
 
@@ -194,6 +201,27 @@ func (s *managerSuite) TestCreateRepoErrors() {
 	s.EqualError(err, "repo error")
 }
 
+func (s *managerSuite) TestCreateInvalidCharacter() {
+	s.character.Attribues[entities.AttributeStrength].Score = 21
+
+	_, err := s.fixture.Put(s.ctx, s.character)
+	s.Error(err)
+
+	var validationErr *validation.Error
+	s.ErrorAs(err, &validationErr)
+	s.Equal(validation.RuleAbilityScoreRange, validationErr.Violations[0].Rule)
+	s.mockRepo.AssertNotCalled(s.T(), "Put")
+}
+
+func (s *managerSuite) TestValidate() {
+	s.character.Attribues[entities.AttributeStrength].Score = 21
+
+	result, err := s.fixture.Validate(s.ctx, s.character)
+	s.NoError(err)
+	s.False(result.Valid())
+	s.Equal(validation.RuleSetSRDStrict, result.RuleSet)
+}
+
 func (s *managerSuite) TestGet() {
 	s.mockClient.On("GetRace", s.race.Key).Return(
 		s.race, nil)
@@ -207,6 +235,111 @@ func (s *managerSuite) TestGet() {
 	s.Equal(s.character, char)
 }
 
+func (s *managerSuite) TestUpdateRetriesOnConflict() {
+	s.mockClient.On("GetRace", s.race.Key).Return(s.race, nil)
+	s.mockClient.On("GetClass", s.class.Key).Return(s.class, nil)
+	s.mockRepo.On("Get", s.ctx, s.id).Return(s.characterData, nil)
+	s.mockRepo.On("Put", s.ctx, mock.Anything).Return(
+		nil, dnderr.NewConflictError("conflict")).Once()
+	s.mockRepo.On("Put", s.ctx, mock.Anything).Return(
+		s.character, nil).Once()
+
+	calls := 0
+	char, err := s.fixture.Update(s.ctx, s.id, func(char *entities.Character) error {
+		calls++
+		char.Experience = 100
+		return nil
+	})
+	s.NoError(err)
+	s.NotNil(char)
+	s.Equal(2, calls)
+	s.mockRepo.AssertNumberOfCalls(s.T(), "Get", 2)
+}
+
+func (s *managerSuite) TestUpdateGivesUpAfterMaxAttempts() {
+	s.mockClient.On("GetRace", s.race.Key).Return(s.race, nil)
+	s.mockClient.On("GetClass", s.class.Key).Return(s.class, nil)
+	s.mockRepo.On("Get", s.ctx, s.id).Return(s.characterData, nil)
+	s.mockRepo.On("Put", s.ctx, mock.Anything).Return(
+		nil, dnderr.NewConflictError("conflict"))
+
+	_, err := s.fixture.Update(s.ctx, s.id, func(char *entities.Character) error {
+		return nil
+	})
+	s.Error(err)
+	s.mockRepo.AssertNumberOfCalls(s.T(), "Put", maxUpdateAttempts)
+}
+
+func (s *managerSuite) TestUpdateReturnsUpdateError() {
+	s.mockClient.On("GetRace", s.race.Key).Return(s.race, nil)
+	s.mockClient.On("GetClass", s.class.Key).Return(s.class, nil)
+	s.mockRepo.On("Get", s.ctx, s.id).Return(s.characterData, nil)
+
+	_, err := s.fixture.Update(s.ctx, s.id, func(char *entities.Character) error {
+		return errors.New("update error")
+	})
+	s.EqualError(err, "update error")
+	s.mockRepo.AssertNotCalled(s.T(), "Put")
+}
+
+func (s *managerSuite) TestAddClassLevel() {
+	wizard := &entities.Class{Key: "wizard", Name: "Wizard", HitDie: 6}
+	s.characterData.Level = 1
+	s.characterData.PendingLevels = 1
+	s.mockClient.On("GetRace", s.race.Key).Return(s.race, nil)
+	s.mockClient.On("GetClass", s.class.Key).Return(s.class, nil)
+	s.mockClient.On("GetClass", wizard.Key).Return(wizard, nil)
+	s.mockRepo.On("Get", s.ctx, s.id).Return(s.characterData, nil)
+	s.mockRepo.On("Put", s.ctx, mock.Anything).Return(s.character, nil)
+
+	char, err := s.fixture.AddClassLevel(s.ctx, s.id, wizard.Key)
+	s.NoError(err)
+	s.Equal(2, char.Level)
+	s.Equal(1, char.ClassLevel(s.class.Key))
+	s.Equal(1, char.ClassLevel(wizard.Key))
+	s.Equal(0, char.PendingLevels)
+}
+
+func (s *managerSuite) TestAddClassLevelWithoutPendingLevels() {
+	wizard := &entities.Class{Key: "wizard", Name: "Wizard", HitDie: 6}
+	s.characterData.Level = 1
+	s.mockClient.On("GetRace", s.race.Key).Return(s.race, nil)
+	s.mockClient.On("GetClass", s.class.Key).Return(s.class, nil)
+	s.mockClient.On("GetClass", wizard.Key).Return(wizard, nil)
+	s.mockRepo.On("Get", s.ctx, s.id).Return(s.characterData, nil)
+
+	_, err := s.fixture.AddClassLevel(s.ctx, s.id, wizard.Key)
+	s.IsType(&dnderr.ResourceExhaustedError{}, err)
+	s.mockRepo.AssertNotCalled(s.T(), "Put")
+}
+
+func (s *managerSuite) TestAddClassLevelMissingClassKey() {
+	_, err := s.fixture.AddClassLevel(s.ctx, s.id, "")
+	s.EqualError(err, "Missing parameter: classKey")
+}
+
+func (s *managerSuite) TestStartImprovementWithoutPending() {
+	_, err := s.fixture.StartImprovement(s.ctx, s.character)
+	s.Error(err)
+	s.IsType(&dnderr.ResourceExhaustedError{}, err)
+}
+
+func (s *managerSuite) TestApplyImprovement() {
+	s.characterData.PendingImprovements = 1
+	s.mockClient.On("GetRace", s.race.Key).Return(s.race, nil)
+	s.mockClient.On("GetClass", s.class.Key).Return(s.class, nil)
+	s.mockRepo.On("Get", s.ctx, s.id).Return(s.characterData, nil)
+	s.mockRepo.On("Put", s.ctx, mock.Anything).Return(s.character, nil)
+
+	char, err := s.fixture.ApplyImprovement(s.ctx, s.id, &entities.AbilityScoreImprovement{
+		Attributes: []entities.Attribute{entities.AttributeStrength, entities.AttributeDexterity},
+	})
+	s.NoError(err)
+	s.Equal(17, char.Attribues[entities.AttributeStrength].Score)
+	s.Equal(16, char.Attribues[entities.AttributeDexterity].Score)
+	s.Equal(0, char.PendingImprovements)
+}
+
 func TestCharacter(t *testing.T) {
 	suite.Run(t, new(managerSuite))
 }
@@ -0,0 +1,147 @@
+package characters
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/validation"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) AddProficiency(ctx context.Context, char *entities.Character, reference *entities.ReferenceItem) (*entities.Character, error) {
+	args := m.Called(ctx, char, reference)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Character), nil
+}
+
+func (m *Mock) Put(ctx context.Context, character *entities.Character) (*entities.Character, error) {
+	args := m.Called(ctx, character)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Character), nil
+}
+
+func (m *Mock) Get(ctx context.Context, id string) (*entities.Character, error) {
+	args := m.Called(ctx, id)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Character), nil
+}
+
+// Update calls the update func on the character returned by the expectation so callers see
+// their change applied
+func (m *Mock) Update(ctx context.Context, id string, update UpdateFunc) (*entities.Character, error) {
+	args := m.Called(ctx, id, update)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	char := args.Get(0).(*entities.Character)
+	err := update(char)
+	if err != nil {
+		return nil, err
+	}
+
+	return char, nil
+}
+
+func (m *Mock) Validate(ctx context.Context, character *entities.Character) (*validation.Result, error) {
+	args := m.Called(ctx, character)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*validation.Result), nil
+}
+
+func (m *Mock) AddClassLevel(ctx context.Context, id string, classKey string) (*entities.Character, error) {
+	args := m.Called(ctx, id, classKey)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Character), nil
+}
+
+func (m *Mock) StartImprovement(ctx context.Context, char *entities.Character) (*entities.Choice, error) {
+	args := m.Called(ctx, char)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Choice), nil
+}
+
+func (m *Mock) ApplyImprovement(ctx context.Context, id string, improvement *entities.AbilityScoreImprovement) (*entities.Character, error) {
+	args := m.Called(ctx, id, improvement)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Character), nil
+}
+
+func (m *Mock) GetChoices(ctx context.Context, characterID string, choiceType entities.ChoiceType) ([]*entities.Choice, error) {
+	args := m.Called(ctx, characterID, choiceType)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entities.Choice), nil
+}
+
+func (m *Mock) SaveChoices(ctx context.Context, characterID string, choiceType entities.ChoiceType, choices []*entities.Choice) error {
+	args := m.Called(ctx, characterID, choiceType, choices)
+
+	return args.Error(0)
+}
+
+func (m *Mock) SaveState(ctx context.Context, state *entities.CharacterCreation) (*entities.CharacterCreation, error) {
+	args := m.Called(ctx, state)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.CharacterCreation), nil
+}
+
+func (m *Mock) GetState(ctx context.Context, id string) (*entities.CharacterCreation, error) {
+	args := m.Called(ctx, id)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.CharacterCreation), nil
+}
+
+func (m *Mock) AddInventory(ctx context.Context, char *entities.Character, key string) (*entities.Character, error) {
+	args := m.Called(ctx, char, key)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Character), nil
+}
@@ -40,27 +40,67 @@ func (m *manager) characterFromData(ctx context.Context, data *character.Data) (
 		return nil
 	})
 
+	var classLevels []*entities.ClassLevel
+	if len(data.Classes) > 0 {
+		classLevels = make([]*entities.ClassLevel, len(data.Classes))
+	}
+
+	for idx, classData := range data.Classes {
+		idx, classData := idx, classData
+		g.Go(func() error {
+			levelClass, err := m.client.GetClass(classData.ClassKey)
+			if err != nil {
+				return err
+			}
+
+			classLevels[idx] = &entities.ClassLevel{
+				Class: levelClass,
+				Level: classData.Level,
+			}
+
+			return nil
+		})
+	}
+
 	err := g.Wait()
 	if err != nil {
 		return nil, err
 	}
 
+	// Characters saved before multiclassing only have the starting class
+	if len(classLevels) == 0 && data.Level > 0 {
+		classLevels = []*entities.ClassLevel{{
+			Class: class,
+			Level: data.Level,
+		}}
+	}
+
 	char := &entities.Character{
-		ID:               data.ID,
-		Name:             data.Name,
-		OwnerID:          data.OwnerID,
-		Speed:            data.Speed,
-		AC:               data.AC,
-		MaxHitPoints:     data.MaxHitPoints,
-		CurrentHitPoints: data.CurrentHitPoints,
-		HitDie:           data.HitDie,
-		Experience:       data.Experience,
-		Level:            data.Level,
-		NextLevel:        data.NextLevel,
-		Race:             race,
-		Class:            class,
-		Attribues:        attributDataToAttributes(data.Attributes),
-		Rolls:            rollDatasToRollResults(data.Rolls),
+		ID:                  data.ID,
+		Version:             data.Version,
+		Name:                data.Name,
+		OwnerID:             data.OwnerID,
+		Speed:               data.Speed,
+		AC:                  data.AC,
+		MaxHitPoints:        data.MaxHitPoints,
+		CurrentHitPoints:    data.CurrentHitPoints,
+		TempHitPoints:       data.TempHitPoints,
+		HitDie:              data.HitDie,
+		Experience:          data.Experience,
+		Level:               data.Level,
+		NextLevel:           data.NextLevel,
+		Race:                race,
+		Class:               class,
+		Classes:             classLevels,
+		Attribues:           attributDataToAttributes(data.Attributes),
+		Rolls:               rollDatasToRollResults(data.Rolls),
+		Feats:               keysToFeats(data.Feats),
+		PendingImprovements: data.PendingImprovements,
+		PendingLevels:       data.PendingLevels,
+	}
+
+	if data.Coins != nil {
+		char.Coins = *data.Coins
 	}
 
 	for _, prof := range data.Proficiencies {
@@ -98,6 +138,21 @@ func (m *manager) characterFromData(ctx context.Context, data *character.Data) (
 	return char, nil
 }
 
+// keysToFeats looks the feats up in the catalog, feats that have been removed are dropped
+func keysToFeats(keys []string) []*entities.Feat {
+	var feats []*entities.Feat
+	for _, key := range keys {
+		feat := entities.GetFeat(key)
+		if feat == nil {
+			continue
+		}
+
+		feats = append(feats, feat)
+	}
+
+	return feats
+}
+
 func dataToProficiency(data *character.Proficiency) *entities.Proficiency {
 	if data == nil {
 		return nil
@@ -165,10 +220,12 @@ func characterToData(input *entities.Character) *character.Data {
 
 	return &character.Data{
 		ID:            input.ID,
+		Version:       input.Version,
 		Name:          input.Name,
 		OwnerID:       input.OwnerID,
 		RaceKey:       input.Race.Key,
 		ClassKey:      input.Class.Key,
+		Classes:       classLevelsToDatas(input.Classes),
 		Attributes:    attributesToAttributeData(input.Attribues),
 		Rolls:         rollResultsToRollDatas(input.Rolls),
 		Proficiencies: proficienciesToDatas(input.Proficiencies),
@@ -176,6 +233,18 @@ func characterToData(input *entities.Character) *character.Data {
 		EquippedSlots: equippedSlotsToDatas(input.EquippedSlots),
 	}
 }
+func classLevelsToDatas(input []*entities.ClassLevel) []*character.ClassLevelData {
+	datas := make([]*character.ClassLevelData, 0, len(input))
+	for _, classLevel := range input {
+		datas = append(datas, &character.ClassLevelData{
+			ClassKey: classLevel.Class.Key,
+			Level:    classLevel.Level,
+		})
+	}
+
+	return datas
+}
+
 func equippedSlotsToDatas(input map[entities.Slot]entities.Equipment) map[entities.Slot]*character.Equipment {
 	datas := make(map[entities.Slot]*character.Equipment)
 
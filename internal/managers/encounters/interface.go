@@ -0,0 +1,85 @@
+package encounters
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+type Manager interface {
+	Build(ctx context.Context, input *BuildInput) (*BuildOutput, error)
+	Create(ctx context.Context, input *CreateInput) (*entities.Encounter, error)
+	Get(ctx context.Context, id string) (*entities.Encounter, error)
+	GetByChannel(ctx context.Context, channelID string) (*entities.Encounter, error)
+	SetMessage(ctx context.Context, input *SetMessageInput) (*entities.Encounter, error)
+	Join(ctx context.Context, input *PlayerInput) (*entities.Encounter, error)
+	Leave(ctx context.Context, input *PlayerInput) (*entities.Encounter, error)
+	Kick(ctx context.Context, input *KickInput) (*entities.Encounter, error)
+	ReadyCheck(ctx context.Context, input *PlayerInput) (*entities.Encounter, error)
+	Ready(ctx context.Context, input *PlayerInput) (*entities.Encounter, error)
+	Start(ctx context.Context, input *StartInput) (*entities.Encounter, error)
+	Resolve(ctx context.Context, id string, outcome string) (*entities.Encounter, error)
+	Archive(ctx context.Context, input *PlayerInput) (*entities.Encounter, error)
+	End(ctx context.Context, input *PlayerInput) (*entities.Encounter, error)
+}
+
+type BuildInput struct {
+	Difficulty Difficulty
+	// PartyLevels has the level of each character in the party
+	PartyLevels []int
+	// MaxMonsters limits the group size, defaults to defaultMaxMonsters
+	MaxMonsters int
+}
+
+type BuildOutput struct {
+	Difficulty Difficulty
+	// Budget is the XP threshold of the party for the difficulty
+	Budget   int
+	Monsters []*entities.MonsterTemplate
+	// XP is what the monsters are worth when defeated
+	XP int
+	// AdjustedXP is the XP with the multiple monster multiplier, it is compared to the budget
+	AdjustedXP int
+}
+
+type CreateInput struct {
+	GMID      string
+	ChannelID string
+	// MaxPlayers caps the party, defaults to entities.DefaultMaxPlayers
+	MaxPlayers int
+	// TurnTimeout is the seconds a player has to take their turn, defaults to
+	// entities.DefaultTurnTimeout when nil and 0 turns the timer off
+	TurnTimeout *int
+	// PingAfter is the seconds before the player is reminded, defaults to entities.DefaultPingAfter
+	// or half the timeout when that is shorter
+	PingAfter int
+	// Stealth has the party roll a group stealth check against the monsters when the fight starts
+	Stealth bool
+}
+
+type SetMessageInput struct {
+	EncounterID string
+	ChannelID   string
+	MessageID   string
+}
+
+// PlayerInput is a player acting on the encounter, for the GM only actions it is the GM
+type PlayerInput struct {
+	EncounterID string
+	PlayerID    string
+}
+
+type KickInput struct {
+	EncounterID string
+	GMID        string
+	PlayerID    string
+}
+
+type StartInput struct {
+	EncounterID string
+	GMID        string
+	// RoomID is the fight the party is handed off to
+	RoomID string
+	// Surprised are the names of the monsters the party snuck up on
+	Surprised []string
+}
@@ -0,0 +1,273 @@
+package encounters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+const (
+	// maxPlayers is the most players the GM can let into an encounter
+	maxPlayers = 8
+	// minTurnTimeout and maxTurnTimeout bound the seconds a turn can be given
+	minTurnTimeout = 15
+	maxTurnTimeout = 3600
+	// maxUpdateAttempts is how many times a change is reloaded and reapplied after a conflict
+	maxUpdateAttempts = 3
+)
+
+// Create opens an encounter for players to join
+func (m *Implementation) Create(ctx context.Context, input *CreateInput) (*entities.Encounter, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.GMID == "" {
+		return nil, dnderr.NewMissingParameterError("input.GMID")
+	}
+
+	size := input.MaxPlayers
+	if size == 0 {
+		size = entities.DefaultMaxPlayers
+	}
+
+	if size < 1 || size > maxPlayers {
+		return nil, dnderr.NewInvalidParameterError("input.MaxPlayers", fmt.Sprintf("must be between 1 and %d", maxPlayers))
+	}
+
+	timeout, pingAfter, err := turnTimeouts(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.encounterRepo.Create(ctx, &entities.Encounter{
+		ChannelID:   input.ChannelID,
+		GMID:        input.GMID,
+		Status:      entities.EncounterStatusOpen,
+		MaxPlayers:  size,
+		Players:     []string{},
+		TurnTimeout: timeout,
+		PingAfter:   pingAfter,
+		Stealth:     input.Stealth,
+	})
+}
+
+// turnTimeouts fills in the turn timer defaults, the reminder has to come before the timeout
+func turnTimeouts(input *CreateInput) (int, int, error) {
+	timeout := entities.DefaultTurnTimeout
+	if input.TurnTimeout != nil {
+		timeout = *input.TurnTimeout
+	}
+
+	if timeout == 0 {
+		return 0, 0, nil
+	}
+
+	if timeout < minTurnTimeout || timeout > maxTurnTimeout {
+		return 0, 0, dnderr.NewInvalidParameterError("input.TurnTimeout", fmt.Sprintf("must be 0 or between %d and %d", minTurnTimeout, maxTurnTimeout))
+	}
+
+	pingAfter := input.PingAfter
+	if pingAfter == 0 {
+		pingAfter = min(entities.DefaultPingAfter, timeout/2)
+	}
+
+	if pingAfter < 0 || pingAfter >= timeout {
+		return 0, 0, dnderr.NewInvalidParameterError("input.PingAfter", "must be less than the turn timeout")
+	}
+
+	return timeout, pingAfter, nil
+}
+
+func (m *Implementation) Get(ctx context.Context, id string) (*entities.Encounter, error) {
+	if id == "" {
+		return nil, dnderr.NewMissingParameterError("id")
+	}
+
+	return m.encounterRepo.Get(ctx, id)
+}
+
+// GetByChannel returns the latest encounter created in the channel
+func (m *Implementation) GetByChannel(ctx context.Context, channelID string) (*entities.Encounter, error) {
+	if channelID == "" {
+		return nil, dnderr.NewMissingParameterError("channelID")
+	}
+
+	return m.encounterRepo.GetByChannel(ctx, channelID)
+}
+
+// SetMessage stores the message that shows the encounter so it can be kept up to date
+func (m *Implementation) SetMessage(ctx context.Context, input *SetMessageInput) (*entities.Encounter, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.MessageID == "" {
+		return nil, dnderr.NewMissingParameterError("input.MessageID")
+	}
+
+	return m.update(ctx, input.EncounterID, func(existing *entities.Encounter) error {
+		existing.MessageID = input.MessageID
+		if input.ChannelID != "" {
+			existing.ChannelID = input.ChannelID
+		}
+
+		return nil
+	})
+}
+
+func (m *Implementation) Join(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	if err := checkPlayerInput(input); err != nil {
+		return nil, err
+	}
+
+	return m.update(ctx, input.EncounterID, func(existing *entities.Encounter) error {
+		return existing.Join(input.PlayerID)
+	})
+}
+
+func (m *Implementation) Leave(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	if err := checkPlayerInput(input); err != nil {
+		return nil, err
+	}
+
+	return m.update(ctx, input.EncounterID, func(existing *entities.Encounter) error {
+		return existing.Leave(input.PlayerID)
+	})
+}
+
+func (m *Implementation) Kick(ctx context.Context, input *KickInput) (*entities.Encounter, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	return m.update(ctx, input.EncounterID, func(existing *entities.Encounter) error {
+		return existing.Kick(input.GMID, input.PlayerID)
+	})
+}
+
+// ReadyCheck has the GM ask the players if they are ready to start
+func (m *Implementation) ReadyCheck(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	if err := checkPlayerInput(input); err != nil {
+		return nil, err
+	}
+
+	return m.update(ctx, input.EncounterID, func(existing *entities.Encounter) error {
+		return existing.StartReadyCheck(input.PlayerID)
+	})
+}
+
+func (m *Implementation) Ready(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	if err := checkPlayerInput(input); err != nil {
+		return nil, err
+	}
+
+	return m.update(ctx, input.EncounterID, func(existing *entities.Encounter) error {
+		return existing.MarkReady(input.PlayerID)
+	})
+}
+
+// Start records the fight the party was handed off to, check entities.Encounter.CanStart before
+// starting the fight
+func (m *Implementation) Start(ctx context.Context, input *StartInput) (*entities.Encounter, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.RoomID == "" {
+		return nil, dnderr.NewMissingParameterError("input.RoomID")
+	}
+
+	return m.update(ctx, input.EncounterID, func(existing *entities.Encounter) error {
+		err := existing.Start(input.GMID, input.RoomID)
+		if err != nil {
+			return err
+		}
+
+		existing.Surprised = input.Surprised
+
+		return nil
+	})
+}
+
+// Resolve marks the encounter's fight as over with how it ended
+func (m *Implementation) Resolve(ctx context.Context, id string, outcome string) (*entities.Encounter, error) {
+	return m.update(ctx, id, func(existing *entities.Encounter) error {
+		return existing.Resolve(outcome)
+	})
+}
+
+// Archive has the GM close the encounter
+func (m *Implementation) Archive(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	if err := checkPlayerInput(input); err != nil {
+		return nil, err
+	}
+
+	return m.update(ctx, input.EncounterID, func(existing *entities.Encounter) error {
+		return existing.Archive(input.PlayerID)
+	})
+}
+
+// End has the GM close the encounter, resolving the fight when it is still going
+func (m *Implementation) End(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	if err := checkPlayerInput(input); err != nil {
+		return nil, err
+	}
+
+	return m.update(ctx, input.EncounterID, func(existing *entities.Encounter) error {
+		return existing.End(input.PlayerID)
+	})
+}
+
+// update applies the change to the stored encounter and saves it when the change is allowed. A
+// save that lost a race with another change reloads the encounter and applies the change again.
+func (m *Implementation) update(ctx context.Context, id string, change func(existing *entities.Encounter) error) (*entities.Encounter, error) {
+	if id == "" {
+		return nil, dnderr.NewMissingParameterError("id")
+	}
+
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		var existing *entities.Encounter
+		existing, err = m.encounterRepo.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		err = change(existing)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err = m.encounterRepo.Update(ctx, existing)
+		if err == nil {
+			return existing, nil
+		}
+
+		var conflictErr *dnderr.ConflictError
+		if !errors.As(err, &conflictErr) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+func checkPlayerInput(input *PlayerInput) error {
+	if input == nil {
+		return dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package encounters
+
+import (
+	"errors"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+// storedEncounter has the GM waiting on two players to answer the ready check
+func (s *suiteManager) storedEncounter() *entities.Encounter {
+	existing := &entities.Encounter{
+		ID:         "encounter-id",
+		GMID:       "gm-id",
+		Status:     entities.EncounterStatusReadyCheck,
+		MaxPlayers: 4,
+		Players:    []string{"player-1", "player-2"},
+		Ready:      []string{"player-1"},
+	}
+
+	s.encounterRepo.On("Get", s.ctx, "encounter-id").Return(existing, nil)
+	s.encounterRepo.On("Update", s.ctx, existing).Return(existing, nil).Maybe()
+
+	return existing
+}
+
+func (s *suiteManager) TestCreate() {
+	s.encounterRepo.On("Create", s.ctx, mock.MatchedBy(func(created *entities.Encounter) bool {
+		return created.GMID == "gm-id" && created.ChannelID == "channel-id" &&
+			created.Status == entities.EncounterStatusOpen && created.MaxPlayers == entities.DefaultMaxPlayers &&
+			created.TurnTimeout == entities.DefaultTurnTimeout && created.PingAfter == entities.DefaultPingAfter
+	})).Return(&entities.Encounter{ID: "encounter-id"}, nil)
+
+	result, err := s.fixture.Create(s.ctx, &CreateInput{
+		GMID:      "gm-id",
+		ChannelID: "channel-id",
+	})
+	s.NoError(err)
+	s.Equal("encounter-id", result.ID)
+}
+
+func (s *suiteManager) TestCreateWithStealth() {
+	s.encounterRepo.On("Create", s.ctx, mock.MatchedBy(func(created *entities.Encounter) bool {
+		return created.Stealth
+	})).Return(&entities.Encounter{ID: "sneaky"}, nil)
+
+	result, err := s.fixture.Create(s.ctx, &CreateInput{GMID: "gm-id", Stealth: true})
+	s.NoError(err)
+	s.Equal("sneaky", result.ID)
+}
+
+func (s *suiteManager) TestCreateInvalidSize() {
+	_, err := s.fixture.Create(s.ctx, &CreateInput{
+		GMID:       "gm-id",
+		MaxPlayers: maxPlayers + 1,
+	})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	_, err = s.fixture.Create(s.ctx, &CreateInput{})
+	s.IsType(&dnderr.MissingParameterError{}, err)
+}
+
+func (s *suiteManager) TestCreateTurnTimer() {
+	s.encounterRepo.On("Create", s.ctx, mock.MatchedBy(func(created *entities.Encounter) bool {
+		return created.TurnTimeout == 30 && created.PingAfter == 15
+	})).Return(&entities.Encounter{ID: "short"}, nil)
+	s.encounterRepo.On("Create", s.ctx, mock.MatchedBy(func(created *entities.Encounter) bool {
+		return created.TurnTimeout == 0 && created.PingAfter == 0
+	})).Return(&entities.Encounter{ID: "off"}, nil)
+
+	short, off := 30, 0
+	result, err := s.fixture.Create(s.ctx, &CreateInput{GMID: "gm-id", TurnTimeout: &short})
+	s.NoError(err)
+	s.Equal("short", result.ID)
+
+	result, err = s.fixture.Create(s.ctx, &CreateInput{GMID: "gm-id", TurnTimeout: &off, PingAfter: 10})
+	s.NoError(err)
+	s.Equal("off", result.ID)
+
+	_, err = s.fixture.Create(s.ctx, &CreateInput{GMID: "gm-id", TurnTimeout: &short, PingAfter: 30})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	tooShort := minTurnTimeout - 1
+	_, err = s.fixture.Create(s.ctx, &CreateInput{GMID: "gm-id", TurnTimeout: &tooShort})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+}
+
+func (s *suiteManager) TestJoin() {
+	existing := s.storedEncounter()
+
+	result, err := s.fixture.Join(s.ctx, &PlayerInput{EncounterID: "encounter-id", PlayerID: "player-3"})
+	s.NoError(err)
+	s.Equal([]string{"player-1", "player-2", "player-3"}, result.Players)
+	s.encounterRepo.AssertCalled(s.T(), "Update", s.ctx, existing)
+}
+
+func (s *suiteManager) TestJoinRefusedIsNotSaved() {
+	s.storedEncounter()
+
+	_, err := s.fixture.Join(s.ctx, &PlayerInput{EncounterID: "encounter-id", PlayerID: "player-1"})
+	s.IsType(&dnderr.AlreadyExistsError{}, err)
+	s.encounterRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestKickOnlyByGM() {
+	s.storedEncounter()
+
+	_, err := s.fixture.Kick(s.ctx, &KickInput{EncounterID: "encounter-id", GMID: "player-1", PlayerID: "player-2"})
+	s.IsType(&dnderr.PermissionDeniedError{}, err)
+
+	result, err := s.fixture.Kick(s.ctx, &KickInput{EncounterID: "encounter-id", GMID: "gm-id", PlayerID: "player-2"})
+	s.NoError(err)
+	s.Equal([]string{"player-1"}, result.Players)
+}
+
+func (s *suiteManager) TestStart() {
+	s.storedEncounter()
+
+	_, err := s.fixture.Start(s.ctx, &StartInput{EncounterID: "encounter-id", GMID: "gm-id", RoomID: "room-id"})
+	s.IsType(&dnderr.ConflictError{}, err)
+
+	_, err = s.fixture.Ready(s.ctx, &PlayerInput{EncounterID: "encounter-id", PlayerID: "player-2"})
+	s.NoError(err)
+
+	result, err := s.fixture.Start(s.ctx, &StartInput{EncounterID: "encounter-id", GMID: "gm-id", RoomID: "room-id"})
+	s.NoError(err)
+	s.Equal(entities.EncounterStatusInProgress, result.Status)
+	s.Equal("room-id", result.RoomID)
+}
+
+func (s *suiteManager) TestStartWithSurprise() {
+	existing := s.storedEncounter()
+	existing.Stealth = true
+	existing.Ready = existing.Players
+
+	result, err := s.fixture.Start(s.ctx, &StartInput{
+		EncounterID: "encounter-id",
+		GMID:        "gm-id",
+		RoomID:      "room-id",
+		Surprised:   []string{"Goblin 1", "Goblin 2"},
+	})
+	s.NoError(err)
+	s.Equal([]string{"Goblin 1", "Goblin 2"}, result.Surprised)
+}
+
+func (s *suiteManager) TestResolveThenArchive() {
+	existing := s.storedEncounter()
+	existing.Status = entities.EncounterStatusInProgress
+
+	_, err := s.fixture.Archive(s.ctx, &PlayerInput{EncounterID: "encounter-id", PlayerID: "gm-id"})
+	s.IsType(&dnderr.ConflictError{}, err)
+
+	resolved, err := s.fixture.Resolve(s.ctx, "encounter-id", "defeat")
+	s.NoError(err)
+	s.Equal("defeat", resolved.Outcome)
+
+	result, err := s.fixture.Archive(s.ctx, &PlayerInput{EncounterID: "encounter-id", PlayerID: "gm-id"})
+	s.NoError(err)
+	s.Equal(entities.EncounterStatusArchived, result.Status)
+}
+
+func (s *suiteManager) TestGetByChannel() {
+	existing := &entities.Encounter{ID: "encounter-id"}
+	s.encounterRepo.On("GetByChannel", s.ctx, "channel-id").Return(existing, nil)
+
+	result, err := s.fixture.GetByChannel(s.ctx, "channel-id")
+	s.NoError(err)
+	s.Equal(existing, result)
+}
+
+func (s *suiteManager) TestEnd() {
+	existing := s.storedEncounter()
+	existing.Status = entities.EncounterStatusInProgress
+
+	result, err := s.fixture.End(s.ctx, &PlayerInput{EncounterID: "encounter-id", PlayerID: "gm-id"})
+	s.NoError(err)
+	s.Equal(entities.EncounterStatusArchived, result.Status)
+}
+
+func (s *suiteManager) TestUpdateNotFound() {
+	s.encounterRepo.On("Get", s.ctx, "missing-id").Return(nil, dnderr.NewNotFoundError("encounter not found"))
+
+	_, err := s.fixture.Leave(s.ctx, &PlayerInput{EncounterID: "missing-id", PlayerID: "player-1"})
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *suiteManager) TestUpdateRetriesAfterConflict() {
+	stale := &entities.Encounter{ID: "encounter-id", Status: entities.EncounterStatusOpen, Players: []string{}}
+	fresh := &entities.Encounter{ID: "encounter-id", Status: entities.EncounterStatusOpen, Players: []string{"player-2"}, Version: 1}
+	s.encounterRepo.On("Get", s.ctx, "encounter-id").Return(stale, nil).Once()
+	s.encounterRepo.On("Get", s.ctx, "encounter-id").Return(fresh, nil).Once()
+	s.encounterRepo.On("Update", s.ctx, stale).Return(nil, dnderr.NewConflictError("encounter was updated")).Once()
+	s.encounterRepo.On("Update", s.ctx, fresh).Return(fresh, nil).Once()
+
+	result, err := s.fixture.Join(s.ctx, &PlayerInput{EncounterID: "encounter-id", PlayerID: "player-1"})
+	s.NoError(err)
+	s.Equal([]string{"player-2", "player-1"}, result.Players)
+	s.encounterRepo.AssertExpectations(s.T())
+}
+
+func (s *suiteManager) TestUpdateRepoError() {
+	existing := &entities.Encounter{ID: "encounter-id", Players: []string{}}
+	expected := errors.New("redis down")
+	s.encounterRepo.On("Get", s.ctx, "encounter-id").Return(existing, nil)
+	s.encounterRepo.On("Update", s.ctx, existing).Return(nil, expected)
+
+	_, err := s.fixture.Join(s.ctx, &PlayerInput{EncounterID: "encounter-id", PlayerID: "player-1"})
+	s.Equal(expected, err)
+}
@@ -0,0 +1,193 @@
+package encounters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/clients/dnd5e"
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/encounter"
+)
+
+const (
+	defaultMaxMonsters = 6
+	// maxLookups is how many monsters of a rating are fetched looking for one that can attack
+	maxLookups = 5
+)
+
+type Implementation struct {
+	client        dnd5e.Client
+	encounterRepo encounter.Repository
+	roller        dice.Roller
+}
+
+type Config struct {
+	Client        dnd5e.Client
+	EncounterRepo encounter.Repository
+}
+
+func New(cfg *Config) (*Implementation, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	if cfg.EncounterRepo == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.EncounterRepo")
+	}
+
+	return &Implementation{
+		client:        cfg.Client,
+		encounterRepo: cfg.EncounterRepo,
+		roller:        &dice.DefaultRoller{},
+	}, nil
+}
+
+// shape is a group of monsters of the same challenge rating
+type shape struct {
+	count      int
+	rating     challengeRating
+	adjustedXP int
+}
+
+// Build picks a group of monsters whose adjusted XP falls between the threshold of the difficulty
+// below and the budget for the party. Each group size gets the highest challenge rating that fits,
+// one of the sizes that lands in range is picked at random.
+func (m *Implementation) Build(ctx context.Context, input *BuildInput) (*BuildOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	budget, err := Budget(input.Difficulty, input.PartyLevels)
+	if err != nil {
+		return nil, err
+	}
+
+	maxMonsters := input.MaxMonsters
+	if maxMonsters <= 0 {
+		maxMonsters = defaultMaxMonsters
+	}
+
+	shapes := buildShapes(budget, floorFor(input.Difficulty, input.PartyLevels, budget), len(input.PartyLevels), maxMonsters)
+
+	picked := shapes[0]
+	if len(shapes) > 1 {
+		roll, err := m.roller.Roll(1, len(shapes), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		picked = shapes[roll.Total-1]
+	}
+
+	template, err := m.pickMonster(picked.rating.Rating)
+	if err != nil {
+		return nil, err
+	}
+
+	xp := template.XP
+	if xp == 0 {
+		xp = picked.rating.XP
+	}
+
+	monsters := make([]*entities.MonsterTemplate, picked.count)
+	for idx := range monsters {
+		monsters[idx] = template
+	}
+
+	return &BuildOutput{
+		Difficulty: input.Difficulty,
+		Budget:     budget,
+		Monsters:   monsters,
+		XP:         xp * picked.count,
+		AdjustedXP: int(float64(xp*picked.count) * Multiplier(picked.count, len(input.PartyLevels))),
+	}, nil
+}
+
+// floorFor is the lowest adjusted XP that still counts as the difficulty, the threshold of the
+// difficulty below or half the budget for easy
+func floorFor(difficulty Difficulty, levels []int, budget int) int {
+	for idx, d := range Difficulties {
+		if d != difficulty || idx == 0 {
+			continue
+		}
+
+		floor, err := Budget(Difficulties[idx-1], levels)
+		if err == nil {
+			return floor
+		}
+	}
+
+	return budget / 2
+}
+
+// buildShapes finds the highest challenge rating for each group size that stays within the budget,
+// keeping the ones at or above the floor. A single monster of the lowest rating is returned when
+// nothing lands in range.
+func buildShapes(budget, floor, partySize, maxMonsters int) []*shape {
+	shapes := make([]*shape, 0)
+	for count := 1; count <= maxMonsters; count++ {
+		multiplier := Multiplier(count, partySize)
+
+		var best *shape
+		for _, rating := range challengeRatings {
+			adjusted := int(float64(rating.XP*count) * multiplier)
+			if adjusted > budget {
+				break
+			}
+
+			best = &shape{count: count, rating: rating, adjustedXP: adjusted}
+		}
+
+		if best != nil && best.adjustedXP >= floor {
+			shapes = append(shapes, best)
+		}
+	}
+
+	if len(shapes) == 0 {
+		lowest := challengeRatings[0]
+		shapes = append(shapes, &shape{
+			count:      1,
+			rating:     lowest,
+			adjustedXP: int(float64(lowest.XP) * Multiplier(1, partySize)),
+		})
+	}
+
+	return shapes
+}
+
+// pickMonster fetches random monsters of the rating until it finds one that can attack
+func (m *Implementation) pickMonster(rating float32) (*entities.MonsterTemplate, error) {
+	listed, err := m.client.ListMonstersByChallengeRating(rating)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := append([]*entities.ReferenceItem{}, listed...)
+
+	for range min(maxLookups, len(candidates)) {
+		roll, err := m.roller.Roll(1, len(candidates), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		idx := roll.Total - 1
+		template, err := m.client.GetMonster(candidates[idx].Key)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(template.AttackPlan()) > 0 {
+			return template, nil
+		}
+
+		candidates = append(candidates[:idx], candidates[idx+1:]...)
+	}
+
+	return nil, dnderr.NewNotFoundError(fmt.Sprintf("no monster that can attack found for challenge rating %s", FormatChallengeRating(rating)))
+}
@@ -0,0 +1,174 @@
+package encounters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/clients/dnd5e"
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/encounter"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteManager struct {
+	suite.Suite
+
+	ctx           context.Context
+	client        *dnd5e.Mock
+	encounterRepo *encounter.Mock
+	roller        *dice.MockRoller
+	fixture       *Implementation
+
+	goblin *entities.MonsterTemplate
+}
+
+func (s *suiteManager) SetupTest() {
+	s.ctx = context.Background()
+	s.client = &dnd5e.Mock{}
+	s.encounterRepo = &encounter.Mock{}
+	s.roller = &dice.MockRoller{}
+
+	s.fixture = &Implementation{
+		client:        s.client,
+		encounterRepo: s.encounterRepo,
+		roller:        s.roller,
+	}
+
+	s.goblin = &entities.MonsterTemplate{
+		Key:             "goblin",
+		Name:            "Goblin",
+		ChallengeRating: 0.25,
+		XP:              50,
+		Actions: []*entities.MonsterAction{{
+			Name:        "Scimitar",
+			AttackBonus: 4,
+			Damage:      []*damage.Damage{{DiceCount: 1, DiceSize: 6, Bonus: 2, DamageType: damage.TypeSlashing}},
+		}},
+	}
+}
+
+func (s *suiteManager) TestBudget() {
+	budget, err := Budget(DifficultyHard, []int{1, 3, 5})
+	s.NoError(err)
+	s.Equal(75+225+750, budget)
+
+	_, err = Budget("impossible", []int{1})
+	s.Error(err)
+
+	_, err = Budget(DifficultyEasy, []int{21})
+	s.Error(err)
+}
+
+func (s *suiteManager) TestMultiplier() {
+	s.Equal(1.5, Multiplier(1, 1))
+	s.Equal(1.0, Multiplier(1, 4))
+	s.Equal(2.0, Multiplier(4, 4))
+	s.Equal(1.5, Multiplier(4, 6))
+	s.Equal(5.0, Multiplier(15, 2))
+	s.Equal(0.5, Multiplier(1, 6))
+}
+
+func (s *suiteManager) TestBuildSingleMonster() {
+	// a level 1 character has 50 XP for medium, a 1.5 multiplier leaves room for CR 1/8 at 37 XP
+	// which is above the easy threshold of 25
+	s.client.On("ListMonstersByChallengeRating", []float32{0.125}).Return([]*entities.ReferenceItem{
+		{Key: "kobold"},
+	}, nil)
+	kobold := &entities.MonsterTemplate{Key: "kobold", XP: 25, Actions: s.goblin.Actions}
+	s.client.On("GetMonster", "kobold").Return(kobold, nil)
+	s.roller.On("Roll", 1, 1, 0).Return(&dice.RollResult{Total: 1}, nil)
+
+	result, err := s.fixture.Build(s.ctx, &BuildInput{
+		Difficulty:  DifficultyMedium,
+		PartyLevels: []int{1},
+		MaxMonsters: 1,
+	})
+	s.NoError(err)
+	s.Equal(50, result.Budget)
+	s.Equal([]*entities.MonsterTemplate{kobold}, result.Monsters)
+	s.Equal(25, result.XP)
+	s.Equal(37, result.AdjustedXP)
+}
+
+func (s *suiteManager) TestBuildGroup() {
+	// four level 3 characters have 900 XP for hard and 600 for medium, the group sizes in range are
+	// 1 x CR 3, 2 x CR 1, 3 x CR 1/2, 4 x CR 1/2 and 6 x CR 1/4
+	s.roller.On("Roll", 1, 5, 0).Return(&dice.RollResult{Total: 4}, nil)
+	s.client.On("ListMonstersByChallengeRating", []float32{0.5}).Return([]*entities.ReferenceItem{
+		{Key: "orc"},
+	}, nil)
+	orc := &entities.MonsterTemplate{Key: "orc", XP: 100, ChallengeRating: 0.5, Actions: s.goblin.Actions}
+	s.client.On("GetMonster", "orc").Return(orc, nil)
+	s.roller.On("Roll", 1, 1, 0).Return(&dice.RollResult{Total: 1}, nil)
+
+	result, err := s.fixture.Build(s.ctx, &BuildInput{
+		Difficulty:  DifficultyHard,
+		PartyLevels: []int{3, 3, 3, 3},
+	})
+	s.NoError(err)
+	s.Equal(900, result.Budget)
+	s.Equal([]*entities.MonsterTemplate{orc, orc, orc, orc}, result.Monsters)
+	s.Equal(400, result.XP)
+	s.Equal(800, result.AdjustedXP)
+}
+
+func (s *suiteManager) TestBuildSkipsMonstersWithoutAttacks() {
+	s.client.On("ListMonstersByChallengeRating", []float32{0.125}).Return([]*entities.ReferenceItem{
+		{Key: "blob"}, {Key: "kobold"},
+	}, nil)
+	blob := &entities.MonsterTemplate{Key: "blob", XP: 25}
+	kobold := &entities.MonsterTemplate{Key: "kobold", XP: 25, Actions: s.goblin.Actions}
+	s.client.On("GetMonster", "blob").Return(blob, nil)
+	s.client.On("GetMonster", "kobold").Return(kobold, nil)
+	s.roller.On("Roll", 1, 2, 0).Return(&dice.RollResult{Total: 1}, nil)
+	s.roller.On("Roll", 1, 1, 0).Return(&dice.RollResult{Total: 1}, nil)
+
+	result, err := s.fixture.Build(s.ctx, &BuildInput{
+		Difficulty:  DifficultyMedium,
+		PartyLevels: []int{1},
+		MaxMonsters: 1,
+	})
+	s.NoError(err)
+	s.Equal([]*entities.MonsterTemplate{kobold}, result.Monsters)
+}
+
+func (s *suiteManager) TestBuildNoMonsterCanAttack() {
+	s.client.On("ListMonstersByChallengeRating", []float32{0.125}).Return([]*entities.ReferenceItem{
+		{Key: "blob"},
+	}, nil)
+	s.client.On("GetMonster", "blob").Return(&entities.MonsterTemplate{Key: "blob"}, nil)
+	s.roller.On("Roll", 1, 1, 0).Return(&dice.RollResult{Total: 1}, nil)
+
+	_, err := s.fixture.Build(s.ctx, &BuildInput{
+		Difficulty:  DifficultyMedium,
+		PartyLevels: []int{1},
+		MaxMonsters: 1,
+	})
+	s.Error(err)
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *suiteManager) TestBuildClientError() {
+	expected := errors.New("api down")
+	s.client.On("ListMonstersByChallengeRating", []float32{0.125}).Return(nil, expected)
+
+	_, err := s.fixture.Build(s.ctx, &BuildInput{
+		Difficulty:  DifficultyMedium,
+		PartyLevels: []int{1},
+		MaxMonsters: 1,
+	})
+	s.Equal(expected, err)
+}
+
+func (s *suiteManager) TestBuildMissingLevels() {
+	_, err := s.fixture.Build(s.ctx, &BuildInput{Difficulty: DifficultyEasy})
+	s.Error(err)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, new(suiteManager))
+}
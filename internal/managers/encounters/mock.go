@@ -0,0 +1,152 @@
+package encounters
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Build(ctx context.Context, input *BuildInput) (*BuildOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*BuildOutput), nil
+}
+
+func (m *Mock) Create(ctx context.Context, input *CreateInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Get(ctx context.Context, id string) (*entities.Encounter, error) {
+	args := m.Called(ctx, id)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) SetMessage(ctx context.Context, input *SetMessageInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Join(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Leave(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Kick(ctx context.Context, input *KickInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) ReadyCheck(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Ready(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Start(ctx context.Context, input *StartInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Archive(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Resolve(ctx context.Context, id string, outcome string) (*entities.Encounter, error) {
+	args := m.Called(ctx, id, outcome)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) GetByChannel(ctx context.Context, channelID string) (*entities.Encounter, error) {
+	args := m.Called(ctx, channelID)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) End(ctx context.Context, input *PlayerInput) (*entities.Encounter, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
@@ -0,0 +1,120 @@
+package encounters
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+)
+
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+	DifficultyDeadly Difficulty = "deadly"
+)
+
+var Difficulties = []Difficulty{DifficultyEasy, DifficultyMedium, DifficultyHard, DifficultyDeadly}
+
+// xpThresholds are the XP thresholds by character level from the DMG, indexed by level - 1
+var xpThresholds = map[Difficulty][20]int{
+	DifficultyEasy:   {25, 50, 75, 125, 250, 300, 350, 450, 550, 600, 800, 1000, 1100, 1250, 1400, 1600, 2000, 2100, 2400, 2800},
+	DifficultyMedium: {50, 100, 150, 250, 500, 600, 750, 900, 1100, 1200, 1600, 2000, 2200, 2500, 2800, 3200, 3900, 4200, 4900, 5700},
+	DifficultyHard:   {75, 150, 225, 375, 750, 900, 1100, 1400, 1600, 1900, 2400, 3000, 3400, 3800, 4300, 4800, 5900, 6300, 7300, 8500},
+	DifficultyDeadly: {100, 200, 400, 500, 1100, 1400, 1700, 2100, 2400, 2800, 3600, 4500, 5100, 5700, 6400, 7200, 8800, 9500, 10900, 12700},
+}
+
+// challengeRating is a challenge rating and the XP a monster of it is worth
+type challengeRating struct {
+	Rating float32
+	XP     int
+}
+
+// challengeRatings are the ratings the builder picks from, CR 0 is left out as most of those
+// monsters cannot attack
+var challengeRatings = []challengeRating{
+	{0.125, 25}, {0.25, 50}, {0.5, 100}, {1, 200}, {2, 450}, {3, 700}, {4, 1100}, {5, 1800},
+	{6, 2300}, {7, 2900}, {8, 3900}, {9, 5000}, {10, 5900}, {11, 7200}, {12, 8400}, {13, 10000},
+	{14, 11500}, {15, 13000}, {16, 15000}, {17, 18000}, {18, 20000}, {19, 22000}, {20, 25000},
+	{21, 33000}, {22, 41000}, {23, 50000}, {24, 62000}, {25, 75000}, {26, 90000}, {27, 105000},
+	{28, 120000}, {29, 135000}, {30, 155000},
+}
+
+// multipliers are applied to the monster XP for the number of monsters
+var multipliers = []float64{0.5, 1, 1.5, 2, 2.5, 3, 4, 5}
+
+// Budget adds up the XP threshold of each character for the difficulty
+func Budget(difficulty Difficulty, levels []int) (int, error) {
+	thresholds, ok := xpThresholds[difficulty]
+	if !ok {
+		return 0, dnderr.NewInvalidParameterError("difficulty", fmt.Sprintf("must be one of %v", Difficulties))
+	}
+
+	if len(levels) == 0 {
+		return 0, dnderr.NewMissingParameterError("levels")
+	}
+
+	budget := 0
+	for _, level := range levels {
+		if level < 1 || level > len(thresholds) {
+			return 0, dnderr.NewInvalidParameterError("levels", fmt.Sprintf("level %d is not between 1 and %d", level, len(thresholds)))
+		}
+
+		budget += thresholds[level-1]
+	}
+
+	return budget, nil
+}
+
+// Multiplier is the multiple monster multiplier from the DMG. Parties of fewer than three use the
+// next multiplier up and parties of six or more the next one down.
+func Multiplier(monsterCount, partySize int) float64 {
+	idx := 1
+	switch {
+	case monsterCount >= 15:
+		idx = 6
+	case monsterCount >= 11:
+		idx = 5
+	case monsterCount >= 7:
+		idx = 4
+	case monsterCount >= 3:
+		idx = 3
+	case monsterCount == 2:
+		idx = 2
+	}
+
+	switch {
+	case partySize < 3:
+		idx++
+	case partySize >= 6:
+		idx--
+	}
+
+	return multipliers[idx]
+}
+
+// ChallengeRatingXP returns the XP for a challenge rating, 0 if it is not in the table
+func ChallengeRatingXP(rating float32) int {
+	for _, cr := range challengeRatings {
+		if cr.Rating == rating {
+			return cr.XP
+		}
+	}
+
+	return 0
+}
+
+// FormatChallengeRating writes fractional ratings the way the SRD does
+func FormatChallengeRating(rating float32) string {
+	switch rating {
+	case 0.125:
+		return "1/8"
+	case 0.25:
+		return "1/4"
+	case 0.5:
+		return "1/2"
+	default:
+		return fmt.Sprintf("%v", rating)
+	}
+}
@@ -0,0 +1,43 @@
+package loot
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+type Manager interface {
+	// Individual rolls the coins carried by each defeated monster
+	Individual(ctx context.Context, input *IndividualInput) (*entities.Loot, error)
+	// Hoard rolls a treasure hoard of coins and equipment
+	Hoard(ctx context.Context, input *HoardInput) (*entities.Loot, error)
+	Choose(ctx context.Context, input *ChooseInput) (*ChooseOutput, error)
+	Get(ctx context.Context, id string) (*entities.Loot, error)
+}
+
+type IndividualInput struct {
+	PlayerIDs []string
+	Mode      entities.LootMode
+	// ChallengeRatings has the rating of each defeated monster
+	ChallengeRatings []float32
+}
+
+type HoardInput struct {
+	PlayerIDs []string
+	Mode      entities.LootMode
+	// ChallengeRating picks the hoard table, use the party level for a treasure room
+	ChallengeRating float32
+}
+
+type ChooseInput struct {
+	LootID   string
+	PlayerID string
+	Index    int
+	Choice   entities.LootChoice
+}
+
+type ChooseOutput struct {
+	Loot *entities.Loot
+	// Item is set once everyone has chosen and the item is handed out
+	Item *entities.LootItem
+}
@@ -0,0 +1,293 @@
+package loot
+
+import (
+	"context"
+	"errors"
+
+	"github.com/KirkDiggler/dnd-bot-go/clients/dnd5e"
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/characters"
+	lootRepository "github.com/KirkDiggler/dnd-bot-go/internal/repositories/loot"
+)
+
+const (
+	// maxHoardItems is the most pieces of equipment found in one hoard
+	maxHoardItems = 3
+	// maxUpdateAttempts is how many times Choose will reload the loot after a conflict
+	maxUpdateAttempts = 3
+)
+
+type Implementation struct {
+	client           dnd5e.Client
+	characterManager characters.Manager
+	lootRepo         lootRepository.Repository
+	roller           dice.Roller
+}
+
+type Config struct {
+	Client           dnd5e.Client
+	CharacterManager characters.Manager
+	LootRepo         lootRepository.Repository
+}
+
+func New(cfg *Config) (*Implementation, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	if cfg.CharacterManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.CharacterManager")
+	}
+
+	if cfg.LootRepo == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.LootRepo")
+	}
+
+	return &Implementation{
+		client:           cfg.Client,
+		characterManager: cfg.CharacterManager,
+		lootRepo:         cfg.LootRepo,
+		roller:           &dice.DefaultRoller{},
+	}, nil
+}
+
+func (m *Implementation) Individual(ctx context.Context, input *IndividualInput) (*entities.Loot, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if len(input.PlayerIDs) == 0 {
+		return nil, dnderr.NewMissingParameterError("input.PlayerIDs")
+	}
+
+	coins := &entities.Coins{}
+	for _, rating := range input.ChallengeRatings {
+		roll, err := m.roller.Roll(1, 100, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range tierFor(rating).individual {
+			if roll.Total > row.upTo {
+				continue
+			}
+
+			err = m.rollCoins(coins, row.coins)
+			if err != nil {
+				return nil, err
+			}
+
+			break
+		}
+	}
+
+	return m.share(ctx, &entities.Loot{
+		PlayerIDs: input.PlayerIDs,
+		Mode:      input.Mode,
+		Coins:     coins,
+		Items:     []*entities.LootItem{},
+	})
+}
+
+func (m *Implementation) Hoard(ctx context.Context, input *HoardInput) (*entities.Loot, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if len(input.PlayerIDs) == 0 {
+		return nil, dnderr.NewMissingParameterError("input.PlayerIDs")
+	}
+
+	t := tierFor(input.ChallengeRating)
+
+	coins := &entities.Coins{}
+	err := m.rollCoins(coins, t.hoard)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := m.roller.Roll(1, 4, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*entities.LootItem, 0, maxHoardItems)
+	for range min(count.Total, maxHoardItems) {
+		pick, err := m.roller.Roll(1, len(t.items), -1)
+		if err != nil {
+			return nil, err
+		}
+
+		equipment, err := m.client.GetEquipment(t.items[pick.Total])
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, &entities.LootItem{
+			Key:  equipment.GetKey(),
+			Name: equipment.GetName(),
+		})
+	}
+
+	return m.share(ctx, &entities.Loot{
+		PlayerIDs: input.PlayerIDs,
+		Mode:      input.Mode,
+		Coins:     coins,
+		Items:     items,
+	})
+}
+
+// Choose records a need, greed or pass on an item. A d100 is rolled for need and greed, once
+// everyone has chosen the item goes into the winner's inventory.
+func (m *Implementation) Choose(ctx context.Context, input *ChooseInput) (*ChooseOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.LootID == "" {
+		return nil, dnderr.NewMissingParameterError("input.LootID")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	switch input.Choice {
+	case entities.LootChoiceNeed, entities.LootChoiceGreed, entities.LootChoicePass:
+	default:
+		return nil, dnderr.NewInvalidParameterError("input.Choice", "must be need, greed or pass")
+	}
+
+	// the choice is saved before the item goes to the winner, a choice that lost a race with
+	// another player's is reapplied to the loot they saved
+	roll := 0
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		var loot *entities.Loot
+		loot, err = m.lootRepo.Get(ctx, input.LootID)
+		if err != nil {
+			return nil, err
+		}
+
+		if loot.Mode != entities.LootModeNeedGreed {
+			return nil, dnderr.NewConflictError("the items were handed out in turn")
+		}
+
+		// the d100 is rolled once, a retry keeps the roll the player already made
+		if roll == 0 && input.Choice != entities.LootChoicePass {
+			var result *dice.RollResult
+			result, err = m.roller.Roll(1, 100, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			roll = result.Total
+		}
+
+		var item *entities.LootItem
+		item, err = loot.Choose(input.PlayerID, input.Index, input.Choice, roll)
+		if err != nil {
+			return nil, err
+		}
+
+		loot, err = m.lootRepo.Update(ctx, loot)
+		if err != nil {
+			var conflictErr *dnderr.ConflictError
+			if errors.As(err, &conflictErr) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		if item != nil && item.WinnerID != "" {
+			err = m.award(ctx, item.WinnerID, item.Key)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return &ChooseOutput{
+			Loot: loot,
+			Item: item,
+		}, nil
+	}
+
+	return nil, err
+}
+
+func (m *Implementation) Get(ctx context.Context, id string) (*entities.Loot, error) {
+	if id == "" {
+		return nil, dnderr.NewMissingParameterError("id")
+	}
+
+	return m.lootRepo.Get(ctx, id)
+}
+
+func (m *Implementation) rollCoins(coins *entities.Coins, rolls []coinRoll) error {
+	for _, coin := range rolls {
+		result, err := m.roller.Roll(coin.dice, 6, 0)
+		if err != nil {
+			return err
+		}
+
+		*coin.coin(coins) += result.Total * coin.multiplier
+	}
+
+	return nil
+}
+
+// share splits the coins between the players and, for round robin, hands the items out in
+// turn before saving the loot
+func (m *Implementation) share(ctx context.Context, loot *entities.Loot) (*entities.Loot, error) {
+	if loot.Mode == "" {
+		loot.Mode = entities.LootModeNeedGreed
+	}
+
+	if !loot.Coins.IsZero() {
+		for idx, purse := range loot.Coins.Split(len(loot.PlayerIDs)) {
+			if purse.IsZero() {
+				continue
+			}
+
+			_, err := m.characterManager.Update(ctx, loot.PlayerIDs[idx], func(char *entities.Character) error {
+				char.Coins.Add(purse)
+
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if loot.Mode == entities.LootModeRoundRobin {
+		for idx, item := range loot.Items {
+			item.WinnerID = loot.PlayerIDs[idx%len(loot.PlayerIDs)]
+			item.Awarded = true
+
+			err := m.award(ctx, item.WinnerID, item.Key)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return m.lootRepo.Create(ctx, loot)
+}
+
+func (m *Implementation) award(ctx context.Context, playerID, key string) error {
+	_, err := m.characterManager.Update(ctx, playerID, func(char *entities.Character) error {
+		_, err := m.characterManager.AddInventory(ctx, char, key)
+
+		return err
+	})
+
+	return err
+}
@@ -0,0 +1,262 @@
+package loot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/clients/dnd5e"
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/characters"
+	lootRepository "github.com/KirkDiggler/dnd-bot-go/internal/repositories/loot"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteManager struct {
+	suite.Suite
+
+	ctx              context.Context
+	client           *dnd5e.Mock
+	characterManager *characters.Mock
+	lootRepo         *lootRepository.Mock
+	roller           *dice.MockRoller
+	fixture          *Implementation
+
+	fighter *entities.Character
+	wizard  *entities.Character
+	// created is the loot passed to the repo
+	created *entities.Loot
+}
+
+func (s *suiteManager) SetupTest() {
+	s.ctx = context.Background()
+	s.client = &dnd5e.Mock{}
+	s.characterManager = &characters.Mock{}
+	s.lootRepo = &lootRepository.Mock{}
+	s.roller = &dice.MockRoller{}
+
+	s.fixture = &Implementation{
+		client:           s.client,
+		characterManager: s.characterManager,
+		lootRepo:         s.lootRepo,
+		roller:           s.roller,
+	}
+
+	s.fighter = &entities.Character{ID: "player-1", Name: "Fighter"}
+	s.wizard = &entities.Character{ID: "player-2", Name: "Wizard"}
+}
+
+func (s *suiteManager) expectCreate() {
+	s.lootRepo.On("Create", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		s.created = args.Get(1).(*entities.Loot)
+	}).Return(&entities.Loot{ID: "loot-1"}, nil)
+}
+
+func (s *suiteManager) TestNew() {
+	_, err := New(&Config{Client: s.client, CharacterManager: s.characterManager})
+	s.IsType(&dnderr.MissingParameterError{}, err)
+
+	result, err := New(&Config{Client: s.client, CharacterManager: s.characterManager, LootRepo: s.lootRepo})
+	s.NoError(err)
+	s.NotNil(result)
+}
+
+func (s *suiteManager) TestIndividualSplitsCoins() {
+	s.roller.On("Roll", 1, 100, 0).Return(&dice.RollResult{Total: 80}, nil)
+	s.roller.On("Roll", 3, 6, 0).Return(&dice.RollResult{Total: 11}, nil)
+	s.characterManager.On("Update", s.ctx, s.fighter.ID, mock.Anything).Return(s.fighter, nil)
+	s.characterManager.On("Update", s.ctx, s.wizard.ID, mock.Anything).Return(s.wizard, nil)
+	s.expectCreate()
+
+	result, err := s.fixture.Individual(s.ctx, &IndividualInput{
+		PlayerIDs:        []string{s.fighter.ID, s.wizard.ID},
+		ChallengeRatings: []float32{0.25, 0.25},
+	})
+	s.NoError(err)
+	s.Equal("loot-1", result.ID)
+
+	result = s.created
+	s.Equal(entities.LootModeNeedGreed, result.Mode)
+	s.Equal(&entities.Coins{Gold: 22}, result.Coins)
+	s.Empty(result.Items)
+	s.Equal(11, s.fighter.Coins.Gold)
+	s.Equal(11, s.wizard.Coins.Gold)
+}
+
+func (s *suiteManager) TestIndividualUsesTier() {
+	s.roller.On("Roll", 1, 100, 0).Return(&dice.RollResult{Total: 50}, nil)
+	s.roller.On("Roll", 6, 6, 0).Return(&dice.RollResult{Total: 20}, nil)
+	s.roller.On("Roll", 2, 6, 0).Return(&dice.RollResult{Total: 7}, nil)
+	s.characterManager.On("Update", s.ctx, s.fighter.ID, mock.Anything).Return(s.fighter, nil)
+	s.expectCreate()
+
+	_, err := s.fixture.Individual(s.ctx, &IndividualInput{
+		PlayerIDs:        []string{s.fighter.ID},
+		ChallengeRatings: []float32{6},
+	})
+	s.NoError(err)
+	s.Equal(&entities.Coins{Silver: 200, Gold: 70}, s.created.Coins)
+}
+
+func (s *suiteManager) TestHoardRoundRobin() {
+	s.roller.On("Roll", 6, 6, 0).Return(&dice.RollResult{Total: 20}, nil)
+	s.roller.On("Roll", 3, 6, 0).Return(&dice.RollResult{Total: 10}, nil)
+	s.roller.On("Roll", 2, 6, 0).Return(&dice.RollResult{Total: 7}, nil)
+	s.roller.On("Roll", 1, 4, -1).Return(&dice.RollResult{Total: 2}, nil)
+	s.roller.On("Roll", 1, 8, -1).Return(&dice.RollResult{Total: 1}, nil).Once()
+	s.roller.On("Roll", 1, 8, -1).Return(&dice.RollResult{Total: 5}, nil).Once()
+	s.client.On("GetEquipment", "shortsword").Return(&entities.Weapon{
+		Base: entities.BasicEquipment{Key: "shortsword", Name: "Shortsword"},
+	}, nil)
+	s.client.On("GetEquipment", "leather-armor").Return(&entities.Weapon{
+		Base: entities.BasicEquipment{Key: "leather-armor", Name: "Leather Armor"},
+	}, nil)
+	s.characterManager.On("Update", s.ctx, s.fighter.ID, mock.Anything).Return(s.fighter, nil)
+	s.characterManager.On("Update", s.ctx, s.wizard.ID, mock.Anything).Return(s.wizard, nil)
+	s.characterManager.On("AddInventory", s.ctx, s.fighter, "shortsword").Return(s.fighter, nil)
+	s.characterManager.On("AddInventory", s.ctx, s.wizard, "leather-armor").Return(s.wizard, nil)
+	s.expectCreate()
+
+	result, err := s.fixture.Hoard(s.ctx, &HoardInput{
+		PlayerIDs:       []string{s.fighter.ID, s.wizard.ID},
+		Mode:            entities.LootModeRoundRobin,
+		ChallengeRating: 3,
+	})
+	s.NoError(err)
+	s.Equal("loot-1", result.ID)
+
+	result = s.created
+	s.Equal(&entities.Coins{Copper: 2000, Silver: 1000, Gold: 70}, result.Coins)
+	s.Require().Len(result.Items, 2)
+	s.Equal("Shortsword", result.Items[0].Name)
+	s.Equal(s.fighter.ID, result.Items[0].WinnerID)
+	s.Equal(s.wizard.ID, result.Items[1].WinnerID)
+	s.True(result.IsSettled())
+	s.Equal(&entities.Coins{Copper: 1000, Silver: 500, Gold: 35}, &s.fighter.Coins)
+	s.characterManager.AssertExpectations(s.T())
+}
+
+func (s *suiteManager) TestChooseAwardsItem() {
+	loot := &entities.Loot{
+		ID:        "loot-1",
+		PlayerIDs: []string{s.fighter.ID, s.wizard.ID},
+		Mode:      entities.LootModeNeedGreed,
+		Items: []*entities.LootItem{{
+			Key:   "longsword",
+			Name:  "Longsword",
+			Rolls: map[string]*entities.LootRoll{s.fighter.ID: {Choice: entities.LootChoiceGreed, Roll: 90}},
+		}},
+	}
+	s.lootRepo.On("Get", s.ctx, loot.ID).Return(loot, nil)
+	s.roller.On("Roll", 1, 100, 0).Return(&dice.RollResult{Total: 12}, nil)
+	s.characterManager.On("Update", s.ctx, s.wizard.ID, mock.Anything).Return(s.wizard, nil)
+	s.characterManager.On("AddInventory", s.ctx, s.wizard, "longsword").Return(s.wizard, nil)
+	s.lootRepo.On("Update", s.ctx, loot).Return(loot, nil)
+
+	result, err := s.fixture.Choose(s.ctx, &ChooseInput{
+		LootID:   loot.ID,
+		PlayerID: s.wizard.ID,
+		Index:    0,
+		Choice:   entities.LootChoiceNeed,
+	})
+	s.NoError(err)
+	s.Equal(s.wizard.ID, result.Item.WinnerID)
+	s.Equal(12, result.Item.Rolls[s.wizard.ID].Roll)
+	s.True(result.Loot.IsSettled())
+	s.characterManager.AssertExpectations(s.T())
+}
+
+func (s *suiteManager) TestChooseRetriesAfterConflict() {
+	offered := func() *entities.Loot {
+		return &entities.Loot{
+			ID:        "loot-1",
+			PlayerIDs: []string{s.fighter.ID, s.wizard.ID},
+			Mode:      entities.LootModeNeedGreed,
+			Items:     []*entities.LootItem{{Key: "longsword", Name: "Longsword"}},
+		}
+	}
+	stale := offered()
+	fresh := offered()
+	fresh.Version = 1
+	fresh.Items[0].Rolls = map[string]*entities.LootRoll{s.fighter.ID: {Choice: entities.LootChoiceGreed, Roll: 90}}
+	s.lootRepo.On("Get", s.ctx, "loot-1").Return(stale, nil).Once()
+	s.lootRepo.On("Get", s.ctx, "loot-1").Return(fresh, nil).Once()
+	s.roller.On("Roll", 1, 100, 0).Return(&dice.RollResult{Total: 12}, nil).Once()
+	s.lootRepo.On("Update", s.ctx, stale).Return(nil, dnderr.NewConflictError("loot loot-1 was updated")).Once()
+	s.lootRepo.On("Update", s.ctx, fresh).Return(fresh, nil).Once()
+	s.characterManager.On("Update", s.ctx, s.wizard.ID, mock.Anything).Return(s.wizard, nil)
+	s.characterManager.On("AddInventory", s.ctx, s.wizard, "longsword").Return(s.wizard, nil)
+
+	result, err := s.fixture.Choose(s.ctx, &ChooseInput{
+		LootID:   "loot-1",
+		PlayerID: s.wizard.ID,
+		Choice:   entities.LootChoiceNeed,
+	})
+	s.NoError(err)
+	s.Equal(s.wizard.ID, result.Item.WinnerID)
+	s.Equal(12, result.Item.Rolls[s.wizard.ID].Roll)
+	s.lootRepo.AssertExpectations(s.T())
+	s.roller.AssertExpectations(s.T())
+}
+
+func (s *suiteManager) TestChooseSavesBeforeAwarding() {
+	loot := &entities.Loot{
+		ID:        "loot-1",
+		PlayerIDs: []string{s.wizard.ID},
+		Mode:      entities.LootModeNeedGreed,
+		Items:     []*entities.LootItem{{Key: "longsword", Name: "Longsword"}},
+	}
+	s.lootRepo.On("Get", s.ctx, loot.ID).Return(loot, nil)
+	s.roller.On("Roll", 1, 100, 0).Return(&dice.RollResult{Total: 12}, nil)
+	s.lootRepo.On("Update", s.ctx, loot).Return(nil, errors.New("boom"))
+
+	_, err := s.fixture.Choose(s.ctx, &ChooseInput{
+		LootID:   loot.ID,
+		PlayerID: s.wizard.ID,
+		Choice:   entities.LootChoiceNeed,
+	})
+	s.EqualError(err, "boom")
+	s.characterManager.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestChooseWaitsForEveryone() {
+	loot := &entities.Loot{
+		ID:        "loot-1",
+		PlayerIDs: []string{s.fighter.ID, s.wizard.ID},
+		Mode:      entities.LootModeNeedGreed,
+		Items:     []*entities.LootItem{{Key: "longsword", Name: "Longsword"}},
+	}
+	s.lootRepo.On("Get", s.ctx, loot.ID).Return(loot, nil)
+	s.lootRepo.On("Update", s.ctx, loot).Return(loot, nil)
+
+	result, err := s.fixture.Choose(s.ctx, &ChooseInput{
+		LootID:   loot.ID,
+		PlayerID: s.fighter.ID,
+		Choice:   entities.LootChoicePass,
+	})
+	s.NoError(err)
+	s.Nil(result.Item)
+	s.False(result.Loot.IsSettled())
+	s.roller.AssertNotCalled(s.T(), "Roll", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestChooseErrors() {
+	_, err := s.fixture.Choose(s.ctx, &ChooseInput{LootID: "loot-1", PlayerID: s.fighter.ID, Choice: "grab"})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+
+	s.lootRepo.On("Get", s.ctx, "loot-1").Return(&entities.Loot{
+		ID:        "loot-1",
+		PlayerIDs: []string{s.fighter.ID},
+		Mode:      entities.LootModeRoundRobin,
+	}, nil)
+
+	_, err = s.fixture.Choose(s.ctx, &ChooseInput{LootID: "loot-1", PlayerID: s.fighter.ID, Choice: entities.LootChoiceNeed})
+	s.IsType(&dnderr.ConflictError{}, err)
+}
+
+func TestSuiteManager(t *testing.T) {
+	suite.Run(t, new(suiteManager))
+}
@@ -0,0 +1,52 @@
+package loot
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Individual(ctx context.Context, input *IndividualInput) (*entities.Loot, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Loot), nil
+}
+
+func (m *Mock) Hoard(ctx context.Context, input *HoardInput) (*entities.Loot, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Loot), nil
+}
+
+func (m *Mock) Choose(ctx context.Context, input *ChooseInput) (*ChooseOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ChooseOutput), nil
+}
+
+func (m *Mock) Get(ctx context.Context, id string) (*entities.Loot, error) {
+	args := m.Called(ctx, id)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Loot), nil
+}
@@ -0,0 +1,91 @@
+package loot
+
+import (
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+type denomination func(coins *entities.Coins) *int
+
+var (
+	copper   denomination = func(c *entities.Coins) *int { return &c.Copper }
+	silver   denomination = func(c *entities.Coins) *int { return &c.Silver }
+	electrum denomination = func(c *entities.Coins) *int { return &c.Electrum }
+	gold     denomination = func(c *entities.Coins) *int { return &c.Gold }
+	platinum denomination = func(c *entities.Coins) *int { return &c.Platinum }
+)
+
+// coinRoll is a number of d6 times a multiplier of one kind of coin
+type coinRoll struct {
+	dice       int
+	multiplier int
+	coin       denomination
+}
+
+// treasureRow is picked when the d100 roll is at most upTo
+type treasureRow struct {
+	upTo  int
+	coins []coinRoll
+}
+
+// tier is the SRD treasure for a band of challenge ratings
+type tier struct {
+	minRating  float32
+	individual []treasureRow
+	hoard      []coinRoll
+	// items are the equipment keys a hoard can hold
+	items []string
+}
+
+// tiers are ordered from the highest challenge rating down
+var tiers = []tier{{
+	minRating: 17,
+	individual: []treasureRow{
+		{upTo: 15, coins: []coinRoll{{2, 1000, electrum}, {8, 100, gold}}},
+		{upTo: 55, coins: []coinRoll{{1, 1000, gold}, {1, 100, platinum}}},
+		{upTo: 100, coins: []coinRoll{{1, 1000, gold}, {2, 100, platinum}}},
+	},
+	hoard: []coinRoll{{12, 1000, gold}, {8, 1000, platinum}},
+	items: []string{"plate-armor", "greatsword", "halberd", "half-plate-armor"},
+}, {
+	minRating: 11,
+	individual: []treasureRow{
+		{upTo: 20, coins: []coinRoll{{4, 100, silver}, {1, 100, gold}}},
+		{upTo: 35, coins: []coinRoll{{1, 100, electrum}, {1, 100, gold}}},
+		{upTo: 75, coins: []coinRoll{{2, 100, gold}, {1, 10, platinum}}},
+		{upTo: 100, coins: []coinRoll{{2, 100, gold}, {2, 10, platinum}}},
+	},
+	hoard: []coinRoll{{4, 1000, gold}, {5, 100, platinum}},
+	items: []string{"greatsword", "greataxe", "halberd", "heavy-crossbow", "half-plate-armor", "splint-armor"},
+}, {
+	minRating: 5,
+	individual: []treasureRow{
+		{upTo: 30, coins: []coinRoll{{4, 100, copper}, {1, 10, electrum}}},
+		{upTo: 60, coins: []coinRoll{{6, 10, silver}, {2, 10, gold}}},
+		{upTo: 70, coins: []coinRoll{{3, 10, electrum}, {2, 10, gold}}},
+		{upTo: 95, coins: []coinRoll{{4, 10, gold}}},
+		{upTo: 100, coins: []coinRoll{{2, 10, gold}, {3, 1, platinum}}},
+	},
+	hoard: []coinRoll{{2, 100, copper}, {2, 1000, silver}, {6, 100, gold}, {3, 10, platinum}},
+	items: []string{"longsword", "rapier", "longbow", "warhammer", "scale-mail", "breastplate", "chain-mail"},
+}, {
+	minRating: 0,
+	individual: []treasureRow{
+		{upTo: 30, coins: []coinRoll{{5, 1, copper}}},
+		{upTo: 60, coins: []coinRoll{{4, 1, silver}}},
+		{upTo: 70, coins: []coinRoll{{3, 1, electrum}}},
+		{upTo: 95, coins: []coinRoll{{3, 1, gold}}},
+		{upTo: 100, coins: []coinRoll{{1, 1, platinum}}},
+	},
+	hoard: []coinRoll{{6, 100, copper}, {3, 100, silver}, {2, 10, gold}},
+	items: []string{"dagger", "shortsword", "handaxe", "light-crossbow", "shield", "leather-armor", "studded-leather-armor", "chain-shirt"},
+}}
+
+func tierFor(rating float32) tier {
+	for _, t := range tiers {
+		if rating >= t.minRating {
+			return t
+		}
+	}
+
+	return tiers[len(tiers)-1]
+}
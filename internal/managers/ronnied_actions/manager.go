@@ -14,6 +14,9 @@ import (
 	"math/rand"
 )
 
+// maxConflictAttempts is how many times a session write is tried before a conflict is returned
+const maxConflictAttempts = 3
+
 type Manager struct {
 	gameRepo    game.Interface
 	sessionRepo session.Interface
@@ -102,22 +105,27 @@ func (m *Manager) AddSessionRoll(ctx context.Context, input *AddSessionRollInput
 		return nil, dnderr.NewMissingParameterError("input.PlayerID")
 	}
 
-	sessionRollResult, err := m.sessionRepo.GetSessionRoll(ctx, &session.GetSessionRollInput{
-		ID: input.SessionRollID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("ronnied_actions.Manager.AddSessionRoll failed to get session roll: %w", err)
-	}
+	var result *session.AddEntryOutput
+	err := m.retryOnConflict(func() error {
+		sessionRollResult, err := m.sessionRepo.GetSessionRoll(ctx, &session.GetSessionRollInput{
+			ID: input.SessionRollID,
+		})
+		if err != nil {
+			return fmt.Errorf("ronnied_actions.Manager.AddSessionRoll failed to get session roll: %w", err)
+		}
 
-	if entry := sessionRollResult.SessionRoll.HasPlayerEntry(input.PlayerID); entry != nil {
-		return nil, dnderr.NewInvalidEntityError("player has already rolled")
-	}
+		if entry := sessionRollResult.SessionRoll.HasPlayerEntry(input.PlayerID); entry != nil {
+			return dnderr.NewInvalidEntityError("player has already rolled")
+		}
+
+		roll := rand.Intn(6) + 1
+		result, err = m.sessionRepo.AddEntry(ctx, &session.AddEntryInput{
+			SessionRollID: input.SessionRollID,
+			PlayerID:      input.PlayerID,
+			Roll:          roll,
+		})
 
-	roll := rand.Intn(6) + 1
-	result, err := m.sessionRepo.AddEntry(ctx, &session.AddEntryInput{
-		SessionRollID: input.SessionRollID,
-		PlayerID:      input.PlayerID,
-		Roll:          roll,
+		return err
 	})
 	if err != nil {
 		return nil, err
@@ -216,19 +224,31 @@ func (m *Manager) JoinSession(ctx context.Context, input *JoinSessionInput) (*Jo
 	}
 
 	// join the session
-	result, err := m.sessionRepo.Join(ctx, &session.JoinInput{
-		SessionID:  input.SessionID,
-		PlayerID:   input.PlayerID,
-		PlayerName: input.PlayerName,
+	var result *session.JoinOutput
+	err := m.retryOnConflict(func() error {
+		var err error
+		result, err = m.sessionRepo.Join(ctx, &session.JoinInput{
+			SessionID:  input.SessionID,
+			PlayerID:   input.PlayerID,
+			PlayerName: input.PlayerName,
+		})
+
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	rollResult, err := m.sessionRepo.JoinSessionRoll(ctx, &session.JoinSessionRollInput{
-		SessionRollID: input.SessionRollID,
-		PlayerID:      input.PlayerID,
-		PlayerName:    input.PlayerName,
+	var rollResult *session.JoinSessionRollOutput
+	err = m.retryOnConflict(func() error {
+		var err error
+		rollResult, err = m.sessionRepo.JoinSessionRoll(ctx, &session.JoinSessionRollInput{
+			SessionRollID: input.SessionRollID,
+			PlayerID:      input.PlayerID,
+			PlayerName:    input.PlayerName,
+		})
+
+		return err
 	})
 	if err != nil {
 		return nil, err
@@ -649,6 +669,26 @@ func (m *Manager) PayDrink(ctx context.Context, input *PayDrinkInput) (*PayDrink
 
 }
 
+// retryOnConflict calls fn again when a concurrent write to the same session made it fail
+func (m *Manager) retryOnConflict(fn func() error) error {
+	var err error
+	for attempt := 0; attempt < maxConflictAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		var conflictErr *dnderr.ConflictError
+		if !errors.As(err, &conflictErr) {
+			return err
+		}
+
+		slog.Info("retrying after conflict", "attempt", attempt+1, "error", err)
+	}
+
+	return err
+}
+
 func shouldAddEntry(roll int) bool {
 	return roll == 1 || roll == 6
 }
@@ -0,0 +1,62 @@
+package rooms
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/combatlog"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+)
+
+// CombatLog pages through the events of a fight, the player's latest fight when no room is given
+func (m *Implementation) CombatLog(ctx context.Context, input *CombatLogInput) (*CombatLogOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" && input.RoomID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	if input.Offset < 0 || input.Limit < 0 {
+		return nil, dnderr.NewInvalidParameterError("input.Offset", "offset and limit must not be negative")
+	}
+
+	roomID := input.RoomID
+	if roomID == "" {
+		rooms, err := m.roomRepo.ListByPlayer(ctx, &room.ListByPlayerInput{
+			PlayerID: input.PlayerID,
+			Limit:    1,
+			Reverse:  true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rooms) == 0 {
+			return nil, dnderr.NewNotFoundError("no fights found")
+		}
+
+		roomID = rooms[0].ID
+	}
+
+	total, err := m.combatLogRepo.Count(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := m.combatLogRepo.List(ctx, &combatlog.ListInput{
+		RoomID: roomID,
+		Offset: int64(input.Offset),
+		Limit:  int64(input.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CombatLogOutput{
+		RoomID: roomID,
+		Events: events,
+		Total:  total,
+	}, nil
+}
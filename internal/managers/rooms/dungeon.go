@@ -0,0 +1,330 @@
+package rooms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/loot"
+	dungeonRepository "github.com/KirkDiggler/dnd-bot-go/internal/repositories/dungeon"
+)
+
+// EnterDungeon puts the player back in the dungeon they are exploring, a new dungeon is built
+// from the seed for the rested party when they are not in one
+func (m *Implementation) EnterDungeon(ctx context.Context, input *EnterDungeonInput) (*EnterDungeonOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	existing, err := m.getActiveDungeon(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return m.createDungeon(ctx, input)
+	}
+
+	out := &EnterDungeonOutput{
+		Dungeon: existing,
+		Log:     describeRoom(existing.Current()),
+	}
+
+	if !existing.Current().Cleared {
+		out.Trap, err = m.roomTrap(ctx, existing.Current())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := m.getActiveRoom(ctx, input.PlayerID)
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return out, nil
+		}
+
+		return nil, err
+	}
+
+	if data.DungeonID == existing.ID {
+		out.Room, err = m.hydrateRoom(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+func (m *Implementation) createDungeon(ctx context.Context, input *EnterDungeonInput) (*EnterDungeonOutput, error) {
+	var seed int64
+	if input.Seed != nil {
+		seed = *input.Seed
+	} else {
+		seed = m.seeder()
+	}
+
+	generated, err := dungeon.Generate(seed, dungeon.DefaultSize)
+	if err != nil {
+		return nil, err
+	}
+
+	generated.PlayerIDs = append([]string{input.PlayerID}, input.PartyIDs...)
+	for _, playerID := range generated.PlayerIDs {
+		_, err = m.rest(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	generated, err = m.dungeonRepo.Create(ctx, generated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EnterDungeonOutput{
+		Dungeon: generated,
+		Log:     describeRoom(generated.Current()),
+	}, nil
+}
+
+// Move takes the party through a door of the room they are in, monsters in the next room start a
+// fight straight away. The party cannot leave a room while they are fighting.
+func (m *Implementation) Move(ctx context.Context, input *MoveInput) (*MoveOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	if input.Direction == "" {
+		return nil, dnderr.NewMissingParameterError("input.Direction")
+	}
+
+	current, err := m.getActiveDungeon(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		return nil, dnderr.NewNotFoundError("no dungeon found, use /dungeon enter")
+	}
+
+	_, err = m.getActiveRoom(ctx, input.PlayerID)
+	if err == nil {
+		return nil, dnderr.NewConflictError("finish the fight or flee before moving on")
+	}
+
+	var notFoundErr *dnderr.NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		return nil, err
+	}
+
+	next, err := current.Move(input.Direction)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &MoveOutput{
+		Dungeon: current,
+		Log:     append([]string{fmt.Sprintf("The party heads %s.", input.Direction)}, describeRoom(next)...),
+	}
+
+	if next.Contents == dungeon.ContentsMonsters && !next.Cleared {
+		fight, err := m.createRoom(ctx, current.PlayerIDs, current.ID, false)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Room = fight.Room
+		out.Outcome = fight.Outcome
+		out.Log = append(out.Log, fight.Log...)
+		out.Log = append(out.Log, applyOutcome(current, fight.Outcome)...)
+	}
+
+	if next.Contents == dungeon.ContentsTrap && !next.Cleared {
+		var lines []string
+		out.Trap, lines, err = m.enterTrapRoom(ctx, current, next)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Log = append(out.Log, lines...)
+	}
+
+	if next.Contents == dungeon.ContentsTreasure && !next.Cleared {
+		out.Loot, err = m.openTreasure(ctx, current.PlayerIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		next.Cleared = true
+		out.Log = append(out.Log, "The party opens the chest.")
+	}
+
+	_, err = m.dungeonRepo.Update(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// openTreasure rolls a hoard for the highest level in the party
+func (m *Implementation) openTreasure(ctx context.Context, playerIDs []string) (*entities.Loot, error) {
+	level := 1
+	for _, playerID := range playerIDs {
+		char, err := m.characterManager.Get(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+
+		level = max(level, char.TotalLevel())
+	}
+
+	return m.lootManager.Hoard(ctx, &loot.HoardInput{
+		PlayerIDs:       playerIDs,
+		Mode:            m.lootMode,
+		ChallengeRating: float32(level),
+	})
+}
+
+// finishDungeonFight updates the dungeon with how the fight in the party's room ended
+func (m *Implementation) finishDungeonFight(ctx context.Context, dungeonID string, outcome Outcome) (*dungeon.Dungeon, []string, error) {
+	current, err := m.dungeonRepo.Get(ctx, dungeonID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if outcome == OutcomeUnset {
+		return current, []string{}, nil
+	}
+
+	log := applyOutcome(current, outcome)
+
+	_, err = m.dungeonRepo.Update(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return current, log, nil
+}
+
+// applyOutcome clears the room on a victory, ends the dungeon on a defeat and sends the party back
+// where they came from when they flee or are let go
+func applyOutcome(current *dungeon.Dungeon, outcome Outcome) []string {
+	switch outcome {
+	case OutcomeVictory:
+		current.Current().Cleared = true
+		if current.IsComplete() {
+			current.Status = dungeon.StatusComplete
+			return []string{"Every monster in the dungeon has been defeated, the dungeon is cleared!"}
+		}
+
+		return []string{"The room is clear."}
+	case OutcomeDefeat:
+		current.Status = dungeon.StatusFailed
+		return []string{"The party is dragged out of the dungeon."}
+	case OutcomeFled:
+		current.Retreat()
+		return []string{"The party flees back the way they came."}
+	case OutcomeSurrendered:
+		current.Retreat()
+		return []string{"The monsters drive the party back the way they came."}
+	default:
+		return []string{}
+	}
+}
+
+// updateDungeon applies the change and saves the dungeon, a dungeon that was saved by someone
+// else since it was loaded is reloaded and the change applied again
+func (m *Implementation) updateDungeon(ctx context.Context, current *dungeon.Dungeon, change func(*dungeon.Dungeon)) (*dungeon.Dungeon, error) {
+	var err error
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		if attempt > 0 {
+			current, err = m.dungeonRepo.Get(ctx, current.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		change(current)
+
+		_, err = m.dungeonRepo.Update(ctx, current)
+		if err == nil {
+			return current, nil
+		}
+
+		var conflictErr *dnderr.ConflictError
+		if !errors.As(err, &conflictErr) {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+// getActiveDungeon returns the dungeon the player is exploring, nil when they are not in one
+func (m *Implementation) getActiveDungeon(ctx context.Context, playerID string) (*dungeon.Dungeon, error) {
+	dungeons, err := m.dungeonRepo.ListByPlayer(ctx, &dungeonRepository.ListByPlayerInput{
+		PlayerID: playerID,
+		Limit:    1,
+		Reverse:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dungeons) == 0 || dungeons[0].Status != dungeon.StatusActive {
+		return nil, nil
+	}
+
+	return dungeons[0], nil
+}
+
+func describeRoom(current *dungeon.Room) []string {
+	var description string
+	switch current.Contents {
+	case dungeon.ContentsEntrance:
+		description = "Daylight spills in through the entrance."
+	case dungeon.ContentsMonsters:
+		description = "Monsters lurk in the shadows!"
+		if current.Cleared {
+			description = "The monsters that lurked here lie defeated."
+		}
+	case dungeon.ContentsTrap:
+		description = "Something about the floor here looks wrong, it could be a trap."
+		if current.Cleared {
+			description = "The trap here has been dealt with."
+		}
+	case dungeon.ContentsTreasure:
+		description = "A treasure chest sits in the corner."
+		if current.Cleared {
+			description = "The treasure chest here is empty."
+		}
+	default:
+		description = "The room is empty."
+	}
+
+	exits := make([]string, len(current.Exits))
+	for idx, exit := range current.Exits {
+		exits[idx] = string(exit)
+	}
+
+	if len(exits) == 1 {
+		return []string{description, fmt.Sprintf("A door leads %s.", exits[0])}
+	}
+
+	return []string{description, fmt.Sprintf("Doors lead %s.", strings.Join(exits, ", "))}
+}
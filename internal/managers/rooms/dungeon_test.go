@@ -0,0 +1,202 @@
+package rooms
+
+import (
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/loot"
+	dungeonRepository "github.com/KirkDiggler/dnd-bot-go/internal/repositories/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+	"github.com/stretchr/testify/mock"
+)
+
+// testDungeon is an entrance with a door east to a room of monsters
+func (s *suiteManager) testDungeon() *dungeon.Dungeon {
+	return &dungeon.Dungeon{
+		ID:        "dungeon-1",
+		Seed:      42,
+		Status:    dungeon.StatusActive,
+		PlayerIDs: []string{s.playerID},
+		Rooms: []*dungeon.Room{
+			{Contents: dungeon.ContentsEntrance, Exits: []dungeon.Direction{dungeon.DirectionEast}, Visited: true, Cleared: true},
+			{Position: dungeon.Position{X: 1}, Contents: dungeon.ContentsMonsters, Exits: []dungeon.Direction{dungeon.DirectionWest}},
+		},
+	}
+}
+
+func (s *suiteManager) expectDungeon(current *dungeon.Dungeon) {
+	s.dungeonRepo.On("ListByPlayer", s.ctx, &dungeonRepository.ListByPlayerInput{
+		PlayerID: s.playerID,
+		Limit:    1,
+		Reverse:  true,
+	}).Return([]*dungeon.Dungeon{current}, nil)
+}
+
+func (s *suiteManager) TestEnterDungeonCreatesDungeon() {
+	seed := int64(42)
+	expected, err := dungeon.Generate(seed, dungeon.DefaultSize)
+	s.Require().NoError(err)
+	expected.PlayerIDs = []string{s.playerID}
+
+	s.dungeonRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*dungeon.Dungeon{}, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.dungeonRepo.On("Create", s.ctx, expected).Return(expected, nil)
+
+	result, err := s.fixture.EnterDungeon(s.ctx, &EnterDungeonInput{
+		PlayerID: s.playerID,
+		Seed:     &seed,
+	})
+	s.NoError(err)
+	s.Equal(expected, result.Dungeon)
+	s.Nil(result.Room)
+	s.Equal("Daylight spills in through the entrance.", result.Log[0])
+	s.charManager.AssertExpectations(s.T())
+}
+
+func (s *suiteManager) TestEnterDungeonResumesFight() {
+	current := s.testDungeon()
+	s.expectDungeon(current)
+	s.room.DungeonID = current.ID
+	s.expectActiveRoom()
+
+	result, err := s.fixture.EnterDungeon(s.ctx, &EnterDungeonInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(current, result.Dungeon)
+	s.Equal([]*entities.Monster{s.monster}, result.Room.Monsters)
+}
+
+func (s *suiteManager) TestMoveStartsFight() {
+	current := s.testDungeon()
+	s.expectDungeon(current)
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.encounters.On("Build", s.ctx, mock.Anything).Return(&encounters.BuildOutput{
+		Monsters: []*entities.MonsterTemplate{s.template},
+	}, nil)
+	s.uuider.On("New").Return(s.monster.ID)
+	s.monsterRepo.On("PutMonster", s.ctx, mock.Anything).Return(s.monster, nil)
+	s.roomRepo.On("Create", s.ctx, mock.MatchedBy(func(data *room.Data) bool {
+		return data.DungeonID == current.ID
+	})).Return(s.room, nil)
+	s.dungeonRepo.On("Update", s.ctx, mock.MatchedBy(func(updated *dungeon.Dungeon) bool {
+		return updated.Position == dungeon.Position{X: 1}
+	})).Return(current, nil)
+
+	result, err := s.fixture.Move(s.ctx, &MoveInput{
+		PlayerID:  s.playerID,
+		Direction: dungeon.DirectionEast,
+	})
+	s.NoError(err)
+	s.True(current.Current().Visited)
+	s.NotNil(result.Room)
+	s.Equal([]string{
+		"The party heads east.",
+		"Monsters lurk in the shadows!",
+		"A door leads west.",
+		"Initiative: Tester (15), Goblin (15)",
+	}, result.Log)
+	s.charManager.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+	s.dungeonRepo.AssertExpectations(s.T())
+}
+
+func (s *suiteManager) TestMoveOpensTreasure() {
+	current := s.testDungeon()
+	current.Rooms[1].Contents = dungeon.ContentsTreasure
+	s.char.Level = 3
+	s.expectDungeon(current)
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	found := &entities.Loot{ID: "loot-1", Coins: &entities.Coins{Gold: 70}}
+	s.loot.On("Hoard", s.ctx, &loot.HoardInput{
+		PlayerIDs:       []string{s.playerID},
+		Mode:            entities.LootModeNeedGreed,
+		ChallengeRating: float32(s.char.TotalLevel()),
+	}).Return(found, nil)
+	s.dungeonRepo.On("Update", s.ctx, current).Return(current, nil)
+
+	result, err := s.fixture.Move(s.ctx, &MoveInput{
+		PlayerID:  s.playerID,
+		Direction: dungeon.DirectionEast,
+	})
+	s.NoError(err)
+	s.Nil(result.Room)
+	s.Equal(found, result.Loot)
+	s.True(current.Current().Cleared)
+	s.Equal("The party opens the chest.", result.Log[len(result.Log)-1])
+	s.loot.AssertExpectations(s.T())
+}
+
+func (s *suiteManager) TestMoveDuringFight() {
+	s.expectDungeon(s.testDungeon())
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{s.room}, nil)
+
+	_, err := s.fixture.Move(s.ctx, &MoveInput{
+		PlayerID:  s.playerID,
+		Direction: dungeon.DirectionEast,
+	})
+	s.IsType(&dnderr.ConflictError{}, err)
+}
+
+func (s *suiteManager) TestMoveWithoutDoor() {
+	s.expectDungeon(s.testDungeon())
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+
+	_, err := s.fixture.Move(s.ctx, &MoveInput{
+		PlayerID:  s.playerID,
+		Direction: dungeon.DirectionNorth,
+	})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+}
+
+func (s *suiteManager) TestMoveWithoutDungeon() {
+	s.dungeonRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*dungeon.Dungeon{}, nil)
+
+	_, err := s.fixture.Move(s.ctx, &MoveInput{
+		PlayerID:  s.playerID,
+		Direction: dungeon.DirectionEast,
+	})
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *suiteManager) TestFleeInDungeonRetreats() {
+	current := s.testDungeon()
+	_, err := current.Move(dungeon.DirectionEast)
+	s.Require().NoError(err)
+
+	s.room.DungeonID = current.ID
+	s.fightOnGrid()
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, mock.Anything).Return(s.room, nil)
+	s.dungeonRepo.On("Get", s.ctx, current.ID).Return(current, nil)
+	s.dungeonRepo.On("Update", s.ctx, current).Return(current, nil)
+
+	result, err := s.fixture.Flee(s.ctx, &FleeInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal([]string{"The party flees back the way they came."}, result.Log)
+	s.Equal(dungeon.Position{}, result.Dungeon.Position)
+	s.False(current.Rooms[1].Cleared)
+}
+
+func (s *suiteManager) TestApplyOutcome() {
+	current := s.testDungeon()
+	_, err := current.Move(dungeon.DirectionEast)
+	s.Require().NoError(err)
+
+	s.Equal([]string{"Every monster in the dungeon has been defeated, the dungeon is cleared!"}, applyOutcome(current, OutcomeVictory))
+	s.True(current.Current().Cleared)
+	s.Equal(dungeon.StatusComplete, current.Status)
+
+	current = s.testDungeon()
+	applyOutcome(current, OutcomeDefeat)
+	s.Equal(dungeon.StatusFailed, current.Status)
+
+	current = s.testDungeon()
+	_, err = current.Move(dungeon.DirectionEast)
+	s.Require().NoError(err)
+	applyOutcome(current, OutcomeSurrendered)
+	s.Equal(dungeon.Position{}, current.Position)
+	s.Equal(dungeon.StatusActive, current.Status)
+}
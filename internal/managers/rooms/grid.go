@@ -0,0 +1,160 @@
+package rooms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+)
+
+// Step moves the character one square on the battle map, it has to be their turn and costs the
+// movement it takes to enter the square. Stepping out of a monster's reach provokes an
+// opportunity attack unless the character disengaged.
+func (m *Implementation) Step(ctx context.Context, input *StepInput) (*StepOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	if input.Direction.Opposite() == "" {
+		return nil, dnderr.NewInvalidParameterError("input.Direction", string(input.Direction))
+	}
+
+	data, err := m.getActiveRoom(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeRoom, err := m.hydrateRoom(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	engine, _, err := m.startFight(ctx, data, activeRoom)
+	if err != nil {
+		return nil, err
+	}
+
+	if activeRoom.Grid == nil {
+		return nil, dnderr.NewInvalidEntityError("this fight has no map")
+	}
+
+	current, err := engine.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	if current.ID != input.PlayerID {
+		return nil, dnderr.NewConflictError(fmt.Sprintf("it is %s's turn", current.Combatant().GetName()))
+	}
+
+	from, ok := activeRoom.Grid.Position(input.PlayerID)
+	if !ok {
+		return nil, dnderr.NewInvalidEntityError(fmt.Sprintf("%s is not on the map", current.Combatant().GetName()))
+	}
+
+	step := dungeon.Position{}.Step(input.Direction)
+	feet, provoked, err := engine.MoveTo(from.Offset(step.X, step.Y))
+	if err != nil {
+		return nil, err
+	}
+
+	log := make([]string, 0, len(provoked)+1)
+	for _, outcome := range provoked {
+		log = append(log, outcome.Lines()...)
+	}
+
+	// an opportunity attack that drops the character ends their turn
+	if current.Combatant().IsDown() {
+		result, err := m.finishTurn(ctx, data, activeRoom, engine, log)
+		if err != nil {
+			return nil, err
+		}
+
+		return &StepOutput{
+			Room:    result.Room,
+			Log:     result.Log,
+			Dungeon: result.Dungeon,
+			Outcome: result.Outcome,
+		}, nil
+	}
+
+	if len(provoked) > 0 {
+		err = m.saveCombatants(ctx, activeRoom)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = m.roomRepo.Update(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.saveEvents(ctx, data.ID, engine, OutcomeUnset)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &StepOutput{
+		Room: activeRoom,
+		Log: append(log,
+			fmt.Sprintf("%s moves %d feet %s, %d feet left.", current.Combatant().GetName(), feet, input.Direction, current.Budget.Movement),
+		),
+	}
+
+	if data.DungeonID != "" {
+		out.Dungeon, err = m.dungeonRepo.Get(ctx, data.DungeonID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// placeCombatants puts anyone standing who is not on the battle map yet on it, the party lines up
+// on the left edge and the monsters on the right
+func placeCombatants(activeRoom *entities.Room) error {
+	grid := activeRoom.Grid
+	if grid == nil {
+		return nil
+	}
+
+	for idx, char := range activeRoom.Characters {
+		err := placeCombatant(grid, char, 0, len(activeRoom.Characters), idx)
+		if err != nil {
+			return err
+		}
+	}
+
+	for idx, mon := range activeRoom.Monsters {
+		err := placeCombatant(grid, mon, grid.Width-1, len(activeRoom.Monsters), idx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// placeCombatant centres a side of count combatants down the column
+func placeCombatant(grid *combat.Grid, combatant combat.Combatant, column, count, idx int) error {
+	if combatant.IsDown() {
+		return nil
+	}
+
+	if _, ok := grid.Position(combatant.GetID()); ok {
+		return nil
+	}
+
+	row := max(grid.Height-count, 0)/2 + idx
+
+	return grid.PlaceNear(combatant.GetID(), combat.Position{X: column, Y: min(row, grid.Height-1)})
+}
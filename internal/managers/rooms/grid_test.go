@@ -0,0 +1,68 @@
+package rooms
+
+import (
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/stretchr/testify/mock"
+)
+
+// fightOnGrid puts the character and the goblin on opposite ends of a small map with the
+// character to act
+func (s *suiteManager) fightOnGrid() {
+	s.room.Combat = &combat.State{
+		Round: 1,
+		Order: []*combat.Participant{
+			{ID: s.playerID, Side: combat.SideParty, Initiative: 15, Budget: &combat.Budget{Action: true, Movement: 30}},
+			{ID: s.monster.ID, Side: combat.SideMonsters, Initiative: 12, Budget: &combat.Budget{Action: true, Movement: 30}},
+		},
+		Grid: &combat.Grid{
+			Width:   5,
+			Height:  2,
+			Terrain: []string{".~...", "#...."},
+			Positions: map[string]combat.Position{
+				s.playerID:   {X: 0, Y: 0},
+				s.monster.ID: {X: 4, Y: 0},
+			},
+		},
+	}
+}
+
+func (s *suiteManager) TestStepMovesCharacter() {
+	s.fightOnGrid()
+	s.expectActiveRoom()
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+
+	result, err := s.fixture.Step(s.ctx, &StepInput{PlayerID: s.playerID, Direction: dungeon.DirectionEast})
+	s.NoError(err)
+	s.Equal([]string{"Tester moves 10 feet east, 20 feet left."}, result.Log)
+	s.Equal(combat.Position{X: 1, Y: 0}, result.Room.Grid.Positions[s.playerID])
+	s.Equal(20, s.room.Combat.Order[0].Budget.Movement)
+	s.combatLog.AssertCalled(s.T(), "Append", s.ctx, s.room.ID, mock.MatchedBy(func(events []*combat.Event) bool {
+		return len(events) == 1 && events[0].Type == combat.EventTypeMove
+	}))
+}
+
+func (s *suiteManager) TestStepIntoWall() {
+	s.fightOnGrid()
+	s.expectActiveRoom()
+
+	_, err := s.fixture.Step(s.ctx, &StepInput{PlayerID: s.playerID, Direction: dungeon.DirectionSouth})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+	s.roomRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestStepOutOfTurn() {
+	s.fightOnGrid()
+	s.room.Combat.Turn = 1
+	s.expectActiveRoom()
+
+	_, err := s.fixture.Step(s.ctx, &StepInput{PlayerID: s.playerID, Direction: dungeon.DirectionEast})
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.EqualError(err, "it is Goblin's turn")
+}
+
+func (s *suiteManager) TestStepUnknownDirection() {
+	_, err := s.fixture.Step(s.ctx, &StepInput{PlayerID: s.playerID, Direction: "up"})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+}
@@ -0,0 +1,157 @@
+package rooms
+
+import (
+	"context"
+	"errors"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+)
+
+// defaultHistoryLimit is how many rooms a page of history shows
+const defaultHistoryLimit = 5
+
+// History pages through the rooms the player has fought in, latest first
+func (m *Implementation) History(ctx context.Context, input *HistoryInput) (*HistoryOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	if input.Offset < 0 || input.Limit < 0 {
+		return nil, dnderr.NewInvalidParameterError("input.Offset", "offset and limit must not be negative")
+	}
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+
+	// one more than the page tells whether there is another page
+	rooms, err := m.roomRepo.ListByPlayer(ctx, &room.ListByPlayerInput{
+		PlayerID: input.PlayerID,
+		Limit:    int64(limit + 1),
+		Offset:   int64(input.Offset),
+		Reverse:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &HistoryOutput{
+		HasMore: len(rooms) > limit,
+	}
+
+	rooms = rooms[:min(len(rooms), limit)]
+	out.Rooms = make([]*RoomSummary, len(rooms))
+
+	// most rooms are fought against the same few kinds of monster
+	names := make(map[string]string)
+	for idx, data := range rooms {
+		out.Rooms[idx], err = m.summarizeRoom(ctx, data, names)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// summarizeRoom names the room's monsters by their kind, names caches the name of each kind
+func (m *Implementation) summarizeRoom(ctx context.Context, data *room.Data, names map[string]string) (*RoomSummary, error) {
+	out := &RoomSummary{
+		ID:        data.ID,
+		Status:    statusToEntity(data.Status),
+		Outcome:   Outcome(data.Outcome),
+		Monsters:  make([]string, 0, len(data.MonsterIDs)),
+		DungeonID: data.DungeonID,
+		StartedAt: data.StartedAt,
+		EndedAt:   data.EndedAt,
+	}
+
+	for _, monsterID := range data.MonsterIDs {
+		mon, err := m.monsterRepo.GetMonster(ctx, monsterID)
+		if err != nil {
+			var notFoundErr *dnderr.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		name, ok := names[mon.Key]
+		if !ok {
+			template, err := m.client.GetMonster(mon.Key)
+			if err != nil {
+				return nil, err
+			}
+
+			name = template.Name
+			names[mon.Key] = name
+		}
+
+		out.Monsters = append(out.Monsters, name)
+	}
+
+	return out, nil
+}
+
+// Abandon closes the player's active room without finishing the fight, no one gets anything for
+// it. In a dungeon the party goes back to the room they came from.
+func (m *Implementation) Abandon(ctx context.Context, input *AbandonInput) (*AbandonOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	data, err := m.getActiveRoom(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeRoom, err := m.hydrateRoom(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.closeRoom(ctx, data, activeRoom, OutcomeAbandoned)
+	if err != nil {
+		return nil, err
+	}
+
+	round := 0
+	if data.Combat != nil {
+		round = data.Combat.Round
+	}
+
+	_, err = m.combatLogRepo.Append(ctx, data.ID, []*combat.Event{{
+		Round: round,
+		Type:  combat.EventTypeEnd,
+		Text:  outcomeText(OutcomeAbandoned),
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &AbandonOutput{
+		Room: activeRoom,
+	}
+
+	if data.DungeonID != "" {
+		// the room is left as it was, the same as when the party flees
+		out.Dungeon, _, err = m.finishDungeonFight(ctx, data.DungeonID, OutcomeFled)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,122 @@
+package rooms
+
+import (
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+	"github.com/stretchr/testify/mock"
+)
+
+func (s *suiteManager) TestHistory() {
+	s.room.Status = room.StatusInactive
+	s.room.Outcome = string(OutcomeVictory)
+	s.room.StartedAt = s.now.Add(-5 * time.Minute)
+	s.room.EndedAt = s.now
+	older := &room.Data{
+		ID:         "room-0",
+		Status:     room.StatusInactive,
+		PlayerIDs:  []string{s.playerID},
+		MonsterIDs: []string{"monster-0", "monster-gone"},
+	}
+
+	s.roomRepo.On("ListByPlayer", s.ctx, &room.ListByPlayerInput{
+		PlayerID: s.playerID,
+		Limit:    3,
+		Offset:   2,
+		Reverse:  true,
+	}).Return([]*room.Data{s.room, older}, nil)
+	s.monsterRepo.On("GetMonster", s.ctx, s.monster.ID).Return(s.monster, nil)
+	s.monsterRepo.On("GetMonster", s.ctx, "monster-0").Return(&entities.Monster{ID: "monster-0", Key: "goblin"}, nil)
+	s.monsterRepo.On("GetMonster", s.ctx, "monster-gone").Return(nil, dnderr.NewNotFoundError("monster not found"))
+	s.client.On("GetMonster", "goblin").Return(s.template, nil).Once()
+
+	result, err := s.fixture.History(s.ctx, &HistoryInput{PlayerID: s.playerID, Offset: 2, Limit: 2})
+	s.NoError(err)
+	s.False(result.HasMore)
+	s.Equal([]*RoomSummary{{
+		ID:        s.room.ID,
+		Status:    entities.RoomStatusInactive,
+		Outcome:   OutcomeVictory,
+		Monsters:  []string{"Goblin"},
+		StartedAt: s.room.StartedAt,
+		EndedAt:   s.now,
+	}, {
+		ID:       older.ID,
+		Status:   entities.RoomStatusInactive,
+		Monsters: []string{"Goblin"},
+	}}, result.Rooms)
+	s.client.AssertNumberOfCalls(s.T(), "GetMonster", 1)
+}
+
+func (s *suiteManager) TestHistoryHasMore() {
+	s.room.MonsterIDs = []string{}
+	s.roomRepo.On("ListByPlayer", s.ctx, &room.ListByPlayerInput{
+		PlayerID: s.playerID,
+		Limit:    defaultHistoryLimit + 1,
+		Reverse:  true,
+	}).Return([]*room.Data{s.room, s.room, s.room, s.room, s.room, s.room}, nil)
+
+	result, err := s.fixture.History(s.ctx, &HistoryInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.True(result.HasMore)
+	s.Len(result.Rooms, defaultHistoryLimit)
+}
+
+func (s *suiteManager) TestHistoryRecordsTheOutcome() {
+	s.monster.CurrentHP = 0
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+	s.loot.On("Individual", s.ctx, mock.Anything).Return(&entities.Loot{ID: "loot-1"}, nil)
+
+	_, err := s.fixture.Attack(s.ctx, &AttackInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(room.StatusInactive, s.room.Status)
+	s.Equal(string(OutcomeVictory), s.room.Outcome)
+	s.Equal(s.now, s.room.EndedAt)
+}
+
+func (s *suiteManager) TestAbandon() {
+	s.expectActiveRoom()
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+
+	result, err := s.fixture.Abandon(s.ctx, &AbandonInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(entities.RoomStatusInactive, result.Room.Status)
+	s.Nil(result.Dungeon)
+	s.Equal(string(OutcomeAbandoned), s.room.Outcome)
+	s.Equal(s.now, s.room.EndedAt)
+	s.combatLog.AssertCalled(s.T(), "Append", s.ctx, s.room.ID, []*combat.Event{
+		{Type: combat.EventTypeEnd, Text: "The party abandons the fight."},
+	})
+	s.charManager.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestAbandonInDungeonRetreats() {
+	current := s.testDungeon()
+	_, err := current.Move(dungeon.DirectionEast)
+	s.Require().NoError(err)
+
+	s.room.DungeonID = current.ID
+	s.expectActiveRoom()
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+	s.dungeonRepo.On("Get", s.ctx, current.ID).Return(current, nil)
+	s.dungeonRepo.On("Update", s.ctx, current).Return(current, nil)
+
+	result, err := s.fixture.Abandon(s.ctx, &AbandonInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(dungeon.Position{}, result.Dungeon.Position)
+	s.Equal(dungeon.StatusActive, result.Dungeon.Status)
+}
+
+func (s *suiteManager) TestAbandonWithoutActiveRoom() {
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+
+	_, err := s.fixture.Abandon(s.ctx, &AbandonInput{PlayerID: s.playerID})
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
@@ -2,20 +2,83 @@ package rooms
 
 import (
 	"context"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
 )
 
 type Manager interface {
 	LoadRoom(ctx context.Context, input *LoadRoomInput) (*LoadRoomOutput, error)
+	// CreatePartyRoom starts a new fight for the whole party, none of them can be in a fight already
+	CreatePartyRoom(ctx context.Context, input *CreatePartyRoomInput) (*LoadRoomOutput, error)
+	// GetRoom loads the room by its id whether or not the fight in it is over
+	GetRoom(ctx context.Context, input *GetRoomInput) (*GetRoomOutput, error)
 	HasActiveRoom(ctx context.Context, input *HasActiveRoomInput) (*HasActiveRoomOutput, error)
+	Attack(ctx context.Context, input *AttackInput) (*AttackOutput, error)
+	Flee(ctx context.Context, input *FleeInput) (*FleeOutput, error)
+	Disengage(ctx context.Context, input *DisengageInput) (*StepOutput, error)
+	Help(ctx context.Context, input *HelpInput) (*StepOutput, error)
+	EndTurn(ctx context.Context, input *EndTurnInput) (*AttackOutput, error)
+	Surrender(ctx context.Context, input *SurrenderInput) (*SurrenderOutput, error)
+	Step(ctx context.Context, input *StepInput) (*StepOutput, error)
+	CurrentTurn(ctx context.Context, input *CurrentTurnInput) (*CurrentTurnOutput, error)
+	SkipTurn(ctx context.Context, input *SkipTurnInput) (*AttackOutput, error)
+	EnterDungeon(ctx context.Context, input *EnterDungeonInput) (*EnterDungeonOutput, error)
+	Move(ctx context.Context, input *MoveInput) (*MoveOutput, error)
+	Disarm(ctx context.Context, input *DisarmInput) (*DisarmOutput, error)
+	CombatLog(ctx context.Context, input *CombatLogInput) (*CombatLogOutput, error)
+	History(ctx context.Context, input *HistoryInput) (*HistoryOutput, error)
+	Abandon(ctx context.Context, input *AbandonInput) (*AbandonOutput, error)
 }
 
 type LoadRoomInput struct {
 	PlayerID string
+	// PartyIDs are the other players that enter with the player when a new room is created
+	PartyIDs []string
+	// Stealth has the party try to sneak up on the monsters when a new room is created
+	Stealth bool
+}
+
+type CreatePartyRoomInput struct {
+	PlayerIDs []string
+	// Stealth has the party try to sneak up on the monsters
+	Stealth bool
+}
+
+type GetRoomInput struct {
+	RoomID string
+}
+
+type GetRoomOutput struct {
+	Room *entities.Room
 }
 
 type LoadRoomOutput struct {
 	Room *entities.Room
+	// Log describes the start of the fight when a new room is created
+	Log     []string
+	Outcome Outcome
+	// Surprise is how sneaking up on the monsters went, nil when the party did not try
+	Surprise *Surprise
+}
+
+// Surprise is the party's group Dexterity (Stealth) check against the monsters' passive
+// perception
+type Surprise struct {
+	Rolls []*StealthRoll
+	// Surprised are the monsters that did not notice the party and lose their first turn
+	Surprised []*entities.Monster
+}
+
+// StealthRoll is a character's part of the group check
+type StealthRoll struct {
+	CharacterID string
+	Name        string
+	Total       int
+	// Disadvantage is set when the character's armor is too noisy to sneak in
+	Disadvantage bool
 }
 
 type HasActiveRoomInput struct {
@@ -25,3 +88,267 @@ type HasActiveRoomInput struct {
 type HasActiveRoomOutput struct {
 	HasActiveRoom bool
 }
+
+// Outcome is how the fight in a room ended
+type Outcome string
+
+const (
+	OutcomeUnset   Outcome = ""
+	OutcomeVictory Outcome = "victory"
+	OutcomeDefeat  Outcome = "defeat"
+	OutcomeFled    Outcome = "fled"
+	// OutcomeAbandoned is a room the party walked away from without fighting it out
+	OutcomeAbandoned Outcome = "abandoned"
+	// OutcomeSurrendered is a fight the monsters let the party walk away from
+	OutcomeSurrendered Outcome = "surrendered"
+)
+
+type AttackInput struct {
+	PlayerID string
+	// TargetID is the monster to attack, defaults to the nearest one still standing. The character
+	// moves toward it when it is out of reach.
+	TargetID string
+}
+
+type AttackOutput struct {
+	Room *entities.Room
+	// Log describes the turns played, the character's attack then the monsters' until it is a
+	// player's turn again
+	Log     []string
+	Outcome Outcome
+	// Dungeon is the dungeon the fight is in, nil for a room on its own
+	Dungeon *dungeon.Dungeon
+	// Loot is what the party found on the monsters, only set on a victory
+	Loot *entities.Loot
+	// Experience is what each character left standing earned, only set on a victory
+	Experience []*ExperienceAward
+}
+
+// DisengageInput has the character take the Disengage action so they can walk away on their turn
+type DisengageInput struct {
+	PlayerID string
+}
+
+// HelpInput has the character take the Help action against a monster next to them
+type HelpInput struct {
+	PlayerID string
+	// TargetID is the monster to distract, defaults to the nearest one still standing
+	TargetID string
+}
+
+// EndTurnInput ends the character's turn without doing anything more
+type EndTurnInput struct {
+	PlayerID string
+}
+
+// ExperienceAward is a character's share of the experience for the defeated monsters
+type ExperienceAward struct {
+	CharacterID string
+	Name        string
+	XP          int
+	// Experience is the character's total after the award
+	Experience int
+	// NextLevel is the experience needed for the next level, 0 at the max level
+	NextLevel    int
+	Level        int
+	LevelsGained int
+	// PendingLevels are the earned levels the character has not taken in a class yet
+	PendingLevels int
+}
+
+type FleeInput struct {
+	PlayerID string
+}
+
+type FleeOutput struct {
+	Room *entities.Room
+	// Log describes the opportunity attacks the party took on the way out
+	Log []string
+	// Outcome is OutcomeFled, or OutcomeDefeat when no one made it out
+	Outcome Outcome
+	// Dungeon is the dungeon the fight was in, the party is back in the room they came from
+	Dungeon *dungeon.Dungeon
+	// Experience is what the characters who got away earned for the monsters they did drop
+	Experience []*ExperienceAward
+}
+
+// SurrenderTerms are what the monsters make of the party giving up
+type SurrenderTerms string
+
+const (
+	// SurrenderRefused monsters keep fighting, the character's action is spent
+	SurrenderRefused SurrenderTerms = "refused"
+	// SurrenderSpared monsters let the party go
+	SurrenderSpared SurrenderTerms = "spared"
+	// SurrenderRansom monsters take half of every character's coins
+	SurrenderRansom SurrenderTerms = "ransom"
+	// SurrenderHoard monsters take every coin the party has
+	SurrenderHoard SurrenderTerms = "hoard"
+)
+
+type SurrenderInput struct {
+	PlayerID string
+}
+
+type SurrenderOutput struct {
+	Room *entities.Room
+	Log  []string
+	// Terms are decided by the kind of monster leading the fight
+	Terms SurrenderTerms
+	// Outcome is OutcomeSurrendered when the monsters accept, a refused surrender plays on
+	Outcome Outcome
+	// Dungeon is the dungeon the fight is in, nil for a room on its own
+	Dungeon *dungeon.Dungeon
+	// Tribute is what each character handed over
+	Tribute []*Tribute
+	// Experience is what the characters earned for the monsters they did drop
+	Experience []*ExperienceAward
+}
+
+// Tribute is the coins a character handed over to the monsters
+type Tribute struct {
+	CharacterID string
+	Name        string
+	Coins       *entities.Coins
+}
+
+// StepInput moves the character one square on the battle map on their turn
+type StepInput struct {
+	PlayerID  string
+	Direction dungeon.Direction
+}
+
+type StepOutput struct {
+	Room *entities.Room
+	Log  []string
+	// Dungeon is the dungeon the fight is in, nil for a room on its own
+	Dungeon *dungeon.Dungeon
+	// Outcome is set when an opportunity attack ended the fight
+	Outcome Outcome
+}
+
+type CurrentTurnInput struct {
+	RoomID string
+}
+
+// CurrentTurnOutput is whose turn it is in the room, Round and Turn tell one turn from the next
+type CurrentTurnOutput struct {
+	// Active is false once the fight is over
+	Active bool
+	// PlayerID is the player whose turn it is, empty on a monster's turn
+	PlayerID string
+	Round    int
+	Turn     int
+	// Outcome is how the fight ended once it is over
+	Outcome Outcome
+}
+
+// SkipTurnInput plays the default turn for a player who ran out of time. Round and Turn are the
+// turn the timer ran for, nothing is played once the fight has moved past it.
+type SkipTurnInput struct {
+	RoomID   string
+	PlayerID string
+	Round    int
+	Turn     int
+}
+
+type EnterDungeonInput struct {
+	PlayerID string
+	// PartyIDs are the other players that enter a new dungeon with the player
+	PartyIDs []string
+	// Seed builds the same dungeon as another player, a random one is used when nil. It is only
+	// used for a new dungeon.
+	Seed *int64
+}
+
+type EnterDungeonOutput struct {
+	Dungeon *dungeon.Dungeon
+	// Room is the fight in the party's room, nil when there is none
+	Room    *entities.Room
+	Log     []string
+	Outcome Outcome
+	// Trap is the trap or challenge in the party's room that is still to be dealt with
+	Trap *entities.Trap
+}
+
+type MoveInput struct {
+	PlayerID  string
+	Direction dungeon.Direction
+}
+
+type MoveOutput struct {
+	Dungeon *dungeon.Dungeon
+	// Room is the fight in the room the party moved into, nil when there is none
+	Room    *entities.Room
+	Log     []string
+	Outcome Outcome
+	// Loot is the hoard in a treasure room the party opened
+	Loot *entities.Loot
+	// Trap is the trap or challenge in the room the party moved into, nil when there is none
+	Trap *entities.Trap
+}
+
+type DisarmInput struct {
+	PlayerID string
+}
+
+type DisarmOutput struct {
+	Dungeon *dungeon.Dungeon
+	// Trap is left armed when the check failed without setting it off
+	Trap *entities.Trap
+	Log  []string
+}
+
+type CombatLogInput struct {
+	PlayerID string
+	// RoomID picks the fight, defaults to the player's latest room
+	RoomID string
+	Offset int
+	// Limit is the number of events to return, 0 returns them all
+	Limit int
+}
+
+type CombatLogOutput struct {
+	RoomID string
+	Events []*combat.Event
+	// Total is the number of events in the fight's log
+	Total int
+}
+
+type HistoryInput struct {
+	PlayerID string
+	Offset   int
+	// Limit is the number of rooms to return, defaults to defaultHistoryLimit
+	Limit int
+}
+
+type HistoryOutput struct {
+	// Rooms are the player's rooms, latest first
+	Rooms []*RoomSummary
+	// HasMore is true when there are older rooms after these
+	HasMore bool
+}
+
+// RoomSummary is a room the player fought in
+type RoomSummary struct {
+	ID     string
+	Status entities.RoomStatus
+	// Outcome is empty while the fight goes on
+	Outcome Outcome
+	// Monsters has the name of each monster the party faced
+	Monsters  []string
+	DungeonID string
+	// StartedAt and EndedAt are zero for rooms stored before they were kept
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+type AbandonInput struct {
+	PlayerID string
+}
+
+type AbandonOutput struct {
+	Room *entities.Room
+	// Dungeon is the dungeon the room was in, the party is back in the room they came from
+	Dungeon *dungeon.Dungeon
+}
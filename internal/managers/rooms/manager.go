@@ -2,31 +2,76 @@ package rooms
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/KirkDiggler/dnd-bot-go/clients/dnd5e"
 	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
 	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
 	"github.com/KirkDiggler/dnd-bot-go/internal/managers/characters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/loot"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/combatlog"
+	dungeonRepository "github.com/KirkDiggler/dnd-bot-go/internal/repositories/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/houserules"
 	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/monster"
 	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/trap"
 	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"math/rand"
+	"strings"
 )
 
-const defaultMonster = "goblin"
+const (
+	// maxRoomMonsters keeps a room's monster group small enough to show and target in a message
+	maxRoomMonsters = 4
+	// maxSeed keeps generated dungeon seeds short enough to share
+	maxSeed = 1_000_000
+	// maxUpdateAttempts is how many times a dungeon change is reloaded and reapplied after a conflict
+	maxUpdateAttempts = 3
+)
 
 type Implementation struct {
 	client           dnd5e.Client
 	characterManager characters.Manager
+	encounterManager encounters.Manager
+	lootManager      loot.Manager
 	roomRepo         room.Repository
 	monsterRepo      monster.Interface
+	dungeonRepo      dungeonRepository.Repository
+	combatLogRepo    combatlog.Repository
+	trapRepo         trap.Repository
+	houseRulesRepo   houserules.Repository
+	guildID          string
 	uuider           types.UUIDGenerator
+	roller           dice.Roller
+	timeClock        types.TimeClock
+	targetStrategy   combat.TargetStrategy
+	lootMode         entities.LootMode
+	// seeder picks the seed of a new dungeon when the player does not give one
+	seeder func() int64
 }
 
 type Config struct {
 	Client           dnd5e.Client
 	CharacterManager characters.Manager
+	EncounterManager encounters.Manager
+	LootManager      loot.Manager
 	RoomRepo         room.Repository
 	MonsterRepo      monster.Interface
+	DungeonRepo      dungeonRepository.Repository
+	CombatLogRepo    combatlog.Repository
+	TrapRepo         trap.Repository
+	// TargetStrategy is how monsters pick who to attack, defaults to the lowest hit points
+	TargetStrategy combat.TargetStrategy
+	// LootMode is how the party shares the items they find, defaults to need or greed
+	LootMode entities.LootMode
+	// HouseRulesRepo holds the guild's house rules for attacks, fights play by the SRD without it
+	HouseRulesRepo houserules.Repository
+	// GuildID is the guild whose house rules the fights use
+	GuildID string
 }
 
 func New(cfg *Config) (*Implementation, error) {
@@ -42,6 +87,14 @@ func New(cfg *Config) (*Implementation, error) {
 		return nil, dnderr.NewMissingParameterError("cfg.CharacterManager")
 	}
 
+	if cfg.EncounterManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.EncounterManager")
+	}
+
+	if cfg.LootManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.LootManager")
+	}
+
 	if cfg.RoomRepo == nil {
 		return nil, dnderr.NewMissingParameterError("cfg.RoomRepo")
 	}
@@ -50,12 +103,56 @@ func New(cfg *Config) (*Implementation, error) {
 		return nil, dnderr.NewMissingParameterError("cfg.MonsterRepo")
 	}
 
+	if cfg.DungeonRepo == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.DungeonRepo")
+	}
+
+	if cfg.CombatLogRepo == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.CombatLogRepo")
+	}
+
+	if cfg.TrapRepo == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.TrapRepo")
+	}
+
+	targetStrategy := cfg.TargetStrategy
+	switch targetStrategy {
+	case "":
+		targetStrategy = combat.TargetLowestHP
+	case combat.TargetLowestHP, combat.TargetRandom, combat.TargetNearest:
+	default:
+		return nil, dnderr.NewInvalidParameterError("cfg.TargetStrategy", string(targetStrategy))
+	}
+
+	lootMode := cfg.LootMode
+	switch lootMode {
+	case "":
+		lootMode = entities.LootModeNeedGreed
+	case entities.LootModeNeedGreed, entities.LootModeRoundRobin:
+	default:
+		return nil, dnderr.NewInvalidParameterError("cfg.LootMode", string(lootMode))
+	}
+
 	return &Implementation{
 		client:           cfg.Client,
 		characterManager: cfg.CharacterManager,
+		encounterManager: cfg.EncounterManager,
+		lootManager:      cfg.LootManager,
 		roomRepo:         cfg.RoomRepo,
 		monsterRepo:      cfg.MonsterRepo,
+		dungeonRepo:      cfg.DungeonRepo,
+		combatLogRepo:    cfg.CombatLogRepo,
+		trapRepo:         cfg.TrapRepo,
+		houseRulesRepo:   cfg.HouseRulesRepo,
+		guildID:          cfg.GuildID,
 		uuider:           &types.GoogleUUID{},
+		roller:           &dice.DefaultRoller{},
+		timeClock:        &types.Clock{},
+		targetStrategy:   targetStrategy,
+		lootMode:         lootMode,
+		seeder: func() int64 {
+			return rand.Int63n(maxSeed)
+		},
 	}, nil
 }
 
@@ -79,13 +176,7 @@ func (m *Implementation) LoadRoom(ctx context.Context, input *LoadRoomInput) (*L
 	}
 
 	if len(rooms) == 0 || rooms[0].Status == room.StatusInactive {
-		out, err := m.createRoom(ctx, input.PlayerID)
-		if err != nil {
-			return nil, err
-		}
-		return &LoadRoomOutput{
-			Room: out,
-		}, nil
+		return m.createRoom(ctx, append([]string{input.PlayerID}, input.PartyIDs...), "", input.Stealth)
 	}
 
 	out, err := m.hydrateRoom(ctx, rooms[0])
@@ -95,6 +186,63 @@ func (m *Implementation) LoadRoom(ctx context.Context, input *LoadRoomInput) (*L
 
 	return &LoadRoomOutput{
 		Room: out,
+		Log:  []string{},
+	}, nil
+}
+
+// CreatePartyRoom always puts the party in a new room, unlike LoadRoom it never picks up a fight
+// one of them is already in
+func (m *Implementation) CreatePartyRoom(ctx context.Context, input *CreatePartyRoomInput) (*LoadRoomOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if len(input.PlayerIDs) == 0 {
+		return nil, dnderr.NewMissingParameterError("input.PlayerIDs")
+	}
+
+	for _, playerID := range input.PlayerIDs {
+		_, err := m.getActiveRoom(ctx, playerID)
+		if err == nil {
+			char, err := m.characterManager.Get(ctx, playerID)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, dnderr.NewConflictError(fmt.Sprintf("%s is already in a fight", char.Name))
+		}
+
+		var notFoundErr *dnderr.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return nil, err
+		}
+	}
+
+	return m.createRoom(ctx, input.PlayerIDs, "", input.Stealth)
+}
+
+// GetRoom loads the room by its id whether or not the fight in it is over
+func (m *Implementation) GetRoom(ctx context.Context, input *GetRoomInput) (*GetRoomOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.RoomID == "" {
+		return nil, dnderr.NewMissingParameterError("input.RoomID")
+	}
+
+	data, err := m.roomRepo.Get(ctx, input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := m.hydrateRoom(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetRoomOutput{
+		Room: out,
 	}, nil
 }
 
@@ -128,6 +276,483 @@ func (m *Implementation) HasActiveRoom(ctx context.Context, input *HasActiveRoom
 	}, nil
 }
 
+// Attack plays the character's turn in their active room. Monsters ahead of the character in
+// the initiative order act first and the ones after act until it is a player's turn again, the
+// room is closed once a side drops.
+func (m *Implementation) Attack(ctx context.Context, input *AttackInput) (*AttackOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	data, err := m.getActiveRoom(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeRoom, err := m.hydrateRoom(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	engine, log, err := m.startFight(ctx, data, activeRoom)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := m.runMonsterTurns(engine)
+	if err != nil {
+		return nil, err
+	}
+
+	log = append(log, lines...)
+
+	if !engine.IsOver() {
+		current, err := engine.Current()
+		if err != nil {
+			return nil, err
+		}
+
+		if current.ID != input.PlayerID {
+			return nil, dnderr.NewConflictError(fmt.Sprintf("it is %s's turn", current.Combatant().GetName()))
+		}
+
+		targetID, err := chooseTarget(engine, activeRoom, input.TargetID)
+		if err != nil {
+			return nil, err
+		}
+
+		outcome, err := engine.Engage(targetID)
+		if err != nil {
+			return nil, err
+		}
+
+		log = append(log, outcome.Lines()...)
+	}
+
+	return m.finishTurn(ctx, data, activeRoom, engine, log)
+}
+
+// finishTurn ends the player's turn and plays the monsters' turns after it, saving the fight and
+// closing the room with the spoils once a side drops
+func (m *Implementation) finishTurn(ctx context.Context, data *room.Data, activeRoom *entities.Room, engine *combat.Engine, log []string) (*AttackOutput, error) {
+	if !engine.IsOver() {
+		_, err := engine.EndTurn()
+		if err != nil {
+			return nil, err
+		}
+
+		lines, err := m.runMonsterTurns(engine)
+		if err != nil {
+			return nil, err
+		}
+
+		log = append(log, lines...)
+	}
+
+	out := &AttackOutput{
+		Room:    activeRoom,
+		Log:     log,
+		Outcome: fightOutcome(engine),
+	}
+
+	if out.Outcome != OutcomeUnset {
+		m.endRoom(data, out.Outcome)
+	}
+
+	// the room is claimed before anything else is written, a turn played twice at once loses
+	// the check-and-set here before it can hurt anyone or pay out the spoils a second time
+	_, err := m.roomRepo.Update(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.saveCombatants(ctx, activeRoom)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.saveEvents(ctx, data.ID, engine, out.Outcome)
+	if err != nil {
+		return nil, err
+	}
+
+	activeRoom.Status = statusToEntity(data.Status)
+
+	if out.Outcome == OutcomeVictory {
+		out.Loot, err = m.victoryLoot(ctx, data.PlayerIDs, activeRoom)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Experience, err = m.awardExperience(ctx, activeRoom)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if data.DungeonID != "" {
+		var lines []string
+		out.Dungeon, lines, err = m.finishDungeonFight(ctx, data.DungeonID, out.Outcome)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Log = append(out.Log, lines...)
+	}
+
+	return out, nil
+}
+
+// chooseTarget checks the target is a monster in the room, picking the nearest one standing when
+// no target is given
+func chooseTarget(engine *combat.Engine, activeRoom *entities.Room, targetID string) (string, error) {
+	if targetID != "" {
+		if activeRoom.Monster(targetID) == nil {
+			return "", dnderr.NewInvalidParameterError("input.TargetID", "target is not a monster in the room")
+		}
+
+		return targetID, nil
+	}
+
+	current, err := engine.Current()
+	if err != nil {
+		return "", err
+	}
+
+	var nearest *entities.Monster
+	for _, mon := range activeRoom.Monsters {
+		if mon.IsDown() {
+			continue
+		}
+
+		if nearest == nil || engine.Distance(current.ID, mon.ID) < engine.Distance(current.ID, nearest.ID) {
+			nearest = mon
+		}
+	}
+
+	if nearest == nil {
+		return "", dnderr.NewNotFoundError("no monster left standing")
+	}
+
+	return nearest.ID, nil
+}
+
+// houseRules are the guild's rules for attacks, the SRD when it has not set any
+func (m *Implementation) houseRules(ctx context.Context) (*attack.Rules, error) {
+	if m.houseRulesRepo == nil || m.guildID == "" {
+		return attack.DefaultRules(), nil
+	}
+
+	rules, err := m.houseRulesRepo.Get(ctx, m.guildID)
+	if err != nil {
+		var notFoundErr *dnderr.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			return attack.DefaultRules(), nil
+		}
+
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// startFight joins the room's combatants to the combat engine, picking the stored fight back up
+// when there is one and rolling initiative when there is not
+func (m *Implementation) startFight(ctx context.Context, data *room.Data, activeRoom *entities.Room) (*combat.Engine, []string, error) {
+	engine, err := m.joinFight(ctx, data, activeRoom)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if engine.IsStarted() {
+		return engine, []string{}, nil
+	}
+
+	log, err := rollInitiative(engine)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return engine, log, nil
+}
+
+// joinFight joins the room's combatants to the combat engine and places them on the grid
+func (m *Implementation) joinFight(ctx context.Context, data *room.Data, activeRoom *entities.Room) (*combat.Engine, error) {
+	rules, err := m.houseRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var grid *combat.Grid
+	if data.Combat == nil {
+		grid, err = combat.GenerateGrid(m.seeder(), combat.DefaultGridWidth, combat.DefaultGridHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	engine, err := combat.New(&combat.Config{
+		Roller: m.roller,
+		Rules:  rules,
+		State:  data.Combat,
+		Grid:   grid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, char := range activeRoom.Characters {
+		err = engine.Join(char, combat.SideParty)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, mon := range activeRoom.Monsters {
+		err = engine.Join(mon, combat.SideMonsters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data.Combat = engine.State()
+	activeRoom.Grid = data.Combat.Grid
+
+	err = placeCombatants(activeRoom)
+	if err != nil {
+		return nil, err
+	}
+
+	return engine, nil
+}
+
+// rollInitiative starts the fight, the surprised lose their first turn
+func rollInitiative(engine *combat.Engine) ([]string, error) {
+	surprised := make(map[string]bool)
+	for _, participant := range engine.Participants() {
+		surprised[participant.ID] = participant.Surprised
+	}
+
+	order, err := engine.RollInitiative()
+	if err != nil {
+		return nil, err
+	}
+
+	initiative := make([]string, len(order))
+	for idx, participant := range order {
+		initiative[idx] = fmt.Sprintf("%s (%d)", participant.Combatant().GetName(), participant.Initiative)
+		if surprised[participant.ID] {
+			initiative[idx] = fmt.Sprintf("%s (%d, surprised)", participant.Combatant().GetName(), participant.Initiative)
+		}
+	}
+
+	return []string{fmt.Sprintf("Initiative: %s", strings.Join(initiative, ", "))}, nil
+}
+
+// runMonsterTurns plays monster turns until it is a player's turn or the fight is over
+func (m *Implementation) runMonsterTurns(engine *combat.Engine) ([]string, error) {
+	log := make([]string, 0)
+	for !engine.IsOver() {
+		current, err := engine.Current()
+		if err != nil {
+			return nil, err
+		}
+
+		if current.Side == combat.SideParty {
+			break
+		}
+
+		outcome, err := engine.TakeTurn(m.targetStrategy)
+		if err != nil {
+			return nil, err
+		}
+
+		log = append(log, outcome.Lines()...)
+	}
+
+	return log, nil
+}
+
+// saveEvents stores what happened in the fight this time, ending the log when the fight is over
+func (m *Implementation) saveEvents(ctx context.Context, roomID string, engine *combat.Engine, outcome Outcome) error {
+	if outcome != OutcomeUnset {
+		engine.RecordEnd(outcomeText(outcome))
+	}
+
+	_, err := m.combatLogRepo.Append(ctx, roomID, engine.Events())
+
+	return err
+}
+
+func outcomeText(outcome Outcome) string {
+	switch outcome {
+	case OutcomeVictory:
+		return "The party is victorious."
+	case OutcomeDefeat:
+		return "The party has fallen."
+	case OutcomeFled:
+		return "The party escapes."
+	case OutcomeAbandoned:
+		return "The party abandons the fight."
+	case OutcomeSurrendered:
+		return "The party surrenders."
+	default:
+		return ""
+	}
+}
+
+func fightOutcome(engine *combat.Engine) Outcome {
+	if !engine.IsOver() {
+		return OutcomeUnset
+	}
+
+	if winner, ok := engine.Winner(); ok && winner == combat.SideParty {
+		return OutcomeVictory
+	}
+
+	return OutcomeDefeat
+}
+
+// victoryLoot rolls the coins carried by the defeated monsters for the party
+func (m *Implementation) victoryLoot(ctx context.Context, playerIDs []string, activeRoom *entities.Room) (*entities.Loot, error) {
+	ratings := make([]float32, len(activeRoom.Monsters))
+	for idx, mon := range activeRoom.Monsters {
+		if mon.Template != nil {
+			ratings[idx] = mon.Template.ChallengeRating
+		}
+	}
+
+	return m.lootManager.Individual(ctx, &loot.IndividualInput{
+		PlayerIDs:        playerIDs,
+		Mode:             m.lootMode,
+		ChallengeRatings: ratings,
+	})
+}
+
+// awardExperience splits the experience of the defeated monsters between the characters left
+// standing, levelling them up when they pass the next level. Monsters still standing when the
+// party gets away are worth nothing.
+func (m *Implementation) awardExperience(ctx context.Context, activeRoom *entities.Room) ([]*ExperienceAward, error) {
+	total := 0
+	for _, mon := range activeRoom.Monsters {
+		if mon.Template != nil && mon.IsDown() {
+			total += mon.Template.XP
+		}
+	}
+
+	survivors := make([]*entities.Character, 0, len(activeRoom.Characters))
+	for _, char := range activeRoom.Characters {
+		if !char.IsDown() {
+			survivors = append(survivors, char)
+		}
+	}
+
+	if total == 0 || len(survivors) == 0 {
+		return []*ExperienceAward{}, nil
+	}
+
+	share := total / len(survivors)
+	awards := make([]*ExperienceAward, len(survivors))
+	for idx, survivor := range survivors {
+		gained := 0
+		updated, err := m.characterManager.Update(ctx, survivor.ID, func(char *entities.Character) error {
+			var err error
+			gained, err = char.AddExperience(share)
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		awards[idx] = &ExperienceAward{
+			CharacterID:   updated.ID,
+			Name:          updated.Name,
+			XP:            share,
+			Experience:    updated.Experience,
+			NextLevel:     updated.NextLevel,
+			Level:         max(updated.TotalLevel(), 1),
+			LevelsGained:  gained,
+			PendingLevels: updated.PendingLevels,
+		}
+	}
+
+	return awards, nil
+}
+
+// saveCombatants stores the hit points everyone in the room was left with
+func (m *Implementation) saveCombatants(ctx context.Context, activeRoom *entities.Room) error {
+	for _, mon := range activeRoom.Monsters {
+		_, err := m.monsterRepo.PutMonster(ctx, mon)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, char := range activeRoom.Characters {
+		err := m.saveHitPoints(ctx, char)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveHitPoints stores the hit points the character was left with after a fight
+func (m *Implementation) saveHitPoints(ctx context.Context, char *entities.Character) error {
+	_, err := m.characterManager.Update(ctx, char.ID, func(current *entities.Character) error {
+		current.CurrentHitPoints = char.CurrentHitPoints
+		current.TempHitPoints = char.TempHitPoints
+
+		return nil
+	})
+
+	return err
+}
+
+func (m *Implementation) getActiveRoom(ctx context.Context, playerID string) (*room.Data, error) {
+	rooms, err := m.roomRepo.ListByPlayer(ctx, &room.ListByPlayerInput{
+		PlayerID: playerID,
+		Limit:    1,
+		Offset:   0,
+		Reverse:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rooms) == 0 || rooms[0].Status != room.StatusActive {
+		return nil, dnderr.NewNotFoundError("no active room found, use /dungeon enter")
+	}
+
+	return rooms[0], nil
+}
+
+func (m *Implementation) closeRoom(ctx context.Context, data *room.Data, activeRoom *entities.Room, outcome Outcome) error {
+	m.endRoom(data, outcome)
+
+	_, err := m.roomRepo.Update(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	activeRoom.Status = statusToEntity(data.Status)
+
+	return nil
+}
+
+// endRoom marks the room as over with how the fight ended
+func (m *Implementation) endRoom(data *room.Data, outcome Outcome) {
+	data.Status = room.StatusInactive
+	data.Outcome = string(outcome)
+	data.EndedAt = m.timeClock.Now()
+}
+
 func statusToEntity(status room.Status) entities.RoomStatus {
 	switch status {
 	case room.StatusActive:
@@ -139,80 +764,216 @@ func statusToEntity(status room.Status) entities.RoomStatus {
 	}
 }
 
-func (m *Implementation) createRoom(ctx context.Context, playerID string) (*entities.Room, error) {
-	monsterTemplate, err := m.client.GetMonster(defaultMonster)
+// createRoom puts a new group of monsters in a room with the party and rolls initiative, monsters
+// that go first take their turns straight away. The party rests before a room on its own, in a
+// dungeon they carry their wounds from room to room.
+func (m *Implementation) createRoom(ctx context.Context, playerIDs []string, dungeonID string, stealth bool) (*LoadRoomOutput, error) {
+	characters := make([]*entities.Character, len(playerIDs))
+	levels := make([]int, len(playerIDs))
+	for idx, playerID := range playerIDs {
+		var character *entities.Character
+		var err error
+		if dungeonID == "" {
+			character, err = m.rest(ctx, playerID)
+		} else {
+			character, err = m.characterManager.Get(ctx, playerID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		characters[idx] = character
+		levels[idx] = max(character.TotalLevel(), 1)
+	}
+
+	encounter, err := m.encounterManager.Build(ctx, &encounters.BuildInput{
+		Difficulty:  encounters.DifficultyMedium,
+		PartyLevels: levels,
+		MaxMonsters: maxRoomMonsters,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	hp, err := dice.RollString(monsterTemplate.HitDice)
+	if len(encounter.Monsters) == 0 {
+		return nil, dnderr.NewNotFoundError("encounter has no monsters")
+	}
+
+	monsters, err := m.spawnMonsters(playerIDs[0], encounter.Monsters)
 	if err != nil {
 		return nil, err
 	}
 
-	mon, err := m.monsterRepo.PutMonster(ctx, &entities.Monster{
-		ID:          m.uuider.New(),
-		CharacterID: playerID,
-		Key:         monsterTemplate.Key,
-		CurrentHP:   hp.Total,
-	})
+	out := &entities.Room{
+		Status:     entities.RoomStatusActive,
+		Characters: characters,
+		Monsters:   monsters,
+	}
+
+	data := room.EntityToData(out)
+	data.DungeonID = dungeonID
+	data.StartedAt = m.timeClock.Now()
+
+	engine, err := m.joinFight(ctx, data, out)
+	if err != nil {
+		return nil, err
+	}
+
+	var surprise *Surprise
+	if stealth {
+		surprise, err = m.sneak(engine, characters, monsters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	log, err := rollInitiative(engine)
 	if err != nil {
 		return nil, err
 	}
 
-	mon.Template = monsterTemplate
+	if surprise != nil {
+		log = append(surprise.Lines(), log...)
+	}
 
-	character, err := m.characterManager.Get(ctx, playerID)
+	lines, err := m.runMonsterTurns(engine)
 	if err != nil {
 		return nil, err
 	}
 
-	data, err := m.roomRepo.Create(ctx, &room.Data{
-		PlayerID:  playerID,
-		MonsterID: mon.ID,
-		Status:    room.StatusActive,
-	})
+	for _, mon := range monsters {
+		_, err = m.monsterRepo.PutMonster(ctx, mon)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(lines) > 0 {
+		for _, char := range characters {
+			err = m.saveHitPoints(ctx, char)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	outcome := fightOutcome(engine)
+	if outcome != OutcomeUnset {
+		m.endRoom(data, outcome)
+	}
+
+	data, err = m.roomRepo.Create(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	out.ID = data.ID
+	out.Status = statusToEntity(data.Status)
+
+	err = m.saveEvents(ctx, data.ID, engine, outcome)
 	if err != nil {
 		return nil, err
 	}
 
-	return &entities.Room{
-		ID:        data.ID,
-		Status:    statusToEntity(data.Status),
-		Character: character,
-		Monster:   mon,
+	return &LoadRoomOutput{
+		Room:     out,
+		Log:      append(log, lines...),
+		Outcome:  outcome,
+		Surprise: surprise,
 	}, nil
 }
 
+// rest heals the character to full so a defeat does not carry over
+func (m *Implementation) rest(ctx context.Context, playerID string) (*entities.Character, error) {
+	return m.characterManager.Update(ctx, playerID, func(char *entities.Character) error {
+		char.Heal(char.MaxHitPoints)
+		char.TempHitPoints = 0
+
+		return nil
+	})
+}
+
+// spawnMonsters rolls hit points for each monster, monsters of the same kind are numbered so
+// they can be told apart
+func (m *Implementation) spawnMonsters(playerID string, templates []*entities.MonsterTemplate) ([]*entities.Monster, error) {
+	kinds := make(map[string]int)
+	for _, template := range templates {
+		kinds[template.Key]++
+	}
+
+	numbers := make(map[string]int)
+	monsters := make([]*entities.Monster, len(templates))
+	for idx, template := range templates {
+		hp, err := dice.RollString(template.HitDice)
+		if err != nil {
+			return nil, err
+		}
+
+		mon := &entities.Monster{
+			ID:          m.uuider.New(),
+			CharacterID: playerID,
+			Key:         template.Key,
+			CurrentHP:   hp.Total,
+			Template:    template,
+		}
+
+		if kinds[template.Key] > 1 {
+			numbers[template.Key]++
+			mon.Name = fmt.Sprintf("%s %d", template.Name, numbers[template.Key])
+		}
+
+		monsters[idx] = mon
+	}
+
+	return monsters, nil
+}
+
 func (m *Implementation) hydrateRoom(ctx context.Context, room *room.Data) (*entities.Room, error) {
 	if room == nil {
 		return nil, dnderr.NewMissingParameterError("room")
 	}
 
 	out := &entities.Room{
-		ID:     room.ID,
-		Status: statusToEntity(room.Status),
+		ID:         room.ID,
+		Status:     statusToEntity(room.Status),
+		Characters: make([]*entities.Character, len(room.PlayerIDs)),
+		Monsters:   make([]*entities.Monster, len(room.MonsterIDs)),
 	}
 
-	character, err := m.characterManager.Get(ctx, room.PlayerID)
-	if err != nil {
-		return nil, err
+	for idx, playerID := range room.PlayerIDs {
+		character, err := m.characterManager.Get(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Characters[idx] = character
 	}
 
-	out.Character = character
+	// monsters in a group usually share a template
+	templates := make(map[string]*entities.MonsterTemplate)
+	for idx, monsterID := range room.MonsterIDs {
+		mon, err := m.monsterRepo.GetMonster(ctx, monsterID)
+		if err != nil {
+			return nil, err
+		}
 
-	mon, err := m.monsterRepo.GetMonster(ctx, room.MonsterID)
-	if err != nil {
-		return nil, err
-	}
+		template, ok := templates[mon.Key]
+		if !ok {
+			template, err = m.client.GetMonster(mon.Key)
+			if err != nil {
+				return nil, err
+			}
 
-	monsterTemplate, err := m.client.GetMonster(mon.Key)
-	if err != nil {
-		return nil, err
+			templates[mon.Key] = template
+		}
+
+		mon.Template = template
+		out.Monsters[idx] = mon
 	}
 
-	mon.Template = monsterTemplate
-	out.Monster = mon
+	if room.Combat != nil {
+		out.Grid = room.Combat.Grid
+	}
 
 	return out, nil
 }
@@ -1 +1,477 @@
 package rooms
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/clients/dnd5e"
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/damage"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/characters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/loot"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/combatlog"
+	dungeonRepository "github.com/KirkDiggler/dnd-bot-go/internal/repositories/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/houserules"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/monster"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/trap"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteManager struct {
+	suite.Suite
+
+	ctx         context.Context
+	client      *dnd5e.Mock
+	charManager *characters.Mock
+	encounters  *encounters.Mock
+	loot        *loot.Mock
+	roomRepo    *room.Mock
+	monsterRepo *monster.Mock
+	dungeonRepo *dungeonRepository.Mock
+	combatLog   *combatlog.Mock
+	traps       *trap.Mock
+	uuider      *types.MockUUID
+	roller      *dice.MockRoller
+	clock       *types.MockClock
+	fixture     *Implementation
+
+	now      time.Time
+	playerID string
+	char     *entities.Character
+	template *entities.MonsterTemplate
+	monster  *entities.Monster
+	room     *room.Data
+}
+
+func (s *suiteManager) SetupTest() {
+	s.ctx = context.Background()
+	s.client = &dnd5e.Mock{}
+	s.charManager = &characters.Mock{}
+	s.encounters = &encounters.Mock{}
+	s.loot = &loot.Mock{}
+	s.roomRepo = &room.Mock{}
+	s.monsterRepo = &monster.Mock{}
+	s.dungeonRepo = &dungeonRepository.Mock{}
+	s.combatLog = &combatlog.Mock{}
+	s.traps = &trap.Mock{}
+	s.uuider = &types.MockUUID{}
+	s.roller = &dice.MockRoller{}
+	s.clock = &types.MockClock{}
+	s.now = time.Date(2024, 3, 1, 18, 30, 0, 0, time.UTC)
+	s.clock.On("Now").Return(s.now)
+
+	s.fixture = &Implementation{
+		client:           s.client,
+		characterManager: s.charManager,
+		encounterManager: s.encounters,
+		lootManager:      s.loot,
+		roomRepo:         s.roomRepo,
+		monsterRepo:      s.monsterRepo,
+		dungeonRepo:      s.dungeonRepo,
+		combatLogRepo:    s.combatLog,
+		trapRepo:         s.traps,
+		uuider:           s.uuider,
+		roller:           s.roller,
+		timeClock:        s.clock,
+		targetStrategy:   combat.TargetLowestHP,
+		lootMode:         entities.LootModeNeedGreed,
+		seeder: func() int64 {
+			return 42
+		},
+	}
+
+	s.combatLog.On("Append", s.ctx, mock.Anything, mock.Anything).Return([]*combat.Event{}, nil).Maybe()
+
+	// ties go to whoever joined first so the character always acts first
+	s.roller.On("Roll", 1, 20, 0).Return(&dice.RollResult{Total: 15}, nil)
+
+	s.playerID = "player-1"
+	s.char = &entities.Character{
+		ID:               s.playerID,
+		Name:             "Tester",
+		AC:               12,
+		MaxHitPoints:     12,
+		CurrentHitPoints: 12,
+	}
+	s.char.AddAttribute(entities.AttributeStrength, 14)
+
+	s.template = &entities.MonsterTemplate{
+		Key:        "goblin",
+		Name:       "Goblin",
+		ArmorClass: 15,
+		HitPoints:  7,
+		HitDice:    "2d6",
+		Actions: []*entities.MonsterAction{{
+			Name:        "Scimitar",
+			AttackBonus: 4,
+			Damage:      []*damage.Damage{{DiceCount: 1, DiceSize: 6, Bonus: 2, DamageType: damage.TypeSlashing}},
+		}},
+	}
+	s.monster = &entities.Monster{
+		ID:          "monster-1",
+		CharacterID: s.playerID,
+		Key:         "goblin",
+		CurrentHP:   7,
+	}
+	s.room = &room.Data{
+		ID:         "room-1",
+		Status:     room.StatusActive,
+		PlayerIDs:  []string{s.playerID},
+		MonsterIDs: []string{s.monster.ID},
+	}
+}
+
+func (s *suiteManager) expectActiveRoom() {
+	s.roomRepo.On("ListByPlayer", s.ctx, &room.ListByPlayerInput{
+		PlayerID: s.playerID,
+		Limit:    1,
+		Reverse:  true,
+	}).Return([]*room.Data{s.room}, nil)
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.monsterRepo.On("GetMonster", s.ctx, s.monster.ID).Return(s.monster, nil)
+	s.client.On("GetMonster", "goblin").Return(s.template, nil)
+}
+
+func (s *suiteManager) TestLoadRoomCreatesRoom() {
+	s.char.CurrentHitPoints = 0
+	s.char.TempHitPoints = 3
+
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+	s.encounters.On("Build", s.ctx, &encounters.BuildInput{
+		Difficulty:  encounters.DifficultyMedium,
+		PartyLevels: []int{1},
+		MaxMonsters: maxRoomMonsters,
+	}).Return(&encounters.BuildOutput{
+		Difficulty: encounters.DifficultyMedium,
+		Monsters:   []*entities.MonsterTemplate{s.template},
+	}, nil)
+	s.uuider.On("New").Return(s.monster.ID)
+	s.monsterRepo.On("PutMonster", s.ctx, mock.Anything).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Create", s.ctx, mock.MatchedBy(func(data *room.Data) bool {
+		return slices.Equal(data.PlayerIDs, []string{s.playerID}) &&
+			slices.Equal(data.MonsterIDs, []string{s.monster.ID}) &&
+			data.Status == room.StatusActive &&
+			data.StartedAt.Equal(s.now) &&
+			data.Combat.Round == 1 &&
+			data.Combat.Order[0].ID == s.playerID &&
+			data.Combat.Grid.Positions[s.playerID].X == 0 &&
+			data.Combat.Grid.Positions[s.monster.ID].X == combat.DefaultGridWidth-1
+	})).Return(s.room, nil)
+
+	result, err := s.fixture.LoadRoom(s.ctx, &LoadRoomInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(s.room.ID, result.Room.ID)
+	s.Equal(entities.RoomStatusActive, result.Room.Status)
+	s.Equal(s.template, result.Room.Monsters[0].Template)
+	s.Equal("Goblin", result.Room.Monsters[0].GetName())
+	s.Equal(12, result.Room.Characters[0].CurrentHitPoints)
+	s.Equal(0, result.Room.Characters[0].TempHitPoints)
+	s.Equal([]string{"Initiative: Tester (15), Goblin (15)"}, result.Log)
+	s.Equal(OutcomeUnset, result.Outcome)
+}
+
+func (s *suiteManager) TestLoadRoomCreatesPartyRoom() {
+	friend := &entities.Character{ID: "player-2", Name: "Friend", AC: 14, Level: 3, MaxHitPoints: 20, CurrentHitPoints: 20}
+
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.charManager.On("Update", s.ctx, friend.ID, mock.Anything).Return(friend, nil)
+	s.encounters.On("Build", s.ctx, &encounters.BuildInput{
+		Difficulty:  encounters.DifficultyMedium,
+		PartyLevels: []int{1, 3},
+		MaxMonsters: maxRoomMonsters,
+	}).Return(&encounters.BuildOutput{
+		Difficulty: encounters.DifficultyMedium,
+		Monsters:   []*entities.MonsterTemplate{s.template, s.template},
+	}, nil)
+	s.uuider.On("New").Return("monster-1").Once()
+	s.uuider.On("New").Return("monster-2").Once()
+	s.monsterRepo.On("PutMonster", s.ctx, mock.Anything).Return(s.monster, nil)
+	s.roomRepo.On("Create", s.ctx, mock.MatchedBy(func(data *room.Data) bool {
+		return slices.Equal(data.PlayerIDs, []string{s.playerID, friend.ID}) &&
+			slices.Equal(data.MonsterIDs, []string{"monster-1", "monster-2"}) &&
+			len(data.Combat.Order) == 4
+	})).Return(s.room, nil)
+
+	result, err := s.fixture.LoadRoom(s.ctx, &LoadRoomInput{
+		PlayerID: s.playerID,
+		PartyIDs: []string{friend.ID},
+	})
+	s.NoError(err)
+	s.Equal([]*entities.Character{s.char, friend}, result.Room.Characters)
+	s.Len(result.Room.Monsters, 2)
+	s.Equal("Goblin 1", result.Room.Monsters[0].GetName())
+	s.Equal("Goblin 2", result.Room.Monsters[1].GetName())
+	s.Equal([]string{"Initiative: Tester (15), Friend (15), Goblin 1 (15), Goblin 2 (15)"}, result.Log)
+	s.monsterRepo.AssertNumberOfCalls(s.T(), "PutMonster", 2)
+}
+
+func (s *suiteManager) TestCreatePartyRoom() {
+	friend := &entities.Character{ID: "player-2", Name: "Friend", AC: 14, Level: 3, MaxHitPoints: 20, CurrentHitPoints: 20}
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.charManager.On("Update", s.ctx, friend.ID, mock.Anything).Return(friend, nil)
+	s.encounters.On("Build", s.ctx, mock.Anything).Return(&encounters.BuildOutput{
+		Difficulty: encounters.DifficultyMedium,
+		Monsters:   []*entities.MonsterTemplate{s.template},
+	}, nil)
+	s.uuider.On("New").Return(s.monster.ID)
+	s.monsterRepo.On("PutMonster", s.ctx, mock.Anything).Return(s.monster, nil)
+	s.roomRepo.On("Create", s.ctx, mock.MatchedBy(func(data *room.Data) bool {
+		return slices.Equal(data.PlayerIDs, []string{s.playerID, friend.ID})
+	})).Return(s.room, nil)
+
+	result, err := s.fixture.CreatePartyRoom(s.ctx, &CreatePartyRoomInput{
+		PlayerIDs: []string{s.playerID, friend.ID},
+	})
+	s.NoError(err)
+	s.Equal([]*entities.Character{s.char, friend}, result.Room.Characters)
+	s.roomRepo.AssertNumberOfCalls(s.T(), "ListByPlayer", 2)
+}
+
+func (s *suiteManager) TestCreatePartyRoomPlayerAlreadyFighting() {
+	friend := &entities.Character{ID: "player-2", Name: "Friend"}
+	s.roomRepo.On("ListByPlayer", s.ctx, &room.ListByPlayerInput{PlayerID: s.playerID, Limit: 1, Reverse: true}).
+		Return([]*room.Data{}, nil)
+	s.roomRepo.On("ListByPlayer", s.ctx, &room.ListByPlayerInput{PlayerID: friend.ID, Limit: 1, Reverse: true}).
+		Return([]*room.Data{s.room}, nil)
+	s.charManager.On("Get", s.ctx, friend.ID).Return(friend, nil)
+
+	_, err := s.fixture.CreatePartyRoom(s.ctx, &CreatePartyRoomInput{
+		PlayerIDs: []string{s.playerID, friend.ID},
+	})
+	s.EqualError(err, dnderr.NewConflictError("Friend is already in a fight").Error())
+	s.roomRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestLoadRoomHydratesActiveRoom() {
+	s.expectActiveRoom()
+
+	result, err := s.fixture.LoadRoom(s.ctx, &LoadRoomInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal([]*entities.Character{s.char}, result.Room.Characters)
+	s.Equal([]*entities.Monster{s.monster}, result.Room.Monsters)
+	s.Equal(s.template, result.Room.Monsters[0].Template)
+}
+
+func (s *suiteManager) TestAttackWithoutActiveRoom() {
+	s.room.Status = room.StatusInactive
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{s.room}, nil)
+
+	result, err := s.fixture.Attack(s.ctx, &AttackInput{PlayerID: s.playerID})
+	s.Error(err)
+	s.IsType(&dnderr.NotFoundError{}, err)
+	s.Nil(result)
+}
+
+func (s *suiteManager) TestAttackSavesTheRound() {
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(&entities.Character{}, nil)
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+	s.loot.On("Individual", s.ctx, mock.Anything).Return(&entities.Loot{ID: "loot-1"}, nil).Maybe()
+
+	result, err := s.fixture.Attack(s.ctx, &AttackInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.NotEmpty(result.Log)
+
+	switch result.Outcome {
+	case OutcomeVictory:
+		s.True(s.monster.IsDown())
+		s.Equal(entities.RoomStatusInactive, result.Room.Status)
+	case OutcomeDefeat:
+		s.True(s.char.IsDown())
+		s.Equal(entities.RoomStatusInactive, result.Room.Status)
+	default:
+		s.Equal(entities.RoomStatusActive, result.Room.Status)
+	}
+
+	s.NotNil(s.room.Combat)
+	s.Equal("Initiative: Tester (15), Goblin (15)", result.Log[0])
+	s.True(strings.HasPrefix(result.Log[1], "Tester "))
+
+	s.monsterRepo.AssertExpectations(s.T())
+	s.charManager.AssertExpectations(s.T())
+	s.roomRepo.AssertExpectations(s.T())
+}
+
+func (s *suiteManager) TestAttackVictoryRollsLoot() {
+	s.monster.CurrentHP = 0
+	s.template.ChallengeRating = 0.25
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+	found := &entities.Loot{ID: "loot-1", Coins: &entities.Coins{Gold: 3}}
+	s.loot.On("Individual", s.ctx, &loot.IndividualInput{
+		PlayerIDs:        []string{s.playerID},
+		Mode:             entities.LootModeNeedGreed,
+		ChallengeRatings: []float32{0.25},
+	}).Return(found, nil)
+
+	result, err := s.fixture.Attack(s.ctx, &AttackInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(OutcomeVictory, result.Outcome)
+	s.Equal(found, result.Loot)
+	s.loot.AssertExpectations(s.T())
+	s.combatLog.AssertCalled(s.T(), "Append", s.ctx, s.room.ID, mock.MatchedBy(func(events []*combat.Event) bool {
+		last := events[len(events)-1]
+		return last.Type == combat.EventTypeEnd && last.Text == "The party is victorious."
+	}))
+}
+
+func (s *suiteManager) TestAttackVictoryLosingTheRoomPaysNothing() {
+	s.char.Experience = 10
+	s.monster.CurrentHP = 0
+	s.template.XP = 100
+	s.expectActiveRoom()
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, s.room).Return(nil, dnderr.NewConflictError("room was updated during the write"))
+
+	_, err := s.fixture.Attack(s.ctx, &AttackInput{PlayerID: s.playerID})
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Equal(10, s.char.Experience)
+	s.loot.AssertNotCalled(s.T(), "Individual", mock.Anything, mock.Anything)
+	s.charManager.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+	s.monsterRepo.AssertNotCalled(s.T(), "PutMonster", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestAttackVictorySplitsExperience() {
+	friend := &entities.Character{ID: "player-2", Name: "Friend", AC: 14, MaxHitPoints: 20, CurrentHitPoints: 20}
+	fallen := &entities.Character{ID: "player-3", Name: "Fallen", AC: 14, MaxHitPoints: 20}
+	s.room.PlayerIDs = []string{s.playerID, friend.ID, fallen.ID}
+	s.char.Class = &entities.Class{Key: "fighter", Name: "Fighter", HitDie: 10}
+	s.char.Level = 1
+	s.char.Experience = 250
+	s.monster.CurrentHP = 0
+	s.template.XP = 100
+	s.expectActiveRoom()
+	s.charManager.On("Get", s.ctx, friend.ID).Return(friend, nil)
+	s.charManager.On("Get", s.ctx, fallen.ID).Return(fallen, nil)
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.charManager.On("Update", s.ctx, friend.ID, mock.Anything).Return(friend, nil)
+	s.charManager.On("Update", s.ctx, fallen.ID, mock.Anything).Return(fallen, nil)
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+	s.loot.On("Individual", s.ctx, mock.Anything).Return(&entities.Loot{ID: "loot-1"}, nil)
+
+	result, err := s.fixture.Attack(s.ctx, &AttackInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(OutcomeVictory, result.Outcome)
+	s.Equal([]*ExperienceAward{{
+		CharacterID:   s.playerID,
+		Name:          "Tester",
+		XP:            50,
+		Experience:    300,
+		NextLevel:     900,
+		Level:         1,
+		LevelsGained:  1,
+		PendingLevels: 1,
+	}, {
+		CharacterID: friend.ID,
+		Name:        "Friend",
+		XP:          50,
+		Experience:  50,
+		NextLevel:   300,
+		Level:       1,
+	}}, result.Experience)
+	s.Equal(0, fallen.Experience)
+}
+
+func (s *suiteManager) TestAttackOutOfTurn() {
+	friend := &entities.Character{ID: "player-2", Name: "Friend", AC: 14, MaxHitPoints: 20, CurrentHitPoints: 20}
+	s.room.PlayerIDs = []string{s.playerID, friend.ID}
+	s.expectActiveRoom()
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{s.room}, nil)
+	s.charManager.On("Get", s.ctx, friend.ID).Return(friend, nil)
+
+	_, err := s.fixture.Attack(s.ctx, &AttackInput{PlayerID: friend.ID})
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.EqualError(err, "it is Tester's turn")
+	s.roomRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestAttackUnknownTarget() {
+	s.expectActiveRoom()
+
+	_, err := s.fixture.Attack(s.ctx, &AttackInput{PlayerID: s.playerID, TargetID: s.playerID})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+}
+
+func (s *suiteManager) TestFlee() {
+	s.fightOnGrid()
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, mock.MatchedBy(func(data *room.Data) bool {
+		return data.Status == room.StatusInactive && data.Outcome == string(OutcomeFled) && data.EndedAt.Equal(s.now)
+	})).Return(s.room, nil)
+
+	result, err := s.fixture.Flee(s.ctx, &FleeInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(OutcomeFled, result.Outcome)
+	s.Equal(entities.RoomStatusInactive, result.Room.Status)
+	s.Empty(result.Log)
+	s.Empty(result.Experience)
+	s.combatLog.AssertCalled(s.T(), "Append", s.ctx, s.room.ID, mock.MatchedBy(func(events []*combat.Event) bool {
+		return len(events) == 2 &&
+			events[0].Type == combat.EventTypeCondition && events[0].Condition == "fled" && events[0].Text == "Tester flees." &&
+			events[1].Type == combat.EventTypeEnd && events[1].Text == "The party escapes."
+	}))
+}
+
+func (s *suiteManager) TestCombatLogUsesLatestRoom() {
+	s.room.Status = room.StatusInactive
+	s.roomRepo.On("ListByPlayer", s.ctx, &room.ListByPlayerInput{
+		PlayerID: s.playerID,
+		Limit:    1,
+		Reverse:  true,
+	}).Return([]*room.Data{s.room}, nil)
+	events := []*combat.Event{{Seq: 5, Round: 2, Type: combat.EventTypeAttack, Text: "Tester hits Goblin."}}
+	s.combatLog.On("Count", s.ctx, s.room.ID).Return(12, nil)
+	s.combatLog.On("List", s.ctx, &combatlog.ListInput{RoomID: s.room.ID, Offset: 5, Limit: 5}).Return(events, nil)
+
+	result, err := s.fixture.CombatLog(s.ctx, &CombatLogInput{PlayerID: s.playerID, Offset: 5, Limit: 5})
+	s.NoError(err)
+	s.Equal(&CombatLogOutput{RoomID: s.room.ID, Events: events, Total: 12}, result)
+}
+
+func (s *suiteManager) TestCombatLogWithoutFights() {
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+
+	_, err := s.fixture.CombatLog(s.ctx, &CombatLogInput{PlayerID: s.playerID})
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *suiteManager) TestHouseRules() {
+	houseRules := &houserules.Mock{}
+	s.fixture.houseRulesRepo = houseRules
+	s.fixture.guildID = "guild-1"
+
+	rules := &attack.Rules{CritDamage: attack.CritDamageMax}
+	houseRules.On("Get", s.ctx, "guild-1").Return(rules, nil).Once()
+	result, err := s.fixture.houseRules(s.ctx)
+	s.NoError(err)
+	s.Equal(rules, result)
+
+	houseRules.On("Get", s.ctx, "guild-1").Return(nil, dnderr.NewNotFoundError("house rules not found")).Once()
+	result, err = s.fixture.houseRules(s.ctx)
+	s.NoError(err)
+	s.Equal(attack.DefaultRules(), result)
+}
+
+func TestSuiteManager(t *testing.T) {
+	suite.Run(t, new(suiteManager))
+}
@@ -0,0 +1,201 @@
+package rooms
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) LoadRoom(ctx context.Context, input *LoadRoomInput) (*LoadRoomOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*LoadRoomOutput), nil
+}
+
+func (m *Mock) CreatePartyRoom(ctx context.Context, input *CreatePartyRoomInput) (*LoadRoomOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*LoadRoomOutput), nil
+}
+
+func (m *Mock) GetRoom(ctx context.Context, input *GetRoomInput) (*GetRoomOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetRoomOutput), nil
+}
+
+func (m *Mock) HasActiveRoom(ctx context.Context, input *HasActiveRoomInput) (*HasActiveRoomOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*HasActiveRoomOutput), nil
+}
+
+func (m *Mock) Attack(ctx context.Context, input *AttackInput) (*AttackOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*AttackOutput), nil
+}
+
+func (m *Mock) Flee(ctx context.Context, input *FleeInput) (*FleeOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*FleeOutput), nil
+}
+
+func (m *Mock) Step(ctx context.Context, input *StepInput) (*StepOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*StepOutput), nil
+}
+
+func (m *Mock) CurrentTurn(ctx context.Context, input *CurrentTurnInput) (*CurrentTurnOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CurrentTurnOutput), nil
+}
+
+func (m *Mock) SkipTurn(ctx context.Context, input *SkipTurnInput) (*AttackOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*AttackOutput), nil
+}
+
+func (m *Mock) EnterDungeon(ctx context.Context, input *EnterDungeonInput) (*EnterDungeonOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*EnterDungeonOutput), nil
+}
+
+func (m *Mock) Move(ctx context.Context, input *MoveInput) (*MoveOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*MoveOutput), nil
+}
+
+func (m *Mock) Disarm(ctx context.Context, input *DisarmInput) (*DisarmOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*DisarmOutput), nil
+}
+
+func (m *Mock) CombatLog(ctx context.Context, input *CombatLogInput) (*CombatLogOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CombatLogOutput), nil
+}
+
+func (m *Mock) History(ctx context.Context, input *HistoryInput) (*HistoryOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*HistoryOutput), nil
+}
+
+func (m *Mock) Abandon(ctx context.Context, input *AbandonInput) (*AbandonOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*AbandonOutput), nil
+}
+
+func (m *Mock) Disengage(ctx context.Context, input *DisengageInput) (*StepOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*StepOutput), nil
+}
+
+func (m *Mock) Help(ctx context.Context, input *HelpInput) (*StepOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*StepOutput), nil
+}
+
+func (m *Mock) EndTurn(ctx context.Context, input *EndTurnInput) (*AttackOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*AttackOutput), nil
+}
+
+func (m *Mock) Surrender(ctx context.Context, input *SurrenderInput) (*SurrenderOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*SurrenderOutput), nil
+}
@@ -0,0 +1,426 @@
+package rooms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+)
+
+// playerTurn is the player's fight loaded up on their turn
+type playerTurn struct {
+	data    *room.Data
+	room    *entities.Room
+	engine  *combat.Engine
+	current *combat.Participant
+	log     []string
+}
+
+// loadTurn picks up the player's fight, it has to be their turn
+func (m *Implementation) loadTurn(ctx context.Context, playerID string) (*playerTurn, error) {
+	data, err := m.getActiveRoom(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeRoom, err := m.hydrateRoom(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	engine, log, err := m.startFight(ctx, data, activeRoom)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := engine.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	if current.ID != playerID {
+		return nil, dnderr.NewConflictError(fmt.Sprintf("it is %s's turn", current.Combatant().GetName()))
+	}
+
+	return &playerTurn{
+		data:    data,
+		room:    activeRoom,
+		engine:  engine,
+		current: current,
+		log:     log,
+	}, nil
+}
+
+// Disengage spends the character's action so they can move away from the monsters without
+// provoking opportunity attacks this turn
+func (m *Implementation) Disengage(ctx context.Context, input *DisengageInput) (*StepOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	turn, err := m.loadTurn(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = turn.engine.Disengage()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.roomRepo.Update(ctx, turn.data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.saveEvents(ctx, turn.data.ID, turn.engine, OutcomeUnset)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &StepOutput{
+		Room: turn.room,
+		Log:  append(turn.log, fmt.Sprintf("%s takes the Disengage action and can move away safely.", turn.current.Combatant().GetName())),
+	}
+
+	if turn.data.DungeonID != "" {
+		out.Dungeon, err = m.dungeonRepo.Get(ctx, turn.data.DungeonID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// Help spends the character's action distracting a monster next to them, the next attack on it
+// by the party has advantage
+func (m *Implementation) Help(ctx context.Context, input *HelpInput) (*StepOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	turn, err := m.loadTurn(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetID, err := chooseTarget(turn.engine, turn.room, input.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = turn.engine.Help(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.roomRepo.Update(ctx, turn.data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.saveEvents(ctx, turn.data.ID, turn.engine, OutcomeUnset)
+	if err != nil {
+		return nil, err
+	}
+
+	target := turn.room.Monster(targetID)
+	out := &StepOutput{
+		Room: turn.room,
+		Log:  append(turn.log, fmt.Sprintf("%s distracts %s, the next attack on it has advantage.", turn.current.Combatant().GetName(), target.GetName())),
+	}
+
+	if turn.data.DungeonID != "" {
+		out.Dungeon, err = m.dungeonRepo.Get(ctx, turn.data.DungeonID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// EndTurn ends the character's turn, the monsters then act until it is a player's turn again
+func (m *Implementation) EndTurn(ctx context.Context, input *EndTurnInput) (*AttackOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	turn, err := m.loadTurn(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	log := append(turn.log, fmt.Sprintf("%s ends their turn.", turn.current.Combatant().GetName()))
+
+	return m.finishTurn(ctx, turn.data, turn.room, turn.engine, log)
+}
+
+// Flee has the whole party run from the fight on the player's turn. Every monster with a
+// character in reach gets an opportunity attack as they go, a character who disengaged first
+// slips away. The party earns the experience for the monsters they dropped but leaves the loot.
+func (m *Implementation) Flee(ctx context.Context, input *FleeInput) (*FleeOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	turn, err := m.loadTurn(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	log := turn.log
+	for _, char := range turn.room.Characters {
+		if char.IsDown() {
+			continue
+		}
+
+		outcomes, err := turn.engine.OpportunityAttacks(char.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, outcome := range outcomes {
+			log = append(log, outcome.Lines()...)
+		}
+	}
+
+	outcome := fightOutcome(turn.engine)
+	if outcome == OutcomeUnset {
+		outcome = OutcomeFled
+		for _, char := range turn.room.Characters {
+			if !char.IsDown() {
+				turn.engine.RecordCondition(char.ID, string(OutcomeFled), fmt.Sprintf("%s flees.", char.Name))
+			}
+		}
+	}
+
+	// the room is claimed first, a second Flee at the same time loses here before anyone is hurt
+	// or paid twice
+	err = m.closeRoom(ctx, turn.data, turn.room, outcome)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.saveCombatants(ctx, turn.room)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.saveEvents(ctx, turn.data.ID, turn.engine, outcome)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &FleeOutput{
+		Room:    turn.room,
+		Log:     log,
+		Outcome: outcome,
+	}
+
+	out.Experience, err = m.awardExperience(ctx, turn.room)
+	if err != nil {
+		return nil, err
+	}
+
+	if turn.data.DungeonID != "" {
+		var lines []string
+		out.Dungeon, lines, err = m.finishDungeonFight(ctx, turn.data.DungeonID, outcome)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Log = append(out.Log, lines...)
+	}
+
+	return out, nil
+}
+
+// Surrender has the party give up on the player's turn, what happens is up to the monster
+// leading the fight. Monsters that do not take prisoners ignore it and the character's action is
+// spent, the rest let the party go for their terms.
+func (m *Implementation) Surrender(ctx context.Context, input *SurrenderInput) (*SurrenderOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	turn, err := m.loadTurn(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var leader *entities.Monster
+	for _, mon := range turn.room.Monsters {
+		if !mon.IsDown() {
+			leader = mon
+			break
+		}
+	}
+
+	if leader == nil {
+		return nil, dnderr.NewNotFoundError("no monster left standing")
+	}
+
+	name := turn.current.Combatant().GetName()
+	terms := surrenderTerms(leader)
+	if terms == SurrenderRefused {
+		if turn.current.Budget.Action {
+			err = turn.engine.UseAction()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		log := append(turn.log, fmt.Sprintf("%s tries to surrender, %s is not taking prisoners.", name, leader.GetName()))
+		result, err := m.finishTurn(ctx, turn.data, turn.room, turn.engine, log)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SurrenderOutput{
+			Room:       result.Room,
+			Log:        result.Log,
+			Terms:      terms,
+			Outcome:    result.Outcome,
+			Dungeon:    result.Dungeon,
+			Experience: result.Experience,
+		}, nil
+	}
+
+	out := &SurrenderOutput{
+		Room:    turn.room,
+		Log:     append(turn.log, fmt.Sprintf("%s surrenders to %s.", name, leader.GetName())),
+		Terms:   terms,
+		Outcome: OutcomeSurrendered,
+		Tribute: make([]*Tribute, 0, len(turn.room.Characters)),
+	}
+
+	for _, char := range turn.room.Characters {
+		turn.engine.RecordCondition(char.ID, string(OutcomeSurrendered), fmt.Sprintf("%s surrenders.", char.Name))
+	}
+
+	// the room is claimed before the tribute is taken, a second Surrender at the same time loses
+	// here instead of emptying the purses twice
+	err = m.closeRoom(ctx, turn.data, turn.room, OutcomeSurrendered)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, char := range turn.room.Characters {
+		if terms == SurrenderSpared || char.Coins.IsZero() {
+			continue
+		}
+
+		taken := &entities.Coins{}
+		_, err = m.characterManager.Update(ctx, char.ID, func(current *entities.Character) error {
+			taken = payTribute(&current.Coins, terms)
+			char.Coins = current.Coins
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		out.Tribute = append(out.Tribute, &Tribute{
+			CharacterID: char.ID,
+			Name:        char.Name,
+			Coins:       taken,
+		})
+		out.Log = append(out.Log, fmt.Sprintf("%s hands over %s.", char.Name, taken.String()))
+	}
+
+	if terms == SurrenderSpared {
+		out.Log = append(out.Log, fmt.Sprintf("%s lets the party go.", leader.GetName()))
+	}
+
+	err = m.saveCombatants(ctx, turn.room)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.saveEvents(ctx, turn.data.ID, turn.engine, OutcomeSurrendered)
+	if err != nil {
+		return nil, err
+	}
+
+	out.Experience, err = m.awardExperience(ctx, turn.room)
+	if err != nil {
+		return nil, err
+	}
+
+	if turn.data.DungeonID != "" {
+		var lines []string
+		out.Dungeon, lines, err = m.finishDungeonFight(ctx, turn.data.DungeonID, OutcomeSurrendered)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Log = append(out.Log, lines...)
+	}
+
+	return out, nil
+}
+
+// surrenderTerms are what the kind of monster does with a party that gives up. Humanoids and
+// giants hold them for ransom, dragons want the whole hoard, fey and celestials let them go and
+// anything else does not understand or care.
+func surrenderTerms(leader *entities.Monster) SurrenderTerms {
+	if leader.Template == nil {
+		return SurrenderRefused
+	}
+
+	switch strings.ToLower(leader.Template.Type) {
+	case "humanoid", "giant":
+		return SurrenderRansom
+	case "dragon":
+		return SurrenderHoard
+	case "fey", "celestial":
+		return SurrenderSpared
+	default:
+		return SurrenderRefused
+	}
+}
+
+// payTribute takes the monsters' cut out of the purse, a ransom rounds in the character's favour
+func payTribute(purse *entities.Coins, terms SurrenderTerms) *entities.Coins {
+	switch terms {
+	case SurrenderHoard:
+		taken := *purse
+		*purse = entities.Coins{}
+
+		return &taken
+	case SurrenderRansom:
+		halves := purse.Split(2)
+		*purse = *halves[0]
+
+		return halves[1]
+	default:
+		return &entities.Coins{}
+	}
+}
@@ -0,0 +1,277 @@
+package rooms
+
+import (
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+	"github.com/stretchr/testify/mock"
+)
+
+// goblinAdjacent puts the goblin next to the character with its reaction ready, the character has
+// the hit points to take a critical hit
+func (s *suiteManager) goblinAdjacent() {
+	s.char.MaxHitPoints = 30
+	s.char.CurrentHitPoints = 30
+	s.fightOnGrid()
+	s.room.Combat.Grid.Positions[s.monster.ID] = combat.Position{X: 1, Y: 0}
+	s.room.Combat.Order[1].Budget.Reaction = true
+}
+
+func (s *suiteManager) TestFleeProvokesOpportunityAttacks() {
+	s.goblinAdjacent()
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, mock.Anything).Return(s.room, nil)
+
+	result, err := s.fixture.Flee(s.ctx, &FleeInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(OutcomeFled, result.Outcome)
+	s.Equal("Goblin makes an opportunity attack as Tester leaves.", result.Log[0])
+	s.False(s.room.Combat.Order[1].Budget.Reaction)
+}
+
+func (s *suiteManager) TestFleeAfterDisengaging() {
+	s.goblinAdjacent()
+	s.room.Combat.Order[0].Disengaged = true
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, mock.Anything).Return(s.room, nil)
+
+	result, err := s.fixture.Flee(s.ctx, &FleeInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(OutcomeFled, result.Outcome)
+	s.Empty(result.Log)
+	s.Equal(30, s.char.CurrentHitPoints)
+}
+
+func (s *suiteManager) TestFleeAwardsExperienceForDroppedMonsters() {
+	dropped := &entities.Monster{ID: "monster-2", CharacterID: s.playerID, Key: "goblin"}
+	s.room.MonsterIDs = []string{s.monster.ID, dropped.ID}
+	s.template.XP = 50
+	s.expectActiveRoom()
+	s.monsterRepo.On("GetMonster", s.ctx, dropped.ID).Return(dropped, nil)
+	s.monsterRepo.On("PutMonster", s.ctx, mock.Anything).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, mock.Anything).Return(s.room, nil)
+
+	result, err := s.fixture.Flee(s.ctx, &FleeInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(OutcomeFled, result.Outcome)
+	s.Require().Len(result.Experience, 1)
+	s.Equal(50, result.Experience[0].XP)
+	s.Equal(50, s.char.Experience)
+	s.loot.AssertNotCalled(s.T(), "Individual", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestFleeLosingTheRoomAwardsNothing() {
+	dropped := &entities.Monster{ID: "monster-2", CharacterID: s.playerID, Key: "goblin"}
+	s.room.MonsterIDs = []string{s.monster.ID, dropped.ID}
+	s.template.XP = 50
+	s.expectActiveRoom()
+	s.monsterRepo.On("GetMonster", s.ctx, dropped.ID).Return(dropped, nil)
+	s.roomRepo.On("Update", s.ctx, mock.Anything).Return(nil, dnderr.NewConflictError("room was updated during the write"))
+
+	_, err := s.fixture.Flee(s.ctx, &FleeInput{PlayerID: s.playerID})
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Equal(0, s.char.Experience)
+	s.charManager.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestFleeOutOfTurn() {
+	s.fightOnGrid()
+	s.room.Combat.Turn = 1
+	s.expectActiveRoom()
+
+	_, err := s.fixture.Flee(s.ctx, &FleeInput{PlayerID: s.playerID})
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.roomRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestDisengage() {
+	s.goblinAdjacent()
+	s.room.Combat.Grid.Positions[s.playerID] = combat.Position{X: 2, Y: 0}
+	s.room.Combat.Grid.Positions[s.monster.ID] = combat.Position{X: 3, Y: 0}
+	s.expectActiveRoom()
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+
+	result, err := s.fixture.Disengage(s.ctx, &DisengageInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal([]string{"Tester takes the Disengage action and can move away safely."}, result.Log)
+	s.True(s.room.Combat.Order[0].Disengaged)
+	s.False(s.room.Combat.Order[0].Budget.Action)
+
+	// stepping away from the goblin is safe now
+	result, err = s.fixture.Step(s.ctx, &StepInput{PlayerID: s.playerID, Direction: dungeon.DirectionWest})
+	s.NoError(err)
+	s.Equal([]string{"Tester moves 10 feet west, 20 feet left."}, result.Log)
+	s.True(s.room.Combat.Order[1].Budget.Reaction)
+	s.Equal(30, s.char.CurrentHitPoints)
+}
+
+func (s *suiteManager) TestHelp() {
+	s.goblinAdjacent()
+	s.expectActiveRoom()
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+
+	result, err := s.fixture.Help(s.ctx, &HelpInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal([]string{"Tester distracts Goblin, the next attack on it has advantage."}, result.Log)
+	s.Equal(s.playerID, s.room.Combat.Order[1].HelpedBy)
+	s.False(s.room.Combat.Order[0].Budget.Action)
+}
+
+func (s *suiteManager) TestHelpOutOfReach() {
+	s.fightOnGrid()
+	s.expectActiveRoom()
+
+	_, err := s.fixture.Help(s.ctx, &HelpInput{PlayerID: s.playerID})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+}
+
+func (s *suiteManager) TestStepAwayProvokes() {
+	s.goblinAdjacent()
+	s.room.Combat.Grid.Positions[s.playerID] = combat.Position{X: 2, Y: 0}
+	s.room.Combat.Grid.Positions[s.monster.ID] = combat.Position{X: 3, Y: 0}
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+
+	result, err := s.fixture.Step(s.ctx, &StepInput{PlayerID: s.playerID, Direction: dungeon.DirectionWest})
+	s.NoError(err)
+	s.Equal("Goblin makes an opportunity attack as Tester leaves.", result.Log[0])
+	s.Equal("Tester moves 10 feet west, 20 feet left.", result.Log[len(result.Log)-1])
+	s.Equal(OutcomeUnset, result.Outcome)
+}
+
+func (s *suiteManager) TestEndTurn() {
+	s.goblinAdjacent()
+	s.room.Combat.Order[0].Budget.Action = false
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+
+	result, err := s.fixture.EndTurn(s.ctx, &EndTurnInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal("Tester ends their turn.", result.Log[0])
+	s.Greater(len(result.Log), 1)
+	s.Equal(2, s.room.Combat.Round)
+}
+
+func (s *suiteManager) TestSurrenderForRansom() {
+	s.template.Type = "humanoid"
+	s.char.Coins = entities.Coins{Gold: 5, Silver: 4}
+	s.fightOnGrid()
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, mock.MatchedBy(func(data *room.Data) bool {
+		return data.Status == room.StatusInactive && data.Outcome == string(OutcomeSurrendered)
+	})).Return(s.room, nil)
+
+	result, err := s.fixture.Surrender(s.ctx, &SurrenderInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(OutcomeSurrendered, result.Outcome)
+	s.Equal(SurrenderRansom, result.Terms)
+	s.Equal(entities.RoomStatusInactive, result.Room.Status)
+	s.Equal([]*Tribute{{CharacterID: s.playerID, Name: "Tester", Coins: &entities.Coins{Gold: 2, Silver: 2}}}, result.Tribute)
+	s.Equal(entities.Coins{Gold: 3, Silver: 2}, s.char.Coins)
+	s.Equal([]string{"Tester surrenders to Goblin.", "Tester hands over 2 gp, 2 sp."}, result.Log)
+	s.combatLog.AssertCalled(s.T(), "Append", s.ctx, s.room.ID, mock.MatchedBy(func(events []*combat.Event) bool {
+		last := events[len(events)-1]
+		return last.Type == combat.EventTypeEnd && last.Text == "The party surrenders."
+	}))
+}
+
+func (s *suiteManager) TestSurrenderLosingTheRoomTakesNoTribute() {
+	s.template.Type = "humanoid"
+	s.char.Coins = entities.Coins{Gold: 5, Silver: 4}
+	s.fightOnGrid()
+	s.expectActiveRoom()
+	s.roomRepo.On("Update", s.ctx, mock.Anything).Return(nil, dnderr.NewConflictError("room was updated during the write"))
+
+	_, err := s.fixture.Surrender(s.ctx, &SurrenderInput{PlayerID: s.playerID})
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Equal(entities.Coins{Gold: 5, Silver: 4}, s.char.Coins)
+	s.charManager.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestSurrenderToDragon() {
+	s.template.Type = "dragon"
+	s.char.Coins = entities.Coins{Gold: 5, Platinum: 1}
+	s.fightOnGrid()
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, mock.Anything).Return(s.room, nil)
+
+	result, err := s.fixture.Surrender(s.ctx, &SurrenderInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(SurrenderHoard, result.Terms)
+	s.Equal(&entities.Coins{Gold: 5, Platinum: 1}, result.Tribute[0].Coins)
+	s.True(s.char.Coins.IsZero())
+}
+
+func (s *suiteManager) TestSurrenderSparedInDungeon() {
+	current := s.testDungeon()
+	_, err := current.Move(dungeon.DirectionEast)
+	s.Require().NoError(err)
+
+	s.template.Type = "fey"
+	s.char.Coins = entities.Coins{Gold: 5}
+	s.room.DungeonID = current.ID
+	s.fightOnGrid()
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, mock.Anything).Return(s.room, nil)
+	s.dungeonRepo.On("Get", s.ctx, current.ID).Return(current, nil)
+	s.dungeonRepo.On("Update", s.ctx, current).Return(current, nil)
+
+	result, err := s.fixture.Surrender(s.ctx, &SurrenderInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(SurrenderSpared, result.Terms)
+	s.Empty(result.Tribute)
+	s.Equal(entities.Coins{Gold: 5}, s.char.Coins)
+	s.Equal(dungeon.Position{}, result.Dungeon.Position)
+	s.Equal([]string{
+		"Tester surrenders to Goblin.",
+		"Goblin lets the party go.",
+		"The monsters drive the party back the way they came.",
+	}, result.Log)
+}
+
+func (s *suiteManager) TestSurrenderRefused() {
+	s.template.Type = "beast"
+	s.char.Coins = entities.Coins{Gold: 5}
+	s.goblinAdjacent()
+	s.expectActiveRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+
+	result, err := s.fixture.Surrender(s.ctx, &SurrenderInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(SurrenderRefused, result.Terms)
+	s.NotEqual(OutcomeSurrendered, result.Outcome)
+	s.Equal("Tester tries to surrender, Goblin is not taking prisoners.", result.Log[0])
+	s.Empty(result.Tribute)
+	s.Equal(entities.Coins{Gold: 5}, s.char.Coins)
+	s.Equal(2, s.room.Combat.Round)
+}
+
+func (s *suiteManager) TestPayTribute() {
+	purse := &entities.Coins{Copper: 3, Gold: 1}
+	s.Equal(&entities.Coins{Copper: 1}, payTribute(purse, SurrenderRansom))
+	s.Equal(&entities.Coins{Copper: 2, Gold: 1}, purse)
+
+	s.Equal(&entities.Coins{Copper: 2, Gold: 1}, payTribute(purse, SurrenderHoard))
+	s.True(purse.IsZero())
+
+	s.Equal(&entities.Coins{}, payTribute(&entities.Coins{Gold: 4}, SurrenderSpared))
+}
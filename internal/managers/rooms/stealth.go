@@ -0,0 +1,126 @@
+package rooms
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+// sneak rolls a group Dexterity (Stealth) check for the party. Passive Perception is the DC, each
+// monster that at least half of the party meets with their check is surprised, characters down
+// before the fight sit it out.
+func (m *Implementation) sneak(engine *combat.Engine, characters []*entities.Character, monsters []*entities.Monster) (*Surprise, error) {
+	out := &Surprise{
+		Rolls:     make([]*StealthRoll, 0, len(characters)),
+		Surprised: make([]*entities.Monster, 0),
+	}
+
+	for _, char := range characters {
+		if char.IsDown() {
+			continue
+		}
+
+		roll, err := m.stealthCheck(char)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Rolls = append(out.Rolls, roll)
+	}
+
+	if len(out.Rolls) == 0 {
+		return out, nil
+	}
+
+	ids := make([]string, 0, len(monsters))
+	for _, mon := range monsters {
+		passive := mon.PassivePerception()
+
+		unseen := 0
+		for _, roll := range out.Rolls {
+			if roll.Total >= passive {
+				unseen++
+			}
+		}
+
+		if unseen*2 >= len(out.Rolls) {
+			out.Surprised = append(out.Surprised, mon)
+			ids = append(ids, mon.ID)
+		}
+	}
+
+	err := engine.Surprise(ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// stealthCheck rolls the character's check, noisy armor rolls twice and keeps the lower
+func (m *Implementation) stealthCheck(char *entities.Character) (*StealthRoll, error) {
+	roll, err := m.roller.Roll(1, 20, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	natural := roll.Total
+	disadvantage := char.StealthDisadvantage()
+	if disadvantage {
+		other, err := m.roller.Roll(1, 20, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		natural = min(natural, other.Total)
+	}
+
+	return &StealthRoll{
+		CharacterID:  char.ID,
+		Name:         char.Name,
+		Total:        natural + char.StealthBonus(),
+		Disadvantage: disadvantage,
+	}, nil
+}
+
+// Lines describes the checks and who was caught off guard
+func (s *Surprise) Lines() []string {
+	if len(s.Rolls) == 0 {
+		return []string{}
+	}
+
+	rolls := make([]string, len(s.Rolls))
+	for idx, roll := range s.Rolls {
+		rolls[idx] = fmt.Sprintf("%s (%d)", roll.Name, roll.Total)
+		if roll.Disadvantage {
+			rolls[idx] = fmt.Sprintf("%s (%d, disadvantage from armor)", roll.Name, roll.Total)
+		}
+	}
+
+	lines := []string{fmt.Sprintf("Stealth: %s", strings.Join(rolls, ", "))}
+	if len(s.Surprised) == 0 {
+		return append(lines, "The monsters notice the party coming.")
+	}
+
+	return append(lines, fmt.Sprintf("%s %s caught by surprise.", strings.Join(s.Names(), ", "), pluralVerb(len(s.Surprised))))
+}
+
+// Names are the names of the surprised monsters
+func (s *Surprise) Names() []string {
+	names := make([]string, len(s.Surprised))
+	for idx, mon := range s.Surprised {
+		names[idx] = mon.GetName()
+	}
+
+	return names
+}
+
+func pluralVerb(count int) string {
+	if count == 1 {
+		return "is"
+	}
+
+	return "are"
+}
@@ -0,0 +1,83 @@
+package rooms
+
+import (
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectNewRoom sets up a new room against the goblin, the created room data is kept in created
+func (s *suiteManager) expectNewRoom(created **room.Data) {
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.encounters.On("Build", s.ctx, mock.Anything).Return(&encounters.BuildOutput{
+		Difficulty: encounters.DifficultyMedium,
+		Monsters:   []*entities.MonsterTemplate{s.template},
+	}, nil)
+	s.uuider.On("New").Return(s.monster.ID)
+	s.monsterRepo.On("PutMonster", s.ctx, mock.Anything).Return(s.monster, nil)
+	s.roomRepo.On("Create", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		*created = args.Get(1).(*room.Data)
+	}).Return(s.room, nil)
+}
+
+func (s *suiteManager) TestLoadRoomSneaksUp() {
+	s.template.Wisdom = 8
+
+	var created *room.Data
+	s.expectNewRoom(&created)
+
+	result, err := s.fixture.LoadRoom(s.ctx, &LoadRoomInput{PlayerID: s.playerID, Stealth: true})
+	s.NoError(err)
+	s.Require().NotNil(result.Surprise)
+	s.Equal([]*StealthRoll{{CharacterID: s.playerID, Name: "Tester", Total: 15}}, result.Surprise.Rolls)
+	s.Equal([]string{"Goblin"}, result.Surprise.Names())
+	s.Equal([]string{
+		"Stealth: Tester (15)",
+		"Goblin is caught by surprise.",
+		"Initiative: Tester (15), Goblin (15, surprised)",
+	}, result.Log)
+
+	goblin := created.Combat.Order[1]
+	s.Equal(s.monster.ID, goblin.ID)
+	s.True(goblin.Surprised)
+	s.False(goblin.Budget.Reaction)
+}
+
+func (s *suiteManager) TestLoadRoomTieMeetsPassivePerception() {
+	s.template.Senses = &entities.MonsterSenses{PassivePerception: 15}
+
+	var created *room.Data
+	s.expectNewRoom(&created)
+
+	result, err := s.fixture.LoadRoom(s.ctx, &LoadRoomInput{PlayerID: s.playerID, Stealth: true})
+	s.NoError(err)
+	s.Require().NotNil(result.Surprise)
+	s.Equal([]string{"Goblin"}, result.Surprise.Names())
+}
+
+func (s *suiteManager) TestLoadRoomSpottedInNoisyArmor() {
+	s.template.Senses = &entities.MonsterSenses{PassivePerception: 16}
+	s.char.EquippedSlots = map[entities.Slot]entities.Equipment{
+		entities.SlotBody: &entities.Armor{
+			Base:                entities.BasicEquipment{Key: "chain-mail", Name: "Chain Mail"},
+			StealthDisadvantage: true,
+		},
+	}
+
+	var created *room.Data
+	s.expectNewRoom(&created)
+
+	result, err := s.fixture.LoadRoom(s.ctx, &LoadRoomInput{PlayerID: s.playerID, Stealth: true})
+	s.NoError(err)
+	s.Require().NotNil(result.Surprise)
+	s.True(result.Surprise.Rolls[0].Disadvantage)
+	s.Empty(result.Surprise.Surprised)
+	s.Equal([]string{
+		"Stealth: Tester (15, disadvantage from armor)",
+		"The monsters notice the party coming.",
+		"Initiative: Tester (15), Goblin (15)",
+	}, result.Log)
+	s.False(created.Combat.Order[1].Surprised)
+}
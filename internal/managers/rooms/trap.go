@@ -0,0 +1,258 @@
+package rooms
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+)
+
+// Disarm has the character try to disarm the trap in the party's room, or get past the challenge.
+// The check adds their proficiency bonus when they have one of the skills or tools the trap
+// needs, a trap that goes off hits the whole party.
+func (m *Implementation) Disarm(ctx context.Context, input *DisarmInput) (*DisarmOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	current, err := m.getActiveDungeon(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		return nil, dnderr.NewNotFoundError("no dungeon found, use /dungeon enter")
+	}
+
+	found, err := m.roomTrap(ctx, current.Current())
+	if err != nil {
+		return nil, err
+	}
+
+	if found == nil || !found.Detected {
+		return nil, dnderr.NewNotFoundError("there is no trap to disarm here")
+	}
+
+	char, err := m.characterManager.Get(ctx, input.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if char.IsDown() {
+		return nil, dnderr.NewInvalidEntityError(fmt.Sprintf("%s is down", char.Name))
+	}
+
+	roll, err := m.roller.Roll(1, 20, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	check := roll.Total + char.CheckBonus(found.DisarmAttribute, found.DisarmWith...)
+	outcome, err := found.Disarm(check)
+	if err != nil {
+		return nil, err
+	}
+
+	versus := fmt.Sprintf("(%d vs DC %d)", check, found.DisarmDC)
+	var log []string
+	switch outcome {
+	case entities.TrapOutcomeDisarmed:
+		if found.Kind == entities.TrapKindChallenge {
+			log = []string{fmt.Sprintf("%s gets the party past the %s %s.", char.Name, found.Name, versus)}
+		} else {
+			log = []string{fmt.Sprintf("%s disarms the %s %s.", char.Name, found.Name, versus)}
+		}
+	case entities.TrapOutcomeFailed:
+		log = []string{fmt.Sprintf("%s fails to disarm the %s %s, it can be tried again.", char.Name, found.Name, versus)}
+	case entities.TrapOutcomeSprung:
+		log = []string{fmt.Sprintf("%s sets off the %s %s!", char.Name, found.Name, versus)}
+	case entities.TrapOutcomeHurt:
+		log = []string{fmt.Sprintf("%s fails to get past the %s %s.", char.Name, found.Name, versus)}
+	}
+
+	// The attempt claims the trap before anyone is hurt, an attempt made at the same time loses
+	// the write and the party only takes the damage once
+	_, err = m.trapRepo.Update(ctx, found)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	switch outcome {
+	case entities.TrapOutcomeSprung:
+		lines, err = m.springTrap(ctx, current, found, current.PlayerIDs)
+	case entities.TrapOutcomeHurt:
+		lines, err = m.springTrap(ctx, current, found, []string{input.PlayerID})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log = append(log, lines...)
+
+	failed := current.Status == dungeon.StatusFailed
+	current, err = m.updateDungeon(ctx, current, func(latest *dungeon.Dungeon) {
+		if failed {
+			latest.Status = dungeon.StatusFailed
+		}
+
+		if room := latest.RoomWithTrap(found.ID); room != nil && !found.IsArmed() {
+			room.Cleared = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DisarmOutput{
+		Dungeon: current,
+		Trap:    found,
+		Log:     log,
+	}, nil
+}
+
+// enterTrapRoom sets up the trap the first time the party walks in. The best passive Perception
+// in the party has to meet the trap's detection DC, a trap no one spots goes off on all of them.
+func (m *Implementation) enterTrapRoom(ctx context.Context, current *dungeon.Dungeon, next *dungeon.Room) (*entities.Trap, []string, error) {
+	found, err := m.roomTrap(ctx, next)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if found != nil {
+		return found, []string{fmt.Sprintf("The %s is still here.", found.Name)}, nil
+	}
+
+	keys := entities.TrapKeys()
+	found, err = entities.NewTrap(keys[rand.New(rand.NewSource(trapSeed(current, next))).Intn(len(keys))])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var spotter *entities.Character
+	best := 0
+	for _, playerID := range current.PlayerIDs {
+		char, err := m.characterManager.Get(ctx, playerID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !char.IsDown() && (spotter == nil || char.PassivePerception() > best) {
+			spotter = char
+			best = char.PassivePerception()
+		}
+	}
+
+	var log []string
+	switch {
+	case found.DetectDC == 0:
+		found.Spot(best)
+		log = []string{fmt.Sprintf("%s: %s", found.Name, found.Description)}
+	case spotter != nil && found.Spot(best):
+		log = []string{fmt.Sprintf("%s spots a trap, %s: %s", spotter.Name, found.Name, found.Description)}
+	default:
+		found.Spring()
+		log = []string{fmt.Sprintf("No one spots the %s! %s", found.Name, found.Description)}
+
+		lines, err := m.springTrap(ctx, current, found, current.PlayerIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		log = append(log, lines...)
+		next.Cleared = true
+	}
+
+	found, err = m.trapRepo.Create(ctx, found)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next.TrapID = found.ID
+
+	return found, log, nil
+}
+
+// springTrap rolls the trap's damage once and has each character make a saving throw for half,
+// the dungeon is lost when it drops the whole party
+func (m *Implementation) springTrap(ctx context.Context, current *dungeon.Dungeon, found *entities.Trap, playerIDs []string) ([]string, error) {
+	dmg, err := m.roller.Roll(found.Damage.DiceCount, found.Damage.DiceSize, found.Damage.Bonus)
+	if err != nil {
+		return nil, err
+	}
+
+	log := make([]string, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		char, err := m.characterManager.Get(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if char.IsDown() {
+			continue
+		}
+
+		roll, err := m.roller.Roll(1, 20, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		save := roll.Total + char.SaveBonus(found.SaveAttribute)
+		amount := dmg.Total
+		result := "fails"
+		if save >= found.SaveDC {
+			amount /= 2
+			result = "makes"
+		}
+
+		var report string
+		_, err = m.characterManager.Update(ctx, playerID, func(current *entities.Character) error {
+			report = current.TakeDamage(amount, found.Damage.DamageType).String()
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		log = append(log, fmt.Sprintf("%s %s a DC %d %s saving throw (%d). %s", char.Name, result, found.SaveDC, found.SaveAttribute, save, report))
+	}
+
+	down := true
+	for _, playerID := range current.PlayerIDs {
+		char, err := m.characterManager.Get(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+
+		down = down && char.IsDown()
+	}
+
+	if down {
+		log = append(log, applyOutcome(current, OutcomeDefeat)...)
+	}
+
+	return log, nil
+}
+
+// trapSeed picks the trap from the dungeon's seed and where the room is in it, a dungeon played
+// again from the same seed gets the same traps
+func trapSeed(current *dungeon.Dungeon, next *dungeon.Room) int64 {
+	return current.Seed + int64(slices.Index(current.Rooms, next))
+}
+
+// roomTrap loads the trap in the room, nil when it has none yet
+func (m *Implementation) roomTrap(ctx context.Context, current *dungeon.Room) (*entities.Trap, error) {
+	if current == nil || current.Contents != dungeon.ContentsTrap || current.TrapID == "" {
+		return nil, nil
+	}
+
+	return m.trapRepo.Get(ctx, current.TrapID)
+}
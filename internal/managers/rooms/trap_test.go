@@ -0,0 +1,186 @@
+package rooms
+
+import (
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/dice"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+	"github.com/stretchr/testify/mock"
+)
+
+// trapDungeon has the party next to a trap room to the east, or in it when the trap is given
+func (s *suiteManager) trapDungeon(found *entities.Trap) *dungeon.Dungeon {
+	current := s.trapDungeonWith(found)
+	s.expectDungeon(current)
+	s.dungeonRepo.On("Update", s.ctx, current).Return(current, nil)
+
+	return current
+}
+
+// trapDungeonWith builds the trap dungeon without expecting it to be loaded or saved
+func (s *suiteManager) trapDungeonWith(found *entities.Trap) *dungeon.Dungeon {
+	current := s.testDungeon()
+	current.Rooms[1].Contents = dungeon.ContentsTrap
+	if found != nil {
+		current.Position = current.Rooms[1].Position
+		current.Rooms[1].Visited = true
+		current.Rooms[1].TrapID = found.ID
+		s.traps.On("Get", s.ctx, found.ID).Return(found, nil)
+	}
+
+	return current
+}
+
+// withTrap seeds the dungeon so the trap room to the east, the second room, picks the trap. The
+// seeds are the ones that pick each.
+func (s *suiteManager) withTrap(current *dungeon.Dungeon, key string) {
+	seeds := map[string]int64{
+		"poison-darts":    11,
+		"collapsing-roof": 1,
+		"rope-bridge":     6,
+	}
+
+	current.Seed = seeds[key] - 1
+}
+
+func (s *suiteManager) storedTrap(key string) *entities.Trap {
+	found, err := entities.NewTrap(key)
+	s.Require().NoError(err)
+	found.ID = "trap-1"
+	found.Detected = true
+
+	return found
+}
+
+func (s *suiteManager) TestMoveSpotsTrap() {
+	s.char.AddAttribute(entities.AttributeWisdom, 12)
+	current := s.trapDungeon(nil)
+	s.withTrap(current, "collapsing-roof")
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.traps.On("Create", s.ctx, mock.MatchedBy(func(found *entities.Trap) bool {
+		return found.Key == "collapsing-roof" && found.Detected && found.IsArmed()
+	})).Return(&entities.Trap{ID: "trap-1"}, nil)
+
+	result, err := s.fixture.Move(s.ctx, &MoveInput{PlayerID: s.playerID, Direction: dungeon.DirectionEast})
+	s.NoError(err)
+	s.Equal("trap-1", current.Current().TrapID)
+	s.False(current.Current().Cleared)
+	s.Equal("Tester spots a trap, Collapsing Roof: A tripwire holds up the rotten supports of the ceiling.", result.Log[len(result.Log)-1])
+}
+
+func (s *suiteManager) TestMoveSpringsHiddenTrap() {
+	current := s.trapDungeon(nil)
+	s.withTrap(current, "poison-darts")
+	s.roomRepo.On("ListByPlayer", s.ctx, mock.Anything).Return([]*room.Data{}, nil)
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roller.On("Roll", 2, 10, 0).Return(&dice.RollResult{Total: 12}, nil)
+	s.traps.On("Create", s.ctx, mock.MatchedBy(func(found *entities.Trap) bool {
+		return !found.Detected && found.Status == entities.TrapStatusSprung
+	})).Return(&entities.Trap{ID: "trap-1"}, nil)
+
+	result, err := s.fixture.Move(s.ctx, &MoveInput{PlayerID: s.playerID, Direction: dungeon.DirectionEast})
+	s.NoError(err)
+	s.True(current.Current().Cleared)
+	s.Equal(6, s.char.CurrentHitPoints)
+	s.Equal([]string{
+		"No one spots the Poison Darts! Tiny holes in the walls hide darts coated in poison, a pressure plate sets them off.",
+		"Tester makes a DC 15 Con saving throw (15). Tester takes 6 poison damage [6/12 HP]",
+	}, result.Log[len(result.Log)-2:])
+}
+
+func (s *suiteManager) TestDisarmWithThievesTools() {
+	found := s.storedTrap("poison-darts")
+	current := s.trapDungeon(found)
+	s.char.AddProficiency(&entities.Proficiency{Key: "thieves-tools", Type: entities.ProficiencyTypeTool})
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.traps.On("Update", s.ctx, found).Return(found, nil)
+
+	result, err := s.fixture.Disarm(s.ctx, &DisarmInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(entities.TrapStatusDisarmed, result.Trap.Status)
+	s.True(current.Current().Cleared)
+	s.Equal([]string{"Tester disarms the Poison Darts (17 vs DC 15)."}, result.Log)
+	s.traps.AssertExpectations(s.T())
+}
+
+func (s *suiteManager) TestDisarmSetsOffTrap() {
+	found := s.storedTrap("poison-darts")
+	found.DisarmDC = 20
+	current := s.trapDungeon(found)
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roller.On("Roll", 2, 10, 0).Return(&dice.RollResult{Total: 30}, nil)
+	s.traps.On("Update", s.ctx, found).Return(found, nil)
+
+	result, err := s.fixture.Disarm(s.ctx, &DisarmInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Equal(entities.TrapStatusSprung, result.Trap.Status)
+	s.Equal([]string{
+		"Tester sets off the Poison Darts (15 vs DC 20)!",
+		"Tester makes a DC 15 Con saving throw (15). Tester takes 15 poison damage [0/12 HP] and Tester drops!",
+		"The party is dragged out of the dungeon.",
+	}, result.Log)
+	s.Equal(dungeon.StatusFailed, current.Status)
+}
+
+func (s *suiteManager) TestDisarmChallengeHurtsWhoTried() {
+	found := s.storedTrap("rope-bridge")
+	found.DisarmDC = 18
+	current := s.trapDungeon(found)
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roller.On("Roll", 2, 6, 0).Return(&dice.RollResult{Total: 7}, nil)
+	s.traps.On("Update", s.ctx, found).Return(found, nil)
+
+	result, err := s.fixture.Disarm(s.ctx, &DisarmInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.True(result.Trap.IsArmed())
+	s.False(current.Current().Cleared)
+	s.Equal([]string{
+		"Tester fails to get past the Rope Bridge (15 vs DC 18).",
+		"Tester makes a DC 12 Dex saving throw (15). Tester takes 3 bludgeoning damage [9/12 HP]",
+	}, result.Log)
+}
+
+func (s *suiteManager) TestDisarmLosingTheTrapDoesNoDamage() {
+	found := s.storedTrap("poison-darts")
+	found.DisarmDC = 20
+	s.expectDungeon(s.trapDungeonWith(found))
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.traps.On("Update", s.ctx, found).Return(nil, dnderr.NewConflictError("trap trap-1 was updated, expected version 0 but found 1"))
+
+	_, err := s.fixture.Disarm(s.ctx, &DisarmInput{PlayerID: s.playerID})
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.charManager.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+	s.roller.AssertNotCalled(s.T(), "Roll", 2, 10, 0)
+	s.dungeonRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestDisarmRetriesTheDungeonAfterAConflict() {
+	found := s.storedTrap("poison-darts")
+	stale := s.trapDungeonWith(found)
+	latest := s.trapDungeonWith(found)
+	latest.Version = 1
+	s.expectDungeon(stale)
+	s.char.AddProficiency(&entities.Proficiency{Key: "thieves-tools", Type: entities.ProficiencyTypeTool})
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.traps.On("Update", s.ctx, found).Return(found, nil)
+	s.dungeonRepo.On("Update", s.ctx, stale).Return(nil, dnderr.NewConflictError("dungeon dungeon-1 was updated during the write")).Once()
+	s.dungeonRepo.On("Get", s.ctx, latest.ID).Return(latest, nil)
+	s.dungeonRepo.On("Update", s.ctx, latest).Return(latest, nil)
+
+	result, err := s.fixture.Disarm(s.ctx, &DisarmInput{PlayerID: s.playerID})
+	s.NoError(err)
+	s.Same(latest, result.Dungeon)
+	s.True(latest.Current().Cleared)
+}
+
+func (s *suiteManager) TestDisarmWithoutTrap() {
+	s.expectDungeon(s.testDungeon())
+
+	_, err := s.fixture.Disarm(s.ctx, &DisarmInput{PlayerID: s.playerID})
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
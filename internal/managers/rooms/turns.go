@@ -0,0 +1,104 @@
+package rooms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+)
+
+// CurrentTurn returns whose turn it is in the room without playing anything
+func (m *Implementation) CurrentTurn(ctx context.Context, input *CurrentTurnInput) (*CurrentTurnOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.RoomID == "" {
+		return nil, dnderr.NewMissingParameterError("input.RoomID")
+	}
+
+	data, err := m.roomRepo.Get(ctx, input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := data.Combat
+	if data.Status != room.StatusActive || state == nil || state.Turn >= len(state.Order) {
+		return &CurrentTurnOutput{
+			Outcome: Outcome(data.Outcome),
+		}, nil
+	}
+
+	out := &CurrentTurnOutput{
+		Active: true,
+		Round:  state.Round,
+		Turn:   state.Turn,
+	}
+
+	if current := state.Order[state.Turn]; current.Side == combat.SideParty {
+		out.PlayerID = current.ID
+	}
+
+	return out, nil
+}
+
+// SkipTurn has the character take the Dodge action for a player who let their turn run out, the
+// monsters then act until it is a player's turn again
+func (m *Implementation) SkipTurn(ctx context.Context, input *SkipTurnInput) (*AttackOutput, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.RoomID == "" {
+		return nil, dnderr.NewMissingParameterError("input.RoomID")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	data, err := m.roomRepo.Get(ctx, input.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := data.Combat
+	if data.Status != room.StatusActive || state == nil || state.Round != input.Round || state.Turn != input.Turn {
+		return nil, dnderr.NewConflictError("the turn has already been played")
+	}
+
+	activeRoom, err := m.hydrateRoom(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	engine, log, err := m.startFight(ctx, data, activeRoom)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := engine.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	if current.ID != input.PlayerID {
+		return nil, dnderr.NewConflictError(fmt.Sprintf("it is %s's turn", current.Combatant().GetName()))
+	}
+
+	log = append(log, fmt.Sprintf("%s ran out of time.", current.Combatant().GetName()))
+
+	// a player who already used their action just ends their turn
+	if current.Budget.Action {
+		err = engine.Dodge()
+		if err != nil {
+			return nil, err
+		}
+
+		log = append(log, fmt.Sprintf("%s takes the Dodge action.", current.Combatant().GetName()))
+	}
+
+	return m.finishTurn(ctx, data, activeRoom, engine, log)
+}
@@ -0,0 +1,97 @@
+package rooms
+
+import (
+	"slices"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+	"github.com/stretchr/testify/mock"
+)
+
+func (s *suiteManager) TestCurrentTurn() {
+	s.fightOnGrid()
+	s.roomRepo.On("Get", s.ctx, s.room.ID).Return(s.room, nil)
+
+	result, err := s.fixture.CurrentTurn(s.ctx, &CurrentTurnInput{RoomID: s.room.ID})
+	s.NoError(err)
+	s.Equal(&CurrentTurnOutput{Active: true, PlayerID: s.playerID, Round: 1}, result)
+
+	s.room.Combat.Turn = 1
+	result, err = s.fixture.CurrentTurn(s.ctx, &CurrentTurnInput{RoomID: s.room.ID})
+	s.NoError(err)
+	s.Equal(&CurrentTurnOutput{Active: true, Round: 1, Turn: 1}, result)
+}
+
+func (s *suiteManager) TestCurrentTurnFightOver() {
+	s.fightOnGrid()
+	s.room.Status = room.StatusInactive
+	s.roomRepo.On("Get", s.ctx, s.room.ID).Return(s.room, nil)
+
+	result, err := s.fixture.CurrentTurn(s.ctx, &CurrentTurnInput{RoomID: s.room.ID})
+	s.NoError(err)
+	s.False(result.Active)
+}
+
+func (s *suiteManager) expectRoom() {
+	s.roomRepo.On("Get", s.ctx, s.room.ID).Return(s.room, nil)
+	s.charManager.On("Get", s.ctx, s.playerID).Return(s.char, nil)
+	s.monsterRepo.On("GetMonster", s.ctx, s.monster.ID).Return(s.monster, nil)
+	s.client.On("GetMonster", "goblin").Return(s.template, nil)
+}
+
+func (s *suiteManager) TestGetRoom() {
+	s.room.Status = room.StatusInactive
+	s.expectRoom()
+
+	result, err := s.fixture.GetRoom(s.ctx, &GetRoomInput{RoomID: s.room.ID})
+	s.NoError(err)
+	s.Equal(s.room.ID, result.Room.ID)
+	s.Equal(entities.RoomStatusInactive, result.Room.Status)
+	s.Equal([]*entities.Character{s.char}, result.Room.Characters)
+	s.roomRepo.AssertNotCalled(s.T(), "ListByPlayer", mock.Anything, mock.Anything)
+
+	_, err = s.fixture.GetRoom(s.ctx, &GetRoomInput{})
+	s.IsType(&dnderr.MissingParameterError{}, err)
+}
+
+func (s *suiteManager) TestSkipTurnDodges() {
+	s.fightOnGrid()
+	s.expectRoom()
+	s.monsterRepo.On("PutMonster", s.ctx, s.monster).Return(s.monster, nil)
+	s.charManager.On("Update", s.ctx, s.playerID, mock.Anything).Return(s.char, nil)
+	s.roomRepo.On("Update", s.ctx, s.room).Return(s.room, nil)
+
+	result, err := s.fixture.SkipTurn(s.ctx, &SkipTurnInput{RoomID: s.room.ID, PlayerID: s.playerID, Round: 1})
+	s.NoError(err)
+	s.Equal([]string{"Tester ran out of time.", "Tester takes the Dodge action."}, result.Log[:2])
+	s.Equal(OutcomeUnset, result.Outcome)
+	s.Equal(entities.RoomStatusActive, result.Room.Status)
+	s.combatLog.AssertCalled(s.T(), "Append", s.ctx, s.room.ID, mock.MatchedBy(func(events []*combat.Event) bool {
+		return slices.ContainsFunc(events, func(event *combat.Event) bool {
+			return event.Condition == combat.ConditionDodging && event.ActorID == s.playerID
+		})
+	}))
+	s.roomRepo.AssertNotCalled(s.T(), "ListByPlayer", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestSkipTurnOutOfTurn() {
+	s.fightOnGrid()
+	s.room.Combat.Turn = 1
+	s.expectRoom()
+
+	_, err := s.fixture.SkipTurn(s.ctx, &SkipTurnInput{RoomID: s.room.ID, PlayerID: s.playerID, Round: 1, Turn: 1})
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.roomRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestSkipTurnAlreadyPlayed() {
+	s.fightOnGrid()
+	s.room.Combat.Round = 2
+	s.expectRoom()
+
+	_, err := s.fixture.SkipTurn(s.ctx, &SkipTurnInput{RoomID: s.room.ID, PlayerID: s.playerID, Round: 1})
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.roomRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
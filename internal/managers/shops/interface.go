@@ -0,0 +1,45 @@
+package shops
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+type Manager interface {
+	// Browse opens the merchant's shop in the guild, the stock comes from the SRD the first time
+	Browse(ctx context.Context, input *BrowseInput) (*entities.Shop, error)
+	// Buy pays the merchant and puts the item in the character's inventory in one update
+	Buy(ctx context.Context, input *TradeInput) (*TradeOutput, error)
+	// Sell takes the item out of the inventory for half of what it is worth
+	Sell(ctx context.Context, input *TradeInput) (*TradeOutput, error)
+	// SetPriceModifier changes what the merchant charges and pays as a percent of the SRD price
+	SetPriceModifier(ctx context.Context, input *SetPriceModifierInput) (*entities.Shop, error)
+}
+
+type BrowseInput struct {
+	GuildID     string
+	MerchantKey string
+}
+
+type TradeInput struct {
+	GuildID     string
+	MerchantKey string
+	PlayerID    string
+	ItemKey     string
+}
+
+type TradeOutput struct {
+	Shop      *entities.Shop
+	Character *entities.Character
+	// Name is the name of the item that changed hands
+	Name  string
+	Price *entities.Coins
+}
+
+type SetPriceModifierInput struct {
+	GuildID     string
+	MerchantKey string
+	// PriceModifier is a percent of the SRD price, 100 is the SRD price
+	PriceModifier int
+}
@@ -0,0 +1,256 @@
+package shops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/clients/dnd5e"
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/characters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/shop"
+)
+
+const (
+	// maxCategoryItems is the most items a merchant stocks from one equipment category
+	maxCategoryItems = 25
+
+	MinPriceModifier = 10
+	MaxPriceModifier = 500
+)
+
+type Implementation struct {
+	client           dnd5e.Client
+	characterManager characters.Manager
+	shopRepo         shop.Repository
+}
+
+type Config struct {
+	Client           dnd5e.Client
+	CharacterManager characters.Manager
+	ShopRepo         shop.Repository
+}
+
+func New(cfg *Config) (*Implementation, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	if cfg.CharacterManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.CharacterManager")
+	}
+
+	if cfg.ShopRepo == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.ShopRepo")
+	}
+
+	return &Implementation{
+		client:           cfg.Client,
+		characterManager: cfg.CharacterManager,
+		shopRepo:         cfg.ShopRepo,
+	}, nil
+}
+
+func (m *Implementation) Browse(ctx context.Context, input *BrowseInput) (*entities.Shop, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	return m.getShop(ctx, input.GuildID, input.MerchantKey)
+}
+
+// Buy charges the shop's price for the item, the coins and the item change hands in the same
+// character update so a failed purchase leaves the purse alone
+func (m *Implementation) Buy(ctx context.Context, input *TradeInput) (*TradeOutput, error) {
+	err := validateTrade(input)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := m.getShop(ctx, input.GuildID, input.MerchantKey)
+	if err != nil {
+		return nil, err
+	}
+
+	item := existing.Item(input.ItemKey)
+	if item == nil {
+		return nil, dnderr.NewNotFoundError(fmt.Sprintf("%s does not sell %s", existing.Merchant.Name, input.ItemKey))
+	}
+
+	price := existing.BuyPrice(item.Cost)
+	char, err := m.characterManager.Update(ctx, input.PlayerID, func(char *entities.Character) error {
+		if !char.Coins.Spend(price) {
+			return dnderr.NewInvalidEntityError(fmt.Sprintf("%s can't afford the %s", char.Name, item.Name))
+		}
+
+		_, err := m.characterManager.AddInventory(ctx, char, item.Key)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TradeOutput{
+		Shop:      existing,
+		Character: char,
+		Name:      item.Name,
+		Price:     entities.CoinsFromCopper(price),
+	}, nil
+}
+
+// Sell pays half of the SRD cost of the item, adjusted by the shop's price modifier. Any
+// merchant buys anything with a cost, the last one of an item is unequipped when it is sold.
+func (m *Implementation) Sell(ctx context.Context, input *TradeInput) (*TradeOutput, error) {
+	err := validateTrade(input)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := m.getShop(ctx, input.GuildID, input.MerchantKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var name string
+	var price int
+	char, err := m.characterManager.Update(ctx, input.PlayerID, func(char *entities.Character) error {
+		item := char.RemoveInventory(input.ItemKey)
+		if item == nil {
+			return dnderr.NewNotFoundError(fmt.Sprintf("%s has no %s to sell", char.Name, input.ItemKey))
+		}
+
+		name = item.GetName()
+		price = existing.SellPrice(entities.EquipmentCost(item))
+		if price == 0 {
+			return dnderr.NewInvalidEntityError(fmt.Sprintf("%s won't pay anything for the %s", existing.Merchant.Name, name))
+		}
+
+		char.Coins.Add(entities.CoinsFromCopper(price))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TradeOutput{
+		Shop:      existing,
+		Character: char,
+		Name:      name,
+		Price:     entities.CoinsFromCopper(price),
+	}, nil
+}
+
+func (m *Implementation) SetPriceModifier(ctx context.Context, input *SetPriceModifierInput) (*entities.Shop, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PriceModifier < MinPriceModifier || input.PriceModifier > MaxPriceModifier {
+		return nil, dnderr.NewInvalidParameterError("input.PriceModifier",
+			fmt.Sprintf("must be between %d and %d", MinPriceModifier, MaxPriceModifier))
+	}
+
+	existing, err := m.getShop(ctx, input.GuildID, input.MerchantKey)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.PriceModifier = input.PriceModifier
+
+	return m.shopRepo.Put(ctx, existing)
+}
+
+func validateTrade(input *TradeInput) error {
+	if input == nil {
+		return dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	if input.ItemKey == "" {
+		return dnderr.NewMissingParameterError("input.ItemKey")
+	}
+
+	return nil
+}
+
+// getShop loads the guild's shop, opening it with the merchant's SRD stock when it is new
+func (m *Implementation) getShop(ctx context.Context, guildID, merchantKey string) (*entities.Shop, error) {
+	if guildID == "" {
+		return nil, dnderr.NewMissingParameterError("guildID")
+	}
+
+	merchant, err := entities.GetMerchant(merchantKey)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := m.shopRepo.Get(ctx, guildID, merchantKey)
+	if err == nil {
+		return existing, nil
+	}
+
+	var notFoundErr *dnderr.NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		return nil, err
+	}
+
+	items, err := m.stock(merchant)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.shopRepo.Put(ctx, &entities.Shop{
+		GuildID:       guildID,
+		Merchant:      merchant,
+		PriceModifier: entities.DefaultPriceModifier,
+		Items:         items,
+	})
+}
+
+// stock looks up the equipment in the merchant's categories, items without a cost are not for sale
+func (m *Implementation) stock(merchant *entities.Merchant) ([]*entities.ShopItem, error) {
+	items := make([]*entities.ShopItem, 0)
+	for _, category := range merchant.Categories {
+		references, err := m.client.ListEquipmentByCategory(category)
+		if err != nil {
+			return nil, err
+		}
+
+		stocked := 0
+		for _, reference := range references {
+			if stocked == maxCategoryItems {
+				break
+			}
+
+			equipment, err := m.client.GetEquipment(reference.Key)
+			if err != nil {
+				return nil, err
+			}
+
+			cost := entities.EquipmentCost(equipment)
+			if cost.Copper() == 0 {
+				continue
+			}
+
+			items = append(items, &entities.ShopItem{
+				Key:      equipment.GetKey(),
+				Name:     equipment.GetName(),
+				Category: category,
+				Cost:     cost,
+			})
+			stocked++
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,201 @@
+package shops
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/clients/dnd5e"
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/characters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/shop"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteManager struct {
+	suite.Suite
+
+	ctx              context.Context
+	client           *dnd5e.Mock
+	characterManager *characters.Mock
+	shopRepo         *shop.Mock
+	fixture          *Implementation
+
+	char     *entities.Character
+	sword    *entities.Weapon
+	shop     *entities.Shop
+	merchant *entities.Merchant
+}
+
+func (s *suiteManager) SetupTest() {
+	s.ctx = context.Background()
+	s.client = &dnd5e.Mock{}
+	s.characterManager = &characters.Mock{}
+	s.shopRepo = &shop.Mock{}
+
+	s.fixture = &Implementation{
+		client:           s.client,
+		characterManager: s.characterManager,
+		shopRepo:         s.shopRepo,
+	}
+
+	s.merchant, _ = entities.GetMerchant("blacksmith")
+	s.sword = &entities.Weapon{Base: entities.BasicEquipment{
+		Key:  "longsword",
+		Name: "Longsword",
+		Cost: &entities.Cost{Quantity: 15, Unit: "gp"},
+	}}
+	s.char = &entities.Character{ID: "player-1", Name: "Tester", Coins: entities.Coins{Gold: 20}}
+	s.shop = &entities.Shop{
+		GuildID:  "guild-1",
+		Merchant: s.merchant,
+		Items: []*entities.ShopItem{{
+			Key:      "longsword",
+			Name:     "Longsword",
+			Category: "martial-weapons",
+			Cost:     s.sword.Base.Cost,
+		}},
+	}
+}
+
+func (s *suiteManager) expectShop() {
+	s.shopRepo.On("Get", s.ctx, "guild-1", "blacksmith").Return(s.shop, nil)
+}
+
+func (s *suiteManager) tradeInput() *TradeInput {
+	return &TradeInput{
+		GuildID:     "guild-1",
+		MerchantKey: "blacksmith",
+		PlayerID:    s.char.ID,
+		ItemKey:     "longsword",
+	}
+}
+
+func (s *suiteManager) TestNew() {
+	_, err := New(&Config{Client: s.client, CharacterManager: s.characterManager})
+	s.IsType(&dnderr.MissingParameterError{}, err)
+
+	result, err := New(&Config{Client: s.client, CharacterManager: s.characterManager, ShopRepo: s.shopRepo})
+	s.NoError(err)
+	s.NotNil(result)
+}
+
+func (s *suiteManager) TestBrowseStocksNewShop() {
+	s.shopRepo.On("Get", s.ctx, "guild-1", "blacksmith").Return(nil, dnderr.NewNotFoundError("shop not found"))
+	s.client.On("ListEquipmentByCategory", "simple-weapons").Return([]*entities.ReferenceItem{
+		{Key: "club", Name: "Club"},
+	}, nil)
+	s.client.On("ListEquipmentByCategory", "martial-weapons").Return([]*entities.ReferenceItem{
+		{Key: "longsword", Name: "Longsword"},
+		{Key: "net", Name: "Net"},
+	}, nil)
+	s.client.On("GetEquipment", "club").Return(&entities.Weapon{Base: entities.BasicEquipment{
+		Key:  "club",
+		Name: "Club",
+		Cost: &entities.Cost{Quantity: 1, Unit: "sp"},
+	}}, nil)
+	s.client.On("GetEquipment", "longsword").Return(s.sword, nil)
+	s.client.On("GetEquipment", "net").Return(&entities.Weapon{Base: entities.BasicEquipment{Key: "net", Name: "Net"}}, nil)
+
+	var created *entities.Shop
+	s.shopRepo.On("Put", s.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*entities.Shop)
+	}).Return(s.shop, nil)
+
+	_, err := s.fixture.Browse(s.ctx, &BrowseInput{GuildID: "guild-1", MerchantKey: "blacksmith"})
+	s.NoError(err)
+	s.Equal(s.merchant, created.Merchant)
+	s.Equal(entities.DefaultPriceModifier, created.PriceModifier)
+	s.Equal([]*entities.ShopItem{
+		{Key: "club", Name: "Club", Category: "simple-weapons", Cost: &entities.Cost{Quantity: 1, Unit: "sp"}},
+		{Key: "longsword", Name: "Longsword", Category: "martial-weapons", Cost: s.sword.Base.Cost},
+	}, created.Items)
+}
+
+func (s *suiteManager) TestBrowseUnknownMerchant() {
+	_, err := s.fixture.Browse(s.ctx, &BrowseInput{GuildID: "guild-1", MerchantKey: "fence"})
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *suiteManager) TestBuy() {
+	s.shop.PriceModifier = 120
+	s.expectShop()
+	s.characterManager.On("Update", s.ctx, s.char.ID, mock.Anything).Return(s.char, nil)
+	s.characterManager.On("AddInventory", s.ctx, s.char, "longsword").Run(func(args mock.Arguments) {
+		args.Get(1).(*entities.Character).AddInventory(s.sword)
+	}).Return(s.char, nil)
+
+	result, err := s.fixture.Buy(s.ctx, s.tradeInput())
+	s.NoError(err)
+	s.Equal("Longsword", result.Name)
+	s.Equal(&entities.Coins{Gold: 18}, result.Price)
+	s.Equal(entities.Coins{Gold: 2}, s.char.Coins)
+	s.Equal([]entities.Equipment{s.sword}, s.char.Inventory[s.sword.GetEquipmentType()])
+}
+
+func (s *suiteManager) TestBuyCantAfford() {
+	s.char.Coins = entities.Coins{Gold: 10}
+	s.expectShop()
+	s.characterManager.On("Update", s.ctx, s.char.ID, mock.Anything).Return(s.char, nil)
+
+	_, err := s.fixture.Buy(s.ctx, s.tradeInput())
+	s.IsType(&dnderr.InvalidEntityError{}, err)
+	s.Equal(entities.Coins{Gold: 10}, s.char.Coins)
+	s.characterManager.AssertNotCalled(s.T(), "AddInventory", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestBuyNotStocked() {
+	s.expectShop()
+
+	input := s.tradeInput()
+	input.ItemKey = "plate-armor"
+	_, err := s.fixture.Buy(s.ctx, input)
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *suiteManager) TestSellForHalf() {
+	s.char.AddInventory(s.sword)
+	s.expectShop()
+	s.characterManager.On("Update", s.ctx, s.char.ID, mock.Anything).Return(s.char, nil)
+
+	result, err := s.fixture.Sell(s.ctx, s.tradeInput())
+	s.NoError(err)
+	s.Equal("Longsword", result.Name)
+	s.Equal(&entities.Coins{Gold: 7, Silver: 5}, result.Price)
+	s.Equal(entities.Coins{Gold: 27, Silver: 5}, s.char.Coins)
+	s.Empty(s.char.Inventory[s.sword.GetEquipmentType()])
+}
+
+func (s *suiteManager) TestSellWithoutItem() {
+	s.expectShop()
+	s.characterManager.On("Update", s.ctx, s.char.ID, mock.Anything).Return(s.char, nil)
+
+	_, err := s.fixture.Sell(s.ctx, s.tradeInput())
+	s.IsType(&dnderr.NotFoundError{}, err)
+	s.Equal(entities.Coins{Gold: 20}, s.char.Coins)
+}
+
+func (s *suiteManager) TestSetPriceModifier() {
+	s.expectShop()
+	s.shopRepo.On("Put", s.ctx, s.shop).Return(s.shop, nil)
+
+	result, err := s.fixture.SetPriceModifier(s.ctx, &SetPriceModifierInput{
+		GuildID:       "guild-1",
+		MerchantKey:   "blacksmith",
+		PriceModifier: 150,
+	})
+	s.NoError(err)
+	s.Equal(150, result.PriceModifier)
+
+	_, err = s.fixture.SetPriceModifier(s.ctx, &SetPriceModifierInput{
+		GuildID:       "guild-1",
+		MerchantKey:   "blacksmith",
+		PriceModifier: 5,
+	})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, new(suiteManager))
+}
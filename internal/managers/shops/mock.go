@@ -0,0 +1,52 @@
+package shops
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Browse(ctx context.Context, input *BrowseInput) (*entities.Shop, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Shop), nil
+}
+
+func (m *Mock) Buy(ctx context.Context, input *TradeInput) (*TradeOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*TradeOutput), nil
+}
+
+func (m *Mock) Sell(ctx context.Context, input *TradeInput) (*TradeOutput, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*TradeOutput), nil
+}
+
+func (m *Mock) SetPriceModifier(ctx context.Context, input *SetPriceModifierInput) (*entities.Shop, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Shop), nil
+}
@@ -0,0 +1,37 @@
+package turntimers
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+)
+
+type Manager interface {
+	// Schedule starts the timer for whoever's turn it is in the encounter's fight, it is a no-op when
+	// the timer is already running for that turn
+	Schedule(ctx context.Context, encounterID string) (*entities.TurnTimer, error)
+	// Tick sends the reminders and skips the turns that are due
+	Tick(ctx context.Context) (*TickOutput, error)
+	Cancel(ctx context.Context, encounterID string) error
+}
+
+type TickOutput struct {
+	// Pings are the timers whose player needs reminding it is their turn
+	Pings []*entities.TurnTimer
+	Skips []*Skip
+	// Failures are the timers that could not be ticked, they are retried after a back off
+	Failures []*Failure
+}
+
+// Failure is a timer that failed to tick and why
+type Failure struct {
+	Timer *entities.TurnTimer
+	Err   error
+}
+
+// Skip is a turn that ran out of time and was played for the player
+type Skip struct {
+	Timer  *entities.TurnTimer
+	Result *rooms.AttackOutput
+}
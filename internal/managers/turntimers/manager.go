@@ -0,0 +1,246 @@
+package turntimers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/turntimer"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+)
+
+const (
+	// baseBackOff is how long a timer waits after its first failed tick, it doubles with each
+	// failure after it up to maxBackOff
+	baseBackOff = 5 * time.Second
+	maxBackOff  = 5 * time.Minute
+)
+
+type Implementation struct {
+	encounterManager encounters.Manager
+	roomManager      rooms.Manager
+	turnTimerRepo    turntimer.Repository
+	timeClock        types.TimeClock
+}
+
+type Config struct {
+	EncounterManager encounters.Manager
+	RoomManager      rooms.Manager
+	TurnTimerRepo    turntimer.Repository
+}
+
+func New(cfg *Config) (*Implementation, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.EncounterManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.EncounterManager")
+	}
+
+	if cfg.RoomManager == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.RoomManager")
+	}
+
+	if cfg.TurnTimerRepo == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.TurnTimerRepo")
+	}
+
+	return &Implementation{
+		encounterManager: cfg.EncounterManager,
+		roomManager:      cfg.RoomManager,
+		turnTimerRepo:    cfg.TurnTimerRepo,
+		timeClock:        &types.Clock{},
+	}, nil
+}
+
+func (m *Implementation) Schedule(ctx context.Context, encounterID string) (*entities.TurnTimer, error) {
+	if encounterID == "" {
+		return nil, dnderr.NewMissingParameterError("encounterID")
+	}
+
+	encounter, err := m.encounterManager.Get(ctx, encounterID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, m.Cancel(ctx, encounterID)
+		}
+
+		return nil, err
+	}
+
+	if encounter.TurnTimeout <= 0 || encounter.GetStatus() != entities.EncounterStatusInProgress || encounter.RoomID == "" {
+		return nil, m.Cancel(ctx, encounterID)
+	}
+
+	turn, err := m.roomManager.CurrentTurn(ctx, &rooms.CurrentTurnInput{
+		RoomID: encounter.RoomID,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, m.Cancel(ctx, encounterID)
+		}
+
+		return nil, err
+	}
+
+	// the monsters never wait on a timer, their turns are played straight after a player's
+	if !turn.Active || turn.PlayerID == "" {
+		return nil, m.Cancel(ctx, encounterID)
+	}
+
+	existing, err := m.turnTimerRepo.Get(ctx, encounterID)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	if existing != nil && existing.IsFor(turn.PlayerID, turn.Round, turn.Turn) {
+		return existing, nil
+	}
+
+	now := m.timeClock.Now()
+
+	return m.turnTimerRepo.Put(ctx, &entities.TurnTimer{
+		EncounterID: encounter.ID,
+		RoomID:      encounter.RoomID,
+		ChannelID:   encounter.ChannelID,
+		PlayerID:    turn.PlayerID,
+		Round:       turn.Round,
+		Turn:        turn.Turn,
+		PingAt:      now.Add(time.Duration(encounter.PingAfter) * time.Second),
+		Deadline:    now.Add(time.Duration(encounter.TurnTimeout) * time.Second),
+	})
+}
+
+// Tick looks at every timer that is due. A timer for a turn that was already played is moved on to
+// the turn being played now, the fight may have moved on without it.
+func (m *Implementation) Tick(ctx context.Context) (*TickOutput, error) {
+	now := m.timeClock.Now()
+
+	due, err := m.turnTimerRepo.ListDue(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &TickOutput{
+		Pings:    make([]*entities.TurnTimer, 0),
+		Skips:    make([]*Skip, 0),
+		Failures: make([]*Failure, 0),
+	}
+
+	// one broken fight does not hold up the timers of the others
+	for _, timer := range due {
+		err = m.tick(ctx, now, timer, out)
+		if err != nil {
+			out.Failures = append(out.Failures, &Failure{
+				Timer: timer,
+				Err:   m.backOff(ctx, now, timer, err),
+			})
+		}
+	}
+
+	return out, nil
+}
+
+// backOff holds the timer back for longer after each failure in a row so a fight that keeps
+// failing is not retried every tick, it returns the error the tick failed with
+func (m *Implementation) backOff(ctx context.Context, now time.Time, timer *entities.TurnTimer, cause error) error {
+	timer.RetryAt = now.Add(min(baseBackOff<<timer.Failures, maxBackOff))
+	timer.Failures++
+
+	_, err := m.turnTimerRepo.Put(ctx, timer)
+	if err != nil {
+		return errors.Join(cause, err)
+	}
+
+	return cause
+}
+
+func (m *Implementation) tick(ctx context.Context, now time.Time, timer *entities.TurnTimer, out *TickOutput) error {
+	turn, err := m.roomManager.CurrentTurn(ctx, &rooms.CurrentTurnInput{
+		RoomID: timer.RoomID,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return m.Cancel(ctx, timer.EncounterID)
+		}
+
+		return err
+	}
+
+	if !turn.Active || !timer.IsFor(turn.PlayerID, turn.Round, turn.Turn) {
+		_, err = m.Schedule(ctx, timer.EncounterID)
+		return err
+	}
+
+	if !now.Before(timer.Deadline) {
+		result, err := m.roomManager.SkipTurn(ctx, &rooms.SkipTurnInput{
+			RoomID:   timer.RoomID,
+			PlayerID: timer.PlayerID,
+			Round:    timer.Round,
+			Turn:     timer.Turn,
+		})
+		if err != nil {
+			// the player acted while the turn was being skipped, their move stands and the timer
+			// follows the fight to whoever is up now
+			if isConflict(err) {
+				_, err = m.Schedule(ctx, timer.EncounterID)
+			}
+
+			return err
+		}
+
+		out.Skips = append(out.Skips, &Skip{
+			Timer:  timer,
+			Result: result,
+		})
+
+		if result.Outcome != rooms.OutcomeUnset {
+			_, err = m.encounterManager.Resolve(ctx, timer.EncounterID, string(result.Outcome))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = m.Schedule(ctx, timer.EncounterID)
+		return err
+	}
+
+	if timer.Pinged {
+		return nil
+	}
+
+	timer.Pinged = true
+	timer.Failures = 0
+	timer.RetryAt = time.Time{}
+	_, err = m.turnTimerRepo.Put(ctx, timer)
+	if err != nil {
+		timer.Pinged = false
+		return err
+	}
+
+	out.Pings = append(out.Pings, timer)
+
+	return nil
+}
+
+func (m *Implementation) Cancel(ctx context.Context, encounterID string) error {
+	if encounterID == "" {
+		return dnderr.NewMissingParameterError("encounterID")
+	}
+
+	return m.turnTimerRepo.Delete(ctx, encounterID)
+}
+
+func isNotFound(err error) bool {
+	var notFoundErr *dnderr.NotFoundError
+	return errors.As(err, &notFoundErr)
+}
+
+func isConflict(err error) bool {
+	var conflictErr *dnderr.ConflictError
+	return errors.As(err, &conflictErr)
+}
@@ -0,0 +1,289 @@
+package turntimers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/turntimer"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteManager struct {
+	suite.Suite
+
+	ctx              context.Context
+	encounterManager *encounters.Mock
+	roomManager      *rooms.Mock
+	turnTimerRepo    *turntimer.Mock
+	timeClock        *types.MockClock
+	fixture          *Implementation
+
+	now       time.Time
+	encounter *entities.Encounter
+	timer     *entities.TurnTimer
+}
+
+func (s *suiteManager) SetupTest() {
+	s.ctx = context.Background()
+	s.encounterManager = &encounters.Mock{}
+	s.roomManager = &rooms.Mock{}
+	s.turnTimerRepo = &turntimer.Mock{}
+	s.timeClock = &types.MockClock{}
+
+	s.fixture = &Implementation{
+		encounterManager: s.encounterManager,
+		roomManager:      s.roomManager,
+		turnTimerRepo:    s.turnTimerRepo,
+		timeClock:        s.timeClock,
+	}
+
+	s.now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.timeClock.On("Now").Return(s.now)
+
+	s.encounter = &entities.Encounter{
+		ID:          "encounter-id",
+		ChannelID:   "channel-id",
+		Status:      entities.EncounterStatusInProgress,
+		Players:     []string{"player-1"},
+		RoomID:      "room-id",
+		TurnTimeout: 120,
+		PingAfter:   60,
+	}
+
+	s.timer = &entities.TurnTimer{
+		EncounterID: "encounter-id",
+		RoomID:      "room-id",
+		ChannelID:   "channel-id",
+		PlayerID:    "player-1",
+		Round:       1,
+		PingAt:      s.now.Add(-time.Minute),
+		Deadline:    s.now.Add(time.Minute),
+	}
+}
+
+func (s *suiteManager) expectTurn(turn *rooms.CurrentTurnOutput) {
+	s.roomManager.On("CurrentTurn", s.ctx, &rooms.CurrentTurnInput{RoomID: "room-id"}).Return(turn, nil)
+}
+
+func (s *suiteManager) TestNew() {
+	_, err := New(&Config{EncounterManager: s.encounterManager, RoomManager: s.roomManager})
+	s.IsType(&dnderr.MissingParameterError{}, err)
+
+	result, err := New(&Config{
+		EncounterManager: s.encounterManager,
+		RoomManager:      s.roomManager,
+		TurnTimerRepo:    s.turnTimerRepo,
+	})
+	s.NoError(err)
+	s.NotNil(result)
+}
+
+func (s *suiteManager) TestSchedule() {
+	s.encounterManager.On("Get", s.ctx, "encounter-id").Return(s.encounter, nil)
+	s.expectTurn(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-1", Round: 2, Turn: 0})
+	s.turnTimerRepo.On("Get", s.ctx, "encounter-id").Return(nil, dnderr.NewNotFoundError("turn timer not found"))
+
+	expected := &entities.TurnTimer{
+		EncounterID: "encounter-id",
+		RoomID:      "room-id",
+		ChannelID:   "channel-id",
+		PlayerID:    "player-1",
+		Round:       2,
+		PingAt:      s.now.Add(time.Minute),
+		Deadline:    s.now.Add(2 * time.Minute),
+	}
+	s.turnTimerRepo.On("Put", s.ctx, expected).Return(expected, nil)
+
+	result, err := s.fixture.Schedule(s.ctx, "encounter-id")
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *suiteManager) TestScheduleKeepsTheRunningTimer() {
+	s.encounterManager.On("Get", s.ctx, "encounter-id").Return(s.encounter, nil)
+	s.expectTurn(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-1", Round: 1, Turn: 0})
+	s.turnTimerRepo.On("Get", s.ctx, "encounter-id").Return(s.timer, nil)
+
+	result, err := s.fixture.Schedule(s.ctx, "encounter-id")
+	s.NoError(err)
+	s.Equal(s.timer, result)
+	s.turnTimerRepo.AssertNotCalled(s.T(), "Put", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestScheduleTimerOff() {
+	s.encounter.TurnTimeout = 0
+	s.encounterManager.On("Get", s.ctx, "encounter-id").Return(s.encounter, nil)
+	s.turnTimerRepo.On("Delete", s.ctx, "encounter-id").Return(nil)
+
+	result, err := s.fixture.Schedule(s.ctx, "encounter-id")
+	s.NoError(err)
+	s.Nil(result)
+	s.turnTimerRepo.AssertCalled(s.T(), "Delete", s.ctx, "encounter-id")
+}
+
+func (s *suiteManager) TestScheduleFightOver() {
+	s.encounterManager.On("Get", s.ctx, "encounter-id").Return(s.encounter, nil)
+	s.expectTurn(&rooms.CurrentTurnOutput{})
+	s.turnTimerRepo.On("Delete", s.ctx, "encounter-id").Return(nil)
+
+	result, err := s.fixture.Schedule(s.ctx, "encounter-id")
+	s.NoError(err)
+	s.Nil(result)
+}
+
+func (s *suiteManager) TestTickPings() {
+	s.turnTimerRepo.On("ListDue", s.ctx, s.now).Return([]*entities.TurnTimer{s.timer}, nil)
+	s.expectTurn(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-1", Round: 1, Turn: 0})
+	s.turnTimerRepo.On("Put", s.ctx, s.timer).Return(s.timer, nil)
+
+	result, err := s.fixture.Tick(s.ctx)
+	s.NoError(err)
+	s.Equal([]*entities.TurnTimer{s.timer}, result.Pings)
+	s.Empty(result.Skips)
+	s.True(s.timer.Pinged)
+}
+
+func (s *suiteManager) TestTickSkipsPastTheDeadline() {
+	s.timer.Pinged = true
+	s.timer.Deadline = s.now
+	s.turnTimerRepo.On("ListDue", s.ctx, s.now).Return([]*entities.TurnTimer{s.timer}, nil)
+	s.roomManager.On("CurrentTurn", s.ctx, &rooms.CurrentTurnInput{RoomID: "room-id"}).
+		Return(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-1", Round: 1, Turn: 0}, nil).Once()
+	s.roomManager.On("CurrentTurn", s.ctx, &rooms.CurrentTurnInput{RoomID: "room-id"}).
+		Return(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-1", Round: 2, Turn: 0}, nil).Once()
+
+	skipped := &rooms.AttackOutput{Log: []string{"Fighter ran out of time."}}
+	s.roomManager.On("SkipTurn", s.ctx, &rooms.SkipTurnInput{RoomID: "room-id", PlayerID: "player-1", Round: 1}).Return(skipped, nil)
+	s.encounterManager.On("Get", s.ctx, "encounter-id").Return(s.encounter, nil)
+	s.turnTimerRepo.On("Get", s.ctx, "encounter-id").Return(s.timer, nil)
+	s.turnTimerRepo.On("Put", s.ctx, mock.MatchedBy(func(next *entities.TurnTimer) bool {
+		return next.Round == 2 && !next.Pinged && next.Deadline.Equal(s.now.Add(2*time.Minute))
+	})).Return(&entities.TurnTimer{}, nil)
+
+	result, err := s.fixture.Tick(s.ctx)
+	s.NoError(err)
+	s.Empty(result.Pings)
+	s.Equal([]*Skip{{Timer: s.timer, Result: skipped}}, result.Skips)
+	s.encounterManager.AssertNotCalled(s.T(), "Resolve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestTickSkipThatEndsTheFightResolves() {
+	s.timer.Deadline = s.now
+	s.turnTimerRepo.On("ListDue", s.ctx, s.now).Return([]*entities.TurnTimer{s.timer}, nil)
+	s.roomManager.On("CurrentTurn", s.ctx, &rooms.CurrentTurnInput{RoomID: "room-id"}).
+		Return(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-1", Round: 1, Turn: 0}, nil).Once()
+	s.roomManager.On("SkipTurn", s.ctx, &rooms.SkipTurnInput{RoomID: "room-id", PlayerID: "player-1", Round: 1}).
+		Return(&rooms.AttackOutput{Outcome: rooms.OutcomeDefeat}, nil)
+
+	resolved := *s.encounter
+	resolved.Status = entities.EncounterStatusResolved
+	s.encounterManager.On("Resolve", s.ctx, "encounter-id", "defeat").Return(&resolved, nil)
+	s.encounterManager.On("Get", s.ctx, "encounter-id").Return(&resolved, nil)
+	s.turnTimerRepo.On("Delete", s.ctx, "encounter-id").Return(nil)
+
+	result, err := s.fixture.Tick(s.ctx)
+	s.NoError(err)
+	s.Len(result.Skips, 1)
+	s.turnTimerRepo.AssertCalled(s.T(), "Delete", s.ctx, "encounter-id")
+}
+
+func (s *suiteManager) TestTickGivesUpWhenThePlayerActed() {
+	s.timer.Deadline = s.now
+	s.turnTimerRepo.On("ListDue", s.ctx, s.now).Return([]*entities.TurnTimer{s.timer}, nil)
+	s.roomManager.On("CurrentTurn", s.ctx, &rooms.CurrentTurnInput{RoomID: "room-id"}).
+		Return(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-1", Round: 1, Turn: 0}, nil).Once()
+	s.roomManager.On("SkipTurn", s.ctx, &rooms.SkipTurnInput{RoomID: "room-id", PlayerID: "player-1", Round: 1}).
+		Return(nil, dnderr.NewConflictError("room room-id was updated during the write"))
+	s.roomManager.On("CurrentTurn", s.ctx, &rooms.CurrentTurnInput{RoomID: "room-id"}).
+		Return(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-2", Round: 1, Turn: 1}, nil).Once()
+	s.encounterManager.On("Get", s.ctx, "encounter-id").Return(s.encounter, nil)
+	s.turnTimerRepo.On("Get", s.ctx, "encounter-id").Return(s.timer, nil)
+	s.turnTimerRepo.On("Put", s.ctx, mock.MatchedBy(func(next *entities.TurnTimer) bool {
+		return next.PlayerID == "player-2" && next.Turn == 1
+	})).Return(&entities.TurnTimer{}, nil)
+
+	result, err := s.fixture.Tick(s.ctx)
+	s.NoError(err)
+	s.Empty(result.Skips)
+	s.encounterManager.AssertNotCalled(s.T(), "Resolve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestTickMovesOnWithTheFight() {
+	s.turnTimerRepo.On("ListDue", s.ctx, s.now).Return([]*entities.TurnTimer{s.timer}, nil)
+	s.expectTurn(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-2", Round: 1, Turn: 1})
+	s.encounterManager.On("Get", s.ctx, "encounter-id").Return(s.encounter, nil)
+	s.turnTimerRepo.On("Get", s.ctx, "encounter-id").Return(s.timer, nil)
+	s.turnTimerRepo.On("Put", s.ctx, mock.MatchedBy(func(next *entities.TurnTimer) bool {
+		return next.PlayerID == "player-2" && next.Turn == 1
+	})).Return(&entities.TurnTimer{}, nil)
+
+	result, err := s.fixture.Tick(s.ctx)
+	s.NoError(err)
+	s.Empty(result.Pings)
+	s.Empty(result.Skips)
+	s.roomManager.AssertNotCalled(s.T(), "SkipTurn", mock.Anything, mock.Anything)
+}
+
+func (s *suiteManager) TestTickRoomGone() {
+	s.turnTimerRepo.On("ListDue", s.ctx, s.now).Return([]*entities.TurnTimer{s.timer}, nil)
+	s.roomManager.On("CurrentTurn", s.ctx, &rooms.CurrentTurnInput{RoomID: "room-id"}).
+		Return(nil, dnderr.NewNotFoundError("room not found"))
+	s.turnTimerRepo.On("Delete", s.ctx, "encounter-id").Return(nil)
+
+	result, err := s.fixture.Tick(s.ctx)
+	s.NoError(err)
+	s.Empty(result.Pings)
+	s.turnTimerRepo.AssertCalled(s.T(), "Delete", s.ctx, "encounter-id")
+}
+
+func (s *suiteManager) TestTickKeepsGoingPastAFailure() {
+	s.timer.Failures = 2
+	broken := &entities.TurnTimer{
+		EncounterID: "broken-id",
+		RoomID:      "broken-room-id",
+		PlayerID:    "player-2",
+		Round:       1,
+		PingAt:      s.now.Add(-time.Minute),
+		Deadline:    s.now.Add(time.Minute),
+		Failures:    2,
+	}
+	s.turnTimerRepo.On("ListDue", s.ctx, s.now).Return([]*entities.TurnTimer{broken, s.timer}, nil)
+	s.roomManager.On("CurrentTurn", s.ctx, &rooms.CurrentTurnInput{RoomID: "broken-room-id"}).
+		Return(nil, errors.New("boom"))
+	s.turnTimerRepo.On("Put", s.ctx, broken).Return(broken, nil)
+	s.expectTurn(&rooms.CurrentTurnOutput{Active: true, PlayerID: "player-1", Round: 1, Turn: 0})
+	s.turnTimerRepo.On("Put", s.ctx, s.timer).Return(s.timer, nil)
+
+	result, err := s.fixture.Tick(s.ctx)
+	s.NoError(err)
+	s.Equal([]*entities.TurnTimer{s.timer}, result.Pings)
+	s.Require().Len(result.Failures, 1)
+	s.Equal(broken, result.Failures[0].Timer)
+	s.EqualError(result.Failures[0].Err, "boom")
+	s.Equal(3, broken.Failures)
+	s.Equal(s.now.Add(20*time.Second), broken.RetryAt)
+	s.Equal(s.now.Add(20*time.Second), broken.NextAt())
+	s.Equal(0, s.timer.Failures)
+}
+
+func (s *suiteManager) TestBackOffIsCapped() {
+	s.timer.Failures = 10
+	s.turnTimerRepo.On("Put", s.ctx, s.timer).Return(nil, errors.New("redis down"))
+
+	err := s.fixture.backOff(s.ctx, s.now, s.timer, errors.New("boom"))
+	s.ErrorContains(err, "boom")
+	s.ErrorContains(err, "redis down")
+	s.Equal(s.now.Add(maxBackOff), s.timer.RetryAt)
+}
+
+func TestManagerSuite(t *testing.T) {
+	suite.Run(t, new(suiteManager))
+}
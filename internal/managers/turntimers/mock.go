@@ -0,0 +1,42 @@
+package turntimers
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Schedule(ctx context.Context, encounterID string) (*entities.TurnTimer, error) {
+	args := m.Called(ctx, encounterID)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+
+	return args.Get(0).(*entities.TurnTimer), nil
+}
+
+func (m *Mock) Tick(ctx context.Context) (*TickOutput, error) {
+	args := m.Called(ctx)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*TickOutput), nil
+}
+
+func (m *Mock) Cancel(ctx context.Context, encounterID string) error {
+	args := m.Called(ctx, encounterID)
+
+	return args.Error(0)
+}
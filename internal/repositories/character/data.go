@@ -3,24 +3,38 @@ package character
 import "github.com/KirkDiggler/dnd-bot-go/internal/entities"
 
 type Data struct {
-	ID               string                       `json:"id"`
-	OwnerID          string                       `json:"owner_id"`
-	Name             string                       `json:"name"`
-	ClassKey         string                       `json:"class_key"`
-	RaceKey          string                       `json:"race_key"`
-	AC               int                          `json:"ac"`
-	Speed            int                          `json:"speed"`
-	HitDie           int                          `json:"hit_die"`
-	Level            int                          `json:"level"`
-	Experience       int                          `json:"experience"`
-	MaxHitPoints     int                          `json:"max_hit_points"`
-	CurrentHitPoints int                          `json:"current_hit_points"`
-	EquippedSlots    map[entities.Slot]*Equipment `json:"equipped_slots"`
-	Attributes       *AttributeData               `json:"attributes"`
-	NextLevel        int                          `json:"next_level"`
-	Rolls            []*RollData                  `json:"rolls"`
-	Proficiencies    []*Proficiency               `json:"proficiencies"`
-	Inventory        []*Equipment                 `json:"inventory"`
+	ID                  string                       `json:"id"`
+	Version             int                          `json:"version"`
+	OwnerID             string                       `json:"owner_id"`
+	Name                string                       `json:"name"`
+	ClassKey            string                       `json:"class_key"`
+	Classes             []*ClassLevelData            `json:"classes"`
+	RaceKey             string                       `json:"race_key"`
+	AC                  int                          `json:"ac"`
+	Speed               int                          `json:"speed"`
+	HitDie              int                          `json:"hit_die"`
+	Level               int                          `json:"level"`
+	Experience          int                          `json:"experience"`
+	MaxHitPoints        int                          `json:"max_hit_points"`
+	CurrentHitPoints    int                          `json:"current_hit_points"`
+	TempHitPoints       int                          `json:"temp_hit_points"`
+	EquippedSlots       map[entities.Slot]*Equipment `json:"equipped_slots"`
+	Attributes          *AttributeData               `json:"attributes"`
+	NextLevel           int                          `json:"next_level"`
+	Rolls               []*RollData                  `json:"rolls"`
+	Proficiencies       []*Proficiency               `json:"proficiencies"`
+	Inventory           []*Equipment                 `json:"inventory"`
+	Coins               *entities.Coins              `json:"coins,omitempty"`
+	Feats               []string                     `json:"feats"`
+	PendingImprovements int                          `json:"pending_improvements"`
+	PendingLevels       int                          `json:"pending_levels"`
+}
+
+// ClassLevelData is the level taken in one class, ClassKey stays the starting class
+// so characters saved before multiclassing still load
+type ClassLevelData struct {
+	ClassKey string `json:"class_key"`
+	Level    int    `json:"level"`
 }
 
 type RollData struct {
@@ -2,6 +2,7 @@ package character
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
 
@@ -58,6 +59,9 @@ func (r *redisRepo) Get(ctx context.Context, id string) (*Data, error) {
 	return data, nil
 }
 
+// Put stores the character, rejecting the write with a ConflictError if the stored
+// version has changed since the character was loaded. A character without an ID has
+// not been loaded and replaces whatever is stored.
 func (r *redisRepo) Put(ctx context.Context, character *entities.Character) (*entities.Character, error) {
 	if character == nil {
 		return nil, dnderr.NewMissingParameterError("character")
@@ -67,13 +71,59 @@ func (r *redisRepo) Put(ctx context.Context, character *entities.Character) (*en
 		return nil, dnderr.NewMissingParameterError("character.OwnerID")
 	}
 
+	replace := character.ID == ""
 	character.ID = character.OwnerID
-	data := dataToJSON(characterToData(character))
+	key := getCharacterKey(character.ID)
 
-	result := r.client.Set(ctx, getCharacterKey(character.ID), data, 0)
-	if result.Err() != nil {
-		return nil, result.Err()
+	var version int
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := r.storedVersion(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		if !replace && current != character.Version {
+			return dnderr.NewConflictError(fmt.Sprintf("character %s was updated, expected version %d but found %d", character.ID, character.Version, current))
+		}
+
+		version = current + 1
+		data := characterToData(character)
+		data.Version = version
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, dataToJSON(data), 0)
+			return nil
+		})
+
+		return err
+	}, key)
+	if err != nil {
+		if errors.Is(err, redis.TxFailedErr) {
+			return nil, dnderr.NewConflictError(fmt.Sprintf("character %s was updated during the write", character.ID))
+		}
+
+		return nil, err
 	}
 
+	character.Version = version
+
 	return character, nil
 }
+
+func (r *redisRepo) storedVersion(ctx context.Context, tx *redis.Tx, key string) (int, error) {
+	result, err := tx.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	data := jsonToData(result)
+	if data == nil {
+		return 0, nil
+	}
+
+	return data.Version, nil
+}
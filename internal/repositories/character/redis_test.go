@@ -7,6 +7,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"testing"
 
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
 
 	"github.com/KirkDiggler/dnd-bot-go/internal/types"
@@ -81,17 +82,71 @@ func (s *characterSuite) TestGetCharacterNotFound() {
 	s.Nil(result)
 }
 
+func (s *characterSuite) expectedPutPayload(version int) string {
+	data := characterToData(s.character)
+	data.Version = version
+
+	return dataToJSON(data)
+}
+
 func (s *characterSuite) TestCreateCharacter() {
-	s.redisMock.ExpectSet(getCharacterKey(s.id), s.jsonPayload, 0).SetVal("OK")
+	s.redisMock.ExpectWatch(getCharacterKey(s.id))
+	s.redisMock.ExpectGet(getCharacterKey(s.id)).RedisNil()
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getCharacterKey(s.id), s.expectedPutPayload(1), 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
 
 	result, err := s.fixture.Put(s.ctx, s.character)
 	s.NoError(err)
 	s.NotNil(result)
 	s.Equal(s.character, result)
+	s.Equal(1, result.Version)
+}
+
+func (s *characterSuite) TestUpdateCharacter() {
+	s.character.Version = 3
+	s.redisMock.ExpectWatch(getCharacterKey(s.id))
+	s.redisMock.ExpectGet(getCharacterKey(s.id)).SetVal(s.expectedPutPayload(3))
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getCharacterKey(s.id), s.expectedPutPayload(4), 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
+
+	result, err := s.fixture.Put(s.ctx, s.character)
+	s.NoError(err)
+	s.Equal(4, result.Version)
+}
+
+func (s *characterSuite) TestUpdateCharacterStaleVersion() {
+	s.character.Version = 2
+	s.redisMock.ExpectWatch(getCharacterKey(s.id))
+	s.redisMock.ExpectGet(getCharacterKey(s.id)).SetVal(s.expectedPutPayload(3))
+
+	result, err := s.fixture.Put(s.ctx, s.character)
+	s.Error(err)
+	s.Nil(result)
+
+	var conflictErr *dnderr.ConflictError
+	s.ErrorAs(err, &conflictErr)
+}
+
+func (s *characterSuite) TestReplaceCharacterIgnoresVersion() {
+	s.redisMock.ExpectWatch(getCharacterKey(s.id))
+	s.redisMock.ExpectGet(getCharacterKey(s.id)).SetVal(s.expectedPutPayload(3))
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getCharacterKey(s.id), s.expectedPutPayload(4), 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
+	s.character.ID = ""
+
+	result, err := s.fixture.Put(s.ctx, s.character)
+	s.NoError(err)
+	s.Equal(4, result.Version)
 }
 
 func (s *characterSuite) TestCreateCharacterError() {
-	s.redisMock.ExpectSet(getCharacterKey(s.id), s.jsonPayload, 0).SetErr(errors.New("test error"))
+	s.redisMock.ExpectWatch(getCharacterKey(s.id))
+	s.redisMock.ExpectGet(getCharacterKey(s.id)).RedisNil()
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getCharacterKey(s.id), s.expectedPutPayload(1), 0).SetErr(errors.New("test error"))
 
 	result, err := s.fixture.Put(s.ctx, s.character)
 	s.Error(err)
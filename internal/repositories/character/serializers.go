@@ -67,27 +67,62 @@ func characterToData(input *entities.Character) *Data {
 		}
 	}
 
+	coins := input.Coins
+
 	return &Data{
-		ID:               input.ID,
-		OwnerID:          input.OwnerID,
-		Name:             input.Name,
-		HitDie:           input.HitDie,
-		AC:               input.AC,
-		MaxHitPoints:     input.MaxHitPoints,
-		CurrentHitPoints: input.CurrentHitPoints,
-		Experience:       input.Experience,
-		NextLevel:        input.NextLevel,
-		Speed:            input.Speed,
-		Level:            input.Level,
-		RaceKey:          raceKey,
-		ClassKey:         classKey,
-		Attributes:       data,
-		Rolls:            rollResultsToRollDatas(input.Rolls),
-		Proficiencies:    proficienciesToDatas(input.Proficiencies),
-		Inventory:        equipmentsToDatas(input.Inventory),
-		EquippedSlots:    equippedSlotsToDatas(input.EquippedSlots),
+		ID:                  input.ID,
+		Version:             input.Version,
+		OwnerID:             input.OwnerID,
+		Name:                input.Name,
+		HitDie:              input.HitDie,
+		AC:                  input.AC,
+		MaxHitPoints:        input.MaxHitPoints,
+		CurrentHitPoints:    input.CurrentHitPoints,
+		TempHitPoints:       input.TempHitPoints,
+		Experience:          input.Experience,
+		NextLevel:           input.NextLevel,
+		Speed:               input.Speed,
+		Level:               input.Level,
+		RaceKey:             raceKey,
+		ClassKey:            classKey,
+		Classes:             classLevelsToDatas(input.Classes),
+		Attributes:          data,
+		Rolls:               rollResultsToRollDatas(input.Rolls),
+		Proficiencies:       proficienciesToDatas(input.Proficiencies),
+		Inventory:           equipmentsToDatas(input.Inventory),
+		Coins:               &coins,
+		EquippedSlots:       equippedSlotsToDatas(input.EquippedSlots),
+		Feats:               featsToKeys(input.Feats),
+		PendingImprovements: input.PendingImprovements,
+		PendingLevels:       input.PendingLevels,
+	}
+}
+
+func featsToKeys(input []*entities.Feat) []string {
+	keys := make([]string, len(input))
+	for idx, feat := range input {
+		keys[idx] = feat.Key
 	}
+
+	return keys
 }
+
+func classLevelsToDatas(input []*entities.ClassLevel) []*ClassLevelData {
+	datas := make([]*ClassLevelData, 0, len(input))
+	for _, classLevel := range input {
+		if classLevel == nil || classLevel.Class == nil {
+			continue
+		}
+
+		datas = append(datas, &ClassLevelData{
+			ClassKey: classLevel.Class.Key,
+			Level:    classLevel.Level,
+		})
+	}
+
+	return datas
+}
+
 func abilityScoreToData(input *entities.AbilityScore) *AbilityScoreData {
 	return &AbilityScoreData{
 		Score: input.Score,
@@ -144,7 +179,7 @@ func equippedSlotsToDatas(input map[entities.Slot]entities.Equipment) map[entiti
 		if data == nil {
 			continue
 		}
-		
+
 		datas[k] = data
 	}
 
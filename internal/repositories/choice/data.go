@@ -16,6 +16,8 @@ const (
 	TypeProficiency Type = "proficiency"
 	TypeLanguage    Type = "language"
 	TypeEquipment   Type = "equipment"
+
+	TypeAbilityScoreImprovement Type = "ability-score-improvement"
 )
 
 type Data struct {
@@ -103,6 +103,7 @@ func dataToChoice(input *Choice) *entities.Choice {
 		Count:  input.Count,
 		Status: statusToChoiceStatus(input.Status),
 		Name:   input.Name,
+		Key:    input.Key,
 	}
 
 	choice.Options = make([]entities.Option, len(input.Options))
@@ -127,6 +128,7 @@ func choiceToData(input *entities.Choice) *Choice {
 		Count:  input.Count,
 		Status: choiceStatusToStatus(input.Status),
 		Name:   input.Name,
+		Key:    input.Key,
 	}
 
 	choice.Options = make([]*Option, len(input.Options))
@@ -272,6 +274,8 @@ func choiceTypeToType(input entities.ChoiceType) Type {
 		return TypeLanguage
 	case entities.ChoiceTypeEquipment:
 		return TypeEquipment
+	case entities.ChoiceTypeAbilityScoreImprovement:
+		return TypeAbilityScoreImprovement
 	default:
 		return TypeUnset
 	}
@@ -285,6 +289,8 @@ func typeToChoiceType(input Type) entities.ChoiceType {
 		return entities.ChoiceTypeLanguage
 	case TypeEquipment:
 		return entities.ChoiceTypeEquipment
+	case TypeAbilityScoreImprovement:
+		return entities.ChoiceTypeAbilityScoreImprovement
 	default:
 		return entities.ChoiceTypeUnset
 	}
@@ -0,0 +1,22 @@
+package combatlog
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+)
+
+// Repository stores the events of each room's fight in order
+type Repository interface {
+	// Append adds the events to the end of the room's log, setting their Seq
+	Append(ctx context.Context, roomID string, events []*combat.Event) ([]*combat.Event, error)
+	List(ctx context.Context, input *ListInput) ([]*combat.Event, error)
+	Count(ctx context.Context, roomID string) (int, error)
+}
+
+type ListInput struct {
+	RoomID string
+	Offset int64
+	// Limit is the number of events to return, 0 returns them all
+	Limit int64
+}
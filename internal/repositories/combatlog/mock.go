@@ -0,0 +1,38 @@
+package combatlog
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Append(ctx context.Context, roomID string, events []*combat.Event) ([]*combat.Event, error) {
+	args := m.Called(ctx, roomID, events)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*combat.Event), nil
+}
+
+func (m *Mock) List(ctx context.Context, input *ListInput) ([]*combat.Event, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*combat.Event), nil
+}
+
+func (m *Mock) Count(ctx context.Context, roomID string) (int, error) {
+	args := m.Called(ctx, roomID)
+
+	return args.Int(0), args.Error(1)
+}
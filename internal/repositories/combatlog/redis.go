@@ -0,0 +1,147 @@
+package combatlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/redis/go-redis/v9"
+)
+
+// maxAppendAttempts is how many times Append numbers the events again after another append
+// lands first
+const maxAppendAttempts = 3
+
+type Redis struct {
+	client redis.UniversalClient
+}
+
+type RedisConfig struct {
+	Client redis.UniversalClient
+}
+
+func NewRedis(cfg *RedisConfig) (*Redis, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	return &Redis{
+		client: cfg.Client,
+	}, nil
+}
+
+func getCombatLogKey(roomID string) string {
+	return "combatLog:" + roomID
+}
+
+func (r *Redis) Append(ctx context.Context, roomID string, events []*combat.Event) ([]*combat.Event, error) {
+	if roomID == "" {
+		return nil, dnderr.NewMissingParameterError("roomID")
+	}
+
+	if len(events) == 0 {
+		return events, nil
+	}
+
+	key := getCombatLogKey(roomID)
+
+	var err error
+	for attempt := 0; attempt < maxAppendAttempts; attempt++ {
+		err = r.client.Watch(ctx, func(tx *redis.Tx) error {
+			return r.push(ctx, tx, key, events)
+		}, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			break
+		}
+	}
+	if err != nil {
+		if errors.Is(err, redis.TxFailedErr) {
+			return nil, dnderr.NewConflictError(fmt.Sprintf("combat log %s kept changing during the append", roomID))
+		}
+
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// push numbers the events from the length of the watched log, the push fails if another append
+// lands in between so two appends can't share sequence numbers
+func (r *Redis) push(ctx context.Context, tx *redis.Tx, key string, events []*combat.Event) error {
+	count, err := tx.LLen(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(events))
+	for idx, event := range events {
+		event.Seq = int(count) + idx
+
+		buf, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		values[idx] = string(buf)
+	}
+
+	_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, values...)
+		return nil
+	})
+
+	return err
+}
+
+func (r *Redis) List(ctx context.Context, input *ListInput) ([]*combat.Event, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.RoomID == "" {
+		return nil, dnderr.NewMissingParameterError("input.RoomID")
+	}
+
+	stop := int64(-1)
+	if input.Limit > 0 {
+		stop = input.Offset + input.Limit - 1
+	}
+
+	results, err := r.client.LRange(ctx, getCombatLogKey(input.RoomID), input.Offset, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*combat.Event, len(results))
+	for idx, result := range results {
+		event := &combat.Event{}
+		err = json.Unmarshal([]byte(result), event)
+		if err != nil {
+			return nil, err
+		}
+
+		events[idx] = event
+	}
+
+	return events, nil
+}
+
+func (r *Redis) Count(ctx context.Context, roomID string) (int, error) {
+	if roomID == "" {
+		return 0, dnderr.NewMissingParameterError("roomID")
+	}
+
+	count, err := r.client.LLen(ctx, getCombatLogKey(roomID)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,139 @@
+package combatlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type combatLogSuite struct {
+	suite.Suite
+
+	ctx       context.Context
+	redisMock redismock.ClientMock
+	fixture   *Redis
+
+	roomID string
+}
+
+func (s *combatLogSuite) SetupTest() {
+	s.ctx = context.Background()
+	client, redisMock := redismock.NewClientMock()
+	s.redisMock = redisMock
+	s.fixture = &Redis{
+		client: client,
+	}
+
+	s.roomID = "room-1"
+}
+
+func (s *combatLogSuite) toJson(event *combat.Event) string {
+	buf, err := json.Marshal(event)
+	s.Require().NoError(err)
+
+	return string(buf)
+}
+
+func (s *combatLogSuite) TestAppendNumbersEvents() {
+	events := []*combat.Event{
+		{Round: 1, Type: combat.EventTypeAttack, Text: "Tester hits Goblin."},
+		{Round: 1, Type: combat.EventTypeDamage, Text: "Goblin takes 5 damage."},
+	}
+
+	s.redisMock.ExpectWatch(getCombatLogKey(s.roomID))
+	s.redisMock.ExpectLLen(getCombatLogKey(s.roomID)).SetVal(3)
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectRPush(getCombatLogKey(s.roomID),
+		s.toJson(&combat.Event{Seq: 3, Round: 1, Type: combat.EventTypeAttack, Text: "Tester hits Goblin."}),
+		s.toJson(&combat.Event{Seq: 4, Round: 1, Type: combat.EventTypeDamage, Text: "Goblin takes 5 damage."}),
+	).SetVal(5)
+	s.redisMock.ExpectTxPipelineExec()
+
+	result, err := s.fixture.Append(s.ctx, s.roomID, events)
+	s.NoError(err)
+	s.Equal(3, result[0].Seq)
+	s.Equal(4, result[1].Seq)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *combatLogSuite) TestAppendRenumbersAfterConflict() {
+	event := &combat.Event{Round: 2, Type: combat.EventTypeAttack, Text: "Goblin misses Tester."}
+
+	s.redisMock.ExpectWatch(getCombatLogKey(s.roomID))
+	s.redisMock.ExpectLLen(getCombatLogKey(s.roomID)).SetVal(3)
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectRPush(getCombatLogKey(s.roomID),
+		s.toJson(&combat.Event{Seq: 3, Round: 2, Type: combat.EventTypeAttack, Text: "Goblin misses Tester."}),
+	).SetVal(4)
+	s.redisMock.ExpectTxPipelineExec().SetErr(redis.TxFailedErr)
+
+	s.redisMock.ExpectWatch(getCombatLogKey(s.roomID))
+	s.redisMock.ExpectLLen(getCombatLogKey(s.roomID)).SetVal(4)
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectRPush(getCombatLogKey(s.roomID),
+		s.toJson(&combat.Event{Seq: 4, Round: 2, Type: combat.EventTypeAttack, Text: "Goblin misses Tester."}),
+	).SetVal(5)
+	s.redisMock.ExpectTxPipelineExec()
+
+	result, err := s.fixture.Append(s.ctx, s.roomID, []*combat.Event{event})
+	s.NoError(err)
+	s.Equal(4, result[0].Seq)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *combatLogSuite) TestAppendNothing() {
+	result, err := s.fixture.Append(s.ctx, s.roomID, []*combat.Event{})
+	s.NoError(err)
+	s.Empty(result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *combatLogSuite) TestAppendRedisError() {
+	s.redisMock.ExpectWatch(getCombatLogKey(s.roomID))
+	s.redisMock.ExpectLLen(getCombatLogKey(s.roomID)).SetErr(errors.New("boom"))
+
+	_, err := s.fixture.Append(s.ctx, s.roomID, []*combat.Event{{Text: "test"}})
+	s.EqualError(err, "boom")
+}
+
+func (s *combatLogSuite) TestList() {
+	event := &combat.Event{Seq: 10, Round: 2, Type: combat.EventTypeDeath, ActorID: "monster-1", Text: "Goblin falls."}
+	s.redisMock.ExpectLRange(getCombatLogKey(s.roomID), 10, 19).SetVal([]string{s.toJson(event)})
+
+	result, err := s.fixture.List(s.ctx, &ListInput{RoomID: s.roomID, Offset: 10, Limit: 10})
+	s.NoError(err)
+	s.Equal([]*combat.Event{event}, result)
+}
+
+func (s *combatLogSuite) TestListAll() {
+	s.redisMock.ExpectLRange(getCombatLogKey(s.roomID), 0, -1).SetVal([]string{})
+
+	result, err := s.fixture.List(s.ctx, &ListInput{RoomID: s.roomID})
+	s.NoError(err)
+	s.Empty(result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *combatLogSuite) TestListValidatesInput() {
+	_, err := s.fixture.List(s.ctx, &ListInput{})
+	s.IsType(&dnderr.MissingParameterError{}, err)
+}
+
+func (s *combatLogSuite) TestCount() {
+	s.redisMock.ExpectLLen(getCombatLogKey(s.roomID)).SetVal(7)
+
+	count, err := s.fixture.Count(s.ctx, s.roomID)
+	s.NoError(err)
+	s.Equal(7, count)
+}
+
+func TestCombatLogSuite(t *testing.T) {
+	suite.Run(t, new(combatLogSuite))
+}
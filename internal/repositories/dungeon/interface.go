@@ -0,0 +1,14 @@
+package dungeon
+
+import (
+	"context"
+
+	dungeonEntities "github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+)
+
+type Repository interface {
+	Create(ctx context.Context, dungeon *dungeonEntities.Dungeon) (*dungeonEntities.Dungeon, error)
+	Update(ctx context.Context, dungeon *dungeonEntities.Dungeon) (*dungeonEntities.Dungeon, error)
+	Get(ctx context.Context, id string) (*dungeonEntities.Dungeon, error)
+	ListByPlayer(ctx context.Context, input *ListByPlayerInput) ([]*dungeonEntities.Dungeon, error)
+}
@@ -0,0 +1,52 @@
+package dungeon
+
+import (
+	"context"
+
+	dungeonEntities "github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Create(ctx context.Context, dungeon *dungeonEntities.Dungeon) (*dungeonEntities.Dungeon, error) {
+	args := m.Called(ctx, dungeon)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dungeonEntities.Dungeon), nil
+}
+
+func (m *Mock) Update(ctx context.Context, dungeon *dungeonEntities.Dungeon) (*dungeonEntities.Dungeon, error) {
+	args := m.Called(ctx, dungeon)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dungeonEntities.Dungeon), nil
+}
+
+func (m *Mock) Get(ctx context.Context, id string) (*dungeonEntities.Dungeon, error) {
+	args := m.Called(ctx, id)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*dungeonEntities.Dungeon), nil
+}
+
+func (m *Mock) ListByPlayer(ctx context.Context, input *ListByPlayerInput) ([]*dungeonEntities.Dungeon, error) {
+	args := m.Called(ctx, input)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*dungeonEntities.Dungeon), nil
+}
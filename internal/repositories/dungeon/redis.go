@@ -0,0 +1,243 @@
+package dungeon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	dungeonEntities "github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"github.com/redis/go-redis/v9"
+)
+
+type Redis struct {
+	client redis.UniversalClient
+	uuider types.UUIDGenerator
+}
+
+type RedisConfig struct {
+	Client redis.UniversalClient
+}
+
+func NewRedis(cfg *RedisConfig) (*Redis, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	return &Redis{
+		client: cfg.Client,
+		uuider: &types.GoogleUUID{},
+	}, nil
+}
+
+func playerDungeonKey(playerID string) string {
+	return "playerDungeon:" + playerID
+}
+
+func getDungeonKey(id string) string {
+	return "dungeon:" + id
+}
+
+func dungeonToJson(dungeon *dungeonEntities.Dungeon) (string, error) {
+	buf, err := json.Marshal(dungeon)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+func jsonToDungeon(jsonStr string) (*dungeonEntities.Dungeon, error) {
+	if jsonStr == "" {
+		return nil, dnderr.NewMissingParameterError("jsonStr")
+	}
+
+	out := &dungeonEntities.Dungeon{}
+	err := json.Unmarshal([]byte(jsonStr), out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Create saves a new dungeon and adds it to the index of each player in it
+func (r *Redis) Create(ctx context.Context, dungeon *dungeonEntities.Dungeon) (*dungeonEntities.Dungeon, error) {
+	if dungeon == nil {
+		return nil, dnderr.NewMissingParameterError("dungeon")
+	}
+
+	if dungeon.ID != "" {
+		return nil, dnderr.NewInvalidEntityError("dungeon.ID must be empty")
+	}
+
+	if len(dungeon.PlayerIDs) == 0 {
+		return nil, dnderr.NewInvalidEntityError("dungeon.PlayerIDs must not be empty")
+	}
+
+	dungeon.ID = r.uuider.New()
+
+	jsonStr, err := dungeonToJson(dungeon)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int64, len(dungeon.PlayerIDs))
+	for idx, playerID := range dungeon.PlayerIDs {
+		counts[idx], err = r.client.ZCard(ctx, playerDungeonKey(playerID)).Result()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, getDungeonKey(dungeon.ID), jsonStr, 0)
+	for idx, playerID := range dungeon.PlayerIDs {
+		pipe.ZAdd(ctx, playerDungeonKey(playerID), redis.Z{
+			Score:  float64(counts[idx]),
+			Member: getDungeonKey(dungeon.ID),
+		})
+	}
+
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dungeon, nil
+}
+
+// Update saves the dungeon when the stored version is the one it was loaded from, a dungeon that
+// changed since is a ConflictError
+func (r *Redis) Update(ctx context.Context, dungeon *dungeonEntities.Dungeon) (*dungeonEntities.Dungeon, error) {
+	if dungeon == nil {
+		return nil, dnderr.NewMissingParameterError("dungeon")
+	}
+
+	if dungeon.ID == "" {
+		return nil, dnderr.NewInvalidEntityError("dungeon.ID must not be empty")
+	}
+
+	key := getDungeonKey(dungeon.ID)
+
+	var version int
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := r.storedVersion(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		if current != dungeon.Version {
+			return dnderr.NewConflictError(fmt.Sprintf("dungeon %s was updated, expected version %d but found %d", dungeon.ID, dungeon.Version, current))
+		}
+
+		version = current + 1
+		stored := *dungeon
+		stored.Version = version
+
+		jsonStr, err := dungeonToJson(&stored)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, jsonStr, 0)
+			return nil
+		})
+
+		return err
+	}, key)
+	if err != nil {
+		if errors.Is(err, redis.TxFailedErr) {
+			return nil, dnderr.NewConflictError(fmt.Sprintf("dungeon %s was updated during the write", dungeon.ID))
+		}
+
+		return nil, err
+	}
+
+	dungeon.Version = version
+
+	return dungeon, nil
+}
+
+func (r *Redis) storedVersion(ctx context.Context, tx *redis.Tx, key string) (int, error) {
+	result, err := tx.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, dnderr.NewNotFoundError("dungeon not found")
+		}
+
+		return 0, err
+	}
+
+	stored, err := jsonToDungeon(result)
+	if err != nil {
+		return 0, err
+	}
+
+	return stored.Version, nil
+}
+
+func (r *Redis) Get(ctx context.Context, id string) (*dungeonEntities.Dungeon, error) {
+	if id == "" {
+		return nil, dnderr.NewMissingParameterError("id")
+	}
+
+	result, err := r.client.Get(ctx, getDungeonKey(id)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, dnderr.NewNotFoundError("dungeon not found")
+		}
+
+		return nil, err
+	}
+
+	return jsonToDungeon(result)
+}
+
+func (r *Redis) ListByPlayer(ctx context.Context, input *ListByPlayerInput) ([]*dungeonEntities.Dungeon, error) {
+	if input == nil {
+		return nil, dnderr.NewMissingParameterError("input")
+	}
+
+	if input.PlayerID == "" {
+		return nil, dnderr.NewMissingParameterError("input.PlayerID")
+	}
+
+	if input.Limit == 0 {
+		input.Limit = 10
+	}
+
+	start := input.Offset
+	stop := input.Offset + input.Limit - 1
+
+	var keys []string
+	var err error
+	if input.Reverse {
+		keys, err = r.client.ZRevRange(ctx, playerDungeonKey(input.PlayerID), start, stop).Result()
+	} else {
+		keys, err = r.client.ZRange(ctx, playerDungeonKey(input.PlayerID), start, stop).Result()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dungeons := make([]*dungeonEntities.Dungeon, len(keys))
+	for idx, key := range keys {
+		dungeon, err := r.Get(ctx, strings.TrimPrefix(key, getDungeonKey("")))
+		if err != nil {
+			return nil, err
+		}
+
+		dungeons[idx] = dungeon
+	}
+
+	return dungeons, nil
+}
@@ -0,0 +1,156 @@
+package dungeon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	dungeonEntities "github.com/KirkDiggler/dnd-bot-go/internal/entities/dungeon"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type dungeonSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	redisMock  redismock.ClientMock
+	mockUuider *types.MockUUID
+	fixture    *Redis
+
+	dungeon     *dungeonEntities.Dungeon
+	dungeonJson string
+}
+
+func (s *dungeonSuite) SetupTest() {
+	s.ctx = context.Background()
+	client, redisMock := redismock.NewClientMock()
+	s.redisMock = redisMock
+	s.mockUuider = &types.MockUUID{}
+	s.fixture = &Redis{
+		client: client,
+		uuider: s.mockUuider,
+	}
+
+	s.dungeon = &dungeonEntities.Dungeon{
+		ID:        "dungeon-1",
+		Seed:      42,
+		Status:    dungeonEntities.StatusActive,
+		PlayerIDs: []string{"player-1", "player-2"},
+		Rooms: []*dungeonEntities.Room{{
+			Contents: dungeonEntities.ContentsEntrance,
+			Exits:    []dungeonEntities.Direction{dungeonEntities.DirectionEast},
+			Visited:  true,
+			Cleared:  true,
+		}},
+	}
+
+	buf, _ := json.Marshal(s.dungeon)
+	s.dungeonJson = string(buf)
+}
+
+func (s *dungeonSuite) TestCreate() {
+	s.mockUuider.On("New").Return(s.dungeon.ID)
+	s.redisMock.ExpectZCard(playerDungeonKey("player-1")).SetVal(3)
+	s.redisMock.ExpectZCard(playerDungeonKey("player-2")).SetVal(0)
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getDungeonKey(s.dungeon.ID), s.dungeonJson, 0).SetVal("OK")
+	s.redisMock.ExpectZAdd(playerDungeonKey("player-1"), redis.Z{Score: 3, Member: getDungeonKey(s.dungeon.ID)}).SetVal(1)
+	s.redisMock.ExpectZAdd(playerDungeonKey("player-2"), redis.Z{Score: 0, Member: getDungeonKey(s.dungeon.ID)}).SetVal(1)
+	s.redisMock.ExpectTxPipelineExec()
+
+	input := *s.dungeon
+	input.ID = ""
+
+	result, err := s.fixture.Create(s.ctx, &input)
+	s.NoError(err)
+	s.Equal(s.dungeon, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *dungeonSuite) TestCreateValidatesInput() {
+	_, err := s.fixture.Create(s.ctx, nil)
+	s.EqualError(err, dnderr.NewMissingParameterError("dungeon").Error())
+
+	_, err = s.fixture.Create(s.ctx, s.dungeon)
+	s.EqualError(err, dnderr.NewInvalidEntityError("dungeon.ID must be empty").Error())
+
+	_, err = s.fixture.Create(s.ctx, &dungeonEntities.Dungeon{})
+	s.EqualError(err, dnderr.NewInvalidEntityError("dungeon.PlayerIDs must not be empty").Error())
+}
+
+func (s *dungeonSuite) storedJson(version int) string {
+	stored := *s.dungeon
+	stored.Version = version
+	buf, _ := json.Marshal(&stored)
+
+	return string(buf)
+}
+
+func (s *dungeonSuite) TestUpdate() {
+	s.dungeon.Version = 1
+	s.redisMock.ExpectWatch(getDungeonKey(s.dungeon.ID))
+	s.redisMock.ExpectGet(getDungeonKey(s.dungeon.ID)).SetVal(s.storedJson(1))
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getDungeonKey(s.dungeon.ID), s.storedJson(2), 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
+
+	result, err := s.fixture.Update(s.ctx, s.dungeon)
+	s.NoError(err)
+	s.Equal(2, result.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *dungeonSuite) TestUpdateStaleVersion() {
+	s.redisMock.ExpectWatch(getDungeonKey(s.dungeon.ID))
+	s.redisMock.ExpectGet(getDungeonKey(s.dungeon.ID)).SetVal(s.storedJson(3))
+
+	_, err := s.fixture.Update(s.ctx, s.dungeon)
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Equal(0, s.dungeon.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *dungeonSuite) TestUpdateError() {
+	s.redisMock.ExpectWatch(getDungeonKey(s.dungeon.ID))
+	s.redisMock.ExpectGet(getDungeonKey(s.dungeon.ID)).SetErr(errors.New("error"))
+
+	_, err := s.fixture.Update(s.ctx, s.dungeon)
+	s.EqualError(err, "error")
+}
+
+func (s *dungeonSuite) TestGet() {
+	s.redisMock.ExpectGet(getDungeonKey(s.dungeon.ID)).SetVal(s.dungeonJson)
+
+	result, err := s.fixture.Get(s.ctx, s.dungeon.ID)
+	s.NoError(err)
+	s.Equal(s.dungeon, result)
+}
+
+func (s *dungeonSuite) TestGetNotFound() {
+	s.redisMock.ExpectGet(getDungeonKey("missing")).SetErr(redis.Nil)
+
+	_, err := s.fixture.Get(s.ctx, "missing")
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *dungeonSuite) TestListByPlayer() {
+	s.redisMock.ExpectZRevRange(playerDungeonKey("player-1"), 0, 0).SetVal([]string{getDungeonKey(s.dungeon.ID)})
+	s.redisMock.ExpectGet(getDungeonKey(s.dungeon.ID)).SetVal(s.dungeonJson)
+
+	result, err := s.fixture.ListByPlayer(s.ctx, &ListByPlayerInput{
+		PlayerID: "player-1",
+		Limit:    1,
+		Reverse:  true,
+	})
+	s.NoError(err)
+	s.Equal([]*dungeonEntities.Dungeon{s.dungeon}, result)
+}
+
+func TestDungeon(t *testing.T) {
+	suite.Run(t, new(dungeonSuite))
+}
@@ -0,0 +1,8 @@
+package dungeon
+
+type ListByPlayerInput struct {
+	PlayerID string
+	Limit    int64
+	Offset   int64
+	Reverse  bool
+}
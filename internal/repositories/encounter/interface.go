@@ -9,4 +9,5 @@ type Repository interface {
 	Create(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error)
 	Update(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error)
 	Get(ctx context.Context, id string) (*entities.Encounter, error)
+	GetByChannel(ctx context.Context, channelID string) (*entities.Encounter, error)
 }
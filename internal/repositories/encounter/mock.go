@@ -0,0 +1,52 @@
+package encounter
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Create(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error) {
+	args := m.Called(ctx, encounter)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Update(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error) {
+	args := m.Called(ctx, encounter)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) Get(ctx context.Context, id string) (*entities.Encounter, error) {
+	args := m.Called(ctx, id)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
+
+func (m *Mock) GetByChannel(ctx context.Context, channelID string) (*entities.Encounter, error) {
+	args := m.Called(ctx, channelID)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Encounter), nil
+}
@@ -3,6 +3,8 @@ package encounter
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/KirkDiggler/dnd-bot-go/dnderr"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
 	"github.com/KirkDiggler/dnd-bot-go/internal/types"
@@ -39,6 +41,11 @@ func getEncounterKey(id string) string {
 	return "encounter:" + id
 }
 
+// channelEncounterKey points at the latest encounter created in the channel
+func channelEncounterKey(channelID string) string {
+	return "encounter:channel:" + channelID
+}
+
 func jsonToEncounter(jsonStr string) (*entities.Encounter, error) {
 	if jsonStr == "" {
 		return nil, dnderr.NewMissingParameterError("jsonStr")
@@ -53,6 +60,7 @@ func jsonToEncounter(jsonStr string) (*entities.Encounter, error) {
 	return &encounter, nil
 }
 
+// Create saves a new encounter, one created in a channel becomes the channel's encounter
 func (r *Redis) Create(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error) {
 	if encounter == nil {
 		return nil, dnderr.NewMissingParameterError("encounter")
@@ -69,8 +77,13 @@ func (r *Redis) Create(ctx context.Context, encounter *entities.Encounter) (*ent
 		return nil, err
 	}
 
-	err = r.client.Set(ctx, getEncounterKey(encounter.ID), jsonStr, 0).Err()
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, getEncounterKey(encounter.ID), jsonStr, 0)
+	if encounter.ChannelID != "" {
+		pipe.Set(ctx, channelEncounterKey(encounter.ChannelID), encounter.ID, 0)
+	}
 
+	_, err = pipe.Exec(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +91,8 @@ func (r *Redis) Create(ctx context.Context, encounter *entities.Encounter) (*ent
 	return encounter, nil
 }
 
+// Update stores the encounter, rejecting the write with a ConflictError if the stored version
+// has changed since the encounter was loaded
 func (r *Redis) Update(ctx context.Context, encounter *entities.Encounter) (*entities.Encounter, error) {
 	if encounter == nil {
 		return nil, dnderr.NewMissingParameterError("encounter")
@@ -87,17 +102,64 @@ func (r *Redis) Update(ctx context.Context, encounter *entities.Encounter) (*ent
 		return nil, dnderr.NewInvalidEntityError("encounter.ID cannot be null")
 	}
 
-	jsonStr, err := encounter.MarshallJSON()
+	key := getEncounterKey(encounter.ID)
+
+	var version int
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := r.storedVersion(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		if current != encounter.Version {
+			return dnderr.NewConflictError(fmt.Sprintf("encounter %s was updated, expected version %d but found %d", encounter.ID, encounter.Version, current))
+		}
+
+		version = current + 1
+		stored := *encounter
+		stored.Version = version
+
+		jsonStr, err := stored.MarshallJSON()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, jsonStr, 0)
+			return nil
+		})
+
+		return err
+	}, key)
 	if err != nil {
+		if errors.Is(err, redis.TxFailedErr) {
+			return nil, dnderr.NewConflictError(fmt.Sprintf("encounter %s was updated during the write", encounter.ID))
+		}
+
 		return nil, err
 	}
 
-	err = r.client.Set(ctx, getEncounterKey(encounter.ID), jsonStr, 0).Err()
+	encounter.Version = version
+
+	return encounter, nil
+}
+
+func (r *Redis) storedVersion(ctx context.Context, tx *redis.Tx, key string) (int, error) {
+	result, err := tx.Get(ctx, key).Result()
 	if err != nil {
-		return nil, err
+		if errors.Is(err, redis.Nil) {
+			return 0, dnderr.NewNotFoundError("encounter not found")
+		}
+
+		return 0, err
 	}
 
-	return encounter, nil
+	stored, err := jsonToEncounter(result)
+	if err != nil {
+		return 0, err
+	}
+
+	return stored.Version, nil
 }
 
 func (r *Redis) Get(ctx context.Context, id string) (*entities.Encounter, error) {
@@ -107,6 +169,10 @@ func (r *Redis) Get(ctx context.Context, id string) (*entities.Encounter, error)
 
 	jsonStr, err := r.client.Get(ctx, getEncounterKey(id)).Result()
 	if err != nil {
+		if err == redis.Nil {
+			return nil, dnderr.NewNotFoundError("encounter not found")
+		}
+
 		return nil, err
 	}
 
@@ -117,3 +183,21 @@ func (r *Redis) Get(ctx context.Context, id string) (*entities.Encounter, error)
 
 	return encounter, nil
 }
+
+// GetByChannel returns the latest encounter created in the channel
+func (r *Redis) GetByChannel(ctx context.Context, channelID string) (*entities.Encounter, error) {
+	if channelID == "" {
+		return nil, dnderr.NewMissingParameterError("channelID")
+	}
+
+	id, err := r.client.Get(ctx, channelEncounterKey(channelID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, dnderr.NewNotFoundError("there is no encounter in this channel")
+		}
+
+		return nil, err
+	}
+
+	return r.Get(ctx, id)
+}
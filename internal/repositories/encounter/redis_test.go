@@ -3,13 +3,13 @@ package encounter
 import (
 	"context"
 	"encoding/json"
+	"testing"
+
 	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
 	"github.com/KirkDiggler/dnd-bot-go/internal/types"
 	"github.com/go-redis/redismock/v9"
-	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/suite"
-	"testing"
-	"time"
 )
 
 type encounterSuite struct {
@@ -18,7 +18,7 @@ type encounterSuite struct {
 	ctx           context.Context
 	fixture       *Redis
 	redisMock     redismock.ClientMock
-	encounter     *Data
+	encounter     *entities.Encounter
 	jsonEncounter string
 	uuiderMock    *types.MockUUID
 	timeMock      *types.MockClock
@@ -35,9 +35,13 @@ func (s *encounterSuite) SetupTest() {
 		timeClock: s.timeMock,
 	}
 	s.redisMock = redisMock
-	s.encounter = &Data{
-		ID:       "1234",
-		PlayerID: "5678",
+	s.encounter = &entities.Encounter{
+		ID:         "1234",
+		GMID:       "5678",
+		ChannelID:  "channel-id",
+		Status:     entities.EncounterStatusOpen,
+		MaxPlayers: 4,
+		Players:    []string{"5678"},
 	}
 	buf, _ := json.Marshal(s.encounter)
 	s.jsonEncounter = string(buf)
@@ -49,56 +53,25 @@ func TestEncounter(t *testing.T) {
 
 func (s *encounterSuite) TestCreateEncounter() {
 	s.uuiderMock.On("New").Return(s.encounter.ID)
-	now := time.Now()
-	s.timeMock.On("Now").Return(now)
-
-	expected := &Data{
-		ID:        s.encounter.ID,
-		PlayerID:  s.encounter.PlayerID,
-		CreatedAt: now,
-		UpdatedAt: now,
-		StartDate: s.encounter.StartDate,
-		EndDate:   s.encounter.EndDate,
-		Status:    s.encounter.Status,
-		MonsterID: s.encounter.MonsterID,
-		RoomID:    s.encounter.RoomID,
-	}
-
-	expectedJson, _ := json.Marshal(expected)
-
-	s.redisMock.ExpectZCard(characterEncounterKey(s.encounter.PlayerID)).SetVal(42)
-
 	s.redisMock.ExpectTxPipeline()
-	s.redisMock.ExpectSet(getEncounterKey(s.encounter.ID), string(expectedJson), 0).SetVal(s.jsonEncounter)
-
-	s.redisMock.ExpectZAdd(characterEncounterKey(s.encounter.PlayerID), redis.Z{
-		Score:  43,
-		Member: getEncounterKey(s.encounter.ID),
-	}).SetVal(42)
-
+	s.redisMock.ExpectSet(getEncounterKey(s.encounter.ID), []byte(s.jsonEncounter), 0).SetVal("OK")
+	s.redisMock.ExpectSet(channelEncounterKey("channel-id"), s.encounter.ID, 0).SetVal("OK")
 	s.redisMock.ExpectTxPipelineExec()
 
-	s.encounter.ID = ""
+	input := *s.encounter
+	input.ID = ""
 
-	result, err := s.fixture.Create(s.ctx, s.encounter)
+	result, err := s.fixture.Create(s.ctx, &input)
 	s.NoError(err)
-	s.NotNil(result)
-	s.Equal(expected, result)
+	s.Equal(s.encounter, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
 }
 
 func (s *encounterSuite) TestCreateEncounter_InvalidInput() {
 	_, err := s.fixture.Create(s.ctx, nil)
-	s.Error(dnderr.NewMissingParameterError("encounter"))
 	s.EqualError(err, dnderr.NewMissingParameterError("encounter").Error())
 
-	_, err = s.fixture.Create(s.ctx, &Data{})
-	s.Error(err)
-	s.EqualError(err, dnderr.NewMissingParameterError("encounter.PlayerID").Error())
-
-	_, err = s.fixture.Create(s.ctx, &Data{
-		ID: "1234",
-	})
-	s.Error(err)
+	_, err = s.fixture.Create(s.ctx, s.encounter)
 	s.EqualError(err, dnderr.NewInvalidEntityError("encounter.ID").Error())
 }
 
@@ -107,65 +80,67 @@ func (s *encounterSuite) TestGetEncounter() {
 
 	result, err := s.fixture.Get(s.ctx, s.encounter.ID)
 	s.NoError(err)
-	s.NotNil(result)
 	s.Equal(s.encounter, result)
 }
 
 func (s *encounterSuite) TestGetEncounterNotFound() {
 	s.redisMock.ExpectGet(getEncounterKey(s.encounter.ID)).RedisNil()
 
-	result, _ := s.fixture.Get(s.ctx, s.encounter.ID)
-	s.NoError(nil)
+	result, err := s.fixture.Get(s.ctx, s.encounter.ID)
+	s.IsType(&dnderr.NotFoundError{}, err)
 	s.Nil(result)
 }
 
-func (s *encounterSuite) TestUpdate() {
-	now := time.Now()
-	s.timeMock.On("Now").Return(now)
-
-	existing := &Data{
-		ID:        s.encounter.ID,
-		PlayerID:  s.encounter.PlayerID,
-		CreatedAt: now.Add(-1 * time.Hour),
-		UpdatedAt: now.Add(-1 * time.Hour),
-		StartDate: s.encounter.StartDate,
-		EndDate:   s.encounter.EndDate,
-		Status:    s.encounter.Status,
-		MonsterID: s.encounter.MonsterID,
-		RoomID:    s.encounter.RoomID,
-	}
+func (s *encounterSuite) TestGetByChannel() {
+	s.redisMock.ExpectGet(channelEncounterKey("channel-id")).SetVal(s.encounter.ID)
+	s.redisMock.ExpectGet(getEncounterKey(s.encounter.ID)).SetVal(s.jsonEncounter)
 
-	expected := &Data{
-		ID:        s.encounter.ID,
-		PlayerID:  s.encounter.PlayerID,
-		CreatedAt: now.Add(-1 * time.Hour),
-		UpdatedAt: now,
-		StartDate: s.encounter.StartDate,
-		EndDate:   s.encounter.EndDate,
-		Status:    s.encounter.Status,
-		MonsterID: s.encounter.MonsterID,
-		RoomID:    s.encounter.RoomID,
-	}
+	result, err := s.fixture.GetByChannel(s.ctx, "channel-id")
+	s.NoError(err)
+	s.Equal(s.encounter, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
 
-	existingJson, _ := json.Marshal(existing)
-	expectedJson, _ := json.Marshal(expected)
+func (s *encounterSuite) TestGetByChannelNotFound() {
+	s.redisMock.ExpectGet(channelEncounterKey("channel-id")).RedisNil()
 
-	s.redisMock.ExpectGet(getEncounterKey(s.encounter.ID)).SetVal(string(existingJson))
-	s.redisMock.ExpectSet(getEncounterKey(s.encounter.ID), string(expectedJson), 0).SetVal(s.jsonEncounter)
+	_, err := s.fixture.GetByChannel(s.ctx, "channel-id")
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *encounterSuite) storedJson(version int) []byte {
+	stored := *s.encounter
+	stored.Version = version
+	buf, _ := stored.MarshallJSON()
+
+	return buf
+}
 
-	err := s.fixture.Update(s.ctx, s.encounter)
+func (s *encounterSuite) TestUpdate() {
+	s.encounter.Version = 1
+	s.redisMock.ExpectWatch(getEncounterKey(s.encounter.ID))
+	s.redisMock.ExpectGet(getEncounterKey(s.encounter.ID)).SetVal(string(s.storedJson(1)))
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getEncounterKey(s.encounter.ID), s.storedJson(2), 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
+
+	result, err := s.fixture.Update(s.ctx, s.encounter)
 	s.NoError(err)
+	s.Equal(2, result.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
 }
 
-func (s *encounterSuite) TestListByPlayer() {
-	s.redisMock.ExpectZRevRange(characterEncounterKey(s.encounter.PlayerID), 0, 10).SetVal([]string{
-		getEncounterKey(s.encounter.ID),
-	})
+func (s *encounterSuite) TestUpdateStaleVersion() {
+	s.redisMock.ExpectWatch(getEncounterKey(s.encounter.ID))
+	s.redisMock.ExpectGet(getEncounterKey(s.encounter.ID)).SetVal(string(s.storedJson(3)))
 
-	s.redisMock.ExpectGet(getEncounterKey(s.encounter.ID)).SetVal(s.jsonEncounter)
+	_, err := s.fixture.Update(s.ctx, s.encounter)
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Equal(0, s.encounter.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
 
-	result, err := s.fixture.ListByPlayer(s.ctx, s.encounter.PlayerID)
-	s.NoError(err)
-	s.NotNil(result)
-	s.Equal([]*Data{s.encounter}, result)
+func (s *encounterSuite) TestUpdateWithoutID() {
+	_, err := s.fixture.Update(s.ctx, &entities.Encounter{})
+	s.IsType(&dnderr.InvalidEntityError{}, err)
 }
@@ -0,0 +1,15 @@
+package houserules
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+)
+
+// Repository keeps the house rules each guild's attacks are rolled by
+type Repository interface {
+	Get(ctx context.Context, guildID string) (*attack.Rules, error)
+	Put(ctx context.Context, guildID string, rules *attack.Rules) (*attack.Rules, error)
+	// Delete puts the guild back on the SRD rules
+	Delete(ctx context.Context, guildID string) error
+}
@@ -0,0 +1,38 @@
+package houserules
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Get(ctx context.Context, guildID string) (*attack.Rules, error) {
+	args := m.Called(ctx, guildID)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*attack.Rules), nil
+}
+
+func (m *Mock) Put(ctx context.Context, guildID string, rules *attack.Rules) (*attack.Rules, error) {
+	args := m.Called(ctx, guildID, rules)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*attack.Rules), nil
+}
+
+func (m *Mock) Delete(ctx context.Context, guildID string) error {
+	args := m.Called(ctx, guildID)
+
+	return args.Error(0)
+}
@@ -0,0 +1,112 @@
+package houserules
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/redis/go-redis/v9"
+)
+
+type Redis struct {
+	client redis.UniversalClient
+}
+
+type RedisConfig struct {
+	Client redis.UniversalClient
+}
+
+func NewRedis(cfg *RedisConfig) (*Redis, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	return &Redis{
+		client: cfg.Client,
+	}, nil
+}
+
+func getRulesKey(guildID string) string {
+	return "houserules:" + guildID
+}
+
+func rulesToJson(rules *attack.Rules) (string, error) {
+	buf, err := json.Marshal(rules)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+func jsonToRules(jsonStr string) (*attack.Rules, error) {
+	if jsonStr == "" {
+		return nil, dnderr.NewMissingParameterError("jsonStr")
+	}
+
+	out := &attack.Rules{}
+	err := json.Unmarshal([]byte(jsonStr), out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (r *Redis) Get(ctx context.Context, guildID string) (*attack.Rules, error) {
+	if guildID == "" {
+		return nil, dnderr.NewMissingParameterError("guildID")
+	}
+
+	jsonStr, err := r.client.Get(ctx, getRulesKey(guildID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, dnderr.NewNotFoundError("house rules not found")
+		}
+
+		return nil, err
+	}
+
+	return jsonToRules(jsonStr)
+}
+
+// Put replaces the guild's house rules, rules the attack pipeline does not know are refused
+func (r *Redis) Put(ctx context.Context, guildID string, rules *attack.Rules) (*attack.Rules, error) {
+	if guildID == "" {
+		return nil, dnderr.NewMissingParameterError("guildID")
+	}
+
+	if rules == nil {
+		return nil, dnderr.NewMissingParameterError("rules")
+	}
+
+	err := rules.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	jsonStr, err := rulesToJson(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.client.Set(ctx, getRulesKey(guildID), jsonStr, 0).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *Redis) Delete(ctx context.Context, guildID string) error {
+	if guildID == "" {
+		return dnderr.NewMissingParameterError("guildID")
+	}
+
+	return r.client.Del(ctx, getRulesKey(guildID)).Err()
+}
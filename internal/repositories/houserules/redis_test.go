@@ -0,0 +1,91 @@
+package houserules
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/attack"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type houseRulesSuite struct {
+	suite.Suite
+
+	ctx       context.Context
+	redisMock redismock.ClientMock
+	fixture   *Redis
+
+	guildID   string
+	rules     *attack.Rules
+	rulesJson string
+}
+
+func (s *houseRulesSuite) SetupTest() {
+	s.ctx = context.Background()
+	client, redisMock := redismock.NewClientMock()
+	s.redisMock = redisMock
+	s.fixture = &Redis{
+		client: client,
+	}
+
+	s.guildID = "guild-1"
+	s.rules = &attack.Rules{
+		CritDamage:   attack.CritDamageMax,
+		CritRange:    19,
+		ConfirmCrits: true,
+		Fumbles:      attack.FumbleTable,
+	}
+
+	buf, _ := json.Marshal(s.rules)
+	s.rulesJson = string(buf)
+}
+
+func (s *houseRulesSuite) TestPut() {
+	s.redisMock.ExpectSet(getRulesKey(s.guildID), s.rulesJson, 0).SetVal("OK")
+
+	result, err := s.fixture.Put(s.ctx, s.guildID, s.rules)
+	s.NoError(err)
+	s.Equal(s.rules, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *houseRulesSuite) TestPutValidatesInput() {
+	_, err := s.fixture.Put(s.ctx, "", s.rules)
+	s.EqualError(err, dnderr.NewMissingParameterError("guildID").Error())
+
+	_, err = s.fixture.Put(s.ctx, s.guildID, nil)
+	s.EqualError(err, dnderr.NewMissingParameterError("rules").Error())
+
+	_, err = s.fixture.Put(s.ctx, s.guildID, &attack.Rules{CritRange: 12})
+	s.IsType(&dnderr.InvalidParameterError{}, err)
+}
+
+func (s *houseRulesSuite) TestGet() {
+	s.redisMock.ExpectGet(getRulesKey(s.guildID)).SetVal(s.rulesJson)
+
+	result, err := s.fixture.Get(s.ctx, s.guildID)
+	s.NoError(err)
+	s.Equal(s.rules, result)
+}
+
+func (s *houseRulesSuite) TestGetNotFound() {
+	s.redisMock.ExpectGet(getRulesKey(s.guildID)).RedisNil()
+
+	_, err := s.fixture.Get(s.ctx, s.guildID)
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *houseRulesSuite) TestDelete() {
+	s.redisMock.ExpectDel(getRulesKey(s.guildID)).SetVal(1)
+
+	err := s.fixture.Delete(s.ctx, s.guildID)
+	s.NoError(err)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func TestHouseRulesSuite(t *testing.T) {
+	suite.Run(t, new(houseRulesSuite))
+}
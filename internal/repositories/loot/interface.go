@@ -0,0 +1,13 @@
+package loot
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+type Repository interface {
+	Create(ctx context.Context, loot *entities.Loot) (*entities.Loot, error)
+	Update(ctx context.Context, loot *entities.Loot) (*entities.Loot, error)
+	Get(ctx context.Context, id string) (*entities.Loot, error)
+}
@@ -0,0 +1,42 @@
+package loot
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Create(ctx context.Context, loot *entities.Loot) (*entities.Loot, error) {
+	args := m.Called(ctx, loot)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Loot), nil
+}
+
+func (m *Mock) Update(ctx context.Context, loot *entities.Loot) (*entities.Loot, error) {
+	args := m.Called(ctx, loot)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Loot), nil
+}
+
+func (m *Mock) Get(ctx context.Context, id string) (*entities.Loot, error) {
+	args := m.Called(ctx, id)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Loot), nil
+}
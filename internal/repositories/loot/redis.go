@@ -0,0 +1,184 @@
+package loot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"github.com/redis/go-redis/v9"
+)
+
+type Redis struct {
+	client redis.UniversalClient
+	uuider types.UUIDGenerator
+}
+
+type RedisConfig struct {
+	Client redis.UniversalClient
+}
+
+func NewRedis(cfg *RedisConfig) (*Redis, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	return &Redis{
+		client: cfg.Client,
+		uuider: &types.GoogleUUID{},
+	}, nil
+}
+
+func getLootKey(id string) string {
+	return "loot:" + id
+}
+
+func lootToJson(loot *entities.Loot) (string, error) {
+	buf, err := json.Marshal(loot)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+func jsonToLoot(jsonStr string) (*entities.Loot, error) {
+	if jsonStr == "" {
+		return nil, dnderr.NewMissingParameterError("jsonStr")
+	}
+
+	out := &entities.Loot{}
+	err := json.Unmarshal([]byte(jsonStr), out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (r *Redis) Create(ctx context.Context, loot *entities.Loot) (*entities.Loot, error) {
+	if loot == nil {
+		return nil, dnderr.NewMissingParameterError("loot")
+	}
+
+	if loot.ID != "" {
+		return nil, dnderr.NewInvalidEntityError("loot.ID must be empty")
+	}
+
+	if len(loot.PlayerIDs) == 0 {
+		return nil, dnderr.NewInvalidEntityError("loot.PlayerIDs must not be empty")
+	}
+
+	loot.ID = r.uuider.New()
+
+	return r.save(ctx, loot)
+}
+
+// Update stores the loot, rejecting the write with a ConflictError if the stored version has
+// changed since the loot was loaded
+func (r *Redis) Update(ctx context.Context, loot *entities.Loot) (*entities.Loot, error) {
+	if loot == nil {
+		return nil, dnderr.NewMissingParameterError("loot")
+	}
+
+	if loot.ID == "" {
+		return nil, dnderr.NewInvalidEntityError("loot.ID must not be empty")
+	}
+
+	key := getLootKey(loot.ID)
+
+	var version int
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := r.storedVersion(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		if current != loot.Version {
+			return dnderr.NewConflictError(fmt.Sprintf("loot %s was updated, expected version %d but found %d", loot.ID, loot.Version, current))
+		}
+
+		version = current + 1
+		stored := *loot
+		stored.Version = version
+
+		jsonStr, err := lootToJson(&stored)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, jsonStr, 0)
+			return nil
+		})
+
+		return err
+	}, key)
+	if err != nil {
+		if errors.Is(err, redis.TxFailedErr) {
+			return nil, dnderr.NewConflictError(fmt.Sprintf("loot %s was updated during the write", loot.ID))
+		}
+
+		return nil, err
+	}
+
+	loot.Version = version
+
+	return loot, nil
+}
+
+func (r *Redis) storedVersion(ctx context.Context, tx *redis.Tx, key string) (int, error) {
+	result, err := tx.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, dnderr.NewNotFoundError("loot not found")
+		}
+
+		return 0, err
+	}
+
+	stored, err := jsonToLoot(result)
+	if err != nil {
+		return 0, err
+	}
+
+	return stored.Version, nil
+}
+
+func (r *Redis) save(ctx context.Context, loot *entities.Loot) (*entities.Loot, error) {
+	jsonStr, err := lootToJson(loot)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.client.Set(ctx, getLootKey(loot.ID), jsonStr, 0).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return loot, nil
+}
+
+func (r *Redis) Get(ctx context.Context, id string) (*entities.Loot, error) {
+	if id == "" {
+		return nil, dnderr.NewMissingParameterError("id")
+	}
+
+	result, err := r.client.Get(ctx, getLootKey(id)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, dnderr.NewNotFoundError("loot not found")
+		}
+
+		return nil, err
+	}
+
+	return jsonToLoot(result)
+}
@@ -0,0 +1,144 @@
+package loot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type lootSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	redisMock  redismock.ClientMock
+	mockUuider *types.MockUUID
+	fixture    *Redis
+
+	loot     *entities.Loot
+	lootJson string
+}
+
+func (s *lootSuite) SetupTest() {
+	s.ctx = context.Background()
+	client, redisMock := redismock.NewClientMock()
+	s.redisMock = redisMock
+	s.mockUuider = &types.MockUUID{}
+	s.fixture = &Redis{
+		client: client,
+		uuider: s.mockUuider,
+	}
+
+	s.loot = &entities.Loot{
+		ID:        "loot-1",
+		PlayerIDs: []string{"player-1", "player-2"},
+		Mode:      entities.LootModeNeedGreed,
+		Coins:     &entities.Coins{Gold: 12},
+		Items: []*entities.LootItem{{
+			Key:  "longsword",
+			Name: "Longsword",
+		}},
+	}
+
+	buf, _ := json.Marshal(s.loot)
+	s.lootJson = string(buf)
+}
+
+func (s *lootSuite) TestCreate() {
+	s.mockUuider.On("New").Return(s.loot.ID)
+	s.redisMock.ExpectSet(getLootKey(s.loot.ID), s.lootJson, 0).SetVal("OK")
+
+	input := *s.loot
+	input.ID = ""
+
+	result, err := s.fixture.Create(s.ctx, &input)
+	s.NoError(err)
+	s.Equal(s.loot, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *lootSuite) TestCreateValidatesInput() {
+	_, err := s.fixture.Create(s.ctx, nil)
+	s.EqualError(err, dnderr.NewMissingParameterError("loot").Error())
+
+	_, err = s.fixture.Create(s.ctx, s.loot)
+	s.EqualError(err, dnderr.NewInvalidEntityError("loot.ID must be empty").Error())
+
+	_, err = s.fixture.Create(s.ctx, &entities.Loot{})
+	s.EqualError(err, dnderr.NewInvalidEntityError("loot.PlayerIDs must not be empty").Error())
+}
+
+func (s *lootSuite) storedJson(version int) string {
+	stored := *s.loot
+	stored.Version = version
+	buf, _ := json.Marshal(&stored)
+
+	return string(buf)
+}
+
+func (s *lootSuite) TestUpdate() {
+	s.loot.Version = 2
+	s.redisMock.ExpectWatch(getLootKey(s.loot.ID))
+	s.redisMock.ExpectGet(getLootKey(s.loot.ID)).SetVal(s.storedJson(2))
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getLootKey(s.loot.ID), s.storedJson(3), 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
+
+	result, err := s.fixture.Update(s.ctx, s.loot)
+	s.NoError(err)
+	s.Equal(3, result.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *lootSuite) TestUpdateStaleVersion() {
+	s.redisMock.ExpectWatch(getLootKey(s.loot.ID))
+	s.redisMock.ExpectGet(getLootKey(s.loot.ID)).SetVal(s.storedJson(1))
+
+	_, err := s.fixture.Update(s.ctx, s.loot)
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Equal(0, s.loot.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *lootSuite) TestUpdateNotFound() {
+	s.redisMock.ExpectWatch(getLootKey(s.loot.ID))
+	s.redisMock.ExpectGet(getLootKey(s.loot.ID)).RedisNil()
+
+	_, err := s.fixture.Update(s.ctx, s.loot)
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *lootSuite) TestUpdateRedisError() {
+	s.redisMock.ExpectWatch(getLootKey(s.loot.ID))
+	s.redisMock.ExpectGet(getLootKey(s.loot.ID)).SetVal(s.storedJson(0))
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getLootKey(s.loot.ID), s.storedJson(1), 0).SetErr(errors.New("boom"))
+
+	_, err := s.fixture.Update(s.ctx, s.loot)
+	s.EqualError(err, "boom")
+}
+
+func (s *lootSuite) TestGet() {
+	s.redisMock.ExpectGet(getLootKey(s.loot.ID)).SetVal(s.lootJson)
+
+	result, err := s.fixture.Get(s.ctx, s.loot.ID)
+	s.NoError(err)
+	s.Equal(s.loot, result)
+}
+
+func (s *lootSuite) TestGetNotFound() {
+	s.redisMock.ExpectGet(getLootKey(s.loot.ID)).RedisNil()
+
+	_, err := s.fixture.Get(s.ctx, s.loot.ID)
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func TestLootSuite(t *testing.T) {
+	suite.Run(t, new(lootSuite))
+}
@@ -20,8 +20,12 @@ func (m *Mock) GetMonster(ctx context.Context, key string) (*entities.Monster, e
 	return args.Get(0).(*entities.Monster), nil
 }
 
-func (m *Mock) PutMonster(ctx context.Context, monster *entities.Monster) error {
+func (m *Mock) PutMonster(ctx context.Context, monster *entities.Monster) (*entities.Monster, error) {
 	args := m.Called(ctx, monster)
 
-	return args.Error(0)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Monster), nil
 }
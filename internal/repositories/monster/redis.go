@@ -74,16 +74,21 @@ func monsterToJson(input *entities.Monster) (string, error) {
 	return string(out), nil
 }
 
-func (r *Redis) PutMonster(ctx context.Context, monster *entities.Monster) error {
+func (r *Redis) PutMonster(ctx context.Context, monster *entities.Monster) (*entities.Monster, error) {
 	if monster == nil {
-		return dnderr.NewMissingParameterError("monster")
+		return nil, dnderr.NewMissingParameterError("monster")
 	}
 
 	key := getMonsterKey(monster.ID)
 	jsonValue, err := monsterToJson(monster)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = r.client.Set(ctx, key, jsonValue, 0).Err()
+	if err != nil {
+		return nil, err
 	}
 
-	return r.client.Set(ctx, key, jsonValue, 0).Err()
+	return monster, nil
 }
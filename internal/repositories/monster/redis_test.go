@@ -63,15 +63,17 @@ func (s *monsterSuite) TestGetMonsterError() {
 func (s *monsterSuite) TestPutMonster() {
 	s.redisMock.ExpectSet("monster:"+s.monster.ID, s.jsonMonster, 0).SetVal("OK")
 
-	err := s.fixture.PutMonster(s.ctx, s.monster)
+	result, err := s.fixture.PutMonster(s.ctx, s.monster)
 	s.NoError(err)
+	s.Equal(s.monster, result)
 }
 
 func (s *monsterSuite) TestPutMonsterError() {
 	s.redisMock.ExpectSet("monster:"+s.monster.ID, s.jsonMonster, 0).SetErr(redis.Nil)
 
-	err := s.fixture.PutMonster(s.ctx, s.monster)
+	result, err := s.fixture.PutMonster(s.ctx, s.monster)
 	s.Error(err)
+	s.Nil(result)
 }
 
 func TestMonster(t *testing.T) {
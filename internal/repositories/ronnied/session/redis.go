@@ -3,6 +3,7 @@ package session
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/KirkDiggler/dnd-bot-go/dnderr"
 	"github.com/KirkDiggler/dnd-bot-go/internal/entities/ronnied"
@@ -138,32 +139,30 @@ func (r *Redis) JoinSessionRoll(ctx context.Context, input *JoinSessionRollInput
 
 	rollKey := getSessionRollKey(input.SessionRollID)
 
-	rollJson, err := r.client.Get(ctx, rollKey).Result()
-	if err != nil {
-		return nil, err
-	}
-
 	roll := &ronnied.SessionRoll{}
-	err = json.Unmarshal([]byte(rollJson), roll)
-	if err != nil {
-		return nil, err
-	}
-
-	if player := roll.HasPlayer(input.PlayerID); player != nil {
-		return nil, dnderr.NewAlreadyExistsError("player already in roll")
-	}
-
-	roll.Players = append(roll.Players, &ronnied.Player{
-		ID:   input.PlayerID,
-		Name: input.PlayerName,
+	err := r.watchAndSet(ctx, rollKey, func(current string) (string, error) {
+		roll = &ronnied.SessionRoll{}
+		err := json.Unmarshal([]byte(current), roll)
+		if err != nil {
+			return "", err
+		}
+
+		if player := roll.HasPlayer(input.PlayerID); player != nil {
+			return "", dnderr.NewAlreadyExistsError("player already in roll")
+		}
+
+		roll.Players = append(roll.Players, &ronnied.Player{
+			ID:   input.PlayerID,
+			Name: input.PlayerName,
+		})
+
+		rollBytes, err := json.Marshal(roll)
+		if err != nil {
+			return "", err
+		}
+
+		return string(rollBytes), nil
 	})
-
-	rollBytes, err := json.Marshal(roll)
-	if err != nil {
-		return nil, err
-	}
-
-	err = r.client.Set(ctx, rollKey, string(rollBytes), 0).Err()
 	if err != nil {
 		return nil, err
 	}
@@ -189,32 +188,30 @@ func (r *Redis) Join(ctx context.Context, input *JoinInput) (*JoinOutput, error)
 
 	sessionKey := getSessionKey(input.SessionID)
 
-	sessionJson, err := r.client.Get(ctx, sessionKey).Result()
-	if err != nil {
-		return nil, err
-	}
-
 	session := &ronnied.Session{}
-	err = json.Unmarshal([]byte(sessionJson), session)
-	if err != nil {
-		return nil, err
-	}
-
-	if player := session.HasPlayer(input.PlayerID); player != nil {
-		return nil, dnderr.NewAlreadyExistsError("player already in session")
-	}
-
-	session.Players = append(session.Players, &ronnied.Player{
-		ID:   input.PlayerID,
-		Name: input.PlayerName,
+	err := r.watchAndSet(ctx, sessionKey, func(current string) (string, error) {
+		session = &ronnied.Session{}
+		err := json.Unmarshal([]byte(current), session)
+		if err != nil {
+			return "", err
+		}
+
+		if player := session.HasPlayer(input.PlayerID); player != nil {
+			return "", dnderr.NewAlreadyExistsError("player already in session")
+		}
+
+		session.Players = append(session.Players, &ronnied.Player{
+			ID:   input.PlayerID,
+			Name: input.PlayerName,
+		})
+
+		sessionBytes, err := json.Marshal(session)
+		if err != nil {
+			return "", err
+		}
+
+		return string(sessionBytes), nil
 	})
-
-	sessionBytes, err := json.Marshal(session)
-	if err != nil {
-		return nil, err
-	}
-
-	err = r.client.Set(ctx, sessionKey, string(sessionBytes), 0).Err()
 	if err != nil {
 		return nil, err
 	}
@@ -341,46 +338,72 @@ func (r *Redis) AddEntry(ctx context.Context, input *AddEntryInput) (*AddEntryOu
 
 	sessionRollKey := getSessionRollKey(input.SessionRollID)
 
-	sessionJson, err := r.client.Get(ctx, sessionRollKey).Result()
+	var entry *ronnied.SessionEntry
+	err := r.watchAndSet(ctx, sessionRollKey, func(current string) (string, error) {
+		sessionRoll := &ronnied.SessionRoll{}
+		err := json.Unmarshal([]byte(current), sessionRoll)
+		if err != nil {
+			return "", err
+		}
+
+		if player := sessionRoll.HasPlayer(input.PlayerID); player == nil {
+			return "", dnderr.NewNotFoundError("player not in session")
+		}
+
+		entry = &ronnied.SessionEntry{
+			ID:            r.uuider.New(),
+			SessionRollID: sessionRoll.ID,
+			PlayerID:      input.PlayerID,
+			Roll:          input.Roll,
+			AssignedTo:    input.AssignedTo,
+		}
+
+		sessionRoll.Entries = append(sessionRoll.Entries, entry)
+
+		entryBytes, err := json.Marshal(sessionRoll)
+		if err != nil {
+			return "", err
+		}
+
+		return string(entryBytes), nil
+	})
 	if err != nil {
-		log.Println("Failed to get session roll:", sessionRollKey, err)
+		log.Println("Failed to add entry to session roll:", sessionRollKey, err)
 
 		return nil, err
 	}
 
-	sessionRoll := &ronnied.SessionRoll{}
-	err = json.Unmarshal([]byte(sessionJson), sessionRoll)
-	if err != nil {
-		return nil, err
-	}
-
-	if player := sessionRoll.HasPlayer(input.PlayerID); player == nil {
-		return nil, dnderr.NewNotFoundError("player not in session")
-	}
+	return &AddEntryOutput{
+		SessionEntry: entry,
+	}, nil
+}
 
-	entry := &ronnied.SessionEntry{
-		ID:            r.uuider.New(),
-		SessionRollID: sessionRoll.ID,
-		PlayerID:      input.PlayerID,
-		Roll:          input.Roll,
-		AssignedTo:    input.AssignedTo,
-	}
+// watchAndSet runs update against the current value of key inside a WATCH, so a write
+// that lands between the read and the set fails with a ConflictError instead of being lost
+func (r *Redis) watchAndSet(ctx context.Context, key string, update func(current string) (string, error)) error {
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, key).Result()
+		if err != nil {
+			return err
+		}
 
-	sessionRoll.Entries = append(sessionRoll.Entries, entry)
+		next, err := update(current)
+		if err != nil {
+			return err
+		}
 
-	entryBytes, err := json.Marshal(sessionRoll)
-	if err != nil {
-		return nil, err
-	}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, next, 0)
+			return nil
+		})
 
-	err = r.client.Set(ctx, sessionRollKey, string(entryBytes), 0).Err()
-	if err != nil {
-		return nil, err
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		return dnderr.NewConflictError(fmt.Sprintf("%s was modified during the update", key))
 	}
 
-	return &AddEntryOutput{
-		SessionEntry: entry,
-	}, nil
+	return err
 }
 
 func getSessionKey(id string) string {
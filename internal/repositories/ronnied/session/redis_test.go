@@ -0,0 +1,171 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities/ronnied"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteRepo struct {
+	suite.Suite
+
+	ctx context.Context
+
+	mockRedis  redismock.ClientMock
+	mockUuider *types.MockUUID
+
+	sessionRoll *ronnied.SessionRoll
+
+	fixture *Redis
+}
+
+func (s *suiteRepo) SetupTest() {
+	s.ctx = context.Background()
+	client, mock := redismock.NewClientMock()
+	s.mockRedis = mock
+	s.mockUuider = &types.MockUUID{}
+
+	s.sessionRoll = &ronnied.SessionRoll{
+		ID:        "roll-1",
+		SessionID: "session-1",
+		Type:      ronnied.RollTypeStart,
+		Players: []*ronnied.Player{{
+			ID:   "player-1",
+			Name: "Player One",
+		}},
+		Entries: []*ronnied.SessionEntry{},
+	}
+
+	s.fixture = &Redis{
+		client: client,
+		uuider: s.mockUuider,
+	}
+}
+
+func (s *suiteRepo) toJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	s.Require().NoError(err)
+
+	return string(b)
+}
+
+func (s *suiteRepo) TestAddEntry() {
+	key := getSessionRollKey(s.sessionRoll.ID)
+	s.mockUuider.On("New").Return("entry-1")
+
+	expected := &ronnied.SessionEntry{
+		ID:            "entry-1",
+		SessionRollID: s.sessionRoll.ID,
+		PlayerID:      "player-1",
+		Roll:          6,
+	}
+
+	s.mockRedis.ExpectWatch(key)
+	s.mockRedis.ExpectGet(key).SetVal(s.toJSON(s.sessionRoll))
+	s.mockRedis.ExpectTxPipeline()
+	s.sessionRoll.Entries = append(s.sessionRoll.Entries, expected)
+	s.mockRedis.ExpectSet(key, s.toJSON(s.sessionRoll), 0).SetVal("OK")
+	s.mockRedis.ExpectTxPipelineExec()
+
+	result, err := s.fixture.AddEntry(s.ctx, &AddEntryInput{
+		SessionRollID: s.sessionRoll.ID,
+		PlayerID:      "player-1",
+		Roll:          6,
+	})
+	s.NoError(err)
+	s.Equal(expected, result.SessionEntry)
+	s.NoError(s.mockRedis.ExpectationsWereMet())
+}
+
+func (s *suiteRepo) TestAddEntryPlayerNotInRoll() {
+	key := getSessionRollKey(s.sessionRoll.ID)
+
+	s.mockRedis.ExpectWatch(key)
+	s.mockRedis.ExpectGet(key).SetVal(s.toJSON(s.sessionRoll))
+
+	result, err := s.fixture.AddEntry(s.ctx, &AddEntryInput{
+		SessionRollID: s.sessionRoll.ID,
+		PlayerID:      "player-2",
+		Roll:          6,
+	})
+	s.Error(err)
+	s.IsType(&dnderr.NotFoundError{}, err)
+	s.Nil(result)
+}
+
+func (s *suiteRepo) TestAddEntryConflict() {
+	key := getSessionRollKey(s.sessionRoll.ID)
+	s.mockUuider.On("New").Return("entry-1")
+
+	s.mockRedis.ExpectWatch(key)
+	s.mockRedis.ExpectGet(key).SetVal(s.toJSON(s.sessionRoll))
+	s.mockRedis.ExpectTxPipeline()
+	update := *s.sessionRoll
+	update.Entries = []*ronnied.SessionEntry{{
+		ID:            "entry-1",
+		SessionRollID: s.sessionRoll.ID,
+		PlayerID:      "player-1",
+		Roll:          6,
+	}}
+	s.mockRedis.ExpectSet(key, s.toJSON(update), 0).SetVal("OK")
+	s.mockRedis.ExpectTxPipelineExec().SetErr(redis.TxFailedErr)
+
+	result, err := s.fixture.AddEntry(s.ctx, &AddEntryInput{
+		SessionRollID: s.sessionRoll.ID,
+		PlayerID:      "player-1",
+		Roll:          6,
+	})
+	s.Error(err)
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Nil(result)
+}
+
+func (s *suiteRepo) TestJoinSessionRoll() {
+	key := getSessionRollKey(s.sessionRoll.ID)
+
+	s.mockRedis.ExpectWatch(key)
+	s.mockRedis.ExpectGet(key).SetVal(s.toJSON(s.sessionRoll))
+	s.mockRedis.ExpectTxPipeline()
+	s.sessionRoll.Players = append(s.sessionRoll.Players, &ronnied.Player{
+		ID:   "player-2",
+		Name: "Player Two",
+	})
+	s.mockRedis.ExpectSet(key, s.toJSON(s.sessionRoll), 0).SetVal("OK")
+	s.mockRedis.ExpectTxPipelineExec()
+
+	result, err := s.fixture.JoinSessionRoll(s.ctx, &JoinSessionRollInput{
+		SessionRollID: s.sessionRoll.ID,
+		PlayerID:      "player-2",
+		PlayerName:    "Player Two",
+	})
+	s.NoError(err)
+	s.Equal(s.sessionRoll, result.SessionRoll)
+	s.NoError(s.mockRedis.ExpectationsWereMet())
+}
+
+func (s *suiteRepo) TestJoinSessionRollAlreadyJoined() {
+	key := getSessionRollKey(s.sessionRoll.ID)
+
+	s.mockRedis.ExpectWatch(key)
+	s.mockRedis.ExpectGet(key).SetVal(s.toJSON(s.sessionRoll))
+
+	result, err := s.fixture.JoinSessionRoll(s.ctx, &JoinSessionRollInput{
+		SessionRollID: s.sessionRoll.ID,
+		PlayerID:      "player-1",
+		PlayerName:    "Player One",
+	})
+	s.Error(err)
+	s.IsType(&dnderr.AlreadyExistsError{}, err)
+	s.Nil(result)
+}
+
+func TestSuiteRepo(t *testing.T) {
+	suite.Run(t, new(suiteRepo))
+}
@@ -1,6 +1,12 @@
 package room
 
-import "github.com/KirkDiggler/dnd-bot-go/internal/entities"
+import (
+	"slices"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
 
 type Status string
 
@@ -11,10 +17,46 @@ const (
 )
 
 type Data struct {
-	ID        string `json:"id"`
-	Status    Status `json:"status"`
-	PlayerID  string `json:"player_id"`
-	MonsterID string `json:"monster_id"`
+	ID         string   `json:"id"`
+	Status     Status   `json:"status"`
+	PlayerIDs  []string `json:"player_ids"`
+	MonsterIDs []string `json:"monster_ids"`
+	// PlayerID and MonsterID are from rooms saved before rooms held more than one combatant a
+	// side, they are moved to PlayerIDs and MonsterIDs when the room is read
+	PlayerID  string `json:"player_id,omitempty"`
+	MonsterID string `json:"monster_id,omitempty"`
+	// DungeonID is the dungeon the room is in, empty for a room on its own
+	DungeonID string `json:"dungeon_id,omitempty"`
+	// Combat is the fight in the room, nil until initiative is rolled
+	Combat *combat.State `json:"combat,omitempty"`
+	// Outcome is how the fight ended, empty while it goes on and for rooms closed before it was kept
+	Outcome string `json:"outcome,omitempty"`
+	// StartedAt and EndedAt are zero for rooms stored before they were kept
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	// Version is the stored version the room was loaded from, used to reject stale writes
+	Version int `json:"version"`
+}
+
+// migrate moves the single player and monster of an old room into the lists, it returns true when
+// the room changed
+func (d *Data) migrate() bool {
+	if d.PlayerID == "" && d.MonsterID == "" {
+		return false
+	}
+
+	if d.PlayerID != "" && !slices.Contains(d.PlayerIDs, d.PlayerID) {
+		d.PlayerIDs = append([]string{d.PlayerID}, d.PlayerIDs...)
+	}
+
+	if d.MonsterID != "" && !slices.Contains(d.MonsterIDs, d.MonsterID) {
+		d.MonsterIDs = append([]string{d.MonsterID}, d.MonsterIDs...)
+	}
+
+	d.PlayerID = ""
+	d.MonsterID = ""
+
+	return true
 }
 
 func EntityToRoomStatus(input entities.RoomStatus) Status {
@@ -33,19 +75,20 @@ func EntityToData(input *entities.Room) *Data {
 		return nil
 	}
 
-	var charID, monsterID string
-	if input.Character != nil {
-		charID = input.Character.ID
+	out := &Data{
+		ID:         input.ID,
+		Status:     EntityToRoomStatus(input.Status),
+		PlayerIDs:  make([]string, len(input.Characters)),
+		MonsterIDs: make([]string, len(input.Monsters)),
 	}
 
-	if input.Monster != nil {
-		monsterID = input.Monster.ID
+	for idx, char := range input.Characters {
+		out.PlayerIDs[idx] = char.ID
 	}
 
-	return &Data{
-		ID:        input.ID,
-		Status:    EntityToRoomStatus(input.Status),
-		PlayerID:  charID,
-		MonsterID: monsterID,
+	for idx, monster := range input.Monsters {
+		out.MonsterIDs[idx] = monster.ID
 	}
+
+	return out
 }
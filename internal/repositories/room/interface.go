@@ -9,4 +9,7 @@ type Repository interface {
 	Update(ctx context.Context, room *Data) (*Data, error)
 	Get(ctx context.Context, id string) (*Data, error)
 	ListByPlayer(ctx context.Context, input *ListByPlayerInput) ([]*Data, error)
+	GetByMonster(ctx context.Context, monsterID string) (*Data, error)
+	// Migrate rewrites rooms saved with a single player and monster, returning how many changed
+	Migrate(ctx context.Context) (int, error)
 }
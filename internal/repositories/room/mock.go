@@ -48,3 +48,19 @@ func (m *Mock) ListByPlayer(ctx context.Context, input *ListByPlayerInput) ([]*D
 
 	return args.Get(0).([]*Data), args.Error(1)
 }
+
+func (m *Mock) GetByMonster(ctx context.Context, monsterID string) (*Data, error) {
+	args := m.Called(ctx, monsterID)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Data), args.Error(1)
+}
+
+func (m *Mock) Migrate(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+
+	return args.Int(0), args.Error(1)
+}
@@ -3,9 +3,12 @@ package room
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/KirkDiggler/dnd-bot-go/dnderr"
 	"github.com/KirkDiggler/dnd-bot-go/internal/types"
 	"github.com/redis/go-redis/v9"
+	"strings"
 )
 
 type Redis struct {
@@ -40,6 +43,10 @@ func getRoomKey(id string) string {
 	return "room:" + id
 }
 
+func monsterRoomKey(monsterID string) string {
+	return "monsterRoom:" + monsterID
+}
+
 func roomToJson(room *Data) (string, error) {
 	if room == nil {
 		return "", dnderr.NewMissingParameterError("room")
@@ -64,10 +71,14 @@ func jsonToRoom(jsonStr string) (*Data, error) {
 		return nil, err
 	}
 
+	room.migrate()
+
 	return &room, nil
 }
 
-// Create creates a room and assigns it to the owners index
+// Create creates a room and assigns it to the index of each player and monster in it. A player can
+// only be in one active room, the create is rejected with a ConflictError if any of them already
+// is or another room is created for them at the same time.
 func (r *Redis) Create(ctx context.Context, room *Data) (*Data, error) {
 	if room == nil {
 		return nil, dnderr.NewMissingParameterError("room")
@@ -77,34 +88,111 @@ func (r *Redis) Create(ctx context.Context, room *Data) (*Data, error) {
 		return nil, dnderr.NewInvalidEntityError("room.ID must be empty")
 	}
 
-	room.ID = r.uuider.New()
+	room.migrate()
 
-	jsonStr, err := roomToJson(room)
-	if err != nil {
-		return nil, err
+	if len(room.PlayerIDs) == 0 {
+		return nil, dnderr.NewInvalidEntityError("room.PlayerIDs must not be empty")
 	}
 
-	roomCount, err := r.client.ZCard(ctx, characterRoomKey(room.PlayerID)).Result()
+	id := r.uuider.New()
+	stored := *room
+	stored.ID = id
+
+	jsonStr, err := roomToJson(&stored)
 	if err != nil {
 		return nil, err
 	}
 
-	// Use pipe to set the room data and add member to character rooms index
-	pipe := r.client.TxPipeline()
-	pipe.Set(ctx, getRoomKey(room.ID), jsonStr, 0)
-	pipe.ZAdd(ctx, characterRoomKey(room.PlayerID), redis.Z{
-		Score:  float64(roomCount),
-		Member: getRoomKey(room.ID),
-	})
+	indexKeys := make([]string, len(room.PlayerIDs))
+	for idx, playerID := range room.PlayerIDs {
+		indexKeys[idx] = characterRoomKey(playerID)
+	}
+
+	err = r.client.Watch(ctx, func(tx *redis.Tx) error {
+		var err error
+		roomCounts := make([]int64, len(room.PlayerIDs))
+		for idx, playerID := range room.PlayerIDs {
+			roomCounts[idx], err = tx.ZCard(ctx, indexKeys[idx]).Result()
+			if err != nil {
+				return err
+			}
+
+			if roomCounts[idx] == 0 {
+				continue
+			}
+
+			active, err := r.latestIsActive(ctx, tx, indexKeys[idx])
+			if err != nil {
+				return err
+			}
+
+			if active {
+				return dnderr.NewConflictError(fmt.Sprintf("player %s is already in an active room", playerID))
+			}
+		}
 
-	_, err = pipe.Exec(ctx)
+		// set the room data and add it to the player and monster indexes together
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, getRoomKey(id), jsonStr, 0)
+			for idx := range room.PlayerIDs {
+				pipe.ZAdd(ctx, indexKeys[idx], redis.Z{
+					Score:  float64(roomCounts[idx]),
+					Member: getRoomKey(id),
+				})
+			}
+
+			for _, monsterID := range room.MonsterIDs {
+				pipe.Set(ctx, monsterRoomKey(monsterID), id, 0)
+			}
+
+			return nil
+		})
+
+		return err
+	}, indexKeys...)
 	if err != nil {
+		if errors.Is(err, redis.TxFailedErr) {
+			return nil, dnderr.NewConflictError("another room was created for the party at the same time")
+		}
+
 		return nil, err
 	}
 
+	room.ID = id
+
 	return room, nil
 }
 
+// latestIsActive returns true when the newest room in the player's index is still being played
+func (r *Redis) latestIsActive(ctx context.Context, tx *redis.Tx, indexKey string) (bool, error) {
+	latest, err := tx.ZRevRange(ctx, indexKey, 0, 0).Result()
+	if err != nil {
+		return false, err
+	}
+
+	if len(latest) == 0 {
+		return false, nil
+	}
+
+	result, err := tx.Get(ctx, latest[0]).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	stored, err := jsonToRoom(result)
+	if err != nil {
+		return false, err
+	}
+
+	return stored.Status == StatusActive, nil
+}
+
+// Update stores the room, rejecting the write with a ConflictError if the stored version has
+// changed since the room was loaded
 func (r *Redis) Update(ctx context.Context, room *Data) (*Data, error) {
 	if room == nil {
 		return nil, dnderr.NewMissingParameterError("room")
@@ -114,19 +202,64 @@ func (r *Redis) Update(ctx context.Context, room *Data) (*Data, error) {
 		return nil, dnderr.NewInvalidEntityError("room.ID must not be empty")
 	}
 
-	jsonStr, err := roomToJson(room)
+	key := getRoomKey(room.ID)
+
+	var version int
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := r.storedVersion(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		if current != room.Version {
+			return dnderr.NewConflictError(fmt.Sprintf("room %s was updated, expected version %d but found %d", room.ID, room.Version, current))
+		}
+
+		version = current + 1
+		stored := *room
+		stored.Version = version
+
+		jsonStr, err := roomToJson(&stored)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, jsonStr, 0)
+			return nil
+		})
+
+		return err
+	}, key)
 	if err != nil {
+		if errors.Is(err, redis.TxFailedErr) {
+			return nil, dnderr.NewConflictError(fmt.Sprintf("room %s was updated during the write", room.ID))
+		}
+
 		return nil, err
 	}
 
-	// TODO: verify room exists
+	room.Version = version
+
+	return room, nil
+}
+
+func (r *Redis) storedVersion(ctx context.Context, tx *redis.Tx, key string) (int, error) {
+	result, err := tx.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, dnderr.NewNotFoundError("room not found")
+		}
+
+		return 0, err
+	}
 
-	err = r.client.Set(ctx, getRoomKey(room.ID), jsonStr, 0).Err()
+	stored, err := jsonToRoom(result)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	return room, nil
+	return stored.Version, nil
 }
 
 func (r *Redis) Get(ctx context.Context, id string) (*Data, error) {
@@ -179,7 +312,8 @@ func (r *Redis) ListByPlayer(ctx context.Context, input *ListByPlayerInput) ([]*
 
 	rooms := make([]*Data, len(roomKeys))
 	for i, roomKey := range roomKeys {
-		room, err := r.Get(ctx, roomKey)
+		// the index holds the room keys, Get expects the id
+		room, err := r.Get(ctx, strings.TrimPrefix(roomKey, getRoomKey("")))
 		if err != nil {
 			return nil, err
 		}
@@ -189,3 +323,69 @@ func (r *Redis) ListByPlayer(ctx context.Context, input *ListByPlayerInput) ([]*
 
 	return rooms, nil
 }
+
+// GetByMonster returns the room the monster is in
+func (r *Redis) GetByMonster(ctx context.Context, monsterID string) (*Data, error) {
+	if monsterID == "" {
+		return nil, dnderr.NewMissingParameterError("monsterID")
+	}
+
+	roomID, err := r.client.Get(ctx, monsterRoomKey(monsterID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, dnderr.NewNotFoundError("room not found")
+		}
+
+		return nil, err
+	}
+
+	return r.Get(ctx, roomID)
+}
+
+// Migrate moves the player and monster of rooms saved before rooms held lists of combatants and
+// indexes the monster, the players are already in the player index
+func (r *Redis) Migrate(ctx context.Context) (int, error) {
+	migrated := 0
+
+	iter := r.client.Scan(ctx, 0, getRoomKey("*"), 0).Iterator()
+	for iter.Next(ctx) {
+		jsonStr, err := r.client.Get(ctx, iter.Val()).Result()
+		if err != nil {
+			return migrated, err
+		}
+
+		room := &Data{}
+		err = json.Unmarshal([]byte(jsonStr), room)
+		if err != nil {
+			return migrated, err
+		}
+
+		if !room.migrate() {
+			continue
+		}
+
+		jsonStr, err = roomToJson(room)
+		if err != nil {
+			return migrated, err
+		}
+
+		pipe := r.client.TxPipeline()
+		pipe.Set(ctx, getRoomKey(room.ID), jsonStr, 0)
+		for _, monsterID := range room.MonsterIDs {
+			pipe.Set(ctx, monsterRoomKey(monsterID), room.ID, 0)
+		}
+
+		_, err = pipe.Exec(ctx)
+		if err != nil {
+			return migrated, err
+		}
+
+		migrated++
+	}
+
+	if err := iter.Err(); err != nil {
+		return migrated, err
+	}
+
+	return migrated, nil
+}
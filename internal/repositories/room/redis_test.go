@@ -35,10 +35,10 @@ func (s *roomSuite) SetupTest() {
 		uuider: s.mockUuider,
 	}
 	s.room = &Data{
-		ID:        "1234",
-		Status:    StatusActive,
-		MonsterID: "1234",
-		PlayerID:  "1337",
+		ID:         "1234",
+		Status:     StatusActive,
+		MonsterIDs: []string{"1234"},
+		PlayerIDs:  []string{"1337"},
 	}
 
 	buf, _ := json.Marshal(s.room)
@@ -59,12 +59,13 @@ func (s *roomSuite) TestCreaeRoom_ValidateInput() {
 func (s *roomSuite) TestCreateRoom_RedisError() {
 	s.mockUuider.On("New").Return(s.room.ID)
 
-	s.redisMock.ExpectZCard(characterRoomKey(s.room.PlayerID)).SetErr(errors.New("redis error"))
+	s.redisMock.ExpectWatch(characterRoomKey(s.room.PlayerIDs[0]))
+	s.redisMock.ExpectZCard(characterRoomKey(s.room.PlayerIDs[0])).SetErr(errors.New("redis error"))
 
 	input := &Data{
-		Status:    StatusActive,
-		MonsterID: s.room.MonsterID,
-		PlayerID:  s.room.PlayerID,
+		Status:     StatusActive,
+		MonsterIDs: s.room.MonsterIDs,
+		PlayerIDs:  s.room.PlayerIDs,
 	}
 	result, err := s.fixture.Create(s.ctx, input)
 	s.Error(err)
@@ -74,29 +75,83 @@ func (s *roomSuite) TestCreateRoom_RedisError() {
 
 func (s *roomSuite) TestCreateRoom() {
 	s.mockUuider.On("New").Return(s.room.ID)
-
-	s.redisMock.ExpectZCard(characterRoomKey(s.room.PlayerID)).SetVal(42)
-
+	s.redisMock.ExpectWatch(characterRoomKey(s.room.PlayerIDs[0]))
+	s.redisMock.ExpectZCard(characterRoomKey(s.room.PlayerIDs[0])).SetVal(42)
+	s.redisMock.ExpectZRevRange(characterRoomKey(s.room.PlayerIDs[0]), 0, 0).SetVal([]string{getRoomKey("old")})
+	s.redisMock.ExpectGet(getRoomKey("old")).SetVal(`{"id":"old","status":"inactive"}`)
 	s.redisMock.ExpectTxPipeline()
 	s.redisMock.ExpectSet(getRoomKey(s.room.ID), s.roomJson, 0).SetVal(s.roomJson)
-
-	s.redisMock.ExpectZAdd(characterRoomKey(s.room.PlayerID), redis.Z{
+	s.redisMock.ExpectZAdd(characterRoomKey(s.room.PlayerIDs[0]), redis.Z{
 		Score:  42,
 		Member: getRoomKey(s.room.ID),
 	}).SetVal(1)
-
+	s.redisMock.ExpectSet(monsterRoomKey(s.room.MonsterIDs[0]), s.room.ID, 0).SetVal("OK")
 	s.redisMock.ExpectTxPipelineExec()
 
 	input := &Data{
-		Status:    StatusActive,
-		MonsterID: s.room.MonsterID,
-		PlayerID:  s.room.PlayerID,
+		Status:     StatusActive,
+		MonsterIDs: s.room.MonsterIDs,
+		PlayerIDs:  s.room.PlayerIDs,
 	}
+
 	result, err := s.fixture.Create(s.ctx, input)
 	s.NoError(err)
 	s.NotNil(result)
 	s.Equal(s.room, result)
 }
+
+func (s *roomSuite) TestCreateRoomIndexesEveryCombatant() {
+	s.room.PlayerIDs = []string{"1337", "4242"}
+	s.room.MonsterIDs = []string{"1234", "5678"}
+	buf, _ := json.Marshal(s.room)
+
+	s.mockUuider.On("New").Return(s.room.ID)
+	s.redisMock.ExpectWatch(characterRoomKey("1337"), characterRoomKey("4242"))
+	s.redisMock.ExpectZCard(characterRoomKey("1337")).SetVal(2)
+	s.redisMock.ExpectZRevRange(characterRoomKey("1337"), 0, 0).SetVal([]string{getRoomKey("old")})
+	s.redisMock.ExpectGet(getRoomKey("old")).SetVal(`{"id":"old","status":"inactive"}`)
+	s.redisMock.ExpectZCard(characterRoomKey("4242")).SetVal(0)
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getRoomKey(s.room.ID), string(buf), 0).SetVal("OK")
+	s.redisMock.ExpectZAdd(characterRoomKey("1337"), redis.Z{Score: 2, Member: getRoomKey(s.room.ID)}).SetVal(1)
+	s.redisMock.ExpectZAdd(characterRoomKey("4242"), redis.Z{Score: 0, Member: getRoomKey(s.room.ID)}).SetVal(1)
+	s.redisMock.ExpectSet(monsterRoomKey("1234"), s.room.ID, 0).SetVal("OK")
+	s.redisMock.ExpectSet(monsterRoomKey("5678"), s.room.ID, 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
+
+	result, err := s.fixture.Create(s.ctx, &Data{
+		Status:     StatusActive,
+		PlayerIDs:  s.room.PlayerIDs,
+		MonsterIDs: s.room.MonsterIDs,
+	})
+	s.NoError(err)
+	s.Equal(s.room, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *roomSuite) TestCreateRoomPlayerAlreadyFighting() {
+	s.mockUuider.On("New").Return(s.room.ID)
+	s.redisMock.ExpectWatch(characterRoomKey(s.room.PlayerIDs[0]))
+	s.redisMock.ExpectZCard(characterRoomKey(s.room.PlayerIDs[0])).SetVal(1)
+	s.redisMock.ExpectZRevRange(characterRoomKey(s.room.PlayerIDs[0]), 0, 0).SetVal([]string{getRoomKey("old")})
+	s.redisMock.ExpectGet(getRoomKey("old")).SetVal(`{"id":"old","status":"active"}`)
+
+	input := &Data{
+		Status:     StatusActive,
+		MonsterIDs: s.room.MonsterIDs,
+		PlayerIDs:  s.room.PlayerIDs,
+	}
+	_, err := s.fixture.Create(s.ctx, input)
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Empty(input.ID)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *roomSuite) TestCreateRoomRequiresPlayers() {
+	_, err := s.fixture.Create(s.ctx, &Data{MonsterIDs: s.room.MonsterIDs})
+	s.EqualError(err, dnderr.NewInvalidEntityError("room.PlayerIDs must not be empty").Error())
+}
+
 func (s *roomSuite) TestGetRoom() {
 	s.redisMock.ExpectGet(getRoomKey(s.room.ID)).SetVal(s.roomJson)
 
@@ -114,17 +169,53 @@ func (s *roomSuite) TestGetRoomNotFound() {
 	s.Nil(result)
 }
 
+func (s *roomSuite) storedJson(version int) string {
+	stored := *s.room
+	stored.Version = version
+	buf, _ := json.Marshal(&stored)
+
+	return string(buf)
+}
+
 func (s *roomSuite) TestUpdateRoom() {
-	s.redisMock.ExpectSet(getRoomKey(s.room.ID), s.roomJson, 0).SetVal(s.roomJson)
+	s.room.Version = 4
+	s.redisMock.ExpectWatch(getRoomKey(s.room.ID))
+	s.redisMock.ExpectGet(getRoomKey(s.room.ID)).SetVal(s.storedJson(4))
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getRoomKey(s.room.ID), s.storedJson(5), 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
 
 	result, err := s.fixture.Update(s.ctx, s.room)
 	s.NoError(err)
 	s.NotNil(result)
-	s.Equal(s.room, result)
+	s.Equal(5, result.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *roomSuite) TestUpdateRoomStaleVersion() {
+	s.redisMock.ExpectWatch(getRoomKey(s.room.ID))
+	s.redisMock.ExpectGet(getRoomKey(s.room.ID)).SetVal(s.storedJson(1))
+
+	result, err := s.fixture.Update(s.ctx, s.room)
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Nil(result)
+	s.Equal(0, s.room.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *roomSuite) TestUpdateRoomNotFound() {
+	s.redisMock.ExpectWatch(getRoomKey(s.room.ID))
+	s.redisMock.ExpectGet(getRoomKey(s.room.ID)).RedisNil()
+
+	_, err := s.fixture.Update(s.ctx, s.room)
+	s.IsType(&dnderr.NotFoundError{}, err)
 }
 
 func (s *roomSuite) TestUpdateRoomError() {
-	s.redisMock.ExpectSet(getRoomKey(s.room.ID), s.roomJson, 0).SetErr(errors.New("error"))
+	s.redisMock.ExpectWatch(getRoomKey(s.room.ID))
+	s.redisMock.ExpectGet(getRoomKey(s.room.ID)).SetVal(s.roomJson)
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getRoomKey(s.room.ID), s.storedJson(1), 0).SetErr(errors.New("error"))
 
 	result, err := s.fixture.Update(s.ctx, s.room)
 	s.Error(err)
@@ -132,6 +223,61 @@ func (s *roomSuite) TestUpdateRoomError() {
 	s.EqualError(err, "error")
 }
 
+func (s *roomSuite) TestGetRoomMigratesSingleCombatant() {
+	s.redisMock.ExpectGet(getRoomKey(s.room.ID)).SetVal(`{"id":"1234","status":"active","player_id":"1337","monster_id":"1234"}`)
+
+	result, err := s.fixture.Get(s.ctx, s.room.ID)
+	s.NoError(err)
+	s.Equal(s.room, result)
+}
+
+func (s *roomSuite) TestGetByMonster() {
+	s.redisMock.ExpectGet(monsterRoomKey(s.room.MonsterIDs[0])).SetVal(s.room.ID)
+	s.redisMock.ExpectGet(getRoomKey(s.room.ID)).SetVal(s.roomJson)
+
+	result, err := s.fixture.GetByMonster(s.ctx, s.room.MonsterIDs[0])
+	s.NoError(err)
+	s.Equal(s.room, result)
+}
+
+func (s *roomSuite) TestGetByMonsterNotFound() {
+	s.redisMock.ExpectGet(monsterRoomKey("missing")).SetErr(redis.Nil)
+
+	_, err := s.fixture.GetByMonster(s.ctx, "missing")
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *roomSuite) TestMigrate() {
+	legacy := `{"id":"1234","status":"active","player_id":"1337","monster_id":"1234"}`
+
+	s.redisMock.ExpectScan(0, getRoomKey("*"), 0).SetVal([]string{getRoomKey(s.room.ID), getRoomKey("5678")}, 0)
+	s.redisMock.ExpectGet(getRoomKey(s.room.ID)).SetVal(legacy)
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getRoomKey(s.room.ID), s.roomJson, 0).SetVal("OK")
+	s.redisMock.ExpectSet(monsterRoomKey(s.room.MonsterIDs[0]), s.room.ID, 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
+	// rooms that are already migrated are left alone
+	s.redisMock.ExpectGet(getRoomKey("5678")).SetVal(s.roomJson)
+
+	migrated, err := s.fixture.Migrate(s.ctx)
+	s.NoError(err)
+	s.Equal(1, migrated)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *roomSuite) TestListByPlayer() {
+	s.redisMock.ExpectZRevRange(characterRoomKey(s.room.PlayerIDs[0]), 0, 0).SetVal([]string{getRoomKey(s.room.ID)})
+	s.redisMock.ExpectGet(getRoomKey(s.room.ID)).SetVal(s.roomJson)
+
+	result, err := s.fixture.ListByPlayer(s.ctx, &ListByPlayerInput{
+		PlayerID: s.room.PlayerIDs[0],
+		Limit:    1,
+		Reverse:  true,
+	})
+	s.NoError(err)
+	s.Equal([]*Data{s.room}, result)
+}
+
 func TestRoom(t *testing.T) {
 	suite.Run(t, new(roomSuite))
 }
@@ -0,0 +1,13 @@
+package shop
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+// Repository keeps each guild's shops, the stock and the prices the GM set
+type Repository interface {
+	Get(ctx context.Context, guildID, merchantKey string) (*entities.Shop, error)
+	Put(ctx context.Context, shop *entities.Shop) (*entities.Shop, error)
+}
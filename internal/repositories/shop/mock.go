@@ -0,0 +1,32 @@
+package shop
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Get(ctx context.Context, guildID, merchantKey string) (*entities.Shop, error) {
+	args := m.Called(ctx, guildID, merchantKey)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Shop), nil
+}
+
+func (m *Mock) Put(ctx context.Context, shop *entities.Shop) (*entities.Shop, error) {
+	args := m.Called(ctx, shop)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Shop), nil
+}
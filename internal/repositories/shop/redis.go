@@ -0,0 +1,107 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/redis/go-redis/v9"
+)
+
+type Redis struct {
+	client redis.UniversalClient
+}
+
+type RedisConfig struct {
+	Client redis.UniversalClient
+}
+
+func NewRedis(cfg *RedisConfig) (*Redis, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	return &Redis{
+		client: cfg.Client,
+	}, nil
+}
+
+func getShopKey(guildID, merchantKey string) string {
+	return "shop:" + guildID + ":" + merchantKey
+}
+
+func shopToJson(shop *entities.Shop) (string, error) {
+	buf, err := json.Marshal(shop)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+func jsonToShop(jsonStr string) (*entities.Shop, error) {
+	if jsonStr == "" {
+		return nil, dnderr.NewMissingParameterError("jsonStr")
+	}
+
+	out := &entities.Shop{}
+	err := json.Unmarshal([]byte(jsonStr), out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (r *Redis) Get(ctx context.Context, guildID, merchantKey string) (*entities.Shop, error) {
+	if guildID == "" {
+		return nil, dnderr.NewMissingParameterError("guildID")
+	}
+
+	if merchantKey == "" {
+		return nil, dnderr.NewMissingParameterError("merchantKey")
+	}
+
+	jsonStr, err := r.client.Get(ctx, getShopKey(guildID, merchantKey)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, dnderr.NewNotFoundError("shop not found")
+		}
+
+		return nil, err
+	}
+
+	return jsonToShop(jsonStr)
+}
+
+// Put replaces the guild's shop for the merchant
+func (r *Redis) Put(ctx context.Context, shop *entities.Shop) (*entities.Shop, error) {
+	if shop == nil {
+		return nil, dnderr.NewMissingParameterError("shop")
+	}
+
+	if shop.GuildID == "" {
+		return nil, dnderr.NewMissingParameterError("shop.GuildID")
+	}
+
+	if shop.Merchant == nil || shop.Merchant.Key == "" {
+		return nil, dnderr.NewMissingParameterError("shop.Merchant.Key")
+	}
+
+	jsonStr, err := shopToJson(shop)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.client.Set(ctx, getShopKey(shop.GuildID, shop.Merchant.Key), jsonStr, 0).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return shop, nil
+}
@@ -0,0 +1,86 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type shopSuite struct {
+	suite.Suite
+
+	ctx       context.Context
+	redisMock redismock.ClientMock
+	fixture   *Redis
+
+	shop     *entities.Shop
+	shopJson string
+}
+
+func (s *shopSuite) SetupTest() {
+	s.ctx = context.Background()
+	client, redisMock := redismock.NewClientMock()
+	s.redisMock = redisMock
+	s.fixture = &Redis{
+		client: client,
+	}
+
+	s.shop = &entities.Shop{
+		GuildID:       "guild-1",
+		Merchant:      &entities.Merchant{Key: "blacksmith", Name: "Brenna's Forge"},
+		PriceModifier: 120,
+		Items: []*entities.ShopItem{{
+			Key:      "longsword",
+			Name:     "Longsword",
+			Category: "martial-weapons",
+			Cost:     &entities.Cost{Quantity: 15, Unit: "gp"},
+		}},
+	}
+
+	buf, _ := json.Marshal(s.shop)
+	s.shopJson = string(buf)
+}
+
+func (s *shopSuite) TestPut() {
+	s.redisMock.ExpectSet("shop:guild-1:blacksmith", s.shopJson, 0).SetVal("OK")
+
+	result, err := s.fixture.Put(s.ctx, s.shop)
+	s.NoError(err)
+	s.Equal(s.shop, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *shopSuite) TestPutValidatesInput() {
+	_, err := s.fixture.Put(s.ctx, nil)
+	s.EqualError(err, dnderr.NewMissingParameterError("shop").Error())
+
+	_, err = s.fixture.Put(s.ctx, &entities.Shop{Merchant: s.shop.Merchant})
+	s.EqualError(err, dnderr.NewMissingParameterError("shop.GuildID").Error())
+
+	_, err = s.fixture.Put(s.ctx, &entities.Shop{GuildID: "guild-1"})
+	s.EqualError(err, dnderr.NewMissingParameterError("shop.Merchant.Key").Error())
+}
+
+func (s *shopSuite) TestGet() {
+	s.redisMock.ExpectGet("shop:guild-1:blacksmith").SetVal(s.shopJson)
+
+	result, err := s.fixture.Get(s.ctx, "guild-1", "blacksmith")
+	s.NoError(err)
+	s.Equal(s.shop, result)
+}
+
+func (s *shopSuite) TestGetNotFound() {
+	s.redisMock.ExpectGet("shop:guild-1:blacksmith").RedisNil()
+
+	_, err := s.fixture.Get(s.ctx, "guild-1", "blacksmith")
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func TestShopSuite(t *testing.T) {
+	suite.Run(t, new(shopSuite))
+}
@@ -0,0 +1,14 @@
+package trap
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+// Repository keeps the traps and skill challenges in dungeon rooms
+type Repository interface {
+	Create(ctx context.Context, trap *entities.Trap) (*entities.Trap, error)
+	Update(ctx context.Context, trap *entities.Trap) (*entities.Trap, error)
+	Get(ctx context.Context, id string) (*entities.Trap, error)
+}
@@ -0,0 +1,42 @@
+package trap
+
+import (
+	"context"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Create(ctx context.Context, trap *entities.Trap) (*entities.Trap, error) {
+	args := m.Called(ctx, trap)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Trap), nil
+}
+
+func (m *Mock) Update(ctx context.Context, trap *entities.Trap) (*entities.Trap, error) {
+	args := m.Called(ctx, trap)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Trap), nil
+}
+
+func (m *Mock) Get(ctx context.Context, id string) (*entities.Trap, error) {
+	args := m.Called(ctx, id)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Trap), nil
+}
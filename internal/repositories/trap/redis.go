@@ -0,0 +1,181 @@
+package trap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"github.com/redis/go-redis/v9"
+)
+
+type Redis struct {
+	client redis.UniversalClient
+	uuider types.UUIDGenerator
+}
+
+type RedisConfig struct {
+	Client redis.UniversalClient
+}
+
+func NewRedis(cfg *RedisConfig) (*Redis, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	return &Redis{
+		client: cfg.Client,
+		uuider: &types.GoogleUUID{},
+	}, nil
+}
+
+func getTrapKey(id string) string {
+	return "trap:" + id
+}
+
+func trapToJson(trap *entities.Trap) (string, error) {
+	buf, err := json.Marshal(trap)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+func jsonToTrap(jsonStr string) (*entities.Trap, error) {
+	if jsonStr == "" {
+		return nil, dnderr.NewMissingParameterError("jsonStr")
+	}
+
+	out := &entities.Trap{}
+	err := json.Unmarshal([]byte(jsonStr), out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Create saves a new trap with a new ID
+func (r *Redis) Create(ctx context.Context, trap *entities.Trap) (*entities.Trap, error) {
+	if trap == nil {
+		return nil, dnderr.NewMissingParameterError("trap")
+	}
+
+	if trap.ID != "" {
+		return nil, dnderr.NewInvalidEntityError("trap.ID must be empty")
+	}
+
+	trap.ID = r.uuider.New()
+
+	return r.put(ctx, trap)
+}
+
+// Update saves the trap when the stored version is the one it was loaded from, a trap that
+// changed since is a ConflictError
+func (r *Redis) Update(ctx context.Context, trap *entities.Trap) (*entities.Trap, error) {
+	if trap == nil {
+		return nil, dnderr.NewMissingParameterError("trap")
+	}
+
+	if trap.ID == "" {
+		return nil, dnderr.NewInvalidEntityError("trap.ID must not be empty")
+	}
+
+	key := getTrapKey(trap.ID)
+
+	var version int
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := r.storedVersion(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		if current != trap.Version {
+			return dnderr.NewConflictError(fmt.Sprintf("trap %s was updated, expected version %d but found %d", trap.ID, trap.Version, current))
+		}
+
+		version = current + 1
+		stored := *trap
+		stored.Version = version
+
+		jsonStr, err := trapToJson(&stored)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, jsonStr, 0)
+			return nil
+		})
+
+		return err
+	}, key)
+	if err != nil {
+		if errors.Is(err, redis.TxFailedErr) {
+			return nil, dnderr.NewConflictError(fmt.Sprintf("trap %s was updated during the write", trap.ID))
+		}
+
+		return nil, err
+	}
+
+	trap.Version = version
+
+	return trap, nil
+}
+
+func (r *Redis) storedVersion(ctx context.Context, tx *redis.Tx, key string) (int, error) {
+	result, err := tx.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, dnderr.NewNotFoundError("trap not found")
+		}
+
+		return 0, err
+	}
+
+	stored, err := jsonToTrap(result)
+	if err != nil {
+		return 0, err
+	}
+
+	return stored.Version, nil
+}
+
+func (r *Redis) put(ctx context.Context, trap *entities.Trap) (*entities.Trap, error) {
+	jsonStr, err := trapToJson(trap)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.client.Set(ctx, getTrapKey(trap.ID), jsonStr, 0).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return trap, nil
+}
+
+func (r *Redis) Get(ctx context.Context, id string) (*entities.Trap, error) {
+	if id == "" {
+		return nil, dnderr.NewMissingParameterError("id")
+	}
+
+	jsonStr, err := r.client.Get(ctx, getTrapKey(id)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, dnderr.NewNotFoundError("trap not found")
+		}
+
+		return nil, err
+	}
+
+	return jsonToTrap(jsonStr)
+}
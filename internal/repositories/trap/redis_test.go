@@ -0,0 +1,128 @@
+package trap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/types"
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type trapSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	redisMock  redismock.ClientMock
+	mockUuider *types.MockUUID
+	fixture    *Redis
+
+	trap     *entities.Trap
+	trapJson string
+}
+
+func (s *trapSuite) SetupTest() {
+	s.ctx = context.Background()
+	client, redisMock := redismock.NewClientMock()
+	s.redisMock = redisMock
+	s.mockUuider = &types.MockUUID{}
+	s.fixture = &Redis{
+		client: client,
+		uuider: s.mockUuider,
+	}
+
+	s.trap, _ = entities.NewTrap("poison-darts")
+	s.trap.ID = "trap-1"
+	s.trap.Detected = true
+
+	buf, _ := json.Marshal(s.trap)
+	s.trapJson = string(buf)
+}
+
+func (s *trapSuite) TestCreate() {
+	s.mockUuider.On("New").Return(s.trap.ID)
+	s.redisMock.ExpectSet(getTrapKey(s.trap.ID), s.trapJson, 0).SetVal("OK")
+
+	input := *s.trap
+	input.ID = ""
+
+	result, err := s.fixture.Create(s.ctx, &input)
+	s.NoError(err)
+	s.Equal(s.trap, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *trapSuite) TestCreateValidatesInput() {
+	_, err := s.fixture.Create(s.ctx, nil)
+	s.EqualError(err, dnderr.NewMissingParameterError("trap").Error())
+
+	_, err = s.fixture.Create(s.ctx, s.trap)
+	s.EqualError(err, dnderr.NewInvalidEntityError("trap.ID must be empty").Error())
+}
+
+func (s *trapSuite) storedJson(version int) string {
+	stored := *s.trap
+	stored.Version = version
+	buf, _ := json.Marshal(&stored)
+
+	return string(buf)
+}
+
+func (s *trapSuite) TestUpdate() {
+	s.trap.Version = 1
+	s.redisMock.ExpectWatch(getTrapKey(s.trap.ID))
+	s.redisMock.ExpectGet(getTrapKey(s.trap.ID)).SetVal(s.storedJson(1))
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getTrapKey(s.trap.ID), s.storedJson(2), 0).SetVal("OK")
+	s.redisMock.ExpectTxPipelineExec()
+
+	result, err := s.fixture.Update(s.ctx, s.trap)
+	s.NoError(err)
+	s.Equal(2, result.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *trapSuite) TestUpdateStaleVersion() {
+	s.redisMock.ExpectWatch(getTrapKey(s.trap.ID))
+	s.redisMock.ExpectGet(getTrapKey(s.trap.ID)).SetVal(s.storedJson(3))
+
+	_, err := s.fixture.Update(s.ctx, s.trap)
+	s.IsType(&dnderr.ConflictError{}, err)
+	s.Equal(0, s.trap.Version)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *trapSuite) TestUpdateError() {
+	s.redisMock.ExpectWatch(getTrapKey(s.trap.ID))
+	s.redisMock.ExpectGet(getTrapKey(s.trap.ID)).SetErr(errors.New("error"))
+
+	_, err := s.fixture.Update(s.ctx, s.trap)
+	s.EqualError(err, "error")
+
+	_, err = s.fixture.Update(s.ctx, &entities.Trap{})
+	s.EqualError(err, dnderr.NewInvalidEntityError("trap.ID must not be empty").Error())
+}
+
+func (s *trapSuite) TestGet() {
+	s.redisMock.ExpectGet(getTrapKey(s.trap.ID)).SetVal(s.trapJson)
+
+	result, err := s.fixture.Get(s.ctx, s.trap.ID)
+	s.NoError(err)
+	s.Equal(s.trap, result)
+}
+
+func (s *trapSuite) TestGetNotFound() {
+	s.redisMock.ExpectGet(getTrapKey("missing")).SetErr(redis.Nil)
+
+	_, err := s.fixture.Get(s.ctx, "missing")
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func TestTrap(t *testing.T) {
+	suite.Run(t, new(trapSuite))
+}
@@ -0,0 +1,18 @@
+package turntimer
+
+import (
+	"context"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+// Repository keeps each encounter's turn timer, the deadlines are stored so timers carry on after
+// a restart
+type Repository interface {
+	Put(ctx context.Context, timer *entities.TurnTimer) (*entities.TurnTimer, error)
+	Get(ctx context.Context, encounterID string) (*entities.TurnTimer, error)
+	Delete(ctx context.Context, encounterID string) error
+	// ListDue returns the timers that need looking at by the time given
+	ListDue(ctx context.Context, at time.Time) ([]*entities.TurnTimer, error)
+}
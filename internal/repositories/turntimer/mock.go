@@ -0,0 +1,49 @@
+package turntimer
+
+import (
+	"context"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type Mock struct {
+	mock.Mock
+}
+
+func (m *Mock) Put(ctx context.Context, timer *entities.TurnTimer) (*entities.TurnTimer, error) {
+	args := m.Called(ctx, timer)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.TurnTimer), nil
+}
+
+func (m *Mock) Get(ctx context.Context, encounterID string) (*entities.TurnTimer, error) {
+	args := m.Called(ctx, encounterID)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.TurnTimer), nil
+}
+
+func (m *Mock) Delete(ctx context.Context, encounterID string) error {
+	args := m.Called(ctx, encounterID)
+
+	return args.Error(0)
+}
+
+func (m *Mock) ListDue(ctx context.Context, at time.Time) ([]*entities.TurnTimer, error) {
+	args := m.Called(ctx, at)
+
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entities.TurnTimer), nil
+}
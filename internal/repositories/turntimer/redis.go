@@ -0,0 +1,160 @@
+package turntimer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/redis/go-redis/v9"
+)
+
+// dueKey is a sorted set of encounter ids scored by when their timer next needs looking at
+const dueKey = "turntimer:due"
+
+type Redis struct {
+	client redis.UniversalClient
+}
+
+type RedisConfig struct {
+	Client redis.UniversalClient
+}
+
+func NewRedis(cfg *RedisConfig) (*Redis, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.Client == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.Client")
+	}
+
+	return &Redis{
+		client: cfg.Client,
+	}, nil
+}
+
+func getTimerKey(encounterID string) string {
+	return "turntimer:" + encounterID
+}
+
+func timerToJson(timer *entities.TurnTimer) (string, error) {
+	buf, err := json.Marshal(timer)
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+func jsonToTimer(jsonStr string) (*entities.TurnTimer, error) {
+	if jsonStr == "" {
+		return nil, dnderr.NewMissingParameterError("jsonStr")
+	}
+
+	out := &entities.TurnTimer{}
+	err := json.Unmarshal([]byte(jsonStr), out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Put saves the encounter's timer, replacing the one it had
+func (r *Redis) Put(ctx context.Context, timer *entities.TurnTimer) (*entities.TurnTimer, error) {
+	if timer == nil {
+		return nil, dnderr.NewMissingParameterError("timer")
+	}
+
+	if timer.EncounterID == "" {
+		return nil, dnderr.NewMissingParameterError("timer.EncounterID")
+	}
+
+	jsonStr, err := timerToJson(timer)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, getTimerKey(timer.EncounterID), jsonStr, 0)
+	pipe.ZAdd(ctx, dueKey, redis.Z{
+		Score:  float64(timer.NextAt().Unix()),
+		Member: timer.EncounterID,
+	})
+
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return timer, nil
+}
+
+func (r *Redis) Get(ctx context.Context, encounterID string) (*entities.TurnTimer, error) {
+	if encounterID == "" {
+		return nil, dnderr.NewMissingParameterError("encounterID")
+	}
+
+	jsonStr, err := r.client.Get(ctx, getTimerKey(encounterID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, dnderr.NewNotFoundError("turn timer not found")
+		}
+
+		return nil, err
+	}
+
+	return jsonToTimer(jsonStr)
+}
+
+func (r *Redis) Delete(ctx context.Context, encounterID string) error {
+	if encounterID == "" {
+		return dnderr.NewMissingParameterError("encounterID")
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, getTimerKey(encounterID))
+	pipe.ZRem(ctx, dueKey, encounterID)
+
+	_, err := pipe.Exec(ctx)
+
+	return err
+}
+
+// ListDue returns the timers due by the time given, soonest first. Timers that went missing are
+// dropped from the index.
+func (r *Redis) ListDue(ctx context.Context, at time.Time) ([]*entities.TurnTimer, error) {
+	ids, err := r.client.ZRangeByScore(ctx, dueKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(at.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*entities.TurnTimer, 0, len(ids))
+	for _, id := range ids {
+		timer, err := r.Get(ctx, id)
+		if err != nil {
+			var notFoundErr *dnderr.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				err = r.client.ZRem(ctx, dueKey, id).Err()
+				if err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+
+			return nil, err
+		}
+
+		out = append(out, timer)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,135 @@
+package turntimer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type turnTimerSuite struct {
+	suite.Suite
+
+	ctx       context.Context
+	redisMock redismock.ClientMock
+	fixture   *Redis
+
+	timer     *entities.TurnTimer
+	timerJson string
+}
+
+func (s *turnTimerSuite) SetupTest() {
+	s.ctx = context.Background()
+	client, redisMock := redismock.NewClientMock()
+	s.redisMock = redisMock
+	s.fixture = &Redis{
+		client: client,
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.timer = &entities.TurnTimer{
+		EncounterID: "encounter-1",
+		RoomID:      "room-1",
+		ChannelID:   "channel-1",
+		PlayerID:    "player-1",
+		Round:       1,
+		Turn:        0,
+		PingAt:      now.Add(time.Minute),
+		Deadline:    now.Add(2 * time.Minute),
+	}
+
+	buf, _ := json.Marshal(s.timer)
+	s.timerJson = string(buf)
+}
+
+func (s *turnTimerSuite) TestPut() {
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getTimerKey(s.timer.EncounterID), s.timerJson, 0).SetVal("OK")
+	s.redisMock.ExpectZAdd(dueKey, redis.Z{
+		Score:  float64(s.timer.PingAt.Unix()),
+		Member: s.timer.EncounterID,
+	}).SetVal(1)
+	s.redisMock.ExpectTxPipelineExec()
+
+	result, err := s.fixture.Put(s.ctx, s.timer)
+	s.NoError(err)
+	s.Equal(s.timer, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *turnTimerSuite) TestPutScoresByDeadlineOncePinged() {
+	s.timer.Pinged = true
+	buf, _ := json.Marshal(s.timer)
+
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectSet(getTimerKey(s.timer.EncounterID), string(buf), 0).SetVal("OK")
+	s.redisMock.ExpectZAdd(dueKey, redis.Z{
+		Score:  float64(s.timer.Deadline.Unix()),
+		Member: s.timer.EncounterID,
+	}).SetVal(0)
+	s.redisMock.ExpectTxPipelineExec()
+
+	_, err := s.fixture.Put(s.ctx, s.timer)
+	s.NoError(err)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *turnTimerSuite) TestPutValidatesInput() {
+	_, err := s.fixture.Put(s.ctx, nil)
+	s.EqualError(err, dnderr.NewMissingParameterError("timer").Error())
+
+	_, err = s.fixture.Put(s.ctx, &entities.TurnTimer{})
+	s.EqualError(err, dnderr.NewMissingParameterError("timer.EncounterID").Error())
+}
+
+func (s *turnTimerSuite) TestGet() {
+	s.redisMock.ExpectGet(getTimerKey(s.timer.EncounterID)).SetVal(s.timerJson)
+
+	result, err := s.fixture.Get(s.ctx, s.timer.EncounterID)
+	s.NoError(err)
+	s.Equal(s.timer, result)
+}
+
+func (s *turnTimerSuite) TestGetNotFound() {
+	s.redisMock.ExpectGet(getTimerKey(s.timer.EncounterID)).RedisNil()
+
+	_, err := s.fixture.Get(s.ctx, s.timer.EncounterID)
+	s.IsType(&dnderr.NotFoundError{}, err)
+}
+
+func (s *turnTimerSuite) TestDelete() {
+	s.redisMock.ExpectTxPipeline()
+	s.redisMock.ExpectDel(getTimerKey(s.timer.EncounterID)).SetVal(1)
+	s.redisMock.ExpectZRem(dueKey, s.timer.EncounterID).SetVal(1)
+	s.redisMock.ExpectTxPipelineExec()
+
+	err := s.fixture.Delete(s.ctx, s.timer.EncounterID)
+	s.NoError(err)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func (s *turnTimerSuite) TestListDue() {
+	at := s.timer.PingAt
+	s.redisMock.ExpectZRangeByScore(dueKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "1704110460",
+	}).SetVal([]string{s.timer.EncounterID, "encounter-gone"})
+	s.redisMock.ExpectGet(getTimerKey(s.timer.EncounterID)).SetVal(s.timerJson)
+	s.redisMock.ExpectGet(getTimerKey("encounter-gone")).RedisNil()
+	s.redisMock.ExpectZRem(dueKey, "encounter-gone").SetVal(1)
+
+	result, err := s.fixture.ListDue(s.ctx, at)
+	s.NoError(err)
+	s.Equal([]*entities.TurnTimer{s.timer}, result)
+	s.NoError(s.redisMock.ExpectationsWereMet())
+}
+
+func TestTurnTimerSuite(t *testing.T) {
+	suite.Run(t, new(turnTimerSuite))
+}
@@ -0,0 +1,49 @@
+package validation
+
+import "github.com/KirkDiggler/dnd-bot-go/dnderr"
+
+const (
+	RuleSetSRDStrict  = "srd-strict"
+	RuleSetHouseRules = "house-rules"
+)
+
+// RuleSet holds the limits the rules are checked against
+type RuleSet struct {
+	Name            string
+	MaxAbilityScore int
+	MaxLevel        int
+	// AllowDuplicateProficiencies stops duplicate proficiencies from being reported
+	AllowDuplicateProficiencies bool
+	// AllowHeavyDualWield lets a character wield two weapons that are not light
+	AllowHeavyDualWield bool
+}
+
+// SRDStrict follows the SRD as written
+func SRDStrict() *RuleSet {
+	return &RuleSet{
+		Name:            RuleSetSRDStrict,
+		MaxAbilityScore: 20,
+		MaxLevel:        20,
+	}
+}
+
+// HouseRules loosens the SRD for tables that want more heroic characters
+func HouseRules() *RuleSet {
+	return &RuleSet{
+		Name:                RuleSetHouseRules,
+		MaxAbilityScore:     24,
+		MaxLevel:            20,
+		AllowHeavyDualWield: true,
+	}
+}
+
+func RuleSetByName(name string) (*RuleSet, error) {
+	switch name {
+	case RuleSetSRDStrict:
+		return SRDStrict(), nil
+	case RuleSetHouseRules:
+		return HouseRules(), nil
+	default:
+		return nil, dnderr.NewInvalidParameterError("name", "unknown rule set "+name)
+	}
+}
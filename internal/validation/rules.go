@@ -0,0 +1,288 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+const (
+	RuleAbilityScoreRange      = "ability-score-range"
+	RuleAbilityModifierRange   = "ability-modifier-range"
+	RuleMissingAttribute       = "missing-attribute"
+	RuleDuplicateProficiency   = "duplicate-proficiency"
+	RuleHands                  = "hands"
+	RuleDualWield              = "dual-wield"
+	RuleEquippedNotInInventory = "equipped-not-in-inventory"
+	RuleLevelRange             = "level-range"
+	RuleClassLevels            = "class-levels"
+	RuleHitPoints              = "hit-points"
+
+	attributesFieldPrefix    = "attributes."
+	proficienciesFieldPrefix = "proficiencies."
+	equippedSlotsFieldPrefix = "equipped_slots."
+)
+
+// modifierForScore is the SRD ability modifier for a score, only used for positive scores
+func modifierForScore(score int) int {
+	return (score - 10) / 2
+}
+
+func checkAbilityScores(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	for _, attr := range entities.Attributes {
+		score := char.Attribues[attr]
+		if score == nil {
+			continue
+		}
+
+		if score.Score > rs.MaxAbilityScore {
+			out = append(out, &Violation{
+				Rule:     RuleAbilityScoreRange,
+				Severity: SeverityError,
+				Field:    attributesFieldPrefix + string(attr),
+				Message:  fmt.Sprintf("score %d is above the maximum of %d", score.Score, rs.MaxAbilityScore),
+			})
+		}
+
+		if score.Score < 0 {
+			out = append(out, &Violation{
+				Rule:     RuleAbilityScoreRange,
+				Severity: SeverityError,
+				Field:    attributesFieldPrefix + string(attr),
+				Message:  fmt.Sprintf("score %d is negative", score.Score),
+			})
+		}
+	}
+
+	return out
+}
+
+// checkAbilityModifiers warns when ability bonuses push a modifier past what the maximum score allows
+func checkAbilityModifiers(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	maxModifier := modifierForScore(rs.MaxAbilityScore)
+	for _, attr := range entities.Attributes {
+		score := char.Attribues[attr]
+		if score == nil {
+			continue
+		}
+
+		if score.Bonus > maxModifier {
+			out = append(out, &Violation{
+				Rule:     RuleAbilityModifierRange,
+				Severity: SeverityWarning,
+				Field:    attributesFieldPrefix + string(attr),
+				Message:  fmt.Sprintf("modifier %+d is above the %+d a score of %d gives", score.Bonus, maxModifier, rs.MaxAbilityScore),
+			})
+		}
+	}
+
+	return out
+}
+
+// checkMissingAttributes warns when some, but not all, of the attributes have been assigned
+func checkMissingAttributes(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	assigned := false
+	for _, score := range char.Attribues {
+		if score != nil && score.Score > 0 {
+			assigned = true
+			break
+		}
+	}
+
+	if !assigned {
+		return out
+	}
+
+	for _, attr := range entities.Attributes {
+		if char.Attribues[attr] == nil {
+			out = append(out, &Violation{
+				Rule:     RuleMissingAttribute,
+				Severity: SeverityWarning,
+				Field:    attributesFieldPrefix + string(attr),
+				Message:  "attribute has not been set",
+			})
+		}
+	}
+
+	return out
+}
+
+func checkDuplicateProficiencies(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	if rs.AllowDuplicateProficiencies {
+		return out
+	}
+
+	for _, profType := range entities.ProficiencyTypes {
+		seen := make(map[string]bool)
+		for _, prof := range char.Proficiencies[profType] {
+			if prof == nil {
+				continue
+			}
+
+			if seen[prof.Key] {
+				out = append(out, &Violation{
+					Rule:     RuleDuplicateProficiency,
+					Severity: SeverityError,
+					Field:    proficienciesFieldPrefix + string(profType),
+					Message:  fmt.Sprintf("%s is listed more than once", prof.Key),
+				})
+				continue
+			}
+
+			seen[prof.Key] = true
+		}
+	}
+
+	return out
+}
+
+// checkHands makes sure a character is not holding more than two hands worth of equipment
+func checkHands(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	hands := 0
+	if char.EquippedSlots[entities.SlotTwoHanded] != nil {
+		hands += 2
+	}
+
+	if char.EquippedSlots[entities.SlotMainHand] != nil {
+		hands++
+	}
+
+	if char.EquippedSlots[entities.SlotOffHand] != nil {
+		hands++
+	}
+
+	if hands > 2 {
+		out = append(out, &Violation{
+			Rule:     RuleHands,
+			Severity: SeverityError,
+			Field:    equippedSlotsFieldPrefix + string(entities.SlotTwoHanded),
+			Message:  fmt.Sprintf("equipment needs %d hands", hands),
+		})
+	}
+
+	return out
+}
+
+// checkDualWield requires both weapons to be light when fighting with two weapons
+func checkDualWield(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	if rs.AllowHeavyDualWield || char.AllowsHeavyDualWield() {
+		return out
+	}
+
+	mainWeapon, mainOk := char.EquippedSlots[entities.SlotMainHand].(*entities.Weapon)
+	offWeapon, offOk := char.EquippedSlots[entities.SlotOffHand].(*entities.Weapon)
+	if !mainOk || !offOk {
+		return out
+	}
+
+	slots := []entities.Slot{entities.SlotMainHand, entities.SlotOffHand}
+	for idx, weapon := range []*entities.Weapon{mainWeapon, offWeapon} {
+		if !weapon.IsLight() {
+			out = append(out, &Violation{
+				Rule:     RuleDualWield,
+				Severity: SeverityError,
+				Field:    equippedSlotsFieldPrefix + string(slots[idx]),
+				Message:  fmt.Sprintf("%s is not light and cannot be dual wielded", weapon.GetName()),
+			})
+		}
+	}
+
+	return out
+}
+
+func checkEquippedInInventory(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	for _, slot := range []entities.Slot{entities.SlotMainHand, entities.SlotOffHand, entities.SlotTwoHanded, entities.SlotBody} {
+		item := char.EquippedSlots[slot]
+		if item == nil {
+			continue
+		}
+
+		found := false
+		for _, items := range char.Inventory {
+			for _, inv := range items {
+				if inv.GetKey() == item.GetKey() {
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			out = append(out, &Violation{
+				Rule:     RuleEquippedNotInInventory,
+				Severity: SeverityError,
+				Field:    equippedSlotsFieldPrefix + string(slot),
+				Message:  fmt.Sprintf("%s is equipped but not in the inventory", item.GetName()),
+			})
+		}
+	}
+
+	return out
+}
+
+func checkLevel(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	if char.Level < 0 || char.Level > rs.MaxLevel {
+		out = append(out, &Violation{
+			Rule:     RuleLevelRange,
+			Severity: SeverityError,
+			Field:    "level",
+			Message:  fmt.Sprintf("level %d is outside 0-%d", char.Level, rs.MaxLevel),
+		})
+	}
+
+	return out
+}
+
+// checkClassLevels makes sure the character level matches the levels taken in each class
+func checkClassLevels(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	if len(char.Classes) == 0 {
+		return out
+	}
+
+	total := 0
+	for _, classLevel := range char.Classes {
+		if classLevel.Level < 1 {
+			out = append(out, &Violation{
+				Rule:     RuleClassLevels,
+				Severity: SeverityError,
+				Field:    "classes." + classLevel.Class.Key,
+				Message:  fmt.Sprintf("class level %d is below 1", classLevel.Level),
+			})
+		}
+
+		total += classLevel.Level
+	}
+
+	if total != char.Level {
+		out = append(out, &Violation{
+			Rule:     RuleClassLevels,
+			Severity: SeverityError,
+			Field:    "level",
+			Message:  fmt.Sprintf("level %d does not match the %d levels taken in classes", char.Level, total),
+		})
+	}
+
+	return out
+}
+
+func checkHitPoints(rs *RuleSet, char *entities.Character) []*Violation {
+	out := make([]*Violation, 0)
+	if char.MaxHitPoints > 0 && char.CurrentHitPoints > char.MaxHitPoints {
+		out = append(out, &Violation{
+			Rule:     RuleHitPoints,
+			Severity: SeverityError,
+			Field:    "current_hit_points",
+			Message:  fmt.Sprintf("%d is above the maximum of %d", char.CurrentHitPoints, char.MaxHitPoints),
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type Violation struct {
+	Rule     string
+	Severity Severity
+	Field    string
+	Message  string
+}
+
+func (v *Violation) String() string {
+	return fmt.Sprintf("[%s] %s: %s", v.Rule, v.Field, v.Message)
+}
+
+type Result struct {
+	RuleSet    string
+	Violations []*Violation
+}
+
+// Valid returns true when there are no error level violations, warnings are allowed
+func (r *Result) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+func (r *Result) Errors() []*Violation {
+	return r.bySeverity(SeverityError)
+}
+
+func (r *Result) Warnings() []*Violation {
+	return r.bySeverity(SeverityWarning)
+}
+
+func (r *Result) bySeverity(severity Severity) []*Violation {
+	out := make([]*Violation, 0)
+	for _, v := range r.Violations {
+		if v.Severity == severity {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// Err returns an *Error holding the error level violations, or nil if the result is valid
+func (r *Result) Err() error {
+	if r.Valid() {
+		return nil
+	}
+
+	return &Error{
+		RuleSet:    r.RuleSet,
+		Violations: r.Errors(),
+	}
+}
+
+type Error struct {
+	RuleSet    string
+	Violations []*Violation
+}
+
+func (e *Error) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+
+	return fmt.Sprintf("character violates %s rules: %s", e.RuleSet, strings.Join(msgs, "; "))
+}
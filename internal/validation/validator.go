@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"github.com/KirkDiggler/dnd-bot-go/dnderr"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+)
+
+// Rule checks one aspect of a character and returns any violations found
+type Rule func(rs *RuleSet, char *entities.Character) []*Violation
+
+type Validator struct {
+	ruleSet *RuleSet
+	rules   []Rule
+}
+
+type Config struct {
+	RuleSet *RuleSet
+}
+
+func New(cfg *Config) (*Validator, error) {
+	if cfg == nil {
+		return nil, dnderr.NewMissingParameterError("cfg")
+	}
+
+	if cfg.RuleSet == nil {
+		return nil, dnderr.NewMissingParameterError("cfg.RuleSet")
+	}
+
+	return &Validator{
+		ruleSet: cfg.RuleSet,
+		rules: []Rule{
+			checkAbilityScores,
+			checkAbilityModifiers,
+			checkMissingAttributes,
+			checkDuplicateProficiencies,
+			checkHands,
+			checkDualWield,
+			checkEquippedInInventory,
+			checkLevel,
+			checkClassLevels,
+			checkHitPoints,
+		},
+	}, nil
+}
+
+func (v *Validator) RuleSet() *RuleSet {
+	return v.ruleSet
+}
+
+// Validate runs every rule against the character
+func (v *Validator) Validate(char *entities.Character) *Result {
+	result := &Result{
+		RuleSet:    v.ruleSet.Name,
+		Violations: make([]*Violation, 0),
+	}
+
+	if char == nil {
+		return result
+	}
+
+	for _, rule := range v.rules {
+		result.Violations = append(result.Violations, rule(v.ruleSet, char)...)
+	}
+
+	return result
+}
@@ -0,0 +1,170 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/stretchr/testify/suite"
+)
+
+type validatorSuite struct {
+	suite.Suite
+
+	fixture *Validator
+	char    *entities.Character
+	dagger  *entities.Weapon
+	sword   *entities.Weapon
+}
+
+func (s *validatorSuite) SetupTest() {
+	s.fixture, _ = New(&Config{RuleSet: SRDStrict()})
+
+	s.dagger = &entities.Weapon{
+		Base:       entities.BasicEquipment{Key: "dagger", Name: "Dagger"},
+		Properties: []*entities.ReferenceItem{{Key: "light"}},
+	}
+	s.sword = &entities.Weapon{
+		Base: entities.BasicEquipment{Key: "longsword", Name: "Longsword"},
+	}
+
+	s.char = &entities.Character{
+		Name:  "Test Character",
+		Level: 1,
+		Inventory: map[entities.EquipmentType][]entities.Equipment{
+			entities.EquipmentTypeWeapon: {s.dagger, s.sword},
+		},
+	}
+	for _, attr := range entities.Attributes {
+		s.char.AddAttribute(attr, 10)
+	}
+}
+
+func (s *validatorSuite) TestValidCharacter() {
+	result := s.fixture.Validate(s.char)
+
+	s.True(result.Valid())
+	s.Empty(result.Violations)
+	s.NoError(result.Err())
+}
+
+func (s *validatorSuite) TestAbilityScoreAboveMax() {
+	s.char.AddAttribute(entities.AttributeStrength, 22)
+
+	result := s.fixture.Validate(s.char)
+
+	s.False(result.Valid())
+	s.Len(result.Errors(), 1)
+	s.Equal(RuleAbilityScoreRange, result.Errors()[0].Rule)
+	s.Equal("attributes.Str", result.Errors()[0].Field)
+}
+
+func (s *validatorSuite) TestAbilityScoreAllowedByHouseRules() {
+	s.fixture, _ = New(&Config{RuleSet: HouseRules()})
+	s.char.AddAttribute(entities.AttributeStrength, 22)
+
+	result := s.fixture.Validate(s.char)
+
+	s.True(result.Valid())
+}
+
+func (s *validatorSuite) TestAbilityBonusOverModifierIsWarning() {
+	s.char.AddAttribute(entities.AttributeStrength, 20)
+	s.char.AddAbilityBonus(&entities.AbilityBonus{Attribute: entities.AttributeStrength, Bonus: 2})
+
+	result := s.fixture.Validate(s.char)
+
+	s.True(result.Valid())
+	s.Len(result.Warnings(), 1)
+	s.Equal(RuleAbilityModifierRange, result.Warnings()[0].Rule)
+}
+
+func (s *validatorSuite) TestMissingAttribute() {
+	delete(s.char.Attribues, entities.AttributeStrength)
+
+	result := s.fixture.Validate(s.char)
+
+	s.True(result.Valid())
+	s.Len(result.Warnings(), 1)
+	s.Equal(RuleMissingAttribute, result.Warnings()[0].Rule)
+}
+
+func (s *validatorSuite) TestDuplicateProficiencies() {
+	s.char.Proficiencies = map[entities.ProficiencyType][]*entities.Proficiency{
+		entities.ProficiencyTypeSkill: {
+			{Key: "skill-stealth", Type: entities.ProficiencyTypeSkill},
+			{Key: "skill-stealth", Type: entities.ProficiencyTypeSkill},
+		},
+	}
+
+	result := s.fixture.Validate(s.char)
+
+	s.False(result.Valid())
+	s.Equal(RuleDuplicateProficiency, result.Errors()[0].Rule)
+}
+
+func (s *validatorSuite) TestTooManyHands() {
+	s.char.EquippedSlots = map[entities.Slot]entities.Equipment{
+		entities.SlotTwoHanded: s.sword,
+		entities.SlotOffHand:   s.dagger,
+	}
+
+	result := s.fixture.Validate(s.char)
+
+	s.False(result.Valid())
+	s.Equal(RuleHands, result.Errors()[0].Rule)
+}
+
+func (s *validatorSuite) TestDualWieldRequiresLight() {
+	s.char.EquippedSlots = map[entities.Slot]entities.Equipment{
+		entities.SlotMainHand: s.sword,
+		entities.SlotOffHand:  s.dagger,
+	}
+
+	result := s.fixture.Validate(s.char)
+
+	s.False(result.Valid())
+	s.Len(result.Errors(), 1)
+	s.Equal(RuleDualWield, result.Errors()[0].Rule)
+	s.Equal("equipped_slots.main-hand", result.Errors()[0].Field)
+}
+
+func (s *validatorSuite) TestEquippedNotInInventory() {
+	s.char.EquippedSlots = map[entities.Slot]entities.Equipment{
+		entities.SlotMainHand: &entities.Weapon{Base: entities.BasicEquipment{Key: "club", Name: "Club"}},
+	}
+
+	result := s.fixture.Validate(s.char)
+
+	s.False(result.Valid())
+	s.Equal(RuleEquippedNotInInventory, result.Errors()[0].Rule)
+}
+
+func (s *validatorSuite) TestHitPointsAboveMax() {
+	s.char.MaxHitPoints = 10
+	s.char.CurrentHitPoints = 12
+
+	result := s.fixture.Validate(s.char)
+
+	s.False(result.Valid())
+	s.Equal(RuleHitPoints, result.Errors()[0].Rule)
+	s.EqualError(result.Err(), "character violates srd-strict rules: [hit-points] current_hit_points: 12 is above the maximum of 10")
+}
+
+func (s *validatorSuite) TestClassLevelsMustMatchLevel() {
+	s.char.Classes = []*entities.ClassLevel{
+		{Class: &entities.Class{Key: "fighter"}, Level: 1},
+		{Class: &entities.Class{Key: "wizard"}, Level: 1},
+	}
+
+	result := s.fixture.Validate(s.char)
+
+	s.False(result.Valid())
+	s.Equal(RuleClassLevels, result.Errors()[0].Rule)
+
+	s.char.Level = 2
+	s.True(s.fixture.Validate(s.char).Valid())
+}
+
+func TestValidator(t *testing.T) {
+	suite.Run(t, new(validatorSuite))
+}
@@ -1,11 +1,29 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"github.com/KirkDiggler/dnd-bot-go/internal/combat"
+	"github.com/KirkDiggler/dnd-bot-go/internal/entities"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/encounters"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/loot"
 	"github.com/KirkDiggler/dnd-bot-go/internal/managers/ronnied_actions"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/rooms"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/shops"
+	"github.com/KirkDiggler/dnd-bot-go/internal/managers/turntimers"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/combatlog"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/dungeon"
 	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/encounter"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/houserules"
+	lootRepository "github.com/KirkDiggler/dnd-bot-go/internal/repositories/loot"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/monster"
 	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/ronnied/game"
 	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/ronnied/session"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/room"
+	shopRepository "github.com/KirkDiggler/dnd-bot-go/internal/repositories/shop"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/trap"
+	"github.com/KirkDiggler/dnd-bot-go/internal/repositories/turntimer"
+	"github.com/KirkDiggler/dnd-bot-go/internal/validation"
 	"github.com/redis/go-redis/v9"
 	"log"
 	"net/http"
@@ -33,6 +51,9 @@ var (
 	guildID    string
 	appID      string
 	redistHost string
+	ruleSet    string
+	targeting  string
+	lootMode   string
 )
 
 func init() {
@@ -44,6 +65,12 @@ func init() {
 		"Application ID")
 	flag.StringVar(&redistHost, "redis", "localhost:6379",
 		"Redis host")
+	flag.StringVar(&ruleSet, "ruleset", validation.RuleSetSRDStrict,
+		"Rule set characters are validated against (srd-strict, house-rules)")
+	flag.StringVar(&targeting, "monster-targeting", string(combat.TargetLowestHP),
+		"How monsters pick who to attack (lowest-hp, random, nearest)")
+	flag.StringVar(&lootMode, "loot-mode", string(entities.LootModeNeedGreed),
+		"How the party shares the items they find (need-greed, round-robin)")
 	flag.Parse()
 
 	log.SetFlags(log.Ldate | log.Ltime | log.Lmicroseconds | log.Lshortfile)
@@ -100,12 +127,17 @@ func main() {
 		panic(err)
 	}
 
+	characterRules, err := validation.RuleSetByName(ruleSet)
+	if err != nil {
+		panic(err)
+	}
+
 	charManager, err := characters.New(&characters.Config{
 		Client:        dnd5eClient,
 		CharacterRepo: charRepo,
 		StateRepo:     stateRepo,
 		ChoiceRepo:    choiceRepo,
-		EncounterRepo: encounterRepo,
+		RuleSet:       characterRules,
 	})
 	if err != nil {
 		panic(err)
@@ -133,14 +165,147 @@ func main() {
 		panic(err)
 	}
 
+	roomRepo, err := room.NewRedis(&room.RedisConfig{
+		Client: redisClient,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// rooms saved with a single player and monster are moved to the combatant lists
+	migrated, err := roomRepo.Migrate(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	if migrated > 0 {
+		log.Printf("Migrated %d rooms", migrated)
+	}
+
+	monsterRepo, err := monster.NewRedis(&monster.RedisConfig{
+		Client: redisClient,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	dungeonRepo, err := dungeon.NewRedis(&dungeon.RedisConfig{
+		Client: redisClient,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	trapRepo, err := trap.NewRedis(&trap.RedisConfig{
+		Client: redisClient,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	encounterManager, err := encounters.New(&encounters.Config{
+		Client:        dnd5eClient,
+		EncounterRepo: encounterRepo,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	combatLogRepo, err := combatlog.NewRedis(&combatlog.RedisConfig{
+		Client: redisClient,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	lootRepo, err := lootRepository.NewRedis(&lootRepository.RedisConfig{
+		Client: redisClient,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	lootManager, err := loot.New(&loot.Config{
+		Client:           dnd5eClient,
+		CharacterManager: charManager,
+		LootRepo:         lootRepo,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	houseRulesRepo, err := houserules.NewRedis(&houserules.RedisConfig{
+		Client: redisClient,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	roomManager, err := rooms.New(&rooms.Config{
+		Client:           dnd5eClient,
+		CharacterManager: charManager,
+		EncounterManager: encounterManager,
+		LootManager:      lootManager,
+		RoomRepo:         roomRepo,
+		MonsterRepo:      monsterRepo,
+		DungeonRepo:      dungeonRepo,
+		CombatLogRepo:    combatLogRepo,
+		TrapRepo:         trapRepo,
+		TargetStrategy:   combat.TargetStrategy(targeting),
+		LootMode:         entities.LootMode(lootMode),
+		HouseRulesRepo:   houseRulesRepo,
+		GuildID:          guildID,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	shopRepo, err := shopRepository.NewRedis(&shopRepository.RedisConfig{
+		Client: redisClient,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	shopManager, err := shops.New(&shops.Config{
+		Client:           dnd5eClient,
+		CharacterManager: charManager,
+		ShopRepo:         shopRepo,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	turnTimerRepo, err := turntimer.NewRedis(&turntimer.RedisConfig{
+		Client: redisClient,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	turnTimerManager, err := turntimers.New(&turntimers.Config{
+		EncounterManager: encounterManager,
+		RoomManager:      roomManager,
+		TurnTimerRepo:    turnTimerRepo,
+	})
+	if err != nil {
+		panic(err)
+	}
+
 	bot, err := discordbot.New(&discordbot.Config{
-		Token:          token,
-		GuildID:        guildID,
-		AppID:          appID,
-		DnD5EClient:    dnd5eClient,
-		PartyRepo:      partyRepo,
-		CharacterRepo:  charManager,
-		RonnieDActions: gameActions,
+		Token:            token,
+		GuildID:          guildID,
+		AppID:            appID,
+		DnD5EClient:      dnd5eClient,
+		PartyRepo:        partyRepo,
+		CharacterRepo:    charManager,
+		RonnieDActions:   gameActions,
+		RoomManager:      roomManager,
+		EncounterManager: encounterManager,
+		LootManager:      lootManager,
+		TurnTimerManager: turnTimerManager,
+		HouseRulesRepo:   houseRulesRepo,
+		ShopManager:      shopManager,
 	})
 	if err != nil {
 		panic(err)